@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/ipc/common"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/ipc/recording"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost/hostCore"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost/tracing"
+)
+
+var traceFlag = flag.String(
+	"trace",
+	"",
+	"write the EEI hook calls made by the replayed execution to this file, as JSON lines")
+
+// replay reruns a contract execution recorded by a recording.RecordingVM,
+// answering the blockchain hook calls with the recorded responses, and checks
+// that it produces the recorded output
+func main() {
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Println("One argument expected - the path to the recording.")
+		os.Exit(1)
+	}
+
+	matches, err := replay(flag.Arg(0))
+	if err != nil {
+		fmt.Printf("ERROR: %s\n", err.Error())
+		os.Exit(1)
+	}
+	if !matches {
+		fmt.Println("MISMATCH: the output differs from the recorded one")
+		os.Exit(1)
+	}
+
+	fmt.Println("SUCCESS")
+}
+
+func replay(recordingPath string) (bool, error) {
+	executionRecording, err := recording.LoadRecording(recordingPath)
+	if err != nil {
+		return false, err
+	}
+
+	hook, err := recording.NewReplayBlockchainHook(executionRecording)
+	if err != nil {
+		return false, err
+	}
+
+	parameters := executionRecording.VMHostParameters()
+	if len(*traceFlag) > 0 {
+		traceFile, errCreate := os.Create(*traceFlag)
+		if errCreate != nil {
+			return false, errCreate
+		}
+		defer func() {
+			_ = traceFile.Close()
+		}()
+
+		parameters.HookTracer, err = tracing.NewJSONLinesTracer(traceFile)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	vm, err := hostCore.NewVMHost(hook, parameters)
+	if err != nil {
+		return false, err
+	}
+
+	vmOutput, executionErr := executionRecording.Run(vm)
+	response := common.NewMessageContractResponse(vmOutput, executionErr)
+	responseJSON, err := json.MarshalIndent(response, "", "    ")
+	if err != nil {
+		return false, err
+	}
+	fmt.Println(string(responseJSON))
+
+	return executionRecording.MatchesResponse(vmOutput, executionErr), nil
+}
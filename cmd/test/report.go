@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	am "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarioexec"
+	mc "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/controller"
+)
+
+// runScenariosWithReport runs a scenario file, or all the scenarios in a directory,
+// then writes the results to the report file, whether the scenarios pass or not
+func runScenariosWithReport(
+	executor *am.VMTestExecutor,
+	scenarioexecPath string,
+	jsonFilePath string,
+	isDir bool,
+) error {
+	reportFormat := mc.ReportFormat(*reportFormatFlag)
+	if reportFormat != mc.ReportFormatJUnit && reportFormat != mc.ReportFormatJSON {
+		return fmt.Errorf("unknown report format: %s", reportFormat)
+	}
+
+	var results []*mc.ScenarioResult
+	var err error
+	generalTestPath := jsonFilePath
+
+	switch {
+	case isDir && *parallelFlag != 1:
+		runner := mc.NewParallelScenarioRunner(
+			func() (mc.ScenarioExecutor, error) {
+				return am.NewVMTestExecutor(scenarioexecPath)
+			},
+			mc.NewDefaultFileResolver(),
+			*parallelFlag,
+		)
+		results, err = runner.RunJSONScenariosInDirectory(jsonFilePath, "", ".scen.json", []string{})
+	case isDir:
+		runner := mc.NewScenarioRunner(executor, mc.NewDefaultFileResolver())
+		results, err = runner.RunJSONScenariosInDirectory(jsonFilePath, "", ".scen.json", []string{})
+	case strings.HasSuffix(jsonFilePath, ".scen.json"):
+		runner := mc.NewScenarioRunner(executor, mc.NewDefaultFileResolver())
+		results = []*mc.ScenarioResult{runner.RunJSONScenario(jsonFilePath)}
+		generalTestPath = filepath.Dir(jsonFilePath)
+	default:
+		return errors.New("reports are only supported for scenarios")
+	}
+	if err != nil {
+		return err
+	}
+
+	scenariosErr := mc.SummarizeScenarioResults(os.Stdout, results, generalTestPath)
+
+	reportFile, err := os.Create(*reportFlag)
+	if err != nil {
+		return err
+	}
+	defer reportFile.Close()
+
+	err = mc.WriteReport(reportFile, reportFormat, results, generalTestPath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Report written to %s\n", *reportFlag)
+	return scenariosErr
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"encoding/hex"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,6 +12,43 @@ import (
 	mc "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/controller"
 )
 
+var gasProfileFlag = flag.Bool(
+	"gas-profile",
+	false,
+	"print the gas consumed by the SC calls, in the folded stack format used by flame graph tools")
+
+var parallelFlag = flag.Int(
+	"parallel",
+	1,
+	"number of scenarios run concurrently when the argument is a directory, each on its own VM; 0 means one per CPU")
+
+var debugFlag = flag.Bool(
+	"debug",
+	false,
+	"pause the scenarios at breakpoints and inspect the world from an interactive prompt; "+
+		"without breakpoints, pauses before the first step")
+
+var breakFlag = flag.String(
+	"break",
+	"",
+	"comma-separated step indexes or txIds to pause before, in debug mode")
+
+var breakAfterFlag = flag.String(
+	"break-after",
+	"",
+	"comma-separated step indexes or txIds to pause after, in debug mode")
+
+var reportFlag = flag.String(
+	"report",
+	"",
+	"write the results of the scenarios and of each of their steps to this file; "+
+		"all the scenarios in a directory are run, even if some of them fail")
+
+var reportFormatFlag = flag.String(
+	"report-format",
+	string(mc.ReportFormatJUnit),
+	"the format of the report: junit or json")
+
 func resolveArgument(exeDir string, arg string) (string, bool, error) {
 	fi, err := os.Stat(arg)
 	if os.IsNotExist(err) {
@@ -32,10 +71,11 @@ func main() {
 	}
 
 	// argument
-	if len(os.Args) != 2 {
+	flag.Parse()
+	if flag.NArg() != 1 {
 		panic("One argument expected - the path to the json test.")
 	}
-	jsonFilePath, isDir, err := resolveArgument(exeDir, os.Args[1])
+	jsonFilePath, isDir, err := resolveArgument(exeDir, flag.Arg(0))
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -47,9 +87,42 @@ func main() {
 	if err != nil {
 		panic("Could not instantiate VM VM")
 	}
+	if *gasProfileFlag {
+		err = executor.EnableGasProfiling()
+		if err != nil {
+			panic("Could not enable gas profiling")
+		}
+	}
+
+	if isDir && *parallelFlag != 1 && *gasProfileFlag {
+		fmt.Println("gas profiling is not supported when running scenarios in parallel")
+		os.Exit(1)
+	}
+	if isDir && *parallelFlag != 1 && *debugFlag {
+		fmt.Println("debugging is not supported when running scenarios in parallel")
+		os.Exit(1)
+	}
+	if *debugFlag {
+		enableStepDebugger(executor)
+	}
 
 	// execute
 	switch {
+	case len(*reportFlag) > 0:
+		err = runScenariosWithReport(executor, scenarioexecPath, jsonFilePath, isDir)
+	case isDir && *parallelFlag != 1:
+		runner := mc.NewParallelScenarioRunner(
+			func() (mc.ScenarioExecutor, error) {
+				return am.NewVMTestExecutor(scenarioexecPath)
+			},
+			mc.NewDefaultFileResolver(),
+			*parallelFlag,
+		)
+		err = runner.RunAllJSONScenariosInDirectory(
+			jsonFilePath,
+			"",
+			".scen.json",
+			[]string{})
 	case isDir:
 		runner := mc.NewScenarioRunner(
 			executor,
@@ -74,6 +147,14 @@ func main() {
 		err = runner.RunSingleJSONTest(jsonFilePath)
 	}
 
+	// print gas profile
+	if *gasProfileFlag {
+		printErr := executor.GasProfile().WriteFoldedStacks(os.Stdout, formatAddress)
+		if printErr != nil {
+			fmt.Println(printErr)
+		}
+	}
+
 	// print result
 	if err == nil {
 		fmt.Println("SUCCESS")
@@ -82,3 +163,31 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+func enableStepDebugger(executor *am.VMTestExecutor) {
+	debugger := executor.EnableStepDebugger(os.Stdin, os.Stdout)
+	addBreakpoints(debugger, am.BreakBefore, *breakFlag)
+	addBreakpoints(debugger, am.BreakAfter, *breakAfterFlag)
+}
+
+func addBreakpoints(debugger *am.StepDebugger, position am.BreakpointPosition, stepRefs string) {
+	for _, stepRef := range strings.Split(stepRefs, ",") {
+		stepRef = strings.TrimSpace(stepRef)
+		if len(stepRef) > 0 {
+			debugger.AddBreakpoint(am.ParseBreakpoint(position, stepRef))
+		}
+	}
+}
+
+// formatAddress keeps the readable test addresses (e.g. "sc:adder___...") as
+// they are, but hex-encodes the others
+func formatAddress(address []byte) string {
+	for _, b := range address {
+		isPrintable := b > ' ' && b <= '~' && b != ';'
+		if !isPrintable {
+			return hex.EncodeToString(address)
+		}
+	}
+
+	return string(address)
+}
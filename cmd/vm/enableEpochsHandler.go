@@ -0,0 +1,43 @@
+package main
+
+import (
+	"github.com/kalyan3104/k-chain-core-go/core"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+)
+
+var _ vmhost.EnableEpochsHandler = (*enableEpochsHandler)(nil)
+
+// enableEpochsHandler is used when the node does not provide one through the
+// init pipe (it cannot be serialized); the VM running out-of-process is
+// expected to behave as if all its flags are active
+type enableEpochsHandler struct {
+}
+
+func newEnableEpochsHandler() *enableEpochsHandler {
+	return &enableEpochsHandler{}
+}
+
+// IsFlagDefined returns true for all flags
+func (handler *enableEpochsHandler) IsFlagDefined(_ core.EnableEpochFlag) bool {
+	return true
+}
+
+// IsFlagEnabled returns true for all flags
+func (handler *enableEpochsHandler) IsFlagEnabled(_ core.EnableEpochFlag) bool {
+	return true
+}
+
+// IsFlagEnabledInEpoch returns true for all flags and epochs
+func (handler *enableEpochsHandler) IsFlagEnabledInEpoch(_ core.EnableEpochFlag, _ uint32) bool {
+	return true
+}
+
+// GetActivationEpoch returns 0 for all flags
+func (handler *enableEpochsHandler) GetActivationEpoch(_ core.EnableEpochFlag) uint32 {
+	return 0
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (handler *enableEpochsHandler) IsInterfaceNil() bool {
+	return handler == nil
+}
@@ -0,0 +1,6 @@
+package main
+
+import "errors"
+
+// ErrCannotGetLogsPipes signals that the pipes used for sending the logs to the node are not available
+var ErrCannotGetLogsPipes = errors.New("cannot get logs pipes")
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	logger "github.com/kalyan3104/k-chain-logger-go"
+	"github.com/kalyan3104/k-chain-logger-go/pipes"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/ipc/common"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/ipc/marshaling"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/ipc/vmpart"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+)
+
+var log = logger.GetOrCreate("vm/main")
+
+const (
+	// ErrCodeSuccess signals success
+	ErrCodeSuccess = iota
+	// ErrCodeInit signals an initialization error
+	ErrCodeInit
+	// ErrCodeCannotCreatePart signals that the VM part could not be created
+	ErrCodeCannotCreatePart
+	// ErrCodeCriticalError signals a critical error in the main loop
+	ErrCodeCriticalError
+	// ErrCodeTerminated signals that the process has been terminated by a signal
+	ErrCodeTerminated
+)
+
+// The file descriptors of the pipes provided by the nodepart.VMDriver, in the
+// order in which they are passed as ExtraFiles (0, 1 and 2 are the standard streams)
+const (
+	fileDescriptorInit         = 3
+	fileDescriptorNodeToVM     = 4
+	fileDescriptorVMToNode     = 5
+	fileDescriptorReadLogsProf = 6
+	fileDescriptorLogsWriter   = 7
+)
+
+func main() {
+	errCode, errMessage := doMain()
+	if errCode != ErrCodeSuccess {
+		log.Error(errMessage)
+	}
+
+	os.Exit(errCode)
+}
+
+// doMain returns (error code, error message)
+func doMain() (int, string) {
+	vmInitFile := getPipeFile(fileDescriptorInit)
+	if vmInitFile == nil {
+		return ErrCodeInit, "Cannot get pipe file: [vmInitFile]"
+	}
+
+	vmArguments, err := common.GetVMArguments(vmInitFile)
+	if err != nil {
+		return ErrCodeInit, "Cannot receive init arguments: " + err.Error()
+	}
+
+	err = startLogsPart(vmArguments.LogsMarshalizer)
+	if err != nil {
+		return ErrCodeInit, "Cannot create logs part: " + err.Error()
+	}
+
+	nodeToVMFile := getPipeFile(fileDescriptorNodeToVM)
+	if nodeToVMFile == nil {
+		return ErrCodeInit, "Cannot get pipe file: [nodeToVMFile]"
+	}
+
+	vmToNodeFile := getPipeFile(fileDescriptorVMToNode)
+	if vmToNodeFile == nil {
+		return ErrCodeInit, "Cannot get pipe file: [vmToNodeFile]"
+	}
+
+	if vmArguments.EnableEpochsHandler == nil {
+		vmArguments.EnableEpochsHandler = newEnableEpochsHandler()
+	}
+
+	part, err := createVMPart(nodeToVMFile, vmToNodeFile, vmArguments)
+	if err != nil {
+		return ErrCodeCannotCreatePart, "Cannot create VMPart: " + err.Error()
+	}
+
+	go handleTerminationSignals()
+
+	err = part.StartLoop()
+	if err == common.ErrStopPerNodeRequest {
+		return ErrCodeSuccess, "(stopped as requested by the node)"
+	}
+	if err != nil {
+		return ErrCodeCriticalError, "Ended VM loop: " + err.Error()
+	}
+
+	return ErrCodeSuccess, ""
+}
+
+// createVMPart records the executions only if asked to, through the environment
+func createVMPart(nodeToVMFile *os.File, vmToNodeFile *os.File, vmArguments *common.VMArguments) (*vmpart.VMPart, error) {
+	marshalizer := marshaling.CreateMarshalizer(vmArguments.MessagesMarshalizer)
+
+	recordingsDirectory := os.Getenv(common.EnvVarVMRecordingsDirectory)
+	if len(recordingsDirectory) == 0 {
+		return vmpart.NewVMPart(
+			vmhost.VMVersion,
+			nodeToVMFile,
+			vmToNodeFile,
+			&vmArguments.VMHostParameters,
+			marshalizer,
+		)
+	}
+
+	log.Info("recording the executions", "directory", recordingsDirectory)
+	return vmpart.NewRecordingVMPart(
+		vmhost.VMVersion,
+		nodeToVMFile,
+		vmToNodeFile,
+		&vmArguments.VMHostParameters,
+		marshalizer,
+		recordingsDirectory,
+	)
+}
+
+func startLogsPart(marshalizerKind marshaling.MarshalizerKind) error {
+	logsProfileReader := getPipeFile(fileDescriptorReadLogsProf)
+	if logsProfileReader == nil {
+		return ErrCannotGetLogsPipes
+	}
+
+	logsWriter := getPipeFile(fileDescriptorLogsWriter)
+	if logsWriter == nil {
+		return ErrCannotGetLogsPipes
+	}
+
+	logsPart, err := pipes.NewChildPart(logsProfileReader, logsWriter, marshaling.CreateMarshalizer(marshalizerKind))
+	if err != nil {
+		return err
+	}
+
+	return logsPart.StartLoop()
+}
+
+func handleTerminationSignals() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	receivedSignal := <-signals
+	log.Info("received termination signal", "signal", receivedSignal.String())
+	os.Exit(ErrCodeTerminated)
+}
+
+func getPipeFile(fileDescriptor uintptr) *os.File {
+	return os.NewFile(fileDescriptor, "/proc/self/fd/"+strconv.Itoa(int(fileDescriptor)))
+}
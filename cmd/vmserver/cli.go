@@ -77,6 +77,13 @@ func initializeCLI(facade *vmserver.DebugFacade) *cli.App {
 		Destination: &args.GasPrice,
 	}
 
+	// For estimate
+	flagSearchGasLimit := cli.BoolFlag{
+		Name:        "search-gas-limit",
+		Usage:       "also search the smallest gas limit which yields the same outcome",
+		Destination: &args.SearchGasLimit,
+	}
+
 	// For deploy / upgrade
 	flagCode := cli.StringFlag{
 		Name:        "code",
@@ -114,6 +121,26 @@ func initializeCLI(facade *vmserver.DebugFacade) *cli.App {
 		Destination: &args.AccountNonce,
 	}
 
+	// For snapshot-world / fork-world / restore-world
+	flagSnapshot := cli.StringFlag{
+		Required:    true,
+		Name:        "snapshot",
+		Destination: &args.SnapshotID,
+	}
+
+	flagNewWorld := cli.StringFlag{
+		Required:    true,
+		Name:        "new-world",
+		Destination: &args.NewWorld,
+	}
+
+	// For export-scenario
+	flagScenarioPath := cli.StringFlag{
+		Required:    true,
+		Name:        "scenario",
+		Destination: &args.ScenarioPath,
+	}
+
 	app.Flags = []cli.Flag{}
 
 	app.Authors = []cli.Author{
@@ -216,6 +243,27 @@ func initializeCLI(facade *vmserver.DebugFacade) *cli.App {
 				flagGasLimit,
 			},
 		},
+		{
+			Name:        "estimate",
+			Description: "estimate the gas needed by a smart contract call",
+			Action: func(context *cli.Context) error {
+				_, err := facade.EstimateGas(args.toEstimateRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagOutcome,
+				flagWorld,
+				flagDatabase,
+				flagContract,
+				flagImpersonated,
+				flagFunction,
+				flagArguments,
+				flagValue,
+				flagGasLimit,
+				flagGasPrice,
+				flagSearchGasLimit,
+			},
+		},
 		{
 			Name:        "create-account",
 			Description: "create account",
@@ -232,6 +280,62 @@ func initializeCLI(facade *vmserver.DebugFacade) *cli.App {
 				flagAccountNonce,
 			},
 		},
+		{
+			Name:        "snapshot-world",
+			Description: "save a snapshot of a world",
+			Action: func(context *cli.Context) error {
+				_, err := facade.SnapshotWorld(args.toSnapshotWorldRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagOutcome,
+				flagWorld,
+				flagDatabase,
+				flagSnapshot,
+			},
+		},
+		{
+			Name:        "fork-world",
+			Description: "copy a world under a new world ID",
+			Action: func(context *cli.Context) error {
+				_, err := facade.ForkWorld(args.toForkWorldRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagOutcome,
+				flagWorld,
+				flagDatabase,
+				flagNewWorld,
+			},
+		},
+		{
+			Name:        "restore-world",
+			Description: "restore a world from one of its snapshots",
+			Action: func(context *cli.Context) error {
+				_, err := facade.RestoreWorld(args.toRestoreWorldRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagOutcome,
+				flagWorld,
+				flagDatabase,
+				flagSnapshot,
+			},
+		},
+		{
+			Name:        "export-scenario",
+			Description: "export the requests recorded for a world as a scenario",
+			Action: func(context *cli.Context) error {
+				_, err := facade.ExportScenario(args.toExportScenarioRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagOutcome,
+				flagWorld,
+				flagDatabase,
+				flagScenarioPath,
+			},
+		},
 	}
 
 	return app
@@ -23,10 +23,15 @@ type cliArguments struct {
 	Value           string
 	GasLimit        uint64
 	GasPrice        uint64
+	SearchGasLimit  bool
 	// For blockchain-related action
 	AccountAddress string
 	AccountBalance string
 	AccountNonce   uint64
+	// For world-related actions
+	SnapshotID   string
+	NewWorld     string
+	ScenarioPath string
 }
 
 func (args *cliArguments) toDeployRequest() vmserver.DeployRequest {
@@ -90,6 +95,14 @@ func (args *cliArguments) toQueryRequest() vmserver.QueryRequest {
 	return *request
 }
 
+func (args *cliArguments) toEstimateRequest() vmserver.EstimateRequest {
+	request := &vmserver.EstimateRequest{}
+	args.populateRunRequest(&request.RunRequest)
+
+	request.SearchGasLimit = args.SearchGasLimit
+	return *request
+}
+
 func (args *cliArguments) toCreateAccountRequest() vmserver.CreateAccountRequest {
 	request := &vmserver.CreateAccountRequest{}
 	args.populateRequestBase(&request.RequestBase)
@@ -99,3 +112,35 @@ func (args *cliArguments) toCreateAccountRequest() vmserver.CreateAccountRequest
 	request.Nonce = args.AccountNonce
 	return *request
 }
+
+func (args *cliArguments) toSnapshotWorldRequest() vmserver.SnapshotWorldRequest {
+	request := &vmserver.SnapshotWorldRequest{}
+	args.populateRequestBase(&request.RequestBase)
+
+	request.SnapshotID = args.SnapshotID
+	return *request
+}
+
+func (args *cliArguments) toForkWorldRequest() vmserver.ForkWorldRequest {
+	request := &vmserver.ForkWorldRequest{}
+	args.populateRequestBase(&request.RequestBase)
+
+	request.NewWorld = args.NewWorld
+	return *request
+}
+
+func (args *cliArguments) toRestoreWorldRequest() vmserver.RestoreWorldRequest {
+	request := &vmserver.RestoreWorldRequest{}
+	args.populateRequestBase(&request.RequestBase)
+
+	request.SnapshotID = args.SnapshotID
+	return *request
+}
+
+func (args *cliArguments) toExportScenarioRequest() vmserver.ExportScenarioRequest {
+	request := &vmserver.ExportScenarioRequest{}
+	args.populateRequestBase(&request.RequestBase)
+
+	request.ScenarioPath = args.ScenarioPath
+	return *request
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"github.com/mitchellh/mapstructure"
+)
+
+// WASMCodePolicy restricts the WASM modules accepted on deploy and upgrade.
+// It is read from the optional WASMCodePolicy and WASMForbiddenImports sections
+// of the gas schedule, so that it is versioned together with it; limits set to
+// 0 are not enforced.
+type WASMCodePolicy struct {
+	RejectFloatingPoint bool                `mapstructure:"-"`
+	ForbiddenImports    map[string]struct{} `mapstructure:"-"`
+	MaxExports          uint64
+	MaxFunctions        uint64
+	MaxGlobals          uint64
+	MaxTableSize        uint64
+	MaxMemoryPages      uint64
+}
+
+// CreateWASMCodePolicy reads the WASM code policy from the gas schedule
+func CreateWASMCodePolicy(gasMap GasScheduleMap) (*WASMCodePolicy, error) {
+	policy := &WASMCodePolicy{
+		ForbiddenImports: make(map[string]struct{}),
+	}
+
+	policyMap := gasMap["WASMCodePolicy"]
+	err := mapstructure.Decode(policyMap, policy)
+	if err != nil {
+		return nil, err
+	}
+	policy.RejectFloatingPoint = policyMap["RejectFloatingPoint"] != 0
+
+	for importName, forbidden := range gasMap["WASMForbiddenImports"] {
+		if forbidden != 0 {
+			policy.ForbiddenImports[importName] = struct{}{}
+		}
+	}
+
+	return policy, nil
+}
+
+// IsEmpty returns true if the policy does not restrict the WASM modules at all
+func (policy *WASMCodePolicy) IsEmpty() bool {
+	return !policy.RejectFloatingPoint &&
+		len(policy.ForbiddenImports) == 0 &&
+		policy.MaxExports == 0 &&
+		policy.MaxFunctions == 0 &&
+		policy.MaxGlobals == 0 &&
+		policy.MaxTableSize == 0 &&
+		policy.MaxMemoryPages == 0
+}
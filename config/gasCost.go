@@ -3,12 +3,14 @@ package config
 import "github.com/kalyan3104/k-chain-vm-v1_2-go/wasmer"
 
 type GasCost struct {
-	BaseOperationCost BaseOperationCost
-	BigIntAPICost     BigIntAPICost
-	EthAPICost        EthAPICost
-	BaseOpsAPICost    BaseOpsAPICost
-	CryptoAPICost     CryptoAPICost
-	WASMOpcodeCost    WASMOpcodeCost
+	BaseOperationCost    BaseOperationCost
+	BigIntAPICost        BigIntAPICost
+	ManagedBufferAPICost ManagedBufferAPICost
+	EthAPICost           EthAPICost
+	BaseOpsAPICost       BaseOpsAPICost
+	CryptoAPICost        CryptoAPICost
+	WASMOpcodeCost       WASMOpcodeCost
+	WASMCodePolicy       WASMCodePolicy
 }
 
 type BaseOperationCost struct {
@@ -126,6 +128,11 @@ type BigIntAPICost struct {
 	BigIntXor                  uint64
 	BigIntShr                  uint64
 	BigIntShl                  uint64
+	BigIntPow                  uint64
+	BigIntSqrt                 uint64
+	BigIntLog2                 uint64
+	BigIntMin                  uint64
+	BigIntMax                  uint64
 	BigIntFinishUnsigned       uint64
 	BigIntFinishSigned         uint64
 	BigIntStorageLoadUnsigned  uint64
@@ -136,13 +143,32 @@ type BigIntAPICost struct {
 	BigIntGetExternalBalance   uint64
 }
 
+type ManagedBufferAPICost struct {
+	MBufferNew          uint64
+	MBufferNewFromBytes uint64
+	MBufferGetLength    uint64
+	MBufferGetBytes     uint64
+	MBufferSetBytes     uint64
+	MBufferAppend       uint64
+	MBufferAppendBytes  uint64
+	MBufferStorageStore uint64
+	MBufferStorageLoad  uint64
+	MBufferGetArgument  uint64
+	MBufferFinish       uint64
+}
+
 type CryptoAPICost struct {
-	SHA256          uint64
-	Keccak256       uint64
-	Ripemd160       uint64
-	VerifyBLS       uint64
-	VerifyEd25519   uint64
-	VerifySecp256k1 uint64
+	SHA256                      uint64
+	Keccak256                   uint64
+	Ripemd160                   uint64
+	VerifyBLS                   uint64
+	VerifyBLSAggregatedPerKey   uint64
+	VerifyBLSMultiSigPerKey     uint64
+	VerifyEd25519               uint64
+	VerifySecp256k1             uint64
+	RecoverSecp256k1            uint64
+	EncodeSecp256k1DerSignature uint64
+	DecodeSecp256k1DerSignature uint64
 }
 
 type WASMOpcodeCost struct {
@@ -38,7 +38,7 @@ func CreateGasConfig(gasMap GasScheduleMap) (*GasCost, error) {
 	}
 
 	bigIntOps := &BigIntAPICost{}
-	err = mapstructure.Decode(gasMap["BigIntAPICost"], bigIntOps)
+	err = mapstructure.Decode(withDefaultCosts(gasMap["BigIntAPICost"], defaultBigIntAPICosts), bigIntOps)
 	if err != nil {
 		return nil, err
 	}
@@ -48,6 +48,17 @@ func CreateGasConfig(gasMap GasScheduleMap) (*GasCost, error) {
 		return nil, err
 	}
 
+	managedBufferOps := &ManagedBufferAPICost{}
+	err = mapstructure.Decode(withDefaultCosts(gasMap["ManagedBufferAPICost"], defaultManagedBufferAPICosts), managedBufferOps)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkForZeroUint64Fields(*managedBufferOps)
+	if err != nil {
+		return nil, err
+	}
+
 	ethOps := &EthAPICost{}
 	err = mapstructure.Decode(gasMap["EthAPICost"], ethOps)
 	if err != nil {
@@ -60,7 +71,7 @@ func CreateGasConfig(gasMap GasScheduleMap) (*GasCost, error) {
 	}
 
 	cryptOps := &CryptoAPICost{}
-	err = mapstructure.Decode(gasMap["CryptoAPICost"], cryptOps)
+	err = mapstructure.Decode(withDefaultCosts(gasMap["CryptoAPICost"], defaultCryptoAPICosts), cryptOps)
 	if err != nil {
 		return nil, err
 	}
@@ -81,18 +92,76 @@ func CreateGasConfig(gasMap GasScheduleMap) (*GasCost, error) {
 		return nil, err
 	}
 
+	codePolicy, err := CreateWASMCodePolicy(gasMap)
+	if err != nil {
+		return nil, err
+	}
+
 	gasCost := &GasCost{
-		BaseOperationCost: *baseOps,
-		BigIntAPICost:     *bigIntOps,
-		EthAPICost:        *ethOps,
-		BaseOpsAPICost:    *baseOpsAPI,
-		CryptoAPICost:     *cryptOps,
-		WASMOpcodeCost:    *opcodeCosts,
+		BaseOperationCost:    *baseOps,
+		BigIntAPICost:        *bigIntOps,
+		ManagedBufferAPICost: *managedBufferOps,
+		EthAPICost:           *ethOps,
+		BaseOpsAPICost:       *baseOpsAPI,
+		CryptoAPICost:        *cryptOps,
+		WASMOpcodeCost:       *opcodeCosts,
+		WASMCodePolicy:       *codePolicy,
 	}
 
 	return gasCost, nil
 }
 
+// defaultBigIntAPICosts price the big int operations added after the basic
+// arithmetic ones for the older gas schedules, as in gasScheduleV3.toml
+var defaultBigIntAPICosts = map[string]uint64{
+	"BigIntPow":  6000,
+	"BigIntSqrt": 6000,
+	"BigIntLog2": 2000,
+	"BigIntMin":  2000,
+	"BigIntMax":  2000,
+}
+
+// defaultCryptoAPICosts price the crypto operations added after the single key
+// signature verifications for the older gas schedules, as in gasScheduleV3.toml
+var defaultCryptoAPICosts = map[string]uint64{
+	"VerifyBLSAggregatedPerKey":   1000000,
+	"VerifyBLSMultiSigPerKey":     2500000,
+	"RecoverSecp256k1":            2000000,
+	"EncodeSecp256k1DerSignature": 10000,
+	"DecodeSecp256k1DerSignature": 10000,
+}
+
+// defaultManagedBufferAPICosts price the managed buffer operations for the gas
+// schedules written before they were added, as in gasScheduleV3.toml
+var defaultManagedBufferAPICosts = map[string]uint64{
+	"MBufferNew":          2000,
+	"MBufferNewFromBytes": 2000,
+	"MBufferGetLength":    2000,
+	"MBufferGetBytes":     2000,
+	"MBufferSetBytes":     2000,
+	"MBufferAppend":       2000,
+	"MBufferAppendBytes":  2000,
+	"MBufferStorageStore": 250000,
+	"MBufferStorageLoad":  100000,
+	"MBufferGetArgument":  1000,
+	"MBufferFinish":       1000,
+}
+
+// withDefaultCosts completes the costs of a gas schedule section with the
+// default costs of the operations it does not price at all, so the older gas
+// schedules remain valid; an operation priced at 0 is still rejected
+func withDefaultCosts(costs map[string]uint64, defaults map[string]uint64) map[string]uint64 {
+	completed := make(map[string]uint64, len(costs)+len(defaults))
+	for operation, cost := range defaults {
+		completed[operation] = cost
+	}
+	for operation, cost := range costs {
+		completed[operation] = cost
+	}
+
+	return completed
+}
+
 func checkForZeroUint64Fields(arg interface{}) error {
 	v := reflect.ValueOf(arg)
 	for i := 0; i < v.NumField(); i++ {
@@ -121,6 +190,7 @@ func FillGasMap(gasMap GasScheduleMap, value, asyncCallbackGasLock uint64) GasSc
 	gasMap["BaseOpsAPICost"] = FillGasMap_BaseOpsAPICosts(value, asyncCallbackGasLock)
 	gasMap["EthAPICost"] = FillGasMap_EthereumAPICosts(value)
 	gasMap["BigIntAPICost"] = FillGasMap_BigIntAPICosts(value)
+	gasMap["ManagedBufferAPICost"] = FillGasMap_ManagedBufferAPICosts(value)
 	gasMap["CryptoAPICost"] = FillGasMap_CryptoAPICosts(value)
 	gasMap["WASMOpcodeCost"] = FillGasMap_WASMOpcodeValues(value)
 
@@ -279,6 +349,11 @@ func FillGasMap_BigIntAPICosts(value uint64) map[string]uint64 {
 	gasMap["BigIntXor"] = value
 	gasMap["BigIntShr"] = value
 	gasMap["BigIntShl"] = value
+	gasMap["BigIntPow"] = value
+	gasMap["BigIntSqrt"] = value
+	gasMap["BigIntLog2"] = value
+	gasMap["BigIntMin"] = value
+	gasMap["BigIntMax"] = value
 	gasMap["BigIntFinishUnsigned"] = value
 	gasMap["BigIntFinishSigned"] = value
 	gasMap["BigIntStorageLoadUnsigned"] = value
@@ -291,14 +366,36 @@ func FillGasMap_BigIntAPICosts(value uint64) map[string]uint64 {
 	return gasMap
 }
 
+func FillGasMap_ManagedBufferAPICosts(value uint64) map[string]uint64 {
+	gasMap := make(map[string]uint64)
+	gasMap["MBufferNew"] = value
+	gasMap["MBufferNewFromBytes"] = value
+	gasMap["MBufferGetLength"] = value
+	gasMap["MBufferGetBytes"] = value
+	gasMap["MBufferSetBytes"] = value
+	gasMap["MBufferAppend"] = value
+	gasMap["MBufferAppendBytes"] = value
+	gasMap["MBufferStorageStore"] = value
+	gasMap["MBufferStorageLoad"] = value
+	gasMap["MBufferGetArgument"] = value
+	gasMap["MBufferFinish"] = value
+
+	return gasMap
+}
+
 func FillGasMap_CryptoAPICosts(value uint64) map[string]uint64 {
 	gasMap := make(map[string]uint64)
 	gasMap["SHA256"] = value
 	gasMap["Keccak256"] = value
 	gasMap["Ripemd160"] = value
 	gasMap["VerifyBLS"] = value
+	gasMap["VerifyBLSAggregatedPerKey"] = value
+	gasMap["VerifyBLSMultiSigPerKey"] = value
 	gasMap["VerifyEd25519"] = value
 	gasMap["VerifySecp256k1"] = value
+	gasMap["RecoverSecp256k1"] = value
+	gasMap["EncodeSecp256k1DerSignature"] = value
+	gasMap["DecodeSecp256k1DerSignature"] = value
 
 	return gasMap
 }
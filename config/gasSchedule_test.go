@@ -74,3 +74,68 @@ func TestDecode_ZeroGasCostError(t *testing.T) {
 	err = checkForZeroUint64Fields(*wasmCosts)
 	assert.Error(t, err)
 }
+
+func TestCreateWASMCodePolicy(t *testing.T) {
+	gasMap := MakeGasMapForTests()
+
+	gasCost, err := CreateGasConfig(gasMap)
+	assert.Nil(t, err)
+	assert.True(t, gasCost.WASMCodePolicy.IsEmpty())
+
+	gasMap["WASMCodePolicy"] = map[string]uint64{
+		"RejectFloatingPoint": 1,
+		"MaxExports":          10,
+		"MaxMemoryPages":      20,
+	}
+	gasMap["WASMForbiddenImports"] = map[string]uint64{
+		"getGasLeft":   1,
+		"getBlockHash": 0,
+	}
+
+	gasCost, err = CreateGasConfig(gasMap)
+	assert.Nil(t, err)
+
+	policy := gasCost.WASMCodePolicy
+	assert.False(t, policy.IsEmpty())
+	assert.True(t, policy.RejectFloatingPoint)
+	assert.Equal(t, uint64(10), policy.MaxExports)
+	assert.Equal(t, uint64(20), policy.MaxMemoryPages)
+	assert.Equal(t, uint64(0), policy.MaxFunctions)
+	assert.Equal(t, map[string]struct{}{"getGasLeft": {}}, policy.ForbiddenImports)
+}
+
+func TestCreateGasConfig_DefaultsMissingCosts(t *testing.T) {
+	gasMap := MakeGasMapForTests()
+	delete(gasMap, "ManagedBufferAPICost")
+
+	gasCost, err := CreateGasConfig(gasMap)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2000), gasCost.ManagedBufferAPICost.MBufferNew)
+	assert.Equal(t, uint64(250000), gasCost.ManagedBufferAPICost.MBufferStorageStore)
+
+	gasMap["ManagedBufferAPICost"] = map[string]uint64{"MBufferNew": 7}
+	gasCost, err = CreateGasConfig(gasMap)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(7), gasCost.ManagedBufferAPICost.MBufferNew)
+	assert.Equal(t, uint64(2000), gasCost.ManagedBufferAPICost.MBufferAppend)
+
+	gasMap["ManagedBufferAPICost"] = map[string]uint64{"MBufferNew": 0}
+	_, err = CreateGasConfig(gasMap)
+	assert.Error(t, err)
+
+	bigIntOps := gasMap["BigIntAPICost"]
+	delete(bigIntOps, "BigIntPow")
+	delete(bigIntOps, "BigIntMax")
+	gasMap["ManagedBufferAPICost"] = map[string]uint64{"MBufferNew": 7}
+	gasCost, err = CreateGasConfig(gasMap)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(6000), gasCost.BigIntAPICost.BigIntPow)
+	assert.Equal(t, uint64(2000), gasCost.BigIntAPICost.BigIntMax)
+
+	delete(gasMap["CryptoAPICost"], "RecoverSecp256k1")
+	delete(gasMap["CryptoAPICost"], "VerifyBLSMultiSigPerKey")
+	gasCost, err = CreateGasConfig(gasMap)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2000000), gasCost.CryptoAPICost.RecoverSecp256k1)
+	assert.Equal(t, uint64(2500000), gasCost.CryptoAPICost.VerifyBLSMultiSigPerKey)
+}
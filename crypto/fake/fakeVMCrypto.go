@@ -0,0 +1,160 @@
+package fake
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/crypto"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/crypto/hashing"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/crypto/signing"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/crypto/signing/secp256k1"
+)
+
+const blsSignatureLength = 48
+const ed25519SignatureLength = 64
+const secp256k1HashLength = 32
+const secp256k1UncompressedPublicKeyLength = 65
+const secp256k1UncompressedPrefix = 0x04
+const derSequenceTag = 0x30
+
+const domainBLS = "bls"
+const domainBLSAggregated = "bls-aggregated"
+const domainBLSMultiSig = "bls-multisig"
+const domainEd25519 = "ed25519"
+const domainSecp256k1 = "secp256k1"
+const domainEcrecover = "ecrecover"
+
+// fakeVMCrypto is a deterministic, insecure crypto.VMCrypto meant for tests
+// which verify many signatures: a signature is accepted if and only if it is
+// the one produced by the matching Sign* function of this package. The hash
+// functions and the DER conversions are the real ones, being cheap.
+type fakeVMCrypto struct {
+	crypto.Hasher
+	secp256k1DER
+}
+
+type secp256k1DER interface {
+	EncodeSecp256k1DERSignature(r []byte, s []byte) ([]byte, error)
+	DecodeSecp256k1DERSignature(sig []byte) ([]byte, []byte, error)
+}
+
+// NewFakeVMCrypto creates a new deterministic fake crypto.VMCrypto
+func NewFakeVMCrypto() *fakeVMCrypto {
+	return &fakeVMCrypto{
+		Hasher:       hashing.NewHasher(),
+		secp256k1DER: secp256k1.NewSecp256k1(),
+	}
+}
+
+// SignBLS returns the signature accepted by VerifyBLS for the given key and message
+func SignBLS(key []byte, msg []byte) []byte {
+	return deriveBytes(blsSignatureLength, domainBLS, key, msg)
+}
+
+// SignBLSAggregated returns the signature accepted by
+// VerifyBLSAggregatedSignature for the given keys and message
+func SignBLSAggregated(keys [][]byte, msg []byte) []byte {
+	return deriveBytes(blsSignatureLength, domainBLSAggregated, append(copyParts(keys), msg)...)
+}
+
+// SignBLSMultiSig returns the signature accepted by VerifyBLSMultiSig for the
+// given keys and message
+func SignBLSMultiSig(keys [][]byte, msg []byte) []byte {
+	return deriveBytes(blsSignatureLength, domainBLSMultiSig, append(copyParts(keys), msg)...)
+}
+
+// SignEd25519 returns the signature accepted by VerifyEd25519 for the given key and message
+func SignEd25519(key []byte, msg []byte) []byte {
+	return deriveBytes(ed25519SignatureLength, domainEd25519, key, msg)
+}
+
+// SignSecp256k1 returns the signature accepted by VerifySecp256k1 for the
+// given key and message; it starts with a DER-like header, since the
+// verifySecp256k1 hook reads the signature length from its second byte
+func SignSecp256k1(key []byte, msg []byte) []byte {
+	body := deriveBytes(secp256k1HashLength, domainSecp256k1, key, msg)
+	return append([]byte{derSequenceTag, byte(len(body))}, body...)
+}
+
+// VerifyBLS accepts only the signature produced by SignBLS
+func (f *fakeVMCrypto) VerifyBLS(key []byte, msg []byte, sig []byte) error {
+	return checkSignature(sig, SignBLS(key, msg))
+}
+
+// VerifyBLSAggregatedSignature accepts only the signature produced by SignBLSAggregated
+func (f *fakeVMCrypto) VerifyBLSAggregatedSignature(keys [][]byte, msg []byte, sig []byte) error {
+	if len(keys) == 0 {
+		return signing.ErrInvalidPublicKey
+	}
+
+	return checkSignature(sig, SignBLSAggregated(keys, msg))
+}
+
+// VerifyBLSMultiSig accepts only the signature produced by SignBLSMultiSig
+func (f *fakeVMCrypto) VerifyBLSMultiSig(keys [][]byte, msg []byte, sig []byte) error {
+	if len(keys) == 0 {
+		return signing.ErrInvalidPublicKey
+	}
+
+	return checkSignature(sig, SignBLSMultiSig(keys, msg))
+}
+
+// VerifyEd25519 accepts only the signature produced by SignEd25519
+func (f *fakeVMCrypto) VerifyEd25519(key []byte, msg []byte, sig []byte) error {
+	return checkSignature(sig, SignEd25519(key, msg))
+}
+
+// VerifySecp256k1 accepts only the signature produced by SignSecp256k1
+func (f *fakeVMCrypto) VerifySecp256k1(key []byte, msg []byte, sig []byte) error {
+	return checkSignature(sig, SignSecp256k1(key, msg))
+}
+
+// Ecrecover returns an uncompressed-looking public key derived from all its
+// inputs, so distinct signatures recover distinct keys
+func (f *fakeVMCrypto) Ecrecover(hash []byte, recoveryID []byte, r []byte, s []byte) ([]byte, error) {
+	key := deriveBytes(secp256k1UncompressedPublicKeyLength-1, domainEcrecover, hash, recoveryID, r, s)
+	return append([]byte{secp256k1UncompressedPrefix}, key...), nil
+}
+
+func checkSignature(sig []byte, expected []byte) error {
+	if string(sig) != string(expected) {
+		return signing.ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// deriveBytes expands the SHA-256 of the domain and the length-prefixed
+// parts to the requested length
+func deriveBytes(length int, domain string, parts ...[]byte) []byte {
+	seed := sha256.New()
+	writePart(seed, []byte(domain))
+	for _, part := range parts {
+		writePart(seed, part)
+	}
+	seedHash := seed.Sum(nil)
+
+	result := make([]byte, 0, length+sha256.Size)
+	for counter := uint32(0); len(result) < length; counter++ {
+		block := sha256.New()
+		_, _ = block.Write(seedHash)
+		_ = binary.Write(block, binary.BigEndian, counter)
+		result = block.Sum(result)
+	}
+
+	return result[:length]
+}
+
+func writePart(hash io.Writer, part []byte) {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(part)))
+	_, _ = hash.Write(length[:])
+	_, _ = hash.Write(part)
+}
+
+func copyParts(parts [][]byte) [][]byte {
+	result := make([][]byte, len(parts), len(parts)+1)
+	copy(result, parts)
+	return result
+}
@@ -0,0 +1,83 @@
+package fake
+
+import (
+	"testing"
+
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/crypto"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/crypto/signing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeVMCrypto_ImplementsVMCrypto(t *testing.T) {
+	t.Parallel()
+
+	var vmCrypto crypto.VMCrypto = NewFakeVMCrypto()
+	require.NotNil(t, vmCrypto)
+}
+
+func TestFakeVMCrypto_SingleKeySignatures(t *testing.T) {
+	t.Parallel()
+
+	f := NewFakeVMCrypto()
+	key := []byte("key")
+	msg := []byte("message")
+
+	require.Len(t, SignBLS(key, msg), blsSignatureLength)
+	require.Len(t, SignEd25519(key, msg), ed25519SignatureLength)
+	require.Equal(t, len(SignSecp256k1(key, msg))-2, int(SignSecp256k1(key, msg)[1]))
+
+	require.Nil(t, f.VerifyBLS(key, msg, SignBLS(key, msg)))
+	require.Nil(t, f.VerifyEd25519(key, msg, SignEd25519(key, msg)))
+	require.Nil(t, f.VerifySecp256k1(key, msg, SignSecp256k1(key, msg)))
+
+	otherMsg := []byte("other message")
+	require.Equal(t, signing.ErrInvalidSignature, f.VerifyBLS(key, otherMsg, SignBLS(key, msg)))
+	require.Equal(t, signing.ErrInvalidSignature, f.VerifyEd25519(key, otherMsg, SignEd25519(key, msg)))
+	require.Equal(t, signing.ErrInvalidSignature, f.VerifySecp256k1(key, otherMsg, SignSecp256k1(key, msg)))
+
+	// signatures are not interchangeable between schemes
+	require.Equal(t, signing.ErrInvalidSignature, f.VerifyEd25519(key, msg, SignBLS(key, msg)))
+
+	// the boundary between key and message matters
+	require.NotEqual(t, SignBLS([]byte("ke"), []byte("ymessage")), SignBLS(key, msg))
+}
+
+func TestFakeVMCrypto_AggregatedSignatures(t *testing.T) {
+	t.Parallel()
+
+	f := NewFakeVMCrypto()
+	keys := [][]byte{[]byte("key1"), []byte("key2"), []byte("key3")}
+	msg := []byte("message")
+
+	require.Nil(t, f.VerifyBLSAggregatedSignature(keys, msg, SignBLSAggregated(keys, msg)))
+	require.Nil(t, f.VerifyBLSMultiSig(keys, msg, SignBLSMultiSig(keys, msg)))
+
+	require.NotNil(t, f.VerifyBLSAggregatedSignature(keys[:2], msg, SignBLSAggregated(keys, msg)))
+	require.NotNil(t, f.VerifyBLSMultiSig(keys, msg, SignBLSAggregated(keys, msg)))
+	require.NotNil(t, f.VerifyBLSAggregatedSignature(nil, msg, SignBLSAggregated(nil, msg)))
+
+	// signing must not modify the keys slice
+	subset := keys[:2]
+	_ = SignBLSAggregated(subset, msg)
+	require.Equal(t, []byte("key3"), keys[2])
+}
+
+func TestFakeVMCrypto_Ecrecover(t *testing.T) {
+	t.Parallel()
+
+	f := NewFakeVMCrypto()
+	hash := []byte("hash")
+	r := []byte("r")
+	s := []byte("s")
+
+	key1, err := f.Ecrecover(hash, []byte{0}, r, s)
+	require.Nil(t, err)
+	require.Len(t, key1, secp256k1UncompressedPublicKeyLength)
+	require.Equal(t, byte(secp256k1UncompressedPrefix), key1[0])
+
+	key2, _ := f.Ecrecover(hash, []byte{0}, r, s)
+	require.Equal(t, key1, key2)
+
+	key3, _ := f.Ecrecover(hash, []byte{1}, r, s)
+	require.NotEqual(t, key1, key3)
+}
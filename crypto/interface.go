@@ -13,6 +13,8 @@ type Hasher interface {
 
 type BLS interface {
 	VerifyBLS(key []byte, msg []byte, sig []byte) error
+	VerifyBLSAggregatedSignature(keys [][]byte, msg []byte, sig []byte) error
+	VerifyBLSMultiSig(keys [][]byte, msg []byte, sig []byte) error
 }
 
 type Ed25519 interface {
@@ -21,6 +23,9 @@ type Ed25519 interface {
 
 type Secp256k1 interface {
 	VerifySecp256k1(key []byte, msg []byte, sig []byte) error
+	Ecrecover(hash []byte, recoveryID []byte, r []byte, s []byte) ([]byte, error)
+	EncodeSecp256k1DERSignature(r []byte, s []byte) ([]byte, error)
+	DecodeSecp256k1DERSignature(sig []byte) ([]byte, []byte, error)
 }
 
 // VMCrypto will provide the interface to the main crypto functionalities of the vm
@@ -0,0 +1,9 @@
+package memoizing
+
+import "errors"
+
+// ErrNilVMCrypto signals that a nil crypto.VMCrypto has been provided
+var ErrNilVMCrypto = errors.New("nil VMCrypto")
+
+// ErrInvalidCapacity signals that the capacity of the verification cache is not positive
+var ErrInvalidCapacity = errors.New("invalid capacity for the verification cache")
@@ -0,0 +1,153 @@
+package memoizing
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/crypto"
+)
+
+const kindBLS = "bls"
+const kindBLSAggregated = "bls-aggregated"
+const kindBLSMultiSig = "bls-multisig"
+const kindEd25519 = "ed25519"
+const kindSecp256k1 = "secp256k1"
+
+type verificationEntry struct {
+	key    [sha256.Size]byte
+	result error
+}
+
+// memoizingVMCrypto wraps a crypto.VMCrypto and remembers the results of the
+// signature verifications, keyed by the kind of verification and its (key,
+// message, signature) arguments, evicting the least recently used result when
+// full. Hashing, key recovery and DER conversions are passed through.
+type memoizingVMCrypto struct {
+	crypto.VMCrypto
+
+	mutex    sync.Mutex
+	capacity int
+	entries  *list.List
+	byKey    map[[sha256.Size]byte]*list.Element
+}
+
+// NewMemoizingVMCrypto creates a new memoizingVMCrypto, remembering at most
+// capacity verification results
+func NewMemoizingVMCrypto(vmCrypto crypto.VMCrypto, capacity int) (*memoizingVMCrypto, error) {
+	if vmCrypto == nil {
+		return nil, ErrNilVMCrypto
+	}
+	if capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+
+	return &memoizingVMCrypto{
+		VMCrypto: vmCrypto,
+		capacity: capacity,
+		entries:  list.New(),
+		byKey:    make(map[[sha256.Size]byte]*list.Element),
+	}, nil
+}
+
+// VerifyBLS verifies a BLS signature, using the remembered result if any
+func (m *memoizingVMCrypto) VerifyBLS(key []byte, msg []byte, sig []byte) error {
+	return m.verify(cacheKey(kindBLS, [][]byte{key}, msg, sig), func() error {
+		return m.VMCrypto.VerifyBLS(key, msg, sig)
+	})
+}
+
+// VerifyBLSAggregatedSignature verifies an aggregated BLS signature, using the remembered result if any
+func (m *memoizingVMCrypto) VerifyBLSAggregatedSignature(keys [][]byte, msg []byte, sig []byte) error {
+	return m.verify(cacheKey(kindBLSAggregated, keys, msg, sig), func() error {
+		return m.VMCrypto.VerifyBLSAggregatedSignature(keys, msg, sig)
+	})
+}
+
+// VerifyBLSMultiSig verifies a BLS multi-signature, using the remembered result if any
+func (m *memoizingVMCrypto) VerifyBLSMultiSig(keys [][]byte, msg []byte, sig []byte) error {
+	return m.verify(cacheKey(kindBLSMultiSig, keys, msg, sig), func() error {
+		return m.VMCrypto.VerifyBLSMultiSig(keys, msg, sig)
+	})
+}
+
+// VerifyEd25519 verifies an ed25519 signature, using the remembered result if any
+func (m *memoizingVMCrypto) VerifyEd25519(key []byte, msg []byte, sig []byte) error {
+	return m.verify(cacheKey(kindEd25519, [][]byte{key}, msg, sig), func() error {
+		return m.VMCrypto.VerifyEd25519(key, msg, sig)
+	})
+}
+
+// VerifySecp256k1 verifies a secp256k1 signature, using the remembered result if any
+func (m *memoizingVMCrypto) VerifySecp256k1(key []byte, msg []byte, sig []byte) error {
+	return m.verify(cacheKey(kindSecp256k1, [][]byte{key}, msg, sig), func() error {
+		return m.VMCrypto.VerifySecp256k1(key, msg, sig)
+	})
+}
+
+// Len returns the number of remembered verification results
+func (m *memoizingVMCrypto) Len() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.entries.Len()
+}
+
+func (m *memoizingVMCrypto) verify(key [sha256.Size]byte, verifyFunc func() error) error {
+	m.mutex.Lock()
+	element, found := m.byKey[key]
+	if found {
+		m.entries.MoveToFront(element)
+		result := element.Value.(*verificationEntry).result
+		m.mutex.Unlock()
+		return result
+	}
+	m.mutex.Unlock()
+
+	// verifying outside the lock; concurrent misses of the same key compute
+	// the same result
+	result := verifyFunc()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, found = m.byKey[key]
+	if found {
+		return result
+	}
+
+	m.byKey[key] = m.entries.PushFront(&verificationEntry{key: key, result: result})
+	if m.entries.Len() > m.capacity {
+		oldest := m.entries.Back()
+		m.entries.Remove(oldest)
+		delete(m.byKey, oldest.Value.(*verificationEntry).key)
+	}
+
+	return result
+}
+
+// cacheKey hashes the kind of the verification and its length-prefixed arguments
+func cacheKey(kind string, keys [][]byte, msg []byte, sig []byte) [sha256.Size]byte {
+	hasher := sha256.New()
+	writePart := func(part []byte) {
+		var length [4]byte
+		binary.BigEndian.PutUint32(length[:], uint32(len(part)))
+		_, _ = hasher.Write(length[:])
+		_, _ = hasher.Write(part)
+	}
+
+	writePart([]byte(kind))
+	var numKeys [4]byte
+	binary.BigEndian.PutUint32(numKeys[:], uint32(len(keys)))
+	_, _ = hasher.Write(numKeys[:])
+	for _, key := range keys {
+		writePart(key)
+	}
+	writePart(msg)
+	writePart(sig)
+
+	var result [sha256.Size]byte
+	copy(result[:], hasher.Sum(nil))
+	return result
+}
@@ -0,0 +1,137 @@
+package memoizing
+
+import (
+	"testing"
+
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/crypto"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/crypto/fake"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/crypto/signing"
+	"github.com/stretchr/testify/require"
+)
+
+type countingVMCrypto struct {
+	crypto.VMCrypto
+	numVerifications int
+}
+
+func (c *countingVMCrypto) VerifyBLS(key []byte, msg []byte, sig []byte) error {
+	c.numVerifications++
+	return c.VMCrypto.VerifyBLS(key, msg, sig)
+}
+
+func (c *countingVMCrypto) VerifyEd25519(key []byte, msg []byte, sig []byte) error {
+	c.numVerifications++
+	return c.VMCrypto.VerifyEd25519(key, msg, sig)
+}
+
+func (c *countingVMCrypto) VerifyBLSMultiSig(keys [][]byte, msg []byte, sig []byte) error {
+	c.numVerifications++
+	return c.VMCrypto.VerifyBLSMultiSig(keys, msg, sig)
+}
+
+func TestNewMemoizingVMCrypto(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMemoizingVMCrypto(nil, 10)
+	require.Nil(t, m)
+	require.Equal(t, ErrNilVMCrypto, err)
+
+	m, err = NewMemoizingVMCrypto(fake.NewFakeVMCrypto(), 0)
+	require.Nil(t, m)
+	require.Equal(t, ErrInvalidCapacity, err)
+
+	m, err = NewMemoizingVMCrypto(fake.NewFakeVMCrypto(), 10)
+	require.Nil(t, err)
+	require.Equal(t, 0, m.Len())
+
+	var vmCrypto crypto.VMCrypto = m
+	require.NotNil(t, vmCrypto)
+}
+
+func TestMemoizingVMCrypto_RemembersResults(t *testing.T) {
+	t.Parallel()
+
+	inner := &countingVMCrypto{VMCrypto: fake.NewFakeVMCrypto()}
+	m, _ := NewMemoizingVMCrypto(inner, 10)
+
+	key := []byte("key")
+	msg := []byte("message")
+	sig := fake.SignBLS(key, msg)
+
+	require.Nil(t, m.VerifyBLS(key, msg, sig))
+	require.Nil(t, m.VerifyBLS(key, msg, sig))
+	require.Equal(t, 1, inner.numVerifications)
+
+	// failed verifications are remembered as well
+	require.Equal(t, signing.ErrInvalidSignature, m.VerifyBLS(key, []byte("other"), sig))
+	require.Equal(t, signing.ErrInvalidSignature, m.VerifyBLS(key, []byte("other"), sig))
+	require.Equal(t, 2, inner.numVerifications)
+
+	// the same arguments in another scheme are verified separately
+	require.Equal(t, signing.ErrInvalidSignature, m.VerifyEd25519(key, msg, sig))
+	require.Equal(t, 3, inner.numVerifications)
+
+	// the boundaries between the arguments are part of the key
+	require.NotNil(t, m.VerifyBLS([]byte("ke"), []byte("ymessage"), sig))
+	require.Equal(t, 4, inner.numVerifications)
+
+	require.Equal(t, 4, m.Len())
+}
+
+func TestMemoizingVMCrypto_MultipleKeys(t *testing.T) {
+	t.Parallel()
+
+	inner := &countingVMCrypto{VMCrypto: fake.NewFakeVMCrypto()}
+	m, _ := NewMemoizingVMCrypto(inner, 10)
+
+	keys := [][]byte{[]byte("key1"), []byte("key2")}
+	msg := []byte("message")
+	sig := fake.SignBLSMultiSig(keys, msg)
+
+	require.Nil(t, m.VerifyBLSMultiSig(keys, msg, sig))
+	require.Nil(t, m.VerifyBLSMultiSig(keys, msg, sig))
+	require.Equal(t, 1, inner.numVerifications)
+
+	require.NotNil(t, m.VerifyBLSMultiSig([][]byte{[]byte("key1key2")}, msg, sig))
+	require.Equal(t, 2, inner.numVerifications)
+}
+
+func TestMemoizingVMCrypto_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	inner := &countingVMCrypto{VMCrypto: fake.NewFakeVMCrypto()}
+	m, _ := NewMemoizingVMCrypto(inner, 2)
+
+	msg := []byte("message")
+	key1, key2, key3 := []byte("key1"), []byte("key2"), []byte("key3")
+
+	_ = m.VerifyBLS(key1, msg, fake.SignBLS(key1, msg))
+	_ = m.VerifyBLS(key2, msg, fake.SignBLS(key2, msg))
+	// key1 becomes the most recently used
+	_ = m.VerifyBLS(key1, msg, fake.SignBLS(key1, msg))
+	require.Equal(t, 2, inner.numVerifications)
+
+	// evicts key2
+	_ = m.VerifyBLS(key3, msg, fake.SignBLS(key3, msg))
+	require.Equal(t, 3, inner.numVerifications)
+	require.Equal(t, 2, m.Len())
+
+	_ = m.VerifyBLS(key1, msg, fake.SignBLS(key1, msg))
+	require.Equal(t, 3, inner.numVerifications)
+
+	_ = m.VerifyBLS(key2, msg, fake.SignBLS(key2, msg))
+	require.Equal(t, 4, inner.numVerifications)
+}
+
+func TestMemoizingVMCrypto_PassesThroughHashing(t *testing.T) {
+	t.Parallel()
+
+	vmCrypto := fake.NewFakeVMCrypto()
+	m, _ := NewMemoizingVMCrypto(vmCrypto, 2)
+
+	expected, _ := vmCrypto.Keccak256([]byte("data"))
+	result, err := m.Keccak256([]byte("data"))
+	require.Nil(t, err)
+	require.Equal(t, expected, result)
+	require.Equal(t, 0, m.Len())
+}
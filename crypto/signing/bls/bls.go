@@ -1,23 +1,37 @@
 package bls
 
 import (
+	"github.com/kalyan3104/k-chain-core-go/hashing/blake2b"
 	crypto "github.com/kalyan3104/k-chain-crypto-go"
 	"github.com/kalyan3104/k-chain-crypto-go/signing"
 	"github.com/kalyan3104/k-chain-crypto-go/signing/mcl"
+	"github.com/kalyan3104/k-chain-crypto-go/signing/mcl/multisig"
 	"github.com/kalyan3104/k-chain-crypto-go/signing/mcl/singlesig"
 )
 
 type bls struct {
-	keyGenerator crypto.KeyGenerator
-	signer       crypto.SingleSigner
+	suite            crypto.Suite
+	keyGenerator     crypto.KeyGenerator
+	signer           crypto.SingleSigner
+	aggregatedSigner crypto.LowLevelSignerBLS
+	multiSigner      crypto.LowLevelSignerBLS
 }
 
 func NewBLS() *bls {
 	b := &bls{}
-	suite := mcl.NewSuiteBLS12()
-	b.keyGenerator = signing.NewKeyGenerator(suite)
+	b.suite = mcl.NewSuiteBLS12()
+	b.keyGenerator = signing.NewKeyGenerator(b.suite)
 	b.signer = singlesig.NewBlsSigner()
 
+	// the aggregated signature is a plain sum of signature shares, which
+	// assumes the keys have proven possession of their private keys
+	b.aggregatedSigner = &multisig.BlsMultiSignerKOSK{}
+
+	// the multi-signature weights each share by a hash of all the public keys
+	// to protect against rogue key attacks; the hasher size is fixed by the scheme
+	hasher, _ := blake2b.NewBlake2bWithSize(multisig.HasherOutputSize)
+	b.multiSigner = &multisig.BlsMultiSigner{Hasher: hasher}
+
 	return b
 }
 
@@ -29,3 +43,39 @@ func (b *bls) VerifyBLS(key []byte, msg []byte, sig []byte) error {
 
 	return b.signer.Verify(publicKey, msg, sig)
 }
+
+// VerifyBLSAggregatedSignature verifies a signature obtained by adding up the
+// signatures of all the given keys over the same message
+func (b *bls) VerifyBLSAggregatedSignature(keys [][]byte, msg []byte, sig []byte) error {
+	publicKeys, err := b.publicKeysFromByteArrays(keys)
+	if err != nil {
+		return err
+	}
+
+	return b.aggregatedSigner.VerifyAggregatedSig(b.suite, publicKeys, sig, msg)
+}
+
+// VerifyBLSMultiSig verifies a rogue-key resistant multi-signature of all the
+// given keys over the same message
+func (b *bls) VerifyBLSMultiSig(keys [][]byte, msg []byte, sig []byte) error {
+	publicKeys, err := b.publicKeysFromByteArrays(keys)
+	if err != nil {
+		return err
+	}
+
+	return b.multiSigner.VerifyAggregatedSig(b.suite, publicKeys, sig, msg)
+}
+
+func (b *bls) publicKeysFromByteArrays(keys [][]byte) ([]crypto.PublicKey, error) {
+	publicKeys := make([]crypto.PublicKey, 0, len(keys))
+	for _, key := range keys {
+		publicKey, err := b.keyGenerator.PublicKeyFromByteArray(key)
+		if err != nil {
+			return nil, err
+		}
+
+		publicKeys = append(publicKeys, publicKey)
+	}
+
+	return publicKeys, nil
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	crypto "github.com/kalyan3104/k-chain-crypto-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -33,3 +34,73 @@ func splitString(t testing.TB, str string) ([]byte, []byte, []byte) {
 
 	return pkBuff, msgBuff, sigBuff
 }
+
+func TestBls_VerifyBLSAggregatedSignature(t *testing.T) {
+	t.Parallel()
+
+	b := NewBLS()
+	msg := []byte("quorum message")
+	keys, sig := createAggregatedSignature(t, b, b.aggregatedSigner, 5, msg)
+
+	require.Nil(t, b.VerifyBLSAggregatedSignature(keys, msg, sig))
+	require.NotNil(t, b.VerifyBLSAggregatedSignature(keys, []byte("other message"), sig))
+	require.NotNil(t, b.VerifyBLSAggregatedSignature(keys[1:], msg, sig))
+	require.NotNil(t, b.VerifyBLSAggregatedSignature(nil, msg, sig))
+
+	// the plain aggregation is not valid under the rogue-key resistant scheme
+	require.NotNil(t, b.VerifyBLSMultiSig(keys, msg, sig))
+}
+
+func TestBls_VerifyBLSMultiSig(t *testing.T) {
+	t.Parallel()
+
+	b := NewBLS()
+	msg := []byte("quorum message")
+	keys, sig := createAggregatedSignature(t, b, b.multiSigner, 5, msg)
+
+	require.Nil(t, b.VerifyBLSMultiSig(keys, msg, sig))
+	require.NotNil(t, b.VerifyBLSMultiSig(keys, []byte("other message"), sig))
+	require.NotNil(t, b.VerifyBLSMultiSig(keys[1:], msg, sig))
+	require.NotNil(t, b.VerifyBLSAggregatedSignature(keys, msg, sig))
+}
+
+func TestBls_VerifyAggregatedWithInvalidKeyShouldErr(t *testing.T) {
+	t.Parallel()
+
+	b := NewBLS()
+	msg := []byte("quorum message")
+	keys, sig := createAggregatedSignature(t, b, b.multiSigner, 2, msg)
+	keys[0] = keys[0][1:]
+
+	assert.NotNil(t, b.VerifyBLSAggregatedSignature(keys, msg, sig))
+	assert.NotNil(t, b.VerifyBLSMultiSig(keys, msg, sig))
+}
+
+func createAggregatedSignature(
+	t testing.TB,
+	b *bls,
+	signer crypto.LowLevelSignerBLS,
+	numSigners int,
+	msg []byte,
+) ([][]byte, []byte) {
+	keys := make([][]byte, 0, numSigners)
+	pubKeys := make([]crypto.PublicKey, 0, numSigners)
+	sigShares := make([][]byte, 0, numSigners)
+	for i := 0; i < numSigners; i++ {
+		privKey, pubKey := b.keyGenerator.GeneratePair()
+		sigShare, err := signer.SignShare(privKey, msg)
+		require.Nil(t, err)
+
+		key, err := pubKey.ToByteArray()
+		require.Nil(t, err)
+
+		keys = append(keys, key)
+		pubKeys = append(pubKeys, pubKey)
+		sigShares = append(sigShares, sigShare)
+	}
+
+	sig, err := signer.AggregateSignatures(b.suite, sigShares, pubKeys)
+	require.Nil(t, err)
+
+	return keys, sig
+}
@@ -9,3 +9,9 @@ var ErrInvalidPublicKey = errors.New("public key is invalid")
 
 // ErrInvalidSignature will be returned when ed25519 signature verification fails
 var ErrInvalidSignature = errors.New("invalid signature")
+
+// ErrInvalidRecoveryID is raised when a secp256k1 recovery id is neither 0, 1, 27 nor 28
+var ErrInvalidRecoveryID = errors.New("invalid recovery id")
+
+// ErrInvalidSignatureComponent is raised when the r or s component of a secp256k1 signature is not a 32-byte value in range
+var ErrInvalidSignatureComponent = errors.New("invalid signature component")
@@ -34,3 +34,124 @@ func (sec *secp256k1) VerifySecp256k1(key []byte, msg []byte, sig []byte) error
 
 	return nil
 }
+
+// Ecrecover recovers the uncompressed public key which produced the (r, s)
+// signature of the given hash; the recovery id is either 0/1 or the
+// Ethereum-style 27/28, encoded as a big-endian number
+func (sec *secp256k1) Ecrecover(hash []byte, recoveryID []byte, r []byte, s []byte) ([]byte, error) {
+	v, err := parseRecoveryID(recoveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r) != componentLength || len(s) != componentLength {
+		return nil, signing.ErrInvalidSignatureComponent
+	}
+
+	compactSig := make([]byte, 0, 1+2*componentLength)
+	compactSig = append(compactSig, compactSigMagicOffset+v)
+	compactSig = append(compactSig, r...)
+	compactSig = append(compactSig, s...)
+
+	pubKey, _, err := ecdsa.RecoverCompact(compactSig, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return pubKey.SerializeUncompressed(), nil
+}
+
+// EncodeSecp256k1DERSignature encodes the 32-byte r and s components of a
+// signature in DER format; s is kept as provided, even when it is not in
+// its low form
+func (sec *secp256k1) EncodeSecp256k1DERSignature(r []byte, s []byte) ([]byte, error) {
+	err := checkSignatureComponent(r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkSignatureComponent(s)
+	if err != nil {
+		return nil, err
+	}
+
+	rInt := derInteger(r)
+	sInt := derInteger(s)
+
+	sig := make([]byte, 0, 6+len(rInt)+len(sInt))
+	sig = append(sig, derSequenceTag, byte(4+len(rInt)+len(sInt)))
+	sig = append(sig, derIntegerTag, byte(len(rInt)))
+	sig = append(sig, rInt...)
+	sig = append(sig, derIntegerTag, byte(len(sInt)))
+	sig = append(sig, sInt...)
+
+	return sig, nil
+}
+
+// DecodeSecp256k1DERSignature extracts the r and s components of a DER
+// signature, each left-padded to 32 bytes
+func (sec *secp256k1) DecodeSecp256k1DERSignature(sig []byte) ([]byte, []byte, error) {
+	signature, err := ecdsa.ParseDERSignature(sig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := signature.R()
+	s := signature.S()
+	rBytes := r.Bytes()
+	sBytes := s.Bytes()
+
+	return rBytes[:], sBytes[:], nil
+}
+
+const componentLength = 32
+const compactSigMagicOffset = 27
+const derSequenceTag = 0x30
+const derIntegerTag = 0x02
+
+func parseRecoveryID(recoveryID []byte) (byte, error) {
+	for len(recoveryID) > 1 && recoveryID[0] == 0 {
+		recoveryID = recoveryID[1:]
+	}
+	if len(recoveryID) != 1 {
+		return 0, signing.ErrInvalidRecoveryID
+	}
+
+	v := recoveryID[0]
+	if v >= compactSigMagicOffset {
+		v -= compactSigMagicOffset
+	}
+	if v > 1 {
+		return 0, signing.ErrInvalidRecoveryID
+	}
+
+	return v, nil
+}
+
+func checkSignatureComponent(component []byte) error {
+	if len(component) != componentLength {
+		return signing.ErrInvalidSignatureComponent
+	}
+
+	var scalar btcec.ModNScalar
+	overflow := scalar.SetByteSlice(component)
+	if overflow || scalar.IsZero() {
+		return signing.ErrInvalidSignatureComponent
+	}
+
+	return nil
+}
+
+// derInteger returns the minimal big-endian encoding of an unsigned value,
+// prefixed with a zero byte when the high bit is set
+func derInteger(value []byte) []byte {
+	for len(value) > 1 && value[0] == 0 {
+		value = value[1:]
+	}
+
+	if value[0]&0x80 == 0 {
+		return value
+	}
+
+	return append([]byte{0}, value...)
+}
@@ -0,0 +1,185 @@
+package secp256k1
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/crypto/signing"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+// signed transaction from the EIP-155 specification: nonce 9, gas price
+// 20 gwei, gas limit 21000, 1 ether sent to 0x3535...35, chain id 1, v = 37
+const eip155SigningHash = "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53"
+const eip155PrivateKey = "4646464646464646464646464646464646464646464646464646464646464646"
+const eip155R = "18515461264373351373200002665853028612451056578545711640558177340181847433846"
+const eip155S = "46948507304638947509940763649030358759909902576025900602547168820602576006531"
+const eip155Sender = "9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f"
+
+// ecrecover precompile vector used by go-ethereum, v = 27
+const precompileHash = "38d18acb67d25c8bb9942764b62f18e17054f66a817bd4295423adf9ed98873e"
+const precompileR = "38d18acb67d25c8bb9942764b62f18e17054f66a817bd4295423adf9ed98873e"
+const precompileS = "789d1dd423d25f0772d2748d60f7e4b81bb14d086eba8e8e8efb6dcff8a4ae02"
+const precompileSender = "ceaccac640adf55b2028469bd36ba501f28b699d"
+
+func TestSecp256k1_EcrecoverEIP155Transaction(t *testing.T) {
+	t.Parallel()
+
+	sec := NewSecp256k1()
+	hash := decodeHex(t, eip155SigningHash)
+	r := decimalTo32Bytes(t, eip155R)
+	s := decimalTo32Bytes(t, eip155S)
+
+	// EIP-155: v = chainId*2 + 35 + recoveryId
+	chainID := 1
+	recoveryID := byte(37 - 35 - 2*chainID)
+
+	pubKey, err := sec.Ecrecover(hash, []byte{recoveryID}, r, s)
+	require.Nil(t, err)
+	require.Equal(t, eip155Sender, ethereumAddress(pubKey))
+
+	privKey, _ := btcec.PrivKeyFromBytes(decodeHex(t, eip155PrivateKey))
+	require.Equal(t, privKey.PubKey().SerializeUncompressed(), pubKey)
+
+	// the same signature must not recover the sender with the other recovery id
+	pubKey, err = sec.Ecrecover(hash, []byte{28}, r, s)
+	if err == nil {
+		require.NotEqual(t, eip155Sender, ethereumAddress(pubKey))
+	}
+}
+
+func TestSecp256k1_EcrecoverPrecompileVector(t *testing.T) {
+	t.Parallel()
+
+	sec := NewSecp256k1()
+	hash := decodeHex(t, precompileHash)
+	r := decodeHex(t, precompileR)
+	s := decodeHex(t, precompileS)
+
+	// the precompile receives v as a 32-byte word
+	v := make([]byte, 32)
+	v[31] = 27
+
+	pubKey, err := sec.Ecrecover(hash, v, r, s)
+	require.Nil(t, err)
+	require.Equal(t, precompileSender, ethereumAddress(pubKey))
+}
+
+func TestSecp256k1_EcrecoverInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	sec := NewSecp256k1()
+	hash := decodeHex(t, precompileHash)
+	r := decodeHex(t, precompileR)
+	s := decodeHex(t, precompileS)
+
+	_, err := sec.Ecrecover(hash, []byte{29}, r, s)
+	require.Equal(t, signing.ErrInvalidRecoveryID, err)
+
+	_, err = sec.Ecrecover(hash, []byte{1, 27}, r, s)
+	require.Equal(t, signing.ErrInvalidRecoveryID, err)
+
+	_, err = sec.Ecrecover(hash, []byte{}, r, s)
+	require.Equal(t, signing.ErrInvalidRecoveryID, err)
+
+	_, err = sec.Ecrecover(hash, []byte{0}, r[1:], s)
+	require.Equal(t, signing.ErrInvalidSignatureComponent, err)
+
+	_, err = sec.Ecrecover(hash, []byte{0}, make([]byte, 32), s)
+	require.NotNil(t, err)
+}
+
+func TestSecp256k1_DERSignatureRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	sec := NewSecp256k1()
+	r := decimalTo32Bytes(t, eip155R)
+	s := decimalTo32Bytes(t, eip155S)
+
+	der, err := sec.EncodeSecp256k1DERSignature(r, s)
+	require.Nil(t, err)
+	require.Equal(t, byte(0x30), der[0])
+	require.Equal(t, len(der)-2, int(der[1]))
+
+	// the encoding must be accepted by the strict DER parser and verify
+	// against the sender key
+	parsed, err := ecdsa.ParseDERSignature(der)
+	require.Nil(t, err)
+	privKey, _ := btcec.PrivKeyFromBytes(decodeHex(t, eip155PrivateKey))
+	require.True(t, parsed.Verify(decodeHex(t, eip155SigningHash), privKey.PubKey()))
+
+	decodedR, decodedS, err := sec.DecodeSecp256k1DERSignature(der)
+	require.Nil(t, err)
+	require.Equal(t, r, decodedR)
+	require.Equal(t, s, decodedS)
+}
+
+func TestSecp256k1_EncodeDERSignaturePadding(t *testing.T) {
+	t.Parallel()
+
+	sec := NewSecp256k1()
+
+	// high bit set: a zero byte is prepended to keep the integer positive
+	r := decodeHex(t, "ff00000000000000000000000000000000000000000000000000000000000001")
+	// leading zeros are stripped
+	s := make([]byte, 32)
+	s[31] = 0x7f
+
+	der, err := sec.EncodeSecp256k1DERSignature(r, s)
+	require.Nil(t, err)
+
+	expected := append([]byte{0x30, 0x26, 0x02, 0x21, 0x00}, r...)
+	expected = append(expected, 0x02, 0x01, 0x7f)
+	require.Equal(t, expected, der)
+
+	decodedR, decodedS, err := sec.DecodeSecp256k1DERSignature(der)
+	require.Nil(t, err)
+	require.Equal(t, r, decodedR)
+	require.Equal(t, s, decodedS)
+}
+
+func TestSecp256k1_DERSignatureInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	sec := NewSecp256k1()
+	s := decimalTo32Bytes(t, eip155S)
+
+	_, err := sec.EncodeSecp256k1DERSignature(make([]byte, 32), s)
+	require.Equal(t, signing.ErrInvalidSignatureComponent, err)
+
+	_, err = sec.EncodeSecp256k1DERSignature(s[:31], s)
+	require.Equal(t, signing.ErrInvalidSignatureComponent, err)
+
+	// the group order itself is out of range
+	n := decodeHex(t, "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141")
+	_, err = sec.EncodeSecp256k1DERSignature(n, s)
+	require.Equal(t, signing.ErrInvalidSignatureComponent, err)
+
+	_, _, err = sec.DecodeSecp256k1DERSignature([]byte{0x30, 0x00})
+	require.NotNil(t, err)
+}
+
+func ethereumAddress(pubKey []byte) string {
+	hash := sha3.NewLegacyKeccak256()
+	_, _ = hash.Write(pubKey[1:])
+	return hex.EncodeToString(hash.Sum(nil)[12:])
+}
+
+func decodeHex(t testing.TB, str string) []byte {
+	buff, err := hex.DecodeString(str)
+	require.Nil(t, err)
+
+	return buff
+}
+
+func decimalTo32Bytes(t testing.TB, str string) []byte {
+	value, ok := big.NewInt(0).SetString(str, 10)
+	require.True(t, ok)
+
+	buff := make([]byte, 32)
+	return value.FillBytes(buff)
+}
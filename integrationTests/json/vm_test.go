@@ -64,6 +64,10 @@ func TestRustBasicFeaturesNoSmallIntApi(t *testing.T) {
 	runAllTestsInFolder(t, "features/basic-features-no-small-int-api/scenarios")
 }
 
+func TestEwasmCounter(t *testing.T) {
+	runAllTestsInFolder(t, "features/ewasm-counter/scenarios")
+}
+
 // Backwards compatibility.
 func TestRustBasicFeaturesLegacy(t *testing.T) {
 	if testing.Short() {
@@ -91,6 +95,10 @@ func TestRustBasicFeaturesLegacy(t *testing.T) {
 // 	})
 // }
 
+func TestCrossShardRounds(t *testing.T) {
+	runSingleTest(t, "features/async/scenarios", "crossShard_rounds.scen.json")
+}
+
 func TestDelegation_v0_2(t *testing.T) {
 	if testing.Short() {
 		t.Skip("not a short test")
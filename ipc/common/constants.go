@@ -2,3 +2,7 @@ package common
 
 // EnvVarVMPath is an environment variable
 const EnvVarVMPath = "VM_PATH"
+
+// EnvVarVMRecordingsDirectory is an environment variable; when set, the VM
+// process saves the recording of each execution in the given directory
+const EnvVarVMRecordingsDirectory = "VM_RECORDINGS_DIRECTORY"
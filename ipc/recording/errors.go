@@ -0,0 +1,21 @@
+package recording
+
+import "errors"
+
+// ErrNilBlockchainHook signals that a nil blockchain hook has been provided
+var ErrNilBlockchainHook = errors.New("nil blockchain hook")
+
+// ErrNilVMExecutionHandler signals that a nil VM has been provided
+var ErrNilVMExecutionHandler = errors.New("nil VM execution handler")
+
+// ErrNilVMHostParameters signals that nil VM host parameters have been provided
+var ErrNilVMHostParameters = errors.New("nil VM host parameters")
+
+// ErrNilRecording signals that a nil recording has been provided
+var ErrNilRecording = errors.New("nil recording")
+
+// ErrBadRecordedRequest signals that the recording does not hold a contract deploy or call request
+var ErrBadRecordedRequest = errors.New("the recording does not hold a contract deploy or call request")
+
+// ErrHookCallNotRecorded signals that the replayed execution made a blockchain hook call which was not recorded
+var ErrHookCallNotRecorded = errors.New("blockchain hook call not recorded")
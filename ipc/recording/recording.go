@@ -0,0 +1,180 @@
+package recording
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"sort"
+
+	"github.com/kalyan3104/k-chain-core-go/core"
+	logger "github.com/kalyan3104/k-chain-logger-go"
+	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/config"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/ipc/common"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/mock"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+)
+
+var log = logger.GetOrCreate("vm/recording")
+
+// RecordedMessage is an IPC message, serialized at the moment it was recorded,
+// along with its kind, so that it can be deserialized
+type RecordedMessage struct {
+	Kind common.MessageKind
+	Data json.RawMessage
+}
+
+// HookCall is a blockchain hook call, as the request and the response messages
+// of the IPC protocol
+type HookCall struct {
+	Request  *RecordedMessage
+	Response *RecordedMessage
+}
+
+// Recording holds a contract execution: the deploy or call request, all the
+// blockchain hook calls made while executing it, in the order they were made,
+// and the response, along with the VM host parameters needed to rerun it
+type Recording struct {
+	VMType                   []byte
+	BlockGasLimit            uint64
+	GasSchedule              config.GasScheduleMap
+	ProtocolBuiltinFunctions vmcommon.FunctionNames
+	ProtectedKeyPrefix       []byte
+	EnabledFlags             []core.EnableEpochFlag
+	Request                  *RecordedMessage
+	HookCalls                []*HookCall
+	Response                 *RecordedMessage
+}
+
+func newRecordedMessage(message common.MessageHandler) *RecordedMessage {
+	data, err := json.Marshal(message)
+	if err != nil {
+		log.Error("cannot serialize message", "kind", message.GetKindName(), "error", err)
+	}
+
+	return &RecordedMessage{
+		Kind: message.GetKind(),
+		Data: data,
+	}
+}
+
+// newRecordedContractResponse records the response of an execution with the
+// output accounts and their storage updates sorted, so that the responses of
+// two executions can be compared
+func newRecordedContractResponse(vmOutput *vmcommon.VMOutput, err error) *RecordedMessage {
+	response := common.NewMessageContractResponse(vmOutput, err)
+
+	accounts := response.SerializableVMOutput.CorrectedOutputAccounts
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i].Address, accounts[j].Address) < 0
+	})
+	for _, account := range accounts {
+		updates := account.StorageUpdates
+		sort.Slice(updates, func(i, j int) bool {
+			return bytes.Compare(updates[i].Offset, updates[j].Offset) < 0
+		})
+	}
+
+	return newRecordedMessage(response)
+}
+
+// Handler deserializes the recorded message
+func (message *RecordedMessage) Handler() (common.MessageHandler, error) {
+	handler := common.CreateMessage(message.Kind)
+	err := json.Unmarshal(message.Data, handler)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler, nil
+}
+
+// SaveRecording writes the recording as JSON to the file at the provided path
+func SaveRecording(recording *Recording, path string) error {
+	if recording == nil {
+		return ErrNilRecording
+	}
+
+	data, err := json.MarshalIndent(recording, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadRecording reads a recording written by SaveRecording
+func LoadRecording(path string) (*Recording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	recording := &Recording{}
+	err = json.Unmarshal(data, recording)
+	if err != nil {
+		return nil, err
+	}
+
+	return recording, nil
+}
+
+// VMHostParameters returns the parameters of the VM host which made the
+// recording; the epoch flags are reported as they were during the execution
+func (recording *Recording) VMHostParameters() *vmhost.VMHostParameters {
+	enabledFlags := make(map[core.EnableEpochFlag]struct{}, len(recording.EnabledFlags))
+	for _, flag := range recording.EnabledFlags {
+		enabledFlags[flag] = struct{}{}
+	}
+
+	return &vmhost.VMHostParameters{
+		VMType:                   recording.VMType,
+		BlockGasLimit:            recording.BlockGasLimit,
+		GasSchedule:              recording.GasSchedule,
+		ProtocolBuiltinFunctions: recording.ProtocolBuiltinFunctions,
+		ProtectedKeyPrefix:       recording.ProtectedKeyPrefix,
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				_, isEnabled := enabledFlags[flag]
+				return isEnabled
+			},
+		},
+	}
+}
+
+// Run executes the recorded request on the provided VM, which is expected to
+// use a ReplayBlockchainHook created from the same recording
+func (recording *Recording) Run(vm vmcommon.VMExecutionHandler) (*vmcommon.VMOutput, error) {
+	request, err := recording.Request.Handler()
+	if err != nil {
+		return nil, err
+	}
+
+	switch typedRequest := request.(type) {
+	case *common.MessageContractDeployRequest:
+		return vm.RunSmartContractCreate(typedRequest.CreateInput)
+	case *common.MessageContractCallRequest:
+		return vm.RunSmartContractCall(typedRequest.CallInput)
+	default:
+		return nil, ErrBadRecordedRequest
+	}
+}
+
+// MatchesResponse returns true if the provided output and error are the ones
+// of the recorded execution
+func (recording *Recording) MatchesResponse(vmOutput *vmcommon.VMOutput, err error) bool {
+	response := newRecordedContractResponse(vmOutput, err)
+	return compactJSON(response.Data) == compactJSON(recording.Response.Data)
+}
+
+// compactJSON removes the insignificant space from the serialized message,
+// which is indented once the recording is saved
+func compactJSON(data []byte) string {
+	buffer := &bytes.Buffer{}
+	err := json.Compact(buffer, data)
+	if err != nil {
+		return string(data)
+	}
+
+	return buffer.String()
+}
@@ -0,0 +1,354 @@
+package recording
+
+import (
+	"sync"
+
+	"github.com/kalyan3104/k-chain-core-go/data/dcdt"
+	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/ipc/common"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+)
+
+var _ vmcommon.BlockchainHook = (*RecordingBlockchainHook)(nil)
+
+// RecordingBlockchainHook forwards the calls to the actual hook and, while an
+// execution is being recorded, records them along with their results, as IPC
+// messages. The compiled code cache and the snapshots are not recorded, being
+// local to the machine running the VM.
+type RecordingBlockchainHook struct {
+	hook         vmcommon.BlockchainHook
+	mutRecording sync.Mutex
+	isRecording  bool
+	hookCalls    []*HookCall
+}
+
+// NewRecordingBlockchainHook creates a new RecordingBlockchainHook wrapping the provided hook
+func NewRecordingBlockchainHook(hook vmcommon.BlockchainHook) (*RecordingBlockchainHook, error) {
+	if vmhost.IfNil(hook) {
+		return nil, ErrNilBlockchainHook
+	}
+
+	return &RecordingBlockchainHook{
+		hook: hook,
+	}, nil
+}
+
+func (recorder *RecordingBlockchainHook) startRecording() {
+	recorder.mutRecording.Lock()
+	recorder.isRecording = true
+	recorder.hookCalls = make([]*HookCall, 0)
+	recorder.mutRecording.Unlock()
+}
+
+func (recorder *RecordingBlockchainHook) stopRecording() []*HookCall {
+	recorder.mutRecording.Lock()
+	defer recorder.mutRecording.Unlock()
+
+	hookCalls := recorder.hookCalls
+	recorder.isRecording = false
+	recorder.hookCalls = nil
+
+	return hookCalls
+}
+
+func (recorder *RecordingBlockchainHook) record(request common.MessageHandler, response common.MessageHandler) {
+	recorder.recordMessages(newRecordedMessage(request), response)
+}
+
+func (recorder *RecordingBlockchainHook) recordMessages(request *RecordedMessage, response common.MessageHandler) {
+	recorder.mutRecording.Lock()
+	defer recorder.mutRecording.Unlock()
+
+	if !recorder.isRecording {
+		return
+	}
+
+	recorder.hookCalls = append(recorder.hookCalls, &HookCall{
+		Request:  request,
+		Response: newRecordedMessage(response),
+	})
+}
+
+// NewAddress forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) NewAddress(creatorAddress []byte, creatorNonce uint64, vmType []byte) ([]byte, error) {
+	result, err := recorder.hook.NewAddress(creatorAddress, creatorNonce, vmType)
+	recorder.record(
+		common.NewMessageBlockchainNewAddressRequest(creatorAddress, creatorNonce, vmType),
+		common.NewMessageBlockchainNewAddressResponse(result, err))
+	return result, err
+}
+
+// GetStorageData forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) GetStorageData(accountAddress []byte, index []byte) ([]byte, uint32, error) {
+	data, trieDepth, err := recorder.hook.GetStorageData(accountAddress, index)
+	recorder.record(
+		common.NewMessageBlockchainGetStorageDataRequest(accountAddress, index),
+		common.NewMessageBlockchainGetStorageDataResponse(data, err))
+	return data, trieDepth, err
+}
+
+// GetBlockhash forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) GetBlockhash(nonce uint64) ([]byte, error) {
+	result, err := recorder.hook.GetBlockhash(nonce)
+	recorder.record(
+		common.NewMessageBlockchainGetBlockhashRequest(nonce),
+		common.NewMessageBlockchainGetBlockhashResponse(result, err))
+	return result, err
+}
+
+// LastNonce forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) LastNonce() uint64 {
+	result := recorder.hook.LastNonce()
+	recorder.record(
+		common.NewMessageBlockchainLastNonceRequest(),
+		common.NewMessageBlockchainLastNonceResponse(result))
+	return result
+}
+
+// LastRound forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) LastRound() uint64 {
+	result := recorder.hook.LastRound()
+	recorder.record(
+		common.NewMessageBlockchainLastRoundRequest(),
+		common.NewMessageBlockchainLastRoundResponse(result))
+	return result
+}
+
+// LastTimeStamp forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) LastTimeStamp() uint64 {
+	result := recorder.hook.LastTimeStamp()
+	recorder.record(
+		common.NewMessageBlockchainLastTimeStampRequest(),
+		common.NewMessageBlockchainLastTimeStampResponse(result))
+	return result
+}
+
+// LastRandomSeed forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) LastRandomSeed() []byte {
+	result := recorder.hook.LastRandomSeed()
+	recorder.record(
+		common.NewMessageBlockchainLastRandomSeedRequest(),
+		common.NewMessageBlockchainLastRandomSeedResponse(result))
+	return result
+}
+
+// LastEpoch forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) LastEpoch() uint32 {
+	result := recorder.hook.LastEpoch()
+	recorder.record(
+		common.NewMessageBlockchainLastEpochRequest(),
+		common.NewMessageBlockchainLastEpochResponse(result))
+	return result
+}
+
+// GetStateRootHash forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) GetStateRootHash() []byte {
+	result := recorder.hook.GetStateRootHash()
+	recorder.record(
+		common.NewMessageBlockchainGetStateRootHashRequest(),
+		common.NewMessageBlockchainGetStateRootHashResponse(result))
+	return result
+}
+
+// CurrentNonce forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) CurrentNonce() uint64 {
+	result := recorder.hook.CurrentNonce()
+	recorder.record(
+		common.NewMessageBlockchainCurrentNonceRequest(),
+		common.NewMessageBlockchainCurrentNonceResponse(result))
+	return result
+}
+
+// CurrentRound forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) CurrentRound() uint64 {
+	result := recorder.hook.CurrentRound()
+	recorder.record(
+		common.NewMessageBlockchainCurrentRoundRequest(),
+		common.NewMessageBlockchainCurrentRoundResponse(result))
+	return result
+}
+
+// CurrentTimeStamp forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) CurrentTimeStamp() uint64 {
+	result := recorder.hook.CurrentTimeStamp()
+	recorder.record(
+		common.NewMessageBlockchainCurrentTimeStampRequest(),
+		common.NewMessageBlockchainCurrentTimeStampResponse(result))
+	return result
+}
+
+// CurrentRandomSeed forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) CurrentRandomSeed() []byte {
+	result := recorder.hook.CurrentRandomSeed()
+	recorder.record(
+		common.NewMessageBlockchainCurrentRandomSeedRequest(),
+		common.NewMessageBlockchainCurrentRandomSeedResponse(result))
+	return result
+}
+
+// CurrentEpoch forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) CurrentEpoch() uint32 {
+	result := recorder.hook.CurrentEpoch()
+	recorder.record(
+		common.NewMessageBlockchainCurrentEpochRequest(),
+		common.NewMessageBlockchainCurrentEpochResponse(result))
+	return result
+}
+
+// ProcessBuiltInFunction forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	// the request is created first, since the builtin function might alter the input
+	request := common.NewMessageBlockchainProcessBuiltinFunctionRequest(*input)
+	requestMessage := newRecordedMessage(request)
+
+	vmOutput, err := recorder.hook.ProcessBuiltInFunction(input)
+	recorder.recordMessages(requestMessage, common.NewMessageBlockchainProcessBuiltinFunctionResponse(vmOutput, err))
+	return vmOutput, err
+}
+
+// GetBuiltinFunctionNames forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) GetBuiltinFunctionNames() vmcommon.FunctionNames {
+	functionNames := recorder.hook.GetBuiltinFunctionNames()
+	recorder.record(
+		common.NewMessageBlockchainGetBuiltinFunctionNamesRequest(),
+		common.NewMessageBlockchainGetBuiltinFunctionNamesResponse(functionNames))
+	return functionNames
+}
+
+// GetAllState forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) GetAllState(address []byte) (map[string][]byte, error) {
+	state, err := recorder.hook.GetAllState(address)
+	recorder.record(
+		common.NewMessageBlockchainGetAllStateRequest(address),
+		common.NewMessageBlockchainGetAllStateResponse(state, err))
+	return state, err
+}
+
+// GetUserAccount forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	account, err := recorder.hook.GetUserAccount(address)
+
+	var recordedAccount *common.Account
+	if err == nil && !vmhost.IfNil(account) {
+		recordedAccount = &common.Account{
+			Nonce:           account.GetNonce(),
+			Address:         account.AddressBytes(),
+			Balance:         account.GetBalance(),
+			CodeMetadata:    account.GetCodeMetadata(),
+			CodeHash:        account.GetCodeHash(),
+			RootHash:        account.GetRootHash(),
+			DeveloperReward: account.GetDeveloperReward(),
+			OwnerAddress:    account.GetOwnerAddress(),
+			UserName:        account.GetUserName(),
+		}
+	}
+
+	recorder.record(
+		common.NewMessageBlockchainGetUserAccountRequest(address),
+		common.NewMessageBlockchainGetUserAccountResponse(recordedAccount, err))
+	return account, err
+}
+
+// GetCode forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) GetCode(account vmcommon.UserAccountHandler) []byte {
+	code := recorder.hook.GetCode(account)
+	recorder.record(
+		common.NewMessageBlockchainGetCodeRequest(codeRequestAccount(account)),
+		common.NewMessageBlockchainGetCodeResponse(code))
+	return code
+}
+
+// codeRequestAccount keeps only the fields which identify the code of the
+// account, since the other ones might be represented differently on replay
+func codeRequestAccount(account vmcommon.UserAccountHandler) *common.Account {
+	if vmhost.IfNil(account) {
+		return nil
+	}
+
+	return &common.Account{
+		Address:  account.AddressBytes(),
+		CodeHash: account.GetCodeHash(),
+	}
+}
+
+// GetShardOfAddress forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) GetShardOfAddress(address []byte) uint32 {
+	shard := recorder.hook.GetShardOfAddress(address)
+	recorder.record(
+		common.NewMessageBlockchainGetShardOfAddressRequest(address),
+		common.NewMessageBlockchainGetShardOfAddressResponse(shard))
+	return shard
+}
+
+// IsSmartContract forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) IsSmartContract(address []byte) bool {
+	result := recorder.hook.IsSmartContract(address)
+	recorder.record(
+		common.NewMessageBlockchainIsSmartContractRequest(address),
+		common.NewMessageBlockchainIsSmartContractResponse(result))
+	return result
+}
+
+// IsPayable forwards the call to the actual hook and records it; only the
+// receiver is recorded, as in the IPC protocol
+func (recorder *RecordingBlockchainHook) IsPayable(sndAddress []byte, recvAddress []byte) (bool, error) {
+	result, err := recorder.hook.IsPayable(sndAddress, recvAddress)
+	recorder.record(
+		common.NewMessageBlockchainIsPayableRequest(recvAddress),
+		common.NewMessageBlockchainIsPayableResponse(result, err))
+	return result, err
+}
+
+// SaveCompiledCode forwards the call to the actual hook
+func (recorder *RecordingBlockchainHook) SaveCompiledCode(codeHash []byte, code []byte) {
+	recorder.hook.SaveCompiledCode(codeHash, code)
+}
+
+// GetCompiledCode forwards the call to the actual hook
+func (recorder *RecordingBlockchainHook) GetCompiledCode(codeHash []byte) (bool, []byte) {
+	return recorder.hook.GetCompiledCode(codeHash)
+}
+
+// ClearCompiledCodes forwards the call to the actual hook
+func (recorder *RecordingBlockchainHook) ClearCompiledCodes() {
+	recorder.hook.ClearCompiledCodes()
+}
+
+// GetDCDTToken forwards the call to the actual hook and records it
+func (recorder *RecordingBlockchainHook) GetDCDTToken(address []byte, tokenID []byte, nonce uint64) (*dcdt.DCDigitalToken, error) {
+	dcdtData, err := recorder.hook.GetDCDTToken(address, tokenID, nonce)
+	recorder.record(
+		common.NewMessageBlockchainGetDCDTTokenRequest(address, tokenID, nonce),
+		common.NewMessageBlockchainGetDCDTTokenResponse(dcdtData, err))
+	return dcdtData, err
+}
+
+// IsPaused forwards the call to the actual hook - not used in v1.2
+func (recorder *RecordingBlockchainHook) IsPaused(tokenID []byte) bool {
+	return recorder.hook.IsPaused(tokenID)
+}
+
+// IsLimitedTransfer forwards the call to the actual hook - not used in v1.2
+func (recorder *RecordingBlockchainHook) IsLimitedTransfer(tokenID []byte) bool {
+	return recorder.hook.IsLimitedTransfer(tokenID)
+}
+
+// GetSnapshot forwards the call to the actual hook
+func (recorder *RecordingBlockchainHook) GetSnapshot() int {
+	return recorder.hook.GetSnapshot()
+}
+
+// RevertToSnapshot forwards the call to the actual hook
+func (recorder *RecordingBlockchainHook) RevertToSnapshot(snapshot int) error {
+	return recorder.hook.RevertToSnapshot(snapshot)
+}
+
+// ExecuteSmartContractCallOnOtherVM forwards the call to the actual hook - not used in v1.2
+func (recorder *RecordingBlockchainHook) ExecuteSmartContractCallOnOtherVM(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	return recorder.hook.ExecuteSmartContractCallOnOtherVM(input)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (recorder *RecordingBlockchainHook) IsInterfaceNil() bool {
+	return recorder == nil
+}
@@ -0,0 +1,148 @@
+package recording
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/kalyan3104/k-chain-core-go/core"
+	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/ipc/common"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost/hostCore"
+)
+
+var _ vmcommon.VMExecutionHandler = (*RecordingVM)(nil)
+
+// RecordingVM wraps a VM created with a RecordingBlockchainHook and saves the
+// recording of each contract deploy and call it executes as a JSON file in a
+// directory, to be replayed with a ReplayBlockchainHook
+type RecordingVM struct {
+	vmcommon.VMExecutionHandler
+	hook          *RecordingBlockchainHook
+	parameters    vmhost.VMHostParameters
+	directory     string
+	mutExecution  sync.Mutex
+	numRecordings uint64
+}
+
+// NewRecordingVM creates a new RecordingVM; the parameters must be the ones
+// the VM has been created with
+func NewRecordingVM(
+	vm vmcommon.VMExecutionHandler,
+	hook *RecordingBlockchainHook,
+	parameters *vmhost.VMHostParameters,
+	directory string,
+) (*RecordingVM, error) {
+	if vmhost.IfNil(vm) {
+		return nil, ErrNilVMExecutionHandler
+	}
+	if hook == nil {
+		return nil, ErrNilBlockchainHook
+	}
+	if parameters == nil {
+		return nil, ErrNilVMHostParameters
+	}
+	if vmhost.IfNil(parameters.EnableEpochsHandler) {
+		return nil, vmhost.ErrNilEnableEpochsHandler
+	}
+
+	err := os.MkdirAll(directory, os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecordingVM{
+		VMExecutionHandler: vm,
+		hook:               hook,
+		parameters:         *parameters,
+		directory:          directory,
+	}, nil
+}
+
+// RunSmartContractCreate executes the deploy on the wrapped VM and saves its recording
+func (vm *RecordingVM) RunSmartContractCreate(input *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, error) {
+	request := common.NewMessageContractDeployRequest(input)
+	return vm.record(request, input.CurrentTxHash, func() (*vmcommon.VMOutput, error) {
+		return vm.VMExecutionHandler.RunSmartContractCreate(input)
+	})
+}
+
+// RunSmartContractCall executes the call on the wrapped VM and saves its recording
+func (vm *RecordingVM) RunSmartContractCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	request := common.NewMessageContractCallRequest(input)
+	return vm.record(request, input.CurrentTxHash, func() (*vmcommon.VMOutput, error) {
+		return vm.VMExecutionHandler.RunSmartContractCall(input)
+	})
+}
+
+// GasScheduleChange changes the gas schedule of the wrapped VM, and of the next recordings
+func (vm *RecordingVM) GasScheduleChange(newGasSchedule map[string]map[string]uint64) {
+	vm.mutExecution.Lock()
+	vm.parameters.GasSchedule = newGasSchedule
+	vm.mutExecution.Unlock()
+
+	vm.VMExecutionHandler.GasScheduleChange(newGasSchedule)
+}
+
+func (vm *RecordingVM) record(
+	request common.MessageHandler,
+	txHash []byte,
+	execute func() (*vmcommon.VMOutput, error),
+) (*vmcommon.VMOutput, error) {
+	vm.mutExecution.Lock()
+	defer vm.mutExecution.Unlock()
+
+	// the request is serialized before the execution, which might alter the input
+	recording := vm.newRecording(request)
+
+	vm.hook.startRecording()
+	vmOutput, err := execute()
+	recording.HookCalls = vm.hook.stopRecording()
+	recording.Response = newRecordedContractResponse(vmOutput, err)
+
+	vm.numRecordings++
+	errSave := SaveRecording(recording, vm.recordingPath(txHash))
+	if errSave != nil {
+		log.Error("RecordingVM: cannot save recording", "err", errSave)
+	}
+
+	return vmOutput, err
+}
+
+func (vm *RecordingVM) newRecording(request common.MessageHandler) *Recording {
+	enabledFlags := make([]core.EnableEpochFlag, 0)
+	for _, flag := range hostCore.AllFlags() {
+		if vm.parameters.EnableEpochsHandler.IsFlagEnabled(flag) {
+			enabledFlags = append(enabledFlags, flag)
+		}
+	}
+
+	return &Recording{
+		VMType:                   vm.parameters.VMType,
+		BlockGasLimit:            vm.parameters.BlockGasLimit,
+		GasSchedule:              vm.parameters.GasSchedule,
+		ProtocolBuiltinFunctions: vm.parameters.ProtocolBuiltinFunctions,
+		ProtectedKeyPrefix:       vm.parameters.ProtectedKeyPrefix,
+		EnabledFlags:             enabledFlags,
+		Request:                  newRecordedMessage(request),
+	}
+}
+
+// recordingPath numbers the recordings in the order of execution, and names
+// them after the hash of the transaction, if any
+func (vm *RecordingVM) recordingPath(txHash []byte) string {
+	fileName := fmt.Sprintf("%06d.json", vm.numRecordings)
+	if len(txHash) > 0 {
+		fileName = fmt.Sprintf("%06d-%s.json", vm.numRecordings, hex.EncodeToString(txHash))
+	}
+
+	return filepath.Join(vm.directory, fileName)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (vm *RecordingVM) IsInterfaceNil() bool {
+	return vm == nil
+}
@@ -0,0 +1,220 @@
+package recording
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kalyan3104/k-chain-core-go/core"
+	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/config"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/ipc/common"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/mock"
+	contextmock "github.com/kalyan3104/k-chain-vm-v1_2-go/mock/context"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost/hostCore"
+	"github.com/stretchr/testify/require"
+)
+
+// hookReadingVM reads from its blockchain hook and returns what it has read
+type hookReadingVM struct {
+	hook vmcommon.BlockchainHook
+}
+
+func (vm *hookReadingVM) RunSmartContractCreate(_ *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, error) {
+	return nil, vmhost.ErrExecutionFailed
+}
+
+func (vm *hookReadingVM) RunSmartContractCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	account, err := vm.hook.GetUserAccount(input.RecipientAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	first, _, _ := vm.hook.GetStorageData(input.RecipientAddr, []byte("counter"))
+	second, _, _ := vm.hook.GetStorageData(input.RecipientAddr, []byte("counter"))
+	code := vm.hook.GetCode(account)
+
+	builtinOutput, err := vm.hook.ProcessBuiltInFunction(&vmcommon.ContractCallInput{
+		VMInput:       vmcommon.VMInput{CallerAddr: input.RecipientAddr, CallValue: big.NewInt(0)},
+		RecipientAddr: input.CallerAddr,
+		Function:      input.Function,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &vmcommon.VMOutput{
+		ReturnData:    [][]byte{first, second, code, builtinOutput.ReturnData[0]},
+		ReturnCode:    vmcommon.Ok,
+		GasRemaining:  vm.hook.CurrentNonce(),
+		GasRefund:     account.GetBalance(),
+		ReturnMessage: input.Function,
+	}, nil
+}
+
+func (vm *hookReadingVM) GasScheduleChange(_ map[string]map[string]uint64) {
+}
+
+func (vm *hookReadingVM) GetVersion() string {
+	return ""
+}
+
+func (vm *hookReadingVM) Close() error {
+	return nil
+}
+
+func (vm *hookReadingVM) IsInterfaceNil() bool {
+	return vm == nil
+}
+
+func createRecordedBlockchainHook() *contextmock.BlockchainHookStub {
+	storageReads := 0
+	return &contextmock.BlockchainHookStub{
+		GetUserAccountCalled: func(address []byte) (vmcommon.UserAccountHandler, error) {
+			return &common.Account{Address: address, Balance: big.NewInt(42), CodeHash: []byte("codeHash")}, nil
+		},
+		GetStorageDataCalled: func(_ []byte, _ []byte) ([]byte, uint32, error) {
+			storageReads++
+			return []byte{byte(storageReads)}, 0, nil
+		},
+		GetCodeCalled: func(account vmcommon.UserAccountHandler) []byte {
+			return []byte("code of " + string(account.AddressBytes()))
+		},
+		ProcessBuiltInFunctionCalled: func(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			return &vmcommon.VMOutput{ReturnData: [][]byte{[]byte(input.Function)}}, nil
+		},
+		CurrentNonceCalled: func() uint64 {
+			return 7
+		},
+	}
+}
+
+func createCallInput() *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:    []byte("alice"),
+			CallValue:     big.NewInt(0),
+			CurrentTxHash: []byte{0xab, 0xcd},
+		},
+		RecipientAddr: []byte("contract"),
+		Function:      "doSomething",
+	}
+}
+
+func recordCall(t *testing.T, directory string) (*vmcommon.VMOutput, *Recording) {
+	hook, err := NewRecordingBlockchainHook(createRecordedBlockchainHook())
+	require.Nil(t, err)
+
+	parameters := &vmhost.VMHostParameters{
+		VMType:        []byte{5, 0},
+		BlockGasLimit: 1000,
+		GasSchedule:   config.MakeGasMap(1, 1),
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == hostCore.SCDeployFlag
+			},
+		},
+	}
+	vm, err := NewRecordingVM(&hookReadingVM{hook: hook}, hook, parameters, directory)
+	require.Nil(t, err)
+
+	vmOutput, err := vm.RunSmartContractCall(createCallInput())
+	require.Nil(t, err)
+
+	recording, err := LoadRecording(filepath.Join(directory, "000001-abcd.json"))
+	require.Nil(t, err)
+
+	return vmOutput, recording
+}
+
+func TestNewRecordingBlockchainHook_NilHook(t *testing.T) {
+	t.Parallel()
+
+	hook, err := NewRecordingBlockchainHook(nil)
+	require.Equal(t, ErrNilBlockchainHook, err)
+	require.True(t, hook.IsInterfaceNil())
+}
+
+func TestRecordingVM_SavesRecording(t *testing.T) {
+	t.Parallel()
+
+	vmOutput, recording := recordCall(t, t.TempDir())
+	require.Equal(t, [][]byte{{1}, {2}, []byte("code of contract"), []byte("doSomething")}, vmOutput.ReturnData)
+
+	require.Equal(t, []core.EnableEpochFlag{hostCore.SCDeployFlag}, recording.EnabledFlags)
+	require.Equal(t, uint64(1000), recording.BlockGasLimit)
+	require.Equal(t, common.ContractCallRequest, recording.Request.Kind)
+	require.Equal(t, common.ContractResponse, recording.Response.Kind)
+	require.Len(t, recording.HookCalls, 6)
+	require.Equal(t, common.BlockchainGetUserAccountRequest, recording.HookCalls[0].Request.Kind)
+	require.Equal(t, common.BlockchainGetUserAccountResponse, recording.HookCalls[0].Response.Kind)
+
+	parameters := recording.VMHostParameters()
+	require.True(t, parameters.EnableEpochsHandler.IsFlagEnabled(hostCore.SCDeployFlag))
+	require.False(t, parameters.EnableEpochsHandler.IsFlagEnabled(hostCore.BuiltInFunctionsFlag))
+}
+
+func TestReplayBlockchainHook_ReproducesExecution(t *testing.T) {
+	t.Parallel()
+
+	recordedOutput, recording := recordCall(t, t.TempDir())
+
+	hook, err := NewReplayBlockchainHook(recording)
+	require.Nil(t, err)
+
+	vmOutput, err := recording.Run(&hookReadingVM{hook: hook})
+	require.Nil(t, err)
+	require.Equal(t, recordedOutput.ReturnData, vmOutput.ReturnData)
+	require.Equal(t, uint64(7), vmOutput.GasRemaining)
+	require.Equal(t, big.NewInt(42), vmOutput.GasRefund)
+	require.True(t, recording.MatchesResponse(vmOutput, err))
+
+	vmOutput.ReturnMessage = "changed"
+	require.False(t, recording.MatchesResponse(vmOutput, err))
+}
+
+func TestReplayBlockchainHook_RepeatsLastResponse(t *testing.T) {
+	t.Parallel()
+
+	_, recording := recordCall(t, t.TempDir())
+
+	hook, err := NewReplayBlockchainHook(recording)
+	require.Nil(t, err)
+
+	for _, expected := range []byte{1, 2, 2} {
+		data, _, errGet := hook.GetStorageData([]byte("contract"), []byte("counter"))
+		require.Nil(t, errGet)
+		require.Equal(t, []byte{expected}, data)
+	}
+}
+
+func TestReplayBlockchainHook_CallNotRecorded(t *testing.T) {
+	t.Parallel()
+
+	_, recording := recordCall(t, t.TempDir())
+
+	hook, err := NewReplayBlockchainHook(recording)
+	require.Nil(t, err)
+
+	_, _, err = hook.GetStorageData([]byte("contract"), []byte("other key"))
+	require.Equal(t, ErrHookCallNotRecorded, err)
+
+	account, err := hook.GetUserAccount([]byte("bob"))
+	require.Equal(t, ErrHookCallNotRecorded, err)
+	require.Nil(t, account)
+
+	require.Equal(t, uint64(0), hook.LastNonce())
+}
+
+func TestSaveRecording_NilRecording(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "recording.json")
+	err := SaveRecording(nil, path)
+	require.Equal(t, ErrNilRecording, err)
+
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+}
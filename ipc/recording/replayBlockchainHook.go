@@ -0,0 +1,391 @@
+package recording
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/kalyan3104/k-chain-core-go/data/dcdt"
+	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/ipc/common"
+)
+
+var _ vmcommon.BlockchainHook = (*ReplayBlockchainHook)(nil)
+
+type recordedResponses struct {
+	responses []*RecordedMessage
+	next      int
+}
+
+// ReplayBlockchainHook answers the blockchain hook calls with the responses
+// recorded for identical calls. Repeated calls get the recorded responses in
+// order, and then the last one again. The compiled code is only cached in
+// memory, since the one of the recording machine cannot be reused.
+type ReplayBlockchainHook struct {
+	mutReplay     sync.Mutex
+	responses     map[string]*recordedResponses
+	compiledCodes map[string][]byte
+}
+
+// NewReplayBlockchainHook creates a new ReplayBlockchainHook answering with
+// the hook calls of the provided recording
+func NewReplayBlockchainHook(recording *Recording) (*ReplayBlockchainHook, error) {
+	if recording == nil {
+		return nil, ErrNilRecording
+	}
+
+	replay := &ReplayBlockchainHook{
+		responses:     make(map[string]*recordedResponses),
+		compiledCodes: make(map[string][]byte),
+	}
+
+	for _, hookCall := range recording.HookCalls {
+		key := compactJSON(hookCall.Request.Data)
+		recorded, ok := replay.responses[key]
+		if !ok {
+			recorded = &recordedResponses{}
+			replay.responses[key] = recorded
+		}
+		recorded.responses = append(recorded.responses, hookCall.Response)
+	}
+
+	return replay, nil
+}
+
+// reply returns the recorded response to the request, or nil if the request
+// was not recorded
+func (replay *ReplayBlockchainHook) reply(request common.MessageHandler) common.MessageHandler {
+	key := compactJSON(newRecordedMessage(request).Data)
+
+	replay.mutReplay.Lock()
+	recorded, ok := replay.responses[key]
+	var response *RecordedMessage
+	if ok {
+		response = recorded.responses[recorded.next]
+		if recorded.next < len(recorded.responses)-1 {
+			recorded.next++
+		}
+	}
+	replay.mutReplay.Unlock()
+
+	if response == nil {
+		log.Error("ReplayBlockchainHook", "err", ErrHookCallNotRecorded, "request", key)
+		return nil
+	}
+
+	handler, err := response.Handler()
+	if err != nil {
+		log.Error("ReplayBlockchainHook", "kind", request.GetKindName(), "err", err)
+		return nil
+	}
+
+	return handler
+}
+
+// NewAddress replies with the recorded response
+func (replay *ReplayBlockchainHook) NewAddress(creatorAddress []byte, creatorNonce uint64, vmType []byte) ([]byte, error) {
+	request := common.NewMessageBlockchainNewAddressRequest(creatorAddress, creatorNonce, vmType)
+	response, ok := replay.reply(request).(*common.MessageBlockchainNewAddressResponse)
+	if !ok {
+		return nil, ErrHookCallNotRecorded
+	}
+
+	return response.Result, response.GetError()
+}
+
+// GetStorageData replies with the recorded response
+func (replay *ReplayBlockchainHook) GetStorageData(accountAddress []byte, index []byte) ([]byte, uint32, error) {
+	request := common.NewMessageBlockchainGetStorageDataRequest(accountAddress, index)
+	response, ok := replay.reply(request).(*common.MessageBlockchainGetStorageDataResponse)
+	if !ok {
+		return nil, 0, ErrHookCallNotRecorded
+	}
+
+	return response.Data, 0, response.GetError()
+}
+
+// GetBlockhash replies with the recorded response
+func (replay *ReplayBlockchainHook) GetBlockhash(nonce uint64) ([]byte, error) {
+	request := common.NewMessageBlockchainGetBlockhashRequest(nonce)
+	response, ok := replay.reply(request).(*common.MessageBlockchainGetBlockhashResponse)
+	if !ok {
+		return nil, ErrHookCallNotRecorded
+	}
+
+	return response.Result, response.GetError()
+}
+
+// LastNonce replies with the recorded response
+func (replay *ReplayBlockchainHook) LastNonce() uint64 {
+	request := common.NewMessageBlockchainLastNonceRequest()
+	response, ok := replay.reply(request).(*common.MessageBlockchainLastNonceResponse)
+	if !ok {
+		return 0
+	}
+
+	return response.Result
+}
+
+// LastRound replies with the recorded response
+func (replay *ReplayBlockchainHook) LastRound() uint64 {
+	request := common.NewMessageBlockchainLastRoundRequest()
+	response, ok := replay.reply(request).(*common.MessageBlockchainLastRoundResponse)
+	if !ok {
+		return 0
+	}
+
+	return response.Result
+}
+
+// LastTimeStamp replies with the recorded response
+func (replay *ReplayBlockchainHook) LastTimeStamp() uint64 {
+	request := common.NewMessageBlockchainLastTimeStampRequest()
+	response, ok := replay.reply(request).(*common.MessageBlockchainLastTimeStampResponse)
+	if !ok {
+		return 0
+	}
+
+	return response.Result
+}
+
+// LastRandomSeed replies with the recorded response
+func (replay *ReplayBlockchainHook) LastRandomSeed() []byte {
+	request := common.NewMessageBlockchainLastRandomSeedRequest()
+	response, ok := replay.reply(request).(*common.MessageBlockchainLastRandomSeedResponse)
+	if !ok {
+		return nil
+	}
+
+	return response.Result
+}
+
+// LastEpoch replies with the recorded response
+func (replay *ReplayBlockchainHook) LastEpoch() uint32 {
+	request := common.NewMessageBlockchainLastEpochRequest()
+	response, ok := replay.reply(request).(*common.MessageBlockchainLastEpochResponse)
+	if !ok {
+		return 0
+	}
+
+	return response.Result
+}
+
+// GetStateRootHash replies with the recorded response
+func (replay *ReplayBlockchainHook) GetStateRootHash() []byte {
+	request := common.NewMessageBlockchainGetStateRootHashRequest()
+	response, ok := replay.reply(request).(*common.MessageBlockchainGetStateRootHashResponse)
+	if !ok {
+		return nil
+	}
+
+	return response.Result
+}
+
+// CurrentNonce replies with the recorded response
+func (replay *ReplayBlockchainHook) CurrentNonce() uint64 {
+	request := common.NewMessageBlockchainCurrentNonceRequest()
+	response, ok := replay.reply(request).(*common.MessageBlockchainCurrentNonceResponse)
+	if !ok {
+		return 0
+	}
+
+	return response.Result
+}
+
+// CurrentRound replies with the recorded response
+func (replay *ReplayBlockchainHook) CurrentRound() uint64 {
+	request := common.NewMessageBlockchainCurrentRoundRequest()
+	response, ok := replay.reply(request).(*common.MessageBlockchainCurrentRoundResponse)
+	if !ok {
+		return 0
+	}
+
+	return response.Result
+}
+
+// CurrentTimeStamp replies with the recorded response
+func (replay *ReplayBlockchainHook) CurrentTimeStamp() uint64 {
+	request := common.NewMessageBlockchainCurrentTimeStampRequest()
+	response, ok := replay.reply(request).(*common.MessageBlockchainCurrentTimeStampResponse)
+	if !ok {
+		return 0
+	}
+
+	return response.Result
+}
+
+// CurrentRandomSeed replies with the recorded response
+func (replay *ReplayBlockchainHook) CurrentRandomSeed() []byte {
+	request := common.NewMessageBlockchainCurrentRandomSeedRequest()
+	response, ok := replay.reply(request).(*common.MessageBlockchainCurrentRandomSeedResponse)
+	if !ok {
+		return nil
+	}
+
+	return response.Result
+}
+
+// CurrentEpoch replies with the recorded response
+func (replay *ReplayBlockchainHook) CurrentEpoch() uint32 {
+	request := common.NewMessageBlockchainCurrentEpochRequest()
+	response, ok := replay.reply(request).(*common.MessageBlockchainCurrentEpochResponse)
+	if !ok {
+		return 0
+	}
+
+	return response.Result
+}
+
+// ProcessBuiltInFunction replies with the recorded response
+func (replay *ReplayBlockchainHook) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	request := common.NewMessageBlockchainProcessBuiltinFunctionRequest(*input)
+	response, ok := replay.reply(request).(*common.MessageBlockchainProcessBuiltinFunctionResponse)
+	if !ok {
+		return nil, ErrHookCallNotRecorded
+	}
+
+	return response.SerializableVMOutput.ConvertToVMOutput(), response.GetError()
+}
+
+// GetBuiltinFunctionNames replies with the recorded response
+func (replay *ReplayBlockchainHook) GetBuiltinFunctionNames() vmcommon.FunctionNames {
+	request := common.NewMessageBlockchainGetBuiltinFunctionNamesRequest()
+	response, ok := replay.reply(request).(*common.MessageBlockchainGetBuiltinFunctionNamesResponse)
+	if !ok {
+		return make(vmcommon.FunctionNames)
+	}
+
+	return response.FunctionNames
+}
+
+// GetAllState replies with the recorded response
+func (replay *ReplayBlockchainHook) GetAllState(address []byte) (map[string][]byte, error) {
+	request := common.NewMessageBlockchainGetAllStateRequest(address)
+	response, ok := replay.reply(request).(*common.MessageBlockchainGetAllStateResponse)
+	if !ok {
+		return nil, ErrHookCallNotRecorded
+	}
+
+	return response.SerializableAllState.ConvertToMap(), response.GetError()
+}
+
+// GetUserAccount replies with the recorded response
+func (replay *ReplayBlockchainHook) GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	request := common.NewMessageBlockchainGetUserAccountRequest(address)
+	response, ok := replay.reply(request).(*common.MessageBlockchainGetUserAccountResponse)
+	if !ok {
+		return nil, ErrHookCallNotRecorded
+	}
+	if response.Account == nil {
+		return nil, response.GetError()
+	}
+
+	return response.Account, response.GetError()
+}
+
+// GetCode replies with the recorded response
+func (replay *ReplayBlockchainHook) GetCode(account vmcommon.UserAccountHandler) []byte {
+	request := common.NewMessageBlockchainGetCodeRequest(codeRequestAccount(account))
+	response, ok := replay.reply(request).(*common.MessageBlockchainGetCodeResponse)
+	if !ok {
+		return nil
+	}
+
+	return response.Code
+}
+
+// GetShardOfAddress replies with the recorded response
+func (replay *ReplayBlockchainHook) GetShardOfAddress(address []byte) uint32 {
+	request := common.NewMessageBlockchainGetShardOfAddressRequest(address)
+	response, ok := replay.reply(request).(*common.MessageBlockchainGetShardOfAddressResponse)
+	if !ok {
+		return 0
+	}
+
+	return response.Shard
+}
+
+// IsSmartContract replies with the recorded response
+func (replay *ReplayBlockchainHook) IsSmartContract(address []byte) bool {
+	request := common.NewMessageBlockchainIsSmartContractRequest(address)
+	response, ok := replay.reply(request).(*common.MessageBlockchainIsSmartContractResponse)
+	if !ok {
+		return false
+	}
+
+	return response.Result
+}
+
+// IsPayable replies with the recorded response
+func (replay *ReplayBlockchainHook) IsPayable(_ []byte, recvAddress []byte) (bool, error) {
+	request := common.NewMessageBlockchainIsPayableRequest(recvAddress)
+	response, ok := replay.reply(request).(*common.MessageBlockchainIsPayableResponse)
+	if !ok {
+		return false, ErrHookCallNotRecorded
+	}
+
+	return response.Result, response.GetError()
+}
+
+// SaveCompiledCode caches the compiled code in memory
+func (replay *ReplayBlockchainHook) SaveCompiledCode(codeHash []byte, code []byte) {
+	replay.mutReplay.Lock()
+	replay.compiledCodes[string(codeHash)] = code
+	replay.mutReplay.Unlock()
+}
+
+// GetCompiledCode returns the compiled code cached in memory, if any
+func (replay *ReplayBlockchainHook) GetCompiledCode(codeHash []byte) (bool, []byte) {
+	replay.mutReplay.Lock()
+	code, found := replay.compiledCodes[string(codeHash)]
+	replay.mutReplay.Unlock()
+
+	return found, code
+}
+
+// ClearCompiledCodes clears the compiled code cached in memory
+func (replay *ReplayBlockchainHook) ClearCompiledCodes() {
+	replay.mutReplay.Lock()
+	replay.compiledCodes = make(map[string][]byte)
+	replay.mutReplay.Unlock()
+}
+
+// GetDCDTToken replies with the recorded response
+func (replay *ReplayBlockchainHook) GetDCDTToken(address []byte, tokenID []byte, nonce uint64) (*dcdt.DCDigitalToken, error) {
+	request := common.NewMessageBlockchainGetDCDTTokenRequest(address, tokenID, nonce)
+	response, ok := replay.reply(request).(*common.MessageBlockchainGetDCDTTokenResponse)
+	if !ok {
+		return nil, ErrHookCallNotRecorded
+	}
+
+	return response.DCDTData, response.GetError()
+}
+
+// IsPaused - not used in v1.2
+func (replay *ReplayBlockchainHook) IsPaused(_ []byte) bool {
+	return false
+}
+
+// IsLimitedTransfer - not used in v1.2
+func (replay *ReplayBlockchainHook) IsLimitedTransfer(_ []byte) bool {
+	return false
+}
+
+// GetSnapshot returns 0, since the replayed state is never changed
+func (replay *ReplayBlockchainHook) GetSnapshot() int {
+	return 0
+}
+
+// RevertToSnapshot does nothing, since the replayed state is never changed
+func (replay *ReplayBlockchainHook) RevertToSnapshot(_ int) error {
+	return nil
+}
+
+// ExecuteSmartContractCallOnOtherVM -
+func (replay *ReplayBlockchainHook) ExecuteSmartContractCallOnOtherVM(_ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	return nil, errors.New("not implemented")
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (replay *ReplayBlockchainHook) IsInterfaceNil() bool {
+	return replay == nil
+}
@@ -2,6 +2,7 @@ package tests
 
 import (
 	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/ipc/common"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/ipc/marshaling"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/ipc/nodepart"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/ipc/recording"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/ipc/vmpart"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/mock"
 	contextmock "github.com/kalyan3104/k-chain-vm-v1_2-go/mock/context"
@@ -56,11 +58,58 @@ func TestVMPart_SendCallRequest(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestVMPart_RecordsCallRequest(t *testing.T) {
+	blockchain := &contextmock.BlockchainHookStub{}
+
+	blockchain.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &worldmock.Account{Code: bytecodeCounter}, nil
+	}
+
+	directory := t.TempDir()
+	response, err := doRecordedContractRequest(t, "4", createCallRequest("increment"), blockchain, directory)
+	require.NotNil(t, response)
+	require.Nil(t, err)
+
+	recorded, err := recording.LoadRecording(filepath.Join(directory, "000001.json"))
+	require.Nil(t, err)
+	require.Equal(t, common.ContractCallRequest, recorded.Request.Kind)
+	require.Equal(t, common.ContractResponse, recorded.Response.Kind)
+	require.NotEmpty(t, recorded.HookCalls)
+	require.Equal(t, common.BlockchainGetUserAccountRequest, recorded.HookCalls[0].Request.Kind)
+
+	// the node got the recorded response
+	typedResponse := response.(*common.MessageContractResponse)
+	require.True(t, recorded.MatchesResponse(typedResponse.SerializableVMOutput.ConvertToVMOutput(), typedResponse.GetError()))
+
+	// the recording replays without the node
+	replayHook, err := recording.NewReplayBlockchainHook(recorded)
+	require.Nil(t, err)
+	replayHost, err := hostCore.NewVMHost(replayHook, recorded.VMHostParameters())
+	require.Nil(t, err)
+	defer func() {
+		_ = replayHost.Close()
+	}()
+
+	vmOutput, err := recorded.Run(replayHost)
+	require.True(t, recorded.MatchesResponse(vmOutput, err))
+}
+
 func doContractRequest(
 	t *testing.T,
 	tag string,
 	request common.MessageHandler,
 	blockchain vmcommon.BlockchainHook,
+) (common.MessageHandler, error) {
+	return doRecordedContractRequest(t, tag, request, blockchain, "")
+}
+
+// doRecordedContractRequest records the execution in the given directory, if any
+func doRecordedContractRequest(
+	t *testing.T,
+	tag string,
+	request common.MessageHandler,
+	blockchain vmcommon.BlockchainHook,
+	recordingsDirectory string,
 ) (common.MessageHandler, error) {
 	files := createTestFiles(t, tag)
 	var response common.MessageHandler
@@ -82,13 +131,26 @@ func doContractRequest(
 			},
 		}
 
-		part, err := vmpart.NewVMPart(
-			"testversion",
-			files.inputOfVM,
-			files.outputOfVM,
-			vmHostParameters,
-			marshaling.CreateMarshalizer(marshaling.JSON),
-		)
+		var part *vmpart.VMPart
+		var err error
+		if len(recordingsDirectory) == 0 {
+			part, err = vmpart.NewVMPart(
+				"testversion",
+				files.inputOfVM,
+				files.outputOfVM,
+				vmHostParameters,
+				marshaling.CreateMarshalizer(marshaling.JSON),
+			)
+		} else {
+			part, err = vmpart.NewRecordingVMPart(
+				"testversion",
+				files.inputOfVM,
+				files.outputOfVM,
+				vmHostParameters,
+				marshaling.CreateMarshalizer(marshaling.JSON),
+				recordingsDirectory,
+			)
+		}
 		assert.Nil(t, err)
 		_ = part.StartLoop()
 		wg.Done()
@@ -8,6 +8,7 @@ import (
 	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/ipc/common"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/ipc/marshaling"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/ipc/recording"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost/hostCore"
 )
@@ -41,9 +42,45 @@ func NewVMPart(
 		return nil, err
 	}
 
+	return newVMPart(version, messenger, newVMHost), nil
+}
+
+// NewRecordingVMPart creates a VM part which saves the recording of each
+// contract deploy and call in the given directory, to be replayed without the node
+func NewRecordingVMPart(
+	version string,
+	input *os.File,
+	output *os.File,
+	vmHostParameters *vmhost.VMHostParameters,
+	marshalizer marshaling.Marshalizer,
+	recordingsDirectory string,
+) (*VMPart, error) {
+	messenger := NewVMMessenger(input, output, marshalizer)
+	blockchain, err := recording.NewRecordingBlockchainHook(NewBlockchainHookGateway(messenger))
+	if err != nil {
+		return nil, err
+	}
+
+	newVMHost, err := hostCore.NewVMHost(
+		blockchain,
+		vmHostParameters,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	recordingVM, err := recording.NewRecordingVM(newVMHost, blockchain, vmHostParameters, recordingsDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	return newVMPart(version, messenger, recordingVM), nil
+}
+
+func newVMPart(version string, messenger *VMMessenger, vmHost vmcommon.VMExecutionHandler) *VMPart {
 	part := &VMPart{
 		Messenger: messenger,
-		VMHost:    newVMHost,
+		VMHost:    vmHost,
 		Version:   version,
 	}
 
@@ -54,7 +91,7 @@ func NewVMPart(
 	part.Repliers[common.VersionRequest] = part.replyToVersionRequest
 	part.Repliers[common.GasScheduleChangeRequest] = part.replyToGasScheduleChange
 
-	return part, nil
+	return part
 }
 
 func (part *VMPart) noopReplier(_ common.MessageHandler) common.MessageHandler {
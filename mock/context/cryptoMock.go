@@ -26,6 +26,16 @@ func (c *CryptoHookMock) VerifyBLS(key []byte, msg []byte, sig []byte) error {
 	return c.Err
 }
 
+// VerifyBLSAggregatedSignature mocked method
+func (c *CryptoHookMock) VerifyBLSAggregatedSignature(keys [][]byte, msg []byte, sig []byte) error {
+	return c.Err
+}
+
+// VerifyBLSMultiSig mocked method
+func (c *CryptoHookMock) VerifyBLSMultiSig(keys [][]byte, msg []byte, sig []byte) error {
+	return c.Err
+}
+
 // VerifyEd25519 mocked method
 func (c *CryptoHookMock) VerifyEd25519(key []byte, msg []byte, sig []byte) error {
 	return c.Err
@@ -40,3 +50,13 @@ func (c *CryptoHookMock) VerifySecp256k1(key []byte, msg []byte, sig []byte) err
 func (c *CryptoHookMock) Ecrecover(hash []byte, recoveryID []byte, r []byte, s []byte) ([]byte, error) {
 	return c.Result, c.Err
 }
+
+// EncodeSecp256k1DERSignature mocked method
+func (c *CryptoHookMock) EncodeSecp256k1DERSignature(r []byte, s []byte) ([]byte, error) {
+	return c.Result, c.Err
+}
+
+// DecodeSecp256k1DERSignature mocked method
+func (c *CryptoHookMock) DecodeSecp256k1DERSignature(sig []byte) ([]byte, []byte, error) {
+	return c.Result, c.Result, c.Err
+}
@@ -53,6 +53,10 @@ func (m *MeteringContextMock) GasSchedule() *config.GasCost {
 func (m *MeteringContextMock) UseGas(_ uint64) {
 }
 
+// UseGasForCategory mocked method
+func (m *MeteringContextMock) UseGasForCategory(_ uint64, _ vmhost.GasCategory) {
+}
+
 // FreeGas mocked method
 func (m *MeteringContextMock) FreeGas(_ uint64) {
 }
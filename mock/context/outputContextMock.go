@@ -141,7 +141,7 @@ func (o *OutputContextMock) ClearReturnData() {
 }
 
 // SelfDestruct mocked method
-func (o *OutputContextMock) SelfDestruct(_ []byte, _ []byte) {
+func (o *OutputContextMock) SelfDestruct(_ []byte, _ []byte) error {
 	panic("not implemented")
 }
 
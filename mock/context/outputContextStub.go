@@ -26,7 +26,7 @@ type OutputContextStub struct {
 	WriteLogCalled                    func(address []byte, topics [][]byte, data []byte)
 	TransferCalled                    func(destination []byte, sender []byte, gasLimit uint64, gasLocked uint64, value *big.Int, input []byte) error
 	TransferDCDTCalled                func(destination []byte, sender []byte, tokenIdentifier []byte, nonce uint64, value *big.Int, input *vmcommon.ContractCallInput) (uint64, error)
-	SelfDestructCalled                func(address []byte, beneficiary []byte)
+	SelfDestructCalled                func(address []byte, beneficiary []byte) error
 	GetRefundCalled                   func() uint64
 	SetRefundCalled                   func(refund uint64)
 	ReturnCodeCalled                  func() vmcommon.ReturnCode
@@ -165,10 +165,11 @@ func (o *OutputContextStub) TransferDCDT(destination []byte, sender []byte, toke
 }
 
 // SelfDestruct mocked method
-func (o *OutputContextStub) SelfDestruct(address []byte, beneficiary []byte) {
+func (o *OutputContextStub) SelfDestruct(address []byte, beneficiary []byte) error {
 	if o.SelfDestructCalled != nil {
-		o.SelfDestructCalled(address, beneficiary)
+		return o.SelfDestructCalled(address, beneficiary)
 	}
+	return nil
 }
 
 // GetRefund mocked method
@@ -96,6 +96,15 @@ func (r *RuntimeContextMock) IsWarmInstance() bool {
 func (r *RuntimeContextMock) ResetWarmInstance() {
 }
 
+// SetMaxWarmInstanceCount mocked method
+func (r *RuntimeContextMock) SetMaxWarmInstanceCount(uint64) {
+}
+
+// WarmInstanceCacheStats mocked method
+func (r *RuntimeContextMock) WarmInstanceCacheStats() vmhost.WarmInstanceCacheStats {
+	return vmhost.WarmInstanceCacheStats{}
+}
+
 // RunningInstancesCount mocked method
 func (r *RuntimeContextMock) RunningInstancesCount() uint64 {
 	return r.RunningInstances
@@ -271,6 +280,11 @@ func (r *RuntimeContextMock) MemStore(_ int32, _ []byte) error {
 	return r.Err
 }
 
+// CheckMemStoreBounds mocked method
+func (r *RuntimeContextMock) CheckMemStoreBounds(_ int32, _ int32) error {
+	return r.Err
+}
+
 // BaseOpsErrorShouldFailExecution mocked method
 func (r *RuntimeContextMock) BaseOpsErrorShouldFailExecution() bool {
 	return r.FailBaseOpsAPI
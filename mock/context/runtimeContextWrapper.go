@@ -71,6 +71,10 @@ type runtimeContextWrapper struct {
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	ResetWarmInstanceFunc func()
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	SetMaxWarmInstanceCountFunc func(maxWarmInstances uint64)
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	WarmInstanceCacheStatsFunc func() vmhost.WarmInstanceCacheStats
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	ReadOnlyFunc func() bool
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	SetReadOnlyFunc func(readOnly bool)
@@ -95,6 +99,8 @@ type runtimeContextWrapper struct {
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	MemStoreFunc func(offset int32, data []byte) error
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	CheckMemStoreBoundsFunc func(offset int32, length int32) error
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	MemLoadFunc func(offset int32, length int32) ([]byte, error)
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	MemLoadMultipleFunc func(offset int32, lengths []int32) ([][]byte, error)
@@ -248,6 +254,14 @@ func NewRuntimeContextWrapper(inputRuntimeContext *vmhost.RuntimeContext) *runti
 		runtimeWrapper.runtimeContext.ResetWarmInstance()
 	}
 
+	runtimeWrapper.SetMaxWarmInstanceCountFunc = func(maxWarmInstances uint64) {
+		runtimeWrapper.runtimeContext.SetMaxWarmInstanceCount(maxWarmInstances)
+	}
+
+	runtimeWrapper.WarmInstanceCacheStatsFunc = func() vmhost.WarmInstanceCacheStats {
+		return runtimeWrapper.runtimeContext.WarmInstanceCacheStats()
+	}
+
 	runtimeWrapper.ReadOnlyFunc = func() bool {
 		return runtimeWrapper.runtimeContext.ReadOnly()
 	}
@@ -296,6 +310,10 @@ func NewRuntimeContextWrapper(inputRuntimeContext *vmhost.RuntimeContext) *runti
 		return runtimeWrapper.runtimeContext.MemStore(offset, data)
 	}
 
+	runtimeWrapper.CheckMemStoreBoundsFunc = func(offset int32, length int32) error {
+		return runtimeWrapper.runtimeContext.CheckMemStoreBounds(offset, length)
+	}
+
 	runtimeWrapper.MemLoadFunc = func(offset int32, length int32) ([]byte, error) {
 		return runtimeWrapper.runtimeContext.MemLoad(offset, length)
 	}
@@ -501,6 +519,16 @@ func (contextWrapper *runtimeContextWrapper) ResetWarmInstance() {
 	contextWrapper.ResetWarmInstanceFunc()
 }
 
+// SetMaxWarmInstanceCount calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *runtimeContextWrapper) SetMaxWarmInstanceCount(maxWarmInstances uint64) {
+	contextWrapper.SetMaxWarmInstanceCountFunc(maxWarmInstances)
+}
+
+// WarmInstanceCacheStats calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *runtimeContextWrapper) WarmInstanceCacheStats() vmhost.WarmInstanceCacheStats {
+	return contextWrapper.WarmInstanceCacheStatsFunc()
+}
+
 // ReadOnly calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
 func (contextWrapper *runtimeContextWrapper) ReadOnly() bool {
 	return contextWrapper.ReadOnlyFunc()
@@ -561,6 +589,11 @@ func (contextWrapper *runtimeContextWrapper) MemStore(offset int32, data []byte)
 	return contextWrapper.MemStoreFunc(offset, data)
 }
 
+// CheckMemStoreBounds calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *runtimeContextWrapper) CheckMemStoreBounds(offset int32, length int32) error {
+	return contextWrapper.CheckMemStoreBoundsFunc(offset, length)
+}
+
 // MemLoad calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
 func (contextWrapper *runtimeContextWrapper) MemLoad(offset int32, length int32) ([]byte, error) {
 	return contextWrapper.MemLoadFunc(offset, length)
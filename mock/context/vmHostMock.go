@@ -19,12 +19,15 @@ type VMHostMock struct {
 
 	EthInput []byte
 
-	BlockchainContext vmhost.BlockchainContext
-	RuntimeContext    vmhost.RuntimeContext
-	OutputContext     vmhost.OutputContext
-	MeteringContext   vmhost.MeteringContext
-	StorageContext    vmhost.StorageContext
-	BigIntContext     vmhost.BigIntContext
+	BlockchainContext    vmhost.BlockchainContext
+	RuntimeContext       vmhost.RuntimeContext
+	OutputContext        vmhost.OutputContext
+	MeteringContext      vmhost.MeteringContext
+	StorageContext       vmhost.StorageContext
+	BigIntContext        vmhost.BigIntContext
+	ManagedBufferContext vmhost.ManagedBufferContext
+	HookTracer           vmhost.HookTracer
+	GasProfilerField     vmhost.GasProfiler
 
 	SCAPIMethods  *wasmer.Imports
 	IsBuiltinFunc bool
@@ -65,6 +68,21 @@ func (host *VMHostMock) BigInt() vmhost.BigIntContext {
 	return host.BigIntContext
 }
 
+// ManagedBuffer mocked method
+func (host *VMHostMock) ManagedBuffer() vmhost.ManagedBufferContext {
+	return host.ManagedBufferContext
+}
+
+// Tracer mocked method
+func (host *VMHostMock) Tracer() vmhost.HookTracer {
+	return host.HookTracer
+}
+
+// GasProfiler mocked method
+func (host *VMHostMock) GasProfiler() vmhost.GasProfiler {
+	return host.GasProfilerField
+}
+
 // IsVMV2Enabled mocked method
 func (host *VMHostMock) IsVMV2Enabled() bool {
 	return true
@@ -90,6 +108,46 @@ func (host *VMHostMock) IsDCDTFunctionsEnabled() bool {
 	return true
 }
 
+// IsBigIntResultSizeLimitEnabled mocked method
+func (host *VMHostMock) IsBigIntResultSizeLimitEnabled() bool {
+	return true
+}
+
+// IsSelfDestructEnabled mocked method
+func (host *VMHostMock) IsSelfDestructEnabled() bool {
+	return true
+}
+
+// IsEthereumAPIEnabled mocked method
+func (host *VMHostMock) IsEthereumAPIEnabled() bool {
+	return true
+}
+
+// IsManagedBufferAPIEnabled mocked method
+func (host *VMHostMock) IsManagedBufferAPIEnabled() bool {
+	return true
+}
+
+// IsBigIntExtendedAPIEnabled mocked method
+func (host *VMHostMock) IsBigIntExtendedAPIEnabled() bool {
+	return true
+}
+
+// IsSecp256k1ExtendedAPIEnabled mocked method
+func (host *VMHostMock) IsSecp256k1ExtendedAPIEnabled() bool {
+	return true
+}
+
+// IsBLSMultiSigAPIEnabled mocked method
+func (host *VMHostMock) IsBLSMultiSigAPIEnabled() bool {
+	return true
+}
+
+// IsMultiDCDTTransferEnabled mocked method
+func (host *VMHostMock) IsMultiDCDTTransferEnabled() bool {
+	return true
+}
+
 // AreInSameShard mocked method
 func (host *VMHostMock) AreInSameShard(_ []byte, _ []byte) bool {
 	return true
@@ -149,3 +207,8 @@ func (host *VMHostMock) GetProtocolBuiltinFunctions() vmcommon.FunctionNames {
 func (host *VMHostMock) IsBuiltinFunctionName(_ string) bool {
 	return host.IsBuiltinFunc
 }
+
+// EstimateGas mocked method
+func (host *VMHostMock) EstimateGas(_ *vmcommon.ContractCallInput, _ bool) (*vmhost.GasEstimate, error) {
+	return nil, nil
+}
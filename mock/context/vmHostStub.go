@@ -23,9 +23,12 @@ type VMHostStub struct {
 	BlockchainCalled                  func() vmhost.BlockchainContext
 	RuntimeCalled                     func() vmhost.RuntimeContext
 	BigIntCalled                      func() vmhost.BigIntContext
+	ManagedBufferCalled               func() vmhost.ManagedBufferContext
 	OutputCalled                      func() vmhost.OutputContext
 	MeteringCalled                    func() vmhost.MeteringContext
 	StorageCalled                     func() vmhost.StorageContext
+	TracerCalled                      func() vmhost.HookTracer
+	GasProfilerCalled                 func() vmhost.GasProfiler
 	RevertDCDTTransferCalled          func(input *vmcommon.ContractCallInput)
 	ExecuteDCDTTransferCalled         func(destination []byte, sender []byte, tokenIdentifier []byte, nonce uint64, value *big.Int, callType vm.CallType, isRevert bool) (*vmcommon.VMOutput, uint64, error)
 	CreateNewContractCalled           func(input *vmcommon.ContractCreateInput) ([]byte, error)
@@ -35,6 +38,7 @@ type VMHostStub struct {
 	GetProtocolBuiltinFunctionsCalled func() vmcommon.FunctionNames
 	IsBuiltinFunctionNameCalled       func(functionName string) bool
 	AreInSameShardCalled              func(left []byte, right []byte) bool
+	EstimateGasCalled                 func(input *vmcommon.ContractCallInput, searchGasLimit bool) (*vmhost.GasEstimate, error)
 }
 
 // InitState mocked method
@@ -97,6 +101,14 @@ func (vhs *VMHostStub) BigInt() vmhost.BigIntContext {
 	return nil
 }
 
+// ManagedBuffer mocked method
+func (vhs *VMHostStub) ManagedBuffer() vmhost.ManagedBufferContext {
+	if vhs.ManagedBufferCalled != nil {
+		return vhs.ManagedBufferCalled()
+	}
+	return nil
+}
+
 // IsVMV2Enabled mocked method
 func (vhs *VMHostStub) IsVMV2Enabled() bool {
 	return true
@@ -122,6 +134,46 @@ func (vhs *VMHostStub) IsDCDTFunctionsEnabled() bool {
 	return true
 }
 
+// IsBigIntResultSizeLimitEnabled mocked method
+func (vhs *VMHostStub) IsBigIntResultSizeLimitEnabled() bool {
+	return true
+}
+
+// IsSelfDestructEnabled mocked method
+func (vhs *VMHostStub) IsSelfDestructEnabled() bool {
+	return true
+}
+
+// IsEthereumAPIEnabled mocked method
+func (vhs *VMHostStub) IsEthereumAPIEnabled() bool {
+	return true
+}
+
+// IsManagedBufferAPIEnabled mocked method
+func (vhs *VMHostStub) IsManagedBufferAPIEnabled() bool {
+	return true
+}
+
+// IsBigIntExtendedAPIEnabled mocked method
+func (vhs *VMHostStub) IsBigIntExtendedAPIEnabled() bool {
+	return true
+}
+
+// IsSecp256k1ExtendedAPIEnabled mocked method
+func (vhs *VMHostStub) IsSecp256k1ExtendedAPIEnabled() bool {
+	return true
+}
+
+// IsBLSMultiSigAPIEnabled mocked method
+func (vhs *VMHostStub) IsBLSMultiSigAPIEnabled() bool {
+	return true
+}
+
+// IsMultiDCDTTransferEnabled mocked method
+func (vhs *VMHostStub) IsMultiDCDTTransferEnabled() bool {
+	return true
+}
+
 // Output mocked method
 func (vhs *VMHostStub) Output() vmhost.OutputContext {
 	if vhs.OutputCalled != nil {
@@ -146,6 +198,22 @@ func (vhs *VMHostStub) Storage() vmhost.StorageContext {
 	return nil
 }
 
+// Tracer mocked method
+func (vhs *VMHostStub) Tracer() vmhost.HookTracer {
+	if vhs.TracerCalled != nil {
+		return vhs.TracerCalled()
+	}
+	return nil
+}
+
+// GasProfiler mocked method
+func (vhs *VMHostStub) GasProfiler() vmhost.GasProfiler {
+	if vhs.GasProfilerCalled != nil {
+		return vhs.GasProfilerCalled()
+	}
+	return nil
+}
+
 // RevertDCDTTransfer mocked method
 func (vhs *VMHostStub) RevertDCDTTransfer(input *vmcommon.ContractCallInput) {
 	if vhs.RevertDCDTTransferCalled != nil {
@@ -216,3 +284,11 @@ func (vhs *VMHostStub) IsBuiltinFunctionName(functionName string) bool {
 	}
 	return false
 }
+
+// EstimateGas mocked method
+func (vhs *VMHostStub) EstimateGas(input *vmcommon.ContractCallInput, searchGasLimit bool) (*vmhost.GasEstimate, error) {
+	if vhs.EstimateGasCalled != nil {
+		return vhs.EstimateGasCalled(input, searchGasLimit)
+	}
+	return nil, nil
+}
@@ -47,6 +47,34 @@ func (bf *BuiltinFunctionsWrapper) SetTokenData(address []byte, tokenKey []byte,
 	return account.SetTokenData(tokenKey, tokenData)
 }
 
+// SetTokenTypesFromData replaces the type guessed from the nonce of each NFT /
+// SFT transfer with the type recorded in the token data, since the nonce alone
+// does not tell the two apart; the data is taken from the first of the given
+// accounts which holds the token.
+func (bf *BuiltinFunctionsWrapper) SetTokenTypesFromData(dcdtTransfers []*vmcommon.DCDTTransfer, holders ...[]byte) {
+	for _, dcdtTransfer := range dcdtTransfers {
+		if dcdtTransfer.DCDTTokenNonce == 0 {
+			continue
+		}
+
+		tokenKey := MakeTokenKey(dcdtTransfer.DCDTTokenName, dcdtTransfer.DCDTTokenNonce)
+		for _, holder := range holders {
+			account := bf.World.AcctMap.GetAccount(holder)
+			if account == nil {
+				continue
+			}
+
+			tokenData, err := account.GetTokenData(tokenKey)
+			if err != nil || tokenData.Value.Sign() == 0 {
+				continue
+			}
+
+			dcdtTransfer.DCDTTokenType = tokenData.Type
+			break
+		}
+	}
+}
+
 // PerformDirectDCDTTransfer calls the real DCDTTransfer function immediately;
 // only works for in-shard transfers for now, but it will be expanded to
 // cross-shard.
@@ -101,3 +129,72 @@ func (bf *BuiltinFunctionsWrapper) PerformDirectDCDTTransfer(
 
 	return vmOutput.GasRemaining, nil
 }
+
+// PerformDirectMultiDCDTTransfer transfers several tokens at once, calling the
+// real MultiDCDTNFTTransfer function immediately; a single token is transferred
+// with PerformDirectDCDTTransfer instead. Like PerformDirectDCDTTransfer, it
+// only works for in-shard transfers for now.
+func (bf *BuiltinFunctionsWrapper) PerformDirectMultiDCDTTransfer(
+	sender []byte,
+	receiver []byte,
+	dcdtTransfers []*vmcommon.DCDTTransfer,
+	callType vm.CallType,
+	gasLimit uint64,
+	gasPrice uint64,
+) (uint64, error) {
+	nrTransfers := len(dcdtTransfers)
+	if nrTransfers == 0 {
+		return gasLimit, nil
+	}
+	if nrTransfers == 1 {
+		dcdtTransfer := dcdtTransfers[0]
+		return bf.PerformDirectDCDTTransfer(
+			sender,
+			receiver,
+			dcdtTransfer.DCDTTokenName,
+			dcdtTransfer.DCDTTokenNonce,
+			dcdtTransfer.DCDTValue,
+			callType,
+			gasLimit,
+			gasPrice)
+	}
+
+	multiTransferInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  sender,
+			Arguments:   make([][]byte, 0, 2+3*nrTransfers),
+			CallValue:   big.NewInt(0),
+			CallType:    callType,
+			GasPrice:    gasPrice,
+			GasProvided: gasLimit,
+			GasLocked:   0,
+		},
+		RecipientAddr:     sender,
+		Function:          core.BuiltInFunctionMultiDCDTNFTTransfer,
+		AllowInitFunction: false,
+	}
+
+	nrTransfersAsBytes := big.NewInt(0).SetUint64(uint64(nrTransfers)).Bytes()
+	multiTransferInput.Arguments = append(multiTransferInput.Arguments, receiver, nrTransfersAsBytes)
+	for _, dcdtTransfer := range dcdtTransfers {
+		nonceAsBytes := big.NewInt(0).SetUint64(dcdtTransfer.DCDTTokenNonce).Bytes()
+		multiTransferInput.Arguments = append(multiTransferInput.Arguments,
+			dcdtTransfer.DCDTTokenName,
+			nonceAsBytes,
+			dcdtTransfer.DCDTValue.Bytes())
+	}
+
+	vmOutput, err := bf.ProcessBuiltInFunction(multiTransferInput)
+	if err != nil {
+		return 0, err
+	}
+
+	if vmOutput.ReturnCode != vmcommon.Ok {
+		return 0, fmt.Errorf(
+			"MultiDCDTNFTTransfer failed: retcode = %d, msg = %s",
+			vmOutput.ReturnCode,
+			vmOutput.ReturnMessage)
+	}
+
+	return vmOutput.GasRemaining, nil
+}
@@ -252,14 +252,15 @@ func (a *Account) loadMockDCDTDataInstance(tokenKey []byte) (string, *dcdt.DCDig
 		return "", nil, err
 	}
 
+	if tokenInstance.TokenMetaData == nil {
+		tokenInstance.TokenMetaData = &dcdt.MetaData{}
+	}
+
 	var tokenName string
-	if tokenInstance.TokenMetaData == nil || tokenInstance.TokenMetaData.Nonce == 0 {
-		// DCDT, no nonce in the key
+	if tokenInstance.TokenMetaData.Nonce == 0 {
+		// DCDT, no nonce in the key; the rest of the metadata is kept
 		tokenNameFromKey := GetTokenNameFromKey(tokenKey)
-		tokenInstance.TokenMetaData = &dcdt.MetaData{
-			Name:  tokenNameFromKey,
-			Nonce: 0,
-		}
+		tokenInstance.TokenMetaData.Name = tokenNameFromKey
 		tokenName = string(tokenNameFromKey)
 	} else {
 		// the key also contains the nonce, we take the token identifier from the metadata
@@ -89,7 +89,8 @@ func (a *Account) GetCodeHash() []byte {
 	return a.CodeHash
 }
 
-// GetRootHash -
+// GetRootHash returns the data root hash computed along with the last state
+// root hash of the world
 func (a *Account) GetRootHash() []byte {
 	return a.RootHash
 }
@@ -65,12 +65,27 @@ func (m *MockAccountsAdapter) RemoveAccount(address []byte) error {
 	return nil
 }
 
-// Commit -
+// Commit makes the current accounts the committed state. Hashing all the
+// accounts after every transaction would be wasteful, so the new state root
+// hash is only computed when asked for, by MockWorld.GetStateRootHash; no root
+// hash is returned.
 func (m *MockAccountsAdapter) Commit() ([]byte, error) {
 	m.Snapshots = make([]AccountMap, 0)
+	m.World.stateRootHashOutdated = true
+
 	return nil, nil
 }
 
+// committedAccounts returns the accounts as they were at the last commit: the
+// first snapshot taken since then, or the current accounts if there is none.
+func (m *MockAccountsAdapter) committedAccounts() AccountMap {
+	if len(m.Snapshots) > 0 {
+		return m.Snapshots[0]
+	}
+
+	return m.World.AcctMap
+}
+
 // Close -
 func (m *MockAccountsAdapter) Close() error {
 	return nil
@@ -117,9 +132,10 @@ func (m *MockAccountsAdapter) GetCode(codeHash []byte) []byte {
 	return nil
 }
 
-// RootHash -
+// RootHash computes the state root hash of the accounts as they are now,
+// without committing them
 func (m *MockAccountsAdapter) RootHash() ([]byte, error) {
-	return nil, ErrTrieHandlingNotImplemented
+	return m.World.AcctMap.ComputeRootHash(), nil
 }
 
 // RecreateTrie -
@@ -114,8 +114,23 @@ func (b *MockWorld) LastEpoch() uint32 {
 	return b.PreviousBlockInfo.BlockEpoch
 }
 
-// GetStateRootHash returns the state root hash from the last committed block
+// GetStateRootHash returns the state root hash of the last committed state,
+// computing it on the first call after a commit
 func (b *MockWorld) GetStateRootHash() []byte {
+	if !b.stateRootHashOutdated {
+		return b.StateRootHash
+	}
+
+	committed := b.AcctMap
+	adapter, ok := b.AccountsAdapter.(*MockAccountsAdapter)
+	if ok {
+		committed = adapter.committedAccounts()
+	}
+
+	b.StateRootHash = committed.commitRootHash()
+	b.AcctMap.keepRootHashesOf(committed)
+	b.stateRootHashOutdated = false
+
 	return b.StateRootHash
 }
 
@@ -210,7 +225,7 @@ func (b *MockWorld) GetUserAccount(address []byte) (vmcommon.UserAccountHandler,
 	}
 
 	account := b.AcctMap.GetAccount(address)
-	if account == nil {
+	if account == nil || b.isOnOtherShard(account) {
 		return nil, fmt.Errorf("account not found: %s", hex.EncodeToString(address))
 	}
 
@@ -220,7 +235,7 @@ func (b *MockWorld) GetUserAccount(address []byte) (vmcommon.UserAccountHandler,
 // GetCode retrieves the code from the given account, or nil if not found
 func (b *MockWorld) GetCode(acc vmcommon.UserAccountHandler) []byte {
 	account := b.AcctMap.GetAccount(acc.AddressBytes())
-	if account == nil {
+	if account == nil || b.isOnOtherShard(account) {
 		return nil
 	}
 
@@ -3,6 +3,7 @@ package worldmock
 import (
 	"fmt"
 
+	"github.com/kalyan3104/k-chain-core-go/core/check"
 	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/config"
 )
@@ -38,6 +39,8 @@ type MockWorld struct {
 	CompiledCode               map[string][]byte
 	BuiltinFuncs               *BuiltinFunctionsWrapper
 	GuardedAccountHandler      vmcommon.GuardedAccountHandler
+	snapshots                  []AccountMap
+	stateRootHashOutdated      bool
 }
 
 // NewMockWorld creates a new MockWorld instance
@@ -82,6 +85,9 @@ func (b *MockWorld) Clear() {
 	b.Blockhashes = nil
 	b.NewAddressMocks = nil
 	b.CompiledCode = make(map[string][]byte)
+	b.StateRootHash = nil
+	b.stateRootHashOutdated = false
+	b.snapshots = nil
 }
 
 // SetCurrentBlockHash -
@@ -92,6 +98,56 @@ func (b *MockWorld) SetCurrentBlockHash(blockHash []byte) {
 	b.Blockhashes = [][]byte{blockHash}
 }
 
+// maxGeneratedBlockHashes limits the number of block hashes generated when advancing multiple blocks at once
+const maxGeneratedBlockHashes = 256
+
+// AdvanceBlock moves the mock on to a later block: the current block info
+// becomes the previous one, while the new current block info is the old one
+// plus the given deltas. The random seed of the delta, if set, replaces the
+// current random seed. The block hashes are shifted accordingly, blockHash
+// becoming the hash of the new current block; the hashes of the skipped
+// blocks, as well as a missing blockHash, are generated. Advancing zero
+// blocks leaves the block hashes untouched.
+func (b *MockWorld) AdvanceBlock(delta *BlockInfo, blockHash []byte) {
+	current := &BlockInfo{}
+	if b.CurrentBlockInfo != nil {
+		*current = *b.CurrentBlockInfo
+	}
+	previous := *current
+
+	current.BlockNonce += delta.BlockNonce
+	current.BlockRound += delta.BlockRound
+	current.BlockTimestamp += delta.BlockTimestamp
+	current.BlockEpoch += delta.BlockEpoch
+	if delta.RandomSeed != nil {
+		current.RandomSeed = delta.RandomSeed
+	}
+
+	numNewHashes := delta.BlockNonce
+	if numNewHashes > maxGeneratedBlockHashes {
+		numNewHashes = maxGeneratedBlockHashes
+	}
+
+	newHashes := make([][]byte, 0, numNewHashes)
+	for i := uint64(0); i < numNewHashes; i++ {
+		nonce := current.BlockNonce - i
+		if i == 0 && len(blockHash) > 0 {
+			newHashes = append(newHashes, blockHash)
+			continue
+		}
+		newHashes = append(newHashes, GenerateMockBlockHash(nonce))
+	}
+
+	// the older hashes are only kept if no block in between was left without a hash
+	if numNewHashes == delta.BlockNonce {
+		newHashes = append(newHashes, b.Blockhashes...)
+	}
+
+	b.PreviousBlockInfo = &previous
+	b.CurrentBlockInfo = current
+	b.Blockhashes = newHashes
+}
+
 // NumberOfShards -
 func (b *MockWorld) NumberOfShards() uint32 {
 	maxShardID := uint32(0)
@@ -121,19 +177,56 @@ func (b *MockWorld) SameShard(firstAddress []byte, secondAddress []byte) bool {
 	return firstAccount.ShardID == secondAccount.ShardID
 }
 
+// isOnOtherShard returns true if the account cannot be seen from SelfShardID;
+// like the builtin functions, GetUserAccount and GetCode only see the accounts
+// of their own shard, so calls to other shards become cross-shard transfers
+func (b *MockWorld) isOnOtherShard(account *Account) bool {
+	return account.ShardID != b.SelfShardID
+}
+
 // CommunicationIdentifier -
 func (b *MockWorld) CommunicationIdentifier(destShardID uint32) string {
 	return fmt.Sprintf("commID-dest-%d", destShardID)
 }
 
-// GetSnapshot -
+// GetSnapshot returns the index of a new snapshot of the accounts, to be passed to RevertToSnapshot.
+// The snapshot is taken by the AccountsAdapter, in the same journal as the backups made by
+// CreateStateBackup; without an AccountsAdapter, a full copy of the accounts is saved.
 func (b *MockWorld) GetSnapshot() int {
+	if check.IfNil(b.AccountsAdapter) {
+		b.snapshots = append(b.snapshots, b.AcctMap.Clone())
+		return len(b.snapshots) - 1
+	}
+
+	// the journal of the mock adapter only holds the snapshots it is asked to take
+	mockAdapter, isMockAdapter := b.AccountsAdapter.(*MockAccountsAdapter)
+	if isMockAdapter {
+		mockAdapter.SnapshotState(nil)
+	}
+
 	return b.AccountsAdapter.JournalLen()
 }
 
-// RevertToSnapshot -
+// RevertToSnapshot restores the accounts as they were when the snapshot was taken,
+// discarding the snapshot and all the ones taken after it
 func (b *MockWorld) RevertToSnapshot(snapshot int) error {
-	return b.AccountsAdapter.RevertToSnapshot(snapshot)
+	if !check.IfNil(b.AccountsAdapter) {
+		return b.AccountsAdapter.RevertToSnapshot(snapshot)
+	}
+
+	if snapshot < 0 || snapshot >= len(b.snapshots) {
+		return fmt.Errorf("snapshot %d out of bounds (min 0, max %d)", snapshot, len(b.snapshots)-1)
+	}
+
+	for address := range b.AcctMap {
+		delete(b.AcctMap, address)
+	}
+	for address, account := range b.snapshots[snapshot] {
+		b.AcctMap[address] = account
+	}
+	b.snapshots = b.snapshots[:snapshot]
+
+	return nil
 }
 
 // ExecuteSmartContractCallOnOtherVM -
@@ -0,0 +1,176 @@
+package worldmock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newAdvanceBlockTestWorld() *MockWorld {
+	world := NewMockWorld()
+	world.CurrentBlockInfo = &BlockInfo{
+		BlockNonce:     5,
+		BlockRound:     7,
+		BlockTimestamp: 100,
+		BlockEpoch:     2,
+	}
+	world.Blockhashes = [][]byte{
+		GenerateMockBlockHash(5),
+		GenerateMockBlockHash(4),
+	}
+	return world
+}
+
+func TestMockWorld_AdvanceBlock_Deltas(t *testing.T) {
+	world := newAdvanceBlockTestWorld()
+
+	var randomSeed [48]byte
+	randomSeed[0] = 42
+	world.AdvanceBlock(&BlockInfo{
+		BlockNonce:     3,
+		BlockRound:     4,
+		BlockTimestamp: 18,
+		BlockEpoch:     1,
+		RandomSeed:     &randomSeed,
+	}, nil)
+
+	require.Equal(t, uint64(8), world.CurrentNonce())
+	require.Equal(t, uint64(11), world.CurrentRound())
+	require.Equal(t, uint64(118), world.CurrentTimeStamp())
+	require.Equal(t, uint32(3), world.CurrentEpoch())
+	require.Equal(t, randomSeed[:], world.CurrentRandomSeed())
+}
+
+func TestMockWorld_AdvanceBlock_RotatesBlockInfo(t *testing.T) {
+	world := newAdvanceBlockTestWorld()
+
+	world.AdvanceBlock(&BlockInfo{BlockNonce: 1, BlockRound: 1, BlockTimestamp: 6}, nil)
+	require.Equal(t, uint64(5), world.LastNonce())
+	require.Equal(t, uint64(7), world.LastRound())
+	require.Equal(t, uint64(100), world.LastTimeStamp())
+	require.Equal(t, uint32(2), world.LastEpoch())
+
+	world.AdvanceBlock(&BlockInfo{BlockNonce: 2, BlockRound: 2, BlockTimestamp: 12}, nil)
+	require.Equal(t, uint64(6), world.LastNonce())
+	require.Equal(t, uint64(8), world.LastRound())
+	require.Equal(t, uint64(106), world.LastTimeStamp())
+	require.Equal(t, uint64(8), world.CurrentNonce())
+	require.Equal(t, uint64(10), world.CurrentRound())
+	require.Equal(t, uint64(118), world.CurrentTimeStamp())
+}
+
+func TestMockWorld_AdvanceBlock_NoCurrentBlockInfo(t *testing.T) {
+	world := NewMockWorld()
+
+	world.AdvanceBlock(&BlockInfo{BlockNonce: 1, BlockRound: 1}, nil)
+	require.Equal(t, uint64(0), world.LastNonce())
+	require.Equal(t, uint64(1), world.CurrentNonce())
+	require.Equal(t, uint64(1), world.CurrentRound())
+}
+
+func TestMockWorld_AdvanceBlock_Blockhashes(t *testing.T) {
+	world := newAdvanceBlockTestWorld()
+	newBlockHash := []byte("next_block_hash_______________")
+
+	world.AdvanceBlock(&BlockInfo{BlockNonce: 3, BlockRound: 3}, newBlockHash)
+
+	hash, err := world.GetBlockhash(8)
+	require.Nil(t, err)
+	require.Equal(t, newBlockHash, hash)
+
+	hash, err = world.GetBlockhash(7)
+	require.Nil(t, err)
+	require.Equal(t, GenerateMockBlockHash(7), hash)
+
+	hash, err = world.GetBlockhash(6)
+	require.Nil(t, err)
+	require.Equal(t, GenerateMockBlockHash(6), hash)
+
+	// the hashes known before the advance are kept, shifted
+	hash, err = world.GetBlockhash(5)
+	require.Nil(t, err)
+	require.Equal(t, GenerateMockBlockHash(5), hash)
+
+	hash, err = world.GetBlockhash(4)
+	require.Nil(t, err)
+	require.Equal(t, GenerateMockBlockHash(4), hash)
+
+	_, err = world.GetBlockhash(3)
+	require.NotNil(t, err)
+
+	_, err = world.GetBlockhash(9)
+	require.NotNil(t, err)
+}
+
+func TestMockWorld_AdvanceBlock_TooManyBlocksDropsOldHashes(t *testing.T) {
+	world := newAdvanceBlockTestWorld()
+
+	world.AdvanceBlock(&BlockInfo{BlockNonce: maxGeneratedBlockHashes + 10}, nil)
+	require.Len(t, world.Blockhashes, maxGeneratedBlockHashes)
+
+	currentNonce := world.CurrentNonce()
+	hash, err := world.GetBlockhash(currentNonce)
+	require.Nil(t, err)
+	require.Equal(t, GenerateMockBlockHash(currentNonce), hash)
+
+	_, err = world.GetBlockhash(5)
+	require.NotNil(t, err)
+}
+
+func TestMockWorld_AdvanceBlock_ZeroBlocks(t *testing.T) {
+	world := newAdvanceBlockTestWorld()
+
+	world.AdvanceBlock(&BlockInfo{BlockEpoch: 1}, nil)
+	require.Equal(t, uint64(5), world.CurrentNonce())
+	require.Equal(t, uint32(3), world.CurrentEpoch())
+	require.Len(t, world.Blockhashes, 2)
+
+	hash, err := world.GetBlockhash(5)
+	require.Nil(t, err)
+	require.Equal(t, GenerateMockBlockHash(5), hash)
+}
+
+func newSnapshotTestWorld() *MockWorld {
+	world := NewMockWorld()
+	account := world.AcctMap.CreateAccount([]byte("account"))
+	account.Storage["key"] = []byte("initial")
+	return world
+}
+
+func TestMockWorld_RevertToSnapshot_AccountsAdapter(t *testing.T) {
+	world := newSnapshotTestWorld()
+	account := world.AcctMap.GetAccount([]byte("account"))
+
+	world.CreateStateBackup()
+	account.Storage["key"] = []byte("backup")
+
+	snapshot := world.GetSnapshot()
+	require.Equal(t, 1, snapshot)
+	account.Storage["key"] = []byte("changed")
+
+	require.Nil(t, world.RevertToSnapshot(snapshot))
+	require.Equal(t, []byte("backup"), world.AcctMap.GetAccount([]byte("account")).Storage["key"])
+
+	// the snapshot is in the same journal as the backup, which is still there
+	require.Nil(t, world.RollbackChanges())
+	require.Equal(t, []byte("initial"), world.AcctMap.GetAccount([]byte("account")).Storage["key"])
+	require.NotNil(t, world.RevertToSnapshot(snapshot))
+}
+
+func TestMockWorld_RevertToSnapshot_WithoutAccountsAdapter(t *testing.T) {
+	world := newSnapshotTestWorld()
+	world.AccountsAdapter = nil
+	account := world.AcctMap.GetAccount([]byte("account"))
+
+	snapshot := world.GetSnapshot()
+	require.Equal(t, 0, snapshot)
+	account.Storage["key"] = []byte("changed")
+	account.Nonce = 5
+	world.AcctMap.CreateAccount([]byte("new account"))
+
+	require.Nil(t, world.RevertToSnapshot(snapshot))
+	require.Equal(t, []byte("initial"), world.AcctMap.GetAccount([]byte("account")).Storage["key"])
+	require.Equal(t, uint64(0), world.AcctMap.GetAccount([]byte("account")).Nonce)
+	require.Nil(t, world.AcctMap.GetAccount([]byte("new account")))
+	require.NotNil(t, world.RevertToSnapshot(snapshot))
+}
@@ -0,0 +1,420 @@
+package worldmock
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/kalyan3104/k-chain-core-go/data/vm"
+	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
+	"github.com/kalyan3104/k-chain-vm-common-go/parsers"
+)
+
+// callbackFunctionName is the function called by a callback transfer whose
+// data only holds arguments, mirroring the VM
+const callbackFunctionName = "callBack"
+
+// CrossShardTransfer is an output transfer produced on one shard and waiting
+// to be executed on the shard of its recipient.
+type CrossShardTransfer struct {
+	SenderShardID      uint32
+	DestinationShardID uint32
+	Sender             []byte
+	Recipient          []byte
+	Value              *big.Int
+	Data               []byte
+	GasLimit           uint64
+	GasLocked          uint64
+	GasPrice           uint64
+	CallType           vm.CallType
+	OriginalTxHash     []byte
+	PrevTxHash         []byte
+	TxHash             []byte
+	Round              uint64
+}
+
+// CrossShardExecution is the outcome of delivering a CrossShardTransfer on
+// its destination shard. VMOutput is nil if the VM was not involved, or if it
+// returned an error.
+type CrossShardExecution struct {
+	Transfer *CrossShardTransfer
+	VMOutput *vmcommon.VMOutput
+	Err      error
+}
+
+type shardBlocks struct {
+	previousBlockInfo *BlockInfo
+	currentBlockInfo  *BlockInfo
+	blockhashes       [][]byte
+}
+
+// MultiShardWorld simulates several shards on top of a single MockWorld. The
+// accounts of all the shards live in the same account map, while the block
+// info of the MockWorld is the one of the shard selected in SelfShardID; the
+// block info of the other shards is kept aside. The outputs applied through
+// UpdateAccounts only change the accounts of the selected shard, the transfers
+// to the other shards being queued until the next call to ExecuteRound.
+type MultiShardWorld struct {
+	World            *MockWorld
+	Round            uint64
+	PendingTransfers []*CrossShardTransfer
+	otherShardBlocks map[uint32]*shardBlocks
+}
+
+// NewMultiShardWorld creates a new MultiShardWorld over the given MockWorld
+func NewMultiShardWorld(world *MockWorld) *MultiShardWorld {
+	return &MultiShardWorld{
+		World:            world,
+		Round:            0,
+		PendingTransfers: nil,
+		otherShardBlocks: make(map[uint32]*shardBlocks),
+	}
+}
+
+// Clear drops the pending transfers and the block info of all the shards
+// other than the selected one.
+func (m *MultiShardWorld) Clear() {
+	m.Round = 0
+	m.PendingTransfers = nil
+	m.ResetBlockInfo()
+}
+
+// ResetBlockInfo forgets the block info of the shards other than the selected
+// one; they start again from a copy of the block info of the selected shard.
+func (m *MultiShardWorld) ResetBlockInfo() {
+	m.otherShardBlocks = make(map[uint32]*shardBlocks)
+}
+
+// SelectShard makes the given shard the one seen by the VM, swapping in its
+// block info. A shard selected for the first time starts with a copy of the
+// block info of the previously selected shard.
+func (m *MultiShardWorld) SelectShard(shardID uint32) {
+	if shardID == m.World.SelfShardID {
+		return
+	}
+
+	selected := m.saveSelectedShardBlocks()
+	m.otherShardBlocks[m.World.SelfShardID] = selected
+
+	blocks, found := m.otherShardBlocks[shardID]
+	if !found {
+		blocks = cloneShardBlocks(selected)
+	}
+	delete(m.otherShardBlocks, shardID)
+
+	m.World.SelfShardID = shardID
+	m.World.PreviousBlockInfo = blocks.previousBlockInfo
+	m.World.CurrentBlockInfo = blocks.currentBlockInfo
+	m.World.Blockhashes = blocks.blockhashes
+}
+
+// SelectShardOf selects the shard of the given account; unknown accounts
+// leave the selection unchanged.
+func (m *MultiShardWorld) SelectShardOf(address []byte) {
+	account := m.World.AcctMap.GetAccount(address)
+	if account == nil {
+		return
+	}
+
+	m.SelectShard(account.ShardID)
+}
+
+// CurrentBlockInfo returns the current block info of the given shard
+func (m *MultiShardWorld) CurrentBlockInfo(shardID uint32) *BlockInfo {
+	if shardID == m.World.SelfShardID {
+		return m.World.CurrentBlockInfo
+	}
+
+	blocks, found := m.otherShardBlocks[shardID]
+	if !found {
+		return m.World.CurrentBlockInfo
+	}
+
+	return blocks.currentBlockInfo
+}
+
+// AdvanceBlock advances all the shards by the same deltas, as described in
+// MockWorld.AdvanceBlock.
+func (m *MultiShardWorld) AdvanceBlock(delta *BlockInfo, blockHash []byte) {
+	selectedShardID := m.World.SelfShardID
+	shards := m.knownShards()
+
+	// the shards selected for the first time must not start from an already advanced copy
+	selected := m.saveSelectedShardBlocks()
+	for _, shardID := range shards {
+		_, found := m.otherShardBlocks[shardID]
+		if shardID != selectedShardID && !found {
+			m.otherShardBlocks[shardID] = cloneShardBlocks(selected)
+		}
+	}
+
+	for _, shardID := range shards {
+		m.SelectShard(shardID)
+		m.World.AdvanceBlock(delta, blockHash)
+	}
+	m.SelectShard(selectedShardID)
+}
+
+// UpdateAccounts applies the output of a call executed on the selected shard.
+// The accounts of the selected shard, as well as the new accounts, are updated
+// right away. The balance deltas of the accounts of other shards are carried
+// by their output transfers, which are queued for the next round.
+func (m *MultiShardWorld) UpdateAccounts(vmInput *vmcommon.VMInput, vmOutput *vmcommon.VMOutput) {
+	outputAccounts := sortedOutputAccounts(vmOutput.OutputAccounts)
+	for _, outputAccount := range outputAccounts {
+		account := m.World.AcctMap.GetAccount(outputAccount.Address)
+		if account == nil || !m.World.isOnOtherShard(account) {
+			m.World.UpdateAccountFromOutputAccount(outputAccount)
+			continue
+		}
+
+		for _, outputTransfer := range outputAccount.OutputTransfers {
+			m.queueTransfer(vmInput, outputAccount.Address, account.ShardID, outputTransfer)
+		}
+	}
+
+	for _, address := range vmOutput.DeletedAccounts {
+		account := m.World.AcctMap.GetAccount(address)
+		if account != nil && !m.World.isOnOtherShard(account) {
+			m.World.AcctMap.DeleteAccount(address)
+		}
+	}
+}
+
+// ExecuteRound advances all the shards by one block, then delivers the
+// transfers queued before the round started, each on its destination shard.
+// The transfers produced during the round, including the callbacks, are left
+// for the next round. The selected shard is restored afterwards.
+func (m *MultiShardWorld) ExecuteRound(vmExecutor vmcommon.VMExecutionHandler) []*CrossShardExecution {
+	m.Round++
+	m.AdvanceBlock(&BlockInfo{BlockNonce: 1, BlockRound: 1}, nil)
+
+	selectedShardID := m.World.SelfShardID
+	transfers := m.PendingTransfers
+	m.PendingTransfers = nil
+
+	executions := make([]*CrossShardExecution, 0, len(transfers))
+	for _, transfer := range transfers {
+		m.SelectShard(transfer.DestinationShardID)
+		executions = append(executions, m.deliverTransfer(vmExecutor, transfer))
+	}
+	m.SelectShard(selectedShardID)
+
+	return executions
+}
+
+func (m *MultiShardWorld) deliverTransfer(vmExecutor vmcommon.VMExecutionHandler, transfer *CrossShardTransfer) *CrossShardExecution {
+	execution := &CrossShardExecution{Transfer: transfer}
+
+	input, isCall := m.createContractCallInput(transfer)
+	if !isCall {
+		m.World.UpdateAccountFromOutputAccount(&vmcommon.OutputAccount{
+			Address:      transfer.Recipient,
+			BalanceDelta: big.NewInt(0).Set(transfer.Value),
+		})
+		return execution
+	}
+
+	if input == nil {
+		m.returnFailedTransfer(transfer, vmcommon.UserError, "invalid cross-shard call data")
+		return execution
+	}
+
+	vmOutput, err := vmExecutor.RunSmartContractCall(input)
+	if err != nil {
+		execution.Err = err
+		m.returnFailedTransfer(transfer, vmcommon.ExecutionFailed, err.Error())
+		return execution
+	}
+
+	execution.VMOutput = vmOutput
+	if vmOutput.ReturnCode != vmcommon.Ok {
+		m.returnFailedTransfer(transfer, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+		return execution
+	}
+
+	m.UpdateAccounts(&input.VMInput, vmOutput)
+
+	return execution
+}
+
+// createContractCallInput returns false if the transfer only moves value;
+// a nil input means the data of the call could not be parsed
+func (m *MultiShardWorld) createContractCallInput(transfer *CrossShardTransfer) (*vmcommon.ContractCallInput, bool) {
+	if len(transfer.Data) == 0 {
+		return nil, false
+	}
+
+	data := string(transfer.Data)
+	if transfer.CallType == vm.AsynchronousCallBack && strings.HasPrefix(data, "@") {
+		data = callbackFunctionName + data
+	}
+
+	function, arguments, err := parsers.NewCallArgsParser().ParseData(data)
+	if err != nil {
+		return nil, true
+	}
+
+	if !m.World.IsSmartContract(transfer.Recipient) && !m.isBuiltinFunction(function) {
+		return nil, false
+	}
+
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:     transfer.Sender,
+			Arguments:      arguments,
+			CallValue:      big.NewInt(0).Set(transfer.Value),
+			CallType:       transfer.CallType,
+			GasPrice:       transfer.GasPrice,
+			GasProvided:    transfer.GasLimit,
+			GasLocked:      transfer.GasLocked,
+			OriginalTxHash: transfer.OriginalTxHash,
+			CurrentTxHash:  transfer.TxHash,
+			PrevTxHash:     transfer.PrevTxHash,
+			DCDTTransfers:  make([]*vmcommon.DCDTTransfer, 0),
+		},
+		RecipientAddr: transfer.Recipient,
+		Function:      function,
+	}, true
+}
+
+// returnFailedTransfer sends the value of a failed transfer back to its
+// sender; a failed async call is answered with a callback carrying the error
+func (m *MultiShardWorld) returnFailedTransfer(transfer *CrossShardTransfer, returnCode vmcommon.ReturnCode, message string) {
+	returnTransfer := vmcommon.OutputTransfer{
+		Value:         big.NewInt(0).Set(transfer.Value),
+		CallType:      vm.DirectCall,
+		SenderAddress: transfer.Recipient,
+	}
+
+	if transfer.CallType == vm.AsynchronousCall {
+		returnTransfer.Data = []byte("@" + hex.EncodeToString([]byte(returnCode.String())) +
+			"@" + hex.EncodeToString([]byte(message)))
+		returnTransfer.GasLimit = transfer.GasLocked
+		returnTransfer.CallType = vm.AsynchronousCallBack
+	} else if transfer.Value.Sign() == 0 {
+		return
+	}
+
+	input := &vmcommon.VMInput{
+		GasPrice:       transfer.GasPrice,
+		OriginalTxHash: transfer.OriginalTxHash,
+		CurrentTxHash:  transfer.TxHash,
+	}
+	m.queueTransfer(input, transfer.Sender, transfer.SenderShardID, returnTransfer)
+}
+
+func (m *MultiShardWorld) queueTransfer(
+	vmInput *vmcommon.VMInput,
+	recipient []byte,
+	destinationShardID uint32,
+	outputTransfer vmcommon.OutputTransfer,
+) {
+	value := big.NewInt(0)
+	if outputTransfer.Value != nil {
+		value.Set(outputTransfer.Value)
+	}
+
+	transfer := &CrossShardTransfer{
+		SenderShardID:      m.World.SelfShardID,
+		DestinationShardID: destinationShardID,
+		Sender:             outputTransfer.SenderAddress,
+		Recipient:          recipient,
+		Value:              value,
+		Data:               outputTransfer.Data,
+		GasLimit:           outputTransfer.GasLimit,
+		GasLocked:          outputTransfer.GasLocked,
+		GasPrice:           vmInput.GasPrice,
+		CallType:           outputTransfer.CallType,
+		OriginalTxHash:     vmInput.OriginalTxHash,
+		PrevTxHash:         vmInput.CurrentTxHash,
+		Round:              m.Round,
+	}
+	if len(transfer.Sender) == 0 {
+		transfer.Sender = vmInput.CallerAddr
+	}
+	transfer.TxHash = generateCrossShardTxHash(vmInput.CurrentTxHash, len(m.PendingTransfers))
+
+	m.PendingTransfers = append(m.PendingTransfers, transfer)
+}
+
+func (m *MultiShardWorld) isBuiltinFunction(function string) bool {
+	if m.World.BuiltinFuncs == nil {
+		return false
+	}
+
+	_, isBuiltin := m.World.GetBuiltinFunctionNames()[function]
+	return isBuiltin
+}
+
+func (m *MultiShardWorld) saveSelectedShardBlocks() *shardBlocks {
+	return &shardBlocks{
+		previousBlockInfo: m.World.PreviousBlockInfo,
+		currentBlockInfo:  m.World.CurrentBlockInfo,
+		blockhashes:       m.World.Blockhashes,
+	}
+}
+
+// knownShards lists the shards of all the accounts, as well as the shards
+// which have their own block info
+func (m *MultiShardWorld) knownShards() []uint32 {
+	numShards := m.World.NumberOfShards()
+	for shardID := range m.otherShardBlocks {
+		if shardID >= numShards {
+			numShards = shardID + 1
+		}
+	}
+	if m.World.SelfShardID >= numShards {
+		numShards = m.World.SelfShardID + 1
+	}
+
+	shards := make([]uint32, 0, numShards)
+	for shardID := uint32(0); shardID < numShards; shardID++ {
+		shards = append(shards, shardID)
+	}
+
+	return shards
+}
+
+func cloneShardBlocks(blocks *shardBlocks) *shardBlocks {
+	clone := &shardBlocks{
+		blockhashes: append([][]byte(nil), blocks.blockhashes...),
+	}
+	if blocks.previousBlockInfo != nil {
+		previousBlockInfo := *blocks.previousBlockInfo
+		clone.previousBlockInfo = &previousBlockInfo
+	}
+	if blocks.currentBlockInfo != nil {
+		currentBlockInfo := *blocks.currentBlockInfo
+		clone.currentBlockInfo = &currentBlockInfo
+	}
+
+	return clone
+}
+
+// sortedOutputAccounts orders the output accounts by address, so that the
+// transfers are queued deterministically
+func sortedOutputAccounts(outputAccounts map[string]*vmcommon.OutputAccount) []*vmcommon.OutputAccount {
+	sorted := make([]*vmcommon.OutputAccount, 0, len(outputAccounts))
+	for _, outputAccount := range outputAccounts {
+		sorted = append(sorted, outputAccount)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Address, sorted[j].Address) < 0
+	})
+
+	return sorted
+}
+
+// generateCrossShardTxHash simulates the hash of the smart contract result
+// carrying a cross-shard transfer
+func generateCrossShardTxHash(prevTxHash []byte, index int) []byte {
+	indexBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(indexBytes, uint64(index))
+	hash := sha256.Sum256(append(append([]byte("scr"), prevTxHash...), indexBytes...))
+	return hash[:]
+}
@@ -0,0 +1,364 @@
+package worldmock
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/kalyan3104/k-chain-core-go/data/vm"
+	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	shardTestUser      = []byte("user____________________________")
+	shardTestCaller    = []byte("caller__________________________")
+	shardTestCallee    = []byte("callee__________________________")
+	shardTestRecipient = []byte("recipient_______________________")
+	shardTestTxHash    = []byte("tx_hash")
+
+	errStubExecution = errors.New("stub execution error")
+)
+
+type stubCall struct {
+	input   *vmcommon.ContractCallInput
+	shardID uint32
+}
+
+// stubVMExecutor answers the calls through runCall and records them, along
+// with the shard selected when each call was made
+type stubVMExecutor struct {
+	world   *MockWorld
+	runCall func(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error)
+	calls   []stubCall
+}
+
+func (s *stubVMExecutor) RunSmartContractCreate(_ *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, error) {
+	return nil, nil
+}
+
+func (s *stubVMExecutor) RunSmartContractCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	s.calls = append(s.calls, stubCall{input: input, shardID: s.world.SelfShardID})
+	return s.runCall(input)
+}
+
+func (s *stubVMExecutor) GasScheduleChange(_ map[string]map[string]uint64) {
+}
+
+func (s *stubVMExecutor) GetVersion() string {
+	return ""
+}
+
+func (s *stubVMExecutor) Close() error {
+	return nil
+}
+
+func (s *stubVMExecutor) IsInterfaceNil() bool {
+	return s == nil
+}
+
+// newMultiShardTestWorld puts the user and the caller contract on shard 0,
+// the callee contract and the recipient on shard 1; shard 0 is selected
+func newMultiShardTestWorld() *MultiShardWorld {
+	world := NewMockWorld()
+	world.CurrentBlockInfo = &BlockInfo{BlockNonce: 10, BlockRound: 10}
+
+	user := world.AcctMap.CreateAccount(shardTestUser)
+	user.Balance = big.NewInt(1000)
+	world.AcctMap.CreateSmartContractAccount(shardTestUser, shardTestCaller, []byte("caller code"))
+
+	callee := world.AcctMap.CreateSmartContractAccount(shardTestUser, shardTestCallee, []byte("callee code"))
+	callee.ShardID = 1
+	recipient := world.AcctMap.CreateAccount(shardTestRecipient)
+	recipient.ShardID = 1
+
+	return NewMultiShardWorld(world)
+}
+
+func newStubVMExecutor(world *MultiShardWorld) *stubVMExecutor {
+	return &stubVMExecutor{
+		world: world.World,
+		runCall: func(_ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+		},
+	}
+}
+
+// sendFromCaller applies the output of a transaction of the user to the caller
+// contract, which forwards the given transfer to an account of shard 1
+func sendFromCaller(world *MultiShardWorld, destination []byte, outputTransfer vmcommon.OutputTransfer) {
+	outputTransfer.SenderAddress = shardTestCaller
+	world.UpdateAccounts(&vmcommon.VMInput{
+		CallerAddr:     shardTestUser,
+		GasPrice:       1,
+		OriginalTxHash: shardTestTxHash,
+		CurrentTxHash:  shardTestTxHash,
+	}, &vmcommon.VMOutput{
+		ReturnCode: vmcommon.Ok,
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			string(shardTestCaller): {
+				Address:      shardTestCaller,
+				BalanceDelta: big.NewInt(0),
+			},
+			string(destination): {
+				Address:         destination,
+				BalanceDelta:    big.NewInt(0).Set(outputTransfer.Value),
+				OutputTransfers: []vmcommon.OutputTransfer{outputTransfer},
+			},
+		},
+	})
+}
+
+func balanceOf(world *MultiShardWorld, address []byte) *big.Int {
+	return world.World.AcctMap.GetAccount(address).Balance
+}
+
+func TestMultiShardWorld_ValueTransferCreditedNextRound(t *testing.T) {
+	world := newMultiShardTestWorld()
+	executor := newStubVMExecutor(world)
+
+	sendFromCaller(world, shardTestRecipient, vmcommon.OutputTransfer{
+		Value:    big.NewInt(100),
+		CallType: vm.DirectCall,
+	})
+
+	require.Equal(t, big.NewInt(0), balanceOf(world, shardTestRecipient))
+	require.Len(t, world.PendingTransfers, 1)
+
+	transfer := world.PendingTransfers[0]
+	require.Equal(t, uint32(0), transfer.SenderShardID)
+	require.Equal(t, uint32(1), transfer.DestinationShardID)
+	require.Equal(t, shardTestCaller, transfer.Sender)
+	require.Equal(t, shardTestRecipient, transfer.Recipient)
+	require.Equal(t, big.NewInt(100), transfer.Value)
+	require.Equal(t, shardTestTxHash, transfer.PrevTxHash)
+	require.Equal(t, uint64(0), transfer.Round)
+
+	executions := world.ExecuteRound(executor)
+	require.Len(t, executions, 1)
+	require.Nil(t, executions[0].VMOutput)
+	require.Nil(t, executions[0].Err)
+	require.Empty(t, executor.calls)
+
+	require.Equal(t, uint64(1), world.Round)
+	require.Empty(t, world.PendingTransfers)
+	require.Equal(t, big.NewInt(100), balanceOf(world, shardTestRecipient))
+	require.Equal(t, uint32(0), world.World.SelfShardID)
+}
+
+func TestMultiShardWorld_AsyncCallThenCallback(t *testing.T) {
+	world := newMultiShardTestWorld()
+	executor := newStubVMExecutor(world)
+	executor.runCall = func(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+		if input.Function == callbackFunctionName {
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+		}
+
+		return &vmcommon.VMOutput{
+			ReturnCode: vmcommon.Ok,
+			OutputAccounts: map[string]*vmcommon.OutputAccount{
+				string(shardTestCallee): {
+					Address:        shardTestCallee,
+					BalanceDelta:   big.NewInt(0).Set(input.CallValue),
+					StorageUpdates: map[string]*vmcommon.StorageUpdate{"done": {Offset: []byte("done"), Data: []byte{1}}},
+				},
+				string(shardTestCaller): {
+					Address:      shardTestCaller,
+					BalanceDelta: big.NewInt(0),
+					OutputTransfers: []vmcommon.OutputTransfer{{
+						Value:         big.NewInt(0),
+						Data:          []byte("@" + hex.EncodeToString([]byte("ok")) + "@2a"),
+						GasLimit:      300,
+						CallType:      vm.AsynchronousCallBack,
+						SenderAddress: shardTestCallee,
+					}},
+				},
+			},
+		}, nil
+	}
+
+	sendFromCaller(world, shardTestCallee, vmcommon.OutputTransfer{
+		Value:     big.NewInt(10),
+		Data:      []byte("doSomething@01"),
+		GasLimit:  1000,
+		GasLocked: 500,
+		CallType:  vm.AsynchronousCall,
+	})
+	require.Len(t, world.PendingTransfers, 1)
+
+	world.ExecuteRound(executor)
+	require.Len(t, executor.calls, 1)
+
+	call := executor.calls[0]
+	require.Equal(t, uint32(1), call.shardID)
+	require.Equal(t, shardTestCaller, call.input.CallerAddr)
+	require.Equal(t, shardTestCallee, call.input.RecipientAddr)
+	require.Equal(t, "doSomething", call.input.Function)
+	require.Equal(t, [][]byte{{1}}, call.input.Arguments)
+	require.Equal(t, big.NewInt(10), call.input.CallValue)
+	require.Equal(t, vm.AsynchronousCall, call.input.CallType)
+	require.Equal(t, uint64(1000), call.input.GasProvided)
+	require.Equal(t, uint64(500), call.input.GasLocked)
+
+	callee := world.World.AcctMap.GetAccount(shardTestCallee)
+	require.Equal(t, big.NewInt(10), callee.Balance)
+	require.Equal(t, []byte{1}, callee.Storage["done"])
+
+	// the callback produced on shard 1 waits for the next round
+	require.Len(t, world.PendingTransfers, 1)
+	callback := world.PendingTransfers[0]
+	require.Equal(t, uint32(1), callback.SenderShardID)
+	require.Equal(t, uint32(0), callback.DestinationShardID)
+	require.Equal(t, shardTestCallee, callback.Sender)
+	require.Equal(t, shardTestCaller, callback.Recipient)
+	require.Equal(t, uint64(1), callback.Round)
+
+	world.ExecuteRound(executor)
+	require.Len(t, executor.calls, 2)
+
+	call = executor.calls[1]
+	require.Equal(t, uint32(0), call.shardID)
+	require.Equal(t, shardTestCallee, call.input.CallerAddr)
+	require.Equal(t, shardTestCaller, call.input.RecipientAddr)
+	require.Equal(t, callbackFunctionName, call.input.Function)
+	require.Equal(t, [][]byte{[]byte("ok"), {0x2a}}, call.input.Arguments)
+	require.Equal(t, vm.AsynchronousCallBack, call.input.CallType)
+	require.Equal(t, uint64(300), call.input.GasProvided)
+
+	require.Empty(t, world.PendingTransfers)
+}
+
+func TestMultiShardWorld_FailedAsyncCallReturnsValueInCallback(t *testing.T) {
+	world := newMultiShardTestWorld()
+	executor := newStubVMExecutor(world)
+	executor.runCall = func(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+		if input.Function == callbackFunctionName {
+			return &vmcommon.VMOutput{
+				ReturnCode: vmcommon.Ok,
+				OutputAccounts: map[string]*vmcommon.OutputAccount{
+					string(shardTestCaller): {
+						Address:      shardTestCaller,
+						BalanceDelta: big.NewInt(0).Set(input.CallValue),
+					},
+				},
+			}, nil
+		}
+
+		return &vmcommon.VMOutput{
+			ReturnCode:    vmcommon.UserError,
+			ReturnMessage: "not allowed",
+		}, nil
+	}
+
+	sendFromCaller(world, shardTestCallee, vmcommon.OutputTransfer{
+		Value:     big.NewInt(10),
+		Data:      []byte("doSomething"),
+		GasLimit:  1000,
+		GasLocked: 500,
+		CallType:  vm.AsynchronousCall,
+	})
+
+	executions := world.ExecuteRound(executor)
+	require.Len(t, executions, 1)
+	require.Nil(t, executions[0].Err)
+	require.Equal(t, vmcommon.UserError, executions[0].VMOutput.ReturnCode)
+
+	// the failed call leaves the callee untouched
+	require.Equal(t, big.NewInt(0), balanceOf(world, shardTestCallee))
+
+	require.Len(t, world.PendingTransfers, 1)
+	callback := world.PendingTransfers[0]
+	require.Equal(t, uint32(1), callback.SenderShardID)
+	require.Equal(t, uint32(0), callback.DestinationShardID)
+	require.Equal(t, shardTestCallee, callback.Sender)
+	require.Equal(t, shardTestCaller, callback.Recipient)
+	require.Equal(t, big.NewInt(10), callback.Value)
+	require.Equal(t, uint64(500), callback.GasLimit)
+	require.Equal(t, vm.AsynchronousCallBack, callback.CallType)
+	expectedData := "@" + hex.EncodeToString([]byte(vmcommon.UserError.String())) +
+		"@" + hex.EncodeToString([]byte("not allowed"))
+	require.Equal(t, expectedData, string(callback.Data))
+
+	world.ExecuteRound(executor)
+	require.Len(t, executor.calls, 2)
+
+	call := executor.calls[1]
+	require.Equal(t, callbackFunctionName, call.input.Function)
+	require.Equal(t, [][]byte{[]byte(vmcommon.UserError.String()), []byte("not allowed")}, call.input.Arguments)
+	require.Equal(t, big.NewInt(10), call.input.CallValue)
+
+	require.Equal(t, big.NewInt(10), balanceOf(world, shardTestCaller))
+	require.Empty(t, world.PendingTransfers)
+}
+
+func TestMultiShardWorld_FailedDirectCallReturnsValue(t *testing.T) {
+	world := newMultiShardTestWorld()
+	executor := newStubVMExecutor(world)
+	executor.runCall = func(_ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+		return nil, errStubExecution
+	}
+
+	sendFromCaller(world, shardTestCallee, vmcommon.OutputTransfer{
+		Value:    big.NewInt(10),
+		Data:     []byte("doSomething"),
+		GasLimit: 1000,
+		CallType: vm.DirectCall,
+	})
+
+	executions := world.ExecuteRound(executor)
+	require.Len(t, executions, 1)
+	require.Equal(t, errStubExecution, executions[0].Err)
+	require.Nil(t, executions[0].VMOutput)
+
+	// a failed direct call gets its value back, without any callback
+	require.Len(t, world.PendingTransfers, 1)
+	returned := world.PendingTransfers[0]
+	require.Equal(t, shardTestCaller, returned.Recipient)
+	require.Equal(t, big.NewInt(10), returned.Value)
+	require.Empty(t, returned.Data)
+	require.Equal(t, vm.DirectCall, returned.CallType)
+
+	world.ExecuteRound(executor)
+	require.Len(t, executor.calls, 1)
+	require.Equal(t, big.NewInt(10), balanceOf(world, shardTestCaller))
+	require.Empty(t, world.PendingTransfers)
+}
+
+func TestMultiShardWorld_BlockInfoKeptPerShard(t *testing.T) {
+	world := newMultiShardTestWorld()
+
+	// a shard selected for the first time starts from a copy of the selected one
+	world.SelectShard(1)
+	require.Equal(t, uint32(1), world.World.SelfShardID)
+	require.Equal(t, uint64(10), world.World.CurrentNonce())
+
+	world.World.AdvanceBlock(&BlockInfo{BlockNonce: 5, BlockRound: 5}, nil)
+	require.Equal(t, uint64(15), world.World.CurrentNonce())
+
+	world.SelectShard(0)
+	require.Equal(t, uint64(10), world.World.CurrentNonce())
+	require.Equal(t, uint64(10), world.CurrentBlockInfo(0).BlockNonce)
+	require.Equal(t, uint64(15), world.CurrentBlockInfo(1).BlockNonce)
+
+	world.AdvanceBlock(&BlockInfo{BlockNonce: 1, BlockRound: 2}, nil)
+	require.Equal(t, uint32(0), world.World.SelfShardID)
+	require.Equal(t, uint64(11), world.CurrentBlockInfo(0).BlockNonce)
+	require.Equal(t, uint64(12), world.CurrentBlockInfo(0).BlockRound)
+	require.Equal(t, uint64(16), world.CurrentBlockInfo(1).BlockNonce)
+	require.Equal(t, uint64(17), world.CurrentBlockInfo(1).BlockRound)
+
+	// each shard remembers its own previous block
+	world.SelectShardOf(shardTestRecipient)
+	require.Equal(t, uint32(1), world.World.SelfShardID)
+	require.Equal(t, uint64(15), world.World.LastNonce())
+	world.SelectShardOf(shardTestUser)
+	require.Equal(t, uint64(10), world.World.LastNonce())
+
+	world.ExecuteRound(newStubVMExecutor(world))
+	require.Equal(t, uint64(12), world.CurrentBlockInfo(0).BlockNonce)
+	require.Equal(t, uint64(17), world.CurrentBlockInfo(1).BlockNonce)
+
+	world.ResetBlockInfo()
+	require.Equal(t, uint64(12), world.CurrentBlockInfo(1).BlockNonce)
+}
@@ -0,0 +1,169 @@
+package worldmock
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"sort"
+)
+
+const merkleLeafPrefix = 0x00
+const merkleNodePrefix = 0x01
+
+// ComputeDataRootHash computes the Merkle root of the storage of the account,
+// with one leaf per non-empty key, in key order. The DCDT balances, metadata,
+// roles and nonces of the account are covered as well, being kept in storage.
+func (a *Account) ComputeDataRootHash() []byte {
+	keys := make([]string, 0, len(a.Storage))
+	for key, value := range a.Storage {
+		if len(value) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	leaves := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		leaves = append(leaves, hashLeaf([]byte(key), a.Storage[key]))
+	}
+
+	return merkleRoot(leaves)
+}
+
+// ComputeRootHash computes the Merkle root of all the accounts, with one leaf
+// per account, in address order. Each leaf covers the nonce, the balance, the
+// code hash and metadata, the owner, the username, the developer reward and
+// the data root hash of the account.
+func (am AccountMap) ComputeRootHash() []byte {
+	accounts := am.sortedAccounts()
+	leaves := make([][]byte, 0, len(accounts))
+	for _, account := range accounts {
+		leaves = append(leaves, account.leafHash(account.ComputeDataRootHash()))
+	}
+
+	return merkleRoot(leaves)
+}
+
+// commitRootHash computes the same root hash as ComputeRootHash, keeping the
+// data root hash of each account in its RootHash
+func (am AccountMap) commitRootHash() []byte {
+	accounts := am.sortedAccounts()
+	leaves := make([][]byte, 0, len(accounts))
+	for _, account := range accounts {
+		account.RootHash = account.ComputeDataRootHash()
+		leaves = append(leaves, account.leafHash(account.RootHash))
+	}
+
+	return merkleRoot(leaves)
+}
+
+// keepRootHashesOf sets the RootHash of the accounts also found among the
+// committed ones to the data root hash kept there by commitRootHash, so the
+// live accounts expose it even when the committed ones are snapshot clones
+func (am AccountMap) keepRootHashesOf(committed AccountMap) {
+	for address, committedAccount := range committed {
+		account, exists := am[address]
+		if exists && account != committedAccount {
+			account.RootHash = committedAccount.RootHash
+		}
+	}
+}
+
+func (am AccountMap) sortedAccounts() []*Account {
+	accounts := make([]*Account, 0, len(am))
+	for _, account := range am {
+		accounts = append(accounts, account)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i].Address, accounts[j].Address) < 0
+	})
+
+	return accounts
+}
+
+func (a *Account) leafHash(dataRootHash []byte) []byte {
+	nonce := make([]byte, 8)
+	binary.BigEndian.PutUint64(nonce, a.Nonce)
+
+	// accounts set up directly with code might not have the code hash yet
+	codeHash := sha256.Sum256(a.Code)
+
+	var balance, developerReward []byte
+	if a.Balance != nil {
+		balance = a.Balance.Bytes()
+	}
+	if a.DeveloperReward != nil {
+		developerReward = a.DeveloperReward.Bytes()
+	}
+
+	return hashLeaf(
+		a.Address,
+		nonce,
+		balance,
+		codeHash[:],
+		a.CodeMetadata,
+		a.OwnerAddress,
+		a.Username,
+		developerReward,
+		dataRootHash,
+	)
+}
+
+// merkleRoot hashes the leaves pairwise, level by level; an odd node is
+// carried over to the next level unchanged
+func merkleRoot(leaves [][]byte) []byte {
+	if len(leaves) == 0 {
+		return emptyRootHash()
+	}
+
+	level := leaves
+	for len(level) > 1 {
+		nextLevel := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				nextLevel = append(nextLevel, level[i])
+				continue
+			}
+			nextLevel = append(nextLevel, hashNode(level[i], level[i+1]))
+		}
+		level = nextLevel
+	}
+
+	return level[0]
+}
+
+// hashLeaf hashes the length-prefixed fields, so that no two different field
+// lists produce the same input
+func hashLeaf(fields ...[]byte) []byte {
+	hasher := sha256.New()
+	_, _ = hasher.Write([]byte{merkleLeafPrefix})
+	for _, field := range fields {
+		writeLengthPrefixed(hasher, field)
+	}
+
+	return hasher.Sum(nil)
+}
+
+func hashNode(left []byte, right []byte) []byte {
+	hasher := sha256.New()
+	_, _ = hasher.Write([]byte{merkleNodePrefix})
+	_, _ = hasher.Write(left)
+	_, _ = hasher.Write(right)
+
+	return hasher.Sum(nil)
+}
+
+func writeLengthPrefixed(hasher hash.Hash, field []byte) {
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(len(field)))
+	_, _ = hasher.Write(length)
+	_, _ = hasher.Write(field)
+}
+
+// emptyRootHash is the root hash of an empty account map, as well as the data
+// root hash of an account without storage
+func emptyRootHash() []byte {
+	emptyHash := sha256.Sum256(nil)
+	return emptyHash[:]
+}
@@ -0,0 +1,185 @@
+package worldmock
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newStateRootTestAccount(address string) *Account {
+	account := NewAccountMap().CreateAccount([]byte(address))
+	account.Nonce = 3
+	account.Balance = big.NewInt(1000)
+	account.Storage["key_a"] = []byte("value_a")
+	account.Storage["key_b"] = []byte("value_b")
+	return account
+}
+
+// newStateRootTestAccounts creates the same accounts and storage each time,
+// inserted in the given order
+func newStateRootTestAccounts(t *testing.T, indexes []int) AccountMap {
+	accounts := NewAccountMap()
+	for _, i := range indexes {
+		account := newStateRootTestAccount(fmt.Sprintf("account_%02d_______________________", i))
+		account.Storage[fmt.Sprintf("key_%02d", i)] = []byte{byte(i)}
+		err := account.SetTokenBalance(MakeTokenKey([]byte("TOKEN-123456"), 0), big.NewInt(int64(i+1)))
+		require.Nil(t, err)
+		accounts.PutAccount(account)
+	}
+
+	return accounts
+}
+
+func newStateRootTestWorld(t *testing.T) *MockWorld {
+	world := NewMockWorld()
+	world.AcctMap = newStateRootTestAccounts(t, []int{0, 1, 2, 3, 4})
+	return world
+}
+
+func stateRootTestIndexes(reversed bool) []int {
+	indexes := make([]int, 0, 10)
+	for i := 0; i < 10; i++ {
+		if reversed {
+			indexes = append(indexes, 9-i)
+		} else {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
+
+func TestComputeRootHash_EmptyAccountMap(t *testing.T) {
+	emptyHash := sha256.Sum256(nil)
+	require.Equal(t, emptyHash[:], NewAccountMap().ComputeRootHash())
+
+	account := NewAccountMap().CreateAccount([]byte("no_storage______________________"))
+	require.Equal(t, emptyHash[:], account.ComputeDataRootHash())
+
+	// empty values are not stored
+	account.Storage["key"] = []byte{}
+	require.Equal(t, emptyHash[:], account.ComputeDataRootHash())
+}
+
+func TestComputeRootHash_IndependentOfOrder(t *testing.T) {
+	accounts := newStateRootTestAccounts(t, stateRootTestIndexes(false))
+	rootHash := accounts.ComputeRootHash()
+
+	// the iteration order of the maps changes between runs
+	for i := 0; i < 20; i++ {
+		require.Equal(t, rootHash, accounts.ComputeRootHash())
+	}
+
+	reversed := newStateRootTestAccounts(t, stateRootTestIndexes(true))
+	require.Equal(t, rootHash, reversed.ComputeRootHash())
+}
+
+func TestComputeRootHash_EqualForIdenticalWorlds(t *testing.T) {
+	first := newStateRootTestAccounts(t, stateRootTestIndexes(false))
+	second := newStateRootTestAccounts(t, stateRootTestIndexes(false))
+	require.Equal(t, first.ComputeRootHash(), second.ComputeRootHash())
+	require.Equal(t, first.ComputeRootHash(), first.Clone().ComputeRootHash())
+}
+
+func TestComputeRootHash_ChangedBySingleChange(t *testing.T) {
+	address := []byte("account_03_______________________")
+	tokenKey := MakeTokenKey([]byte("TOKEN-123456"), 0)
+
+	changes := map[string]func(account *Account){
+		"storage": func(account *Account) {
+			account.Storage["key_a"] = []byte("other value")
+		},
+		"new storage": func(account *Account) {
+			account.Storage["key_c"] = []byte{1}
+		},
+		"balance": func(account *Account) {
+			account.Balance = big.NewInt(1001)
+		},
+		"nonce": func(account *Account) {
+			account.Nonce++
+		},
+		"dcdt": func(account *Account) {
+			err := account.SetTokenBalance(tokenKey, big.NewInt(100))
+			require.Nil(t, err)
+		},
+	}
+
+	unchangedRootHash := newStateRootTestAccounts(t, stateRootTestIndexes(false)).ComputeRootHash()
+	rootHashes := make(map[string]string)
+	for name, change := range changes {
+		accounts := newStateRootTestAccounts(t, stateRootTestIndexes(false))
+		account := accounts.GetAccount(address)
+		unchangedDataRootHash := account.ComputeDataRootHash()
+
+		change(account)
+		rootHash := accounts.ComputeRootHash()
+		require.NotEqual(t, unchangedRootHash, rootHash, name)
+
+		if name != "balance" && name != "nonce" {
+			require.NotEqual(t, unchangedDataRootHash, account.ComputeDataRootHash(), name)
+		}
+
+		for otherName, otherRootHash := range rootHashes {
+			require.NotEqual(t, otherRootHash, string(rootHash), "%s and %s", name, otherName)
+		}
+		rootHashes[name] = string(rootHash)
+	}
+}
+
+func TestMockWorld_GetStateRootHash_ComputedAfterCommit(t *testing.T) {
+	world := newStateRootTestWorld(t)
+	require.Nil(t, world.GetStateRootHash())
+
+	err := world.CommitChanges()
+	require.Nil(t, err)
+
+	committedRootHash := world.AcctMap.ComputeRootHash()
+	require.Equal(t, committedRootHash, world.GetStateRootHash())
+
+	account := world.AcctMap.GetAccount([]byte("account_02_______________________"))
+	require.Equal(t, account.ComputeDataRootHash(), account.GetRootHash())
+}
+
+func TestMockWorld_GetStateRootHash_IgnoresUncommittedChanges(t *testing.T) {
+	world := newStateRootTestWorld(t)
+	err := world.CommitChanges()
+	require.Nil(t, err)
+	committedRootHash := world.AcctMap.ComputeRootHash()
+
+	// the hash is asked for in the middle of a transaction
+	world.CreateStateBackup()
+	world.AcctMap.GetAccount([]byte("account_01_______________________")).Nonce++
+	require.Equal(t, committedRootHash, world.GetStateRootHash())
+
+	err = world.RollbackChanges()
+	require.Nil(t, err)
+	require.Equal(t, committedRootHash, world.GetStateRootHash())
+
+	world.CreateStateBackup()
+	world.AcctMap.GetAccount([]byte("account_01_______________________")).Nonce++
+	err = world.CommitChanges()
+	require.Nil(t, err)
+
+	newRootHash := world.GetStateRootHash()
+	require.NotEqual(t, committedRootHash, newRootHash)
+	require.Equal(t, world.AcctMap.ComputeRootHash(), newRootHash)
+}
+
+func TestMockWorld_GetStateRootHash_KeepsRootHashOnLiveAccounts(t *testing.T) {
+	world := newStateRootTestWorld(t)
+	err := world.CommitChanges()
+	require.Nil(t, err)
+
+	// the committed accounts are the snapshot clones, not the live ones
+	world.CreateStateBackup()
+	account := world.AcctMap.GetAccount([]byte("account_02_______________________"))
+	committedDataRootHash := account.ComputeDataRootHash()
+	account.Storage["key_c"] = []byte("value_c")
+	world.GetStateRootHash()
+
+	require.Equal(t, committedDataRootHash, account.GetRootHash())
+	require.NotEqual(t, account.ComputeDataRootHash(), account.GetRootHash())
+}
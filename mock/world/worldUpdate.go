@@ -70,6 +70,7 @@ func (b *MockWorld) UpdateAccountFromOutputAccount(modAcct *vmcommon.OutputAccou
 	if acct == nil {
 		acct = b.AcctMap.CreateAccount(modAcct.Address)
 		acct.OwnerAddress = modAcct.CodeDeployerAddress
+		acct.ShardID = b.SelfShardID
 		b.AcctMap.PutAccount(acct)
 	}
 	acct.Exists = true
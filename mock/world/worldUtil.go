@@ -1,5 +1,10 @@
 package worldmock
 
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
 // GenerateMockAddress simulates creation of a new address by the protocol.
 func GenerateMockAddress(creatorAddress []byte, creatorNonce uint64) []byte {
 	result := make([]byte, 32)
@@ -14,3 +19,11 @@ func GenerateMockAddress(creatorAddress []byte, creatorNonce uint64) []byte {
 	copy(result[30:], creatorAddress[30:])
 	return result
 }
+
+// GenerateMockBlockHash simulates the hash of the block with the given nonce.
+func GenerateMockBlockHash(nonce uint64) []byte {
+	nonceBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(nonceBytes, nonce)
+	hash := sha256.Sum256(append([]byte("block"), nonceBytes...))
+	return hash[:]
+}
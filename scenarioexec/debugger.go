@@ -0,0 +1,394 @@
+package scenarioexec
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	vmi "github.com/kalyan3104/k-chain-vm-common-go"
+	worldmock "github.com/kalyan3104/k-chain-vm-v1_2-go/mock/world"
+	er "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/expression/reconstructor"
+	mj "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/json/model"
+)
+
+// ErrDebuggingAborted signals that the user stopped the scenario from the step debugger
+var ErrDebuggingAborted = errors.New("scenario aborted from the step debugger")
+
+// BreakpointPosition tells whether a breakpoint pauses before or after its step.
+type BreakpointPosition int
+
+const (
+	// BreakBefore pauses before the step is executed.
+	BreakBefore BreakpointPosition = iota
+
+	// BreakAfter pauses after the step is executed.
+	BreakAfter
+)
+
+func (position BreakpointPosition) String() string {
+	if position == BreakAfter {
+		return "after"
+	}
+	return "before"
+}
+
+// Breakpoint identifies a step either by its index, counting all the steps
+// executed since the last Reset, or by its txId.
+type Breakpoint struct {
+	Position  BreakpointPosition
+	StepIndex int
+	TxID      string
+}
+
+// ParseBreakpoint parses a step index or a txId into a Breakpoint.
+func ParseBreakpoint(position BreakpointPosition, stepRef string) Breakpoint {
+	stepIndex, err := strconv.Atoi(stepRef)
+	if err == nil && stepIndex >= 0 {
+		return Breakpoint{Position: position, StepIndex: stepIndex}
+	}
+
+	return Breakpoint{Position: position, StepIndex: -1, TxID: stepRef}
+}
+
+func (bp Breakpoint) matches(position BreakpointPosition, stepIndex int, txID string) bool {
+	if bp.Position != position {
+		return false
+	}
+	if len(bp.TxID) > 0 {
+		return bp.TxID == txID
+	}
+	return bp.StepIndex == stepIndex
+}
+
+func (bp Breakpoint) String() string {
+	if len(bp.TxID) > 0 {
+		return fmt.Sprintf("%s txId %s", bp.Position, bp.TxID)
+	}
+	return fmt.Sprintf("%s step %d", bp.Position, bp.StepIndex)
+}
+
+// StepDebugger pauses the execution of scenarios before or after the steps
+// matching its breakpoints, then reads commands from a line-oriented REPL.
+type StepDebugger struct {
+	executor    *VMTestExecutor
+	input       *bufio.Scanner
+	output      io.Writer
+	breakpoints []Breakpoint
+	stepping    bool
+
+	pendingWorld worldmock.AccountMap
+	pendingBlock *worldmock.BlockInfo
+
+	lastStepIndex  int
+	lastStep       mj.Step
+	worldBefore    worldmock.AccountMap
+	blockBefore    *worldmock.BlockInfo
+	worldAfter     worldmock.AccountMap
+	blockAfter     *worldmock.BlockInfo
+	hasWorldBefore bool
+}
+
+// EnableStepDebugger attaches a StepDebugger to the executor, reading commands
+// from input and writing to output. With no breakpoints, the debugger pauses before the first step.
+func (ae *VMTestExecutor) EnableStepDebugger(input io.Reader, output io.Writer) *StepDebugger {
+	ae.debugger = &StepDebugger{
+		executor:      ae,
+		input:         bufio.NewScanner(input),
+		output:        output,
+		breakpoints:   make([]Breakpoint, 0),
+		stepping:      true,
+		lastStepIndex: -1,
+	}
+	return ae.debugger
+}
+
+// AddBreakpoint adds a breakpoint; the debugger no longer pauses
+// before the first step, unless the breakpoint requires it.
+func (debugger *StepDebugger) AddBreakpoint(bp Breakpoint) {
+	debugger.breakpoints = append(debugger.breakpoints, bp)
+	debugger.stepping = false
+}
+
+func (debugger *StepDebugger) beforeStep(stepIndex int, step mj.Step) error {
+	if !debugger.shouldPause(BreakBefore, stepIndex, step) {
+		return nil
+	}
+
+	debugger.printf("paused before step %d: %s\n", stepIndex, describeStep(step))
+	return debugger.repl()
+}
+
+// snapshotWorld keeps a copy of the world as it is before a step,
+// in order to show the changes made by the step
+func (debugger *StepDebugger) snapshotWorld() {
+	debugger.pendingWorld = debugger.executor.World.AcctMap.Clone()
+	debugger.pendingBlock = cloneBlockInfo(debugger.executor.World.CurrentBlockInfo)
+}
+
+func (debugger *StepDebugger) afterStep(stepIndex int, step mj.Step, stepErr error) error {
+	debugger.lastStepIndex = stepIndex
+	debugger.lastStep = step
+	debugger.worldBefore = debugger.pendingWorld
+	debugger.blockBefore = debugger.pendingBlock
+	debugger.worldAfter = debugger.executor.World.AcctMap
+	debugger.blockAfter = debugger.executor.World.CurrentBlockInfo
+	debugger.hasWorldBefore = true
+
+	if stepErr != nil {
+		debugger.printf("step %d failed: %s\n", stepIndex, stepErr.Error())
+		debugger.stepping = true
+	}
+
+	if !debugger.shouldPause(BreakAfter, stepIndex, step) {
+		return nil
+	}
+
+	debugger.printf("paused after step %d: %s\n", stepIndex, describeStep(step))
+	return debugger.repl()
+}
+
+func (debugger *StepDebugger) shouldPause(position BreakpointPosition, stepIndex int, step mj.Step) bool {
+	if debugger.stepping {
+		return true
+	}
+
+	txID := stepTxID(step)
+	for _, bp := range debugger.breakpoints {
+		if bp.matches(position, stepIndex, txID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (debugger *StepDebugger) repl() error {
+	for {
+		debugger.printf("(debug) ")
+		if !debugger.input.Scan() {
+			// no more input, carry on without pausing
+			debugger.printf("\n")
+			debugger.stepping = false
+			debugger.breakpoints = nil
+			return nil
+		}
+
+		fields := strings.Fields(debugger.input.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		command, args := fields[0], fields[1:]
+		switch command {
+		case "c", "continue":
+			debugger.stepping = false
+			return nil
+		case "s", "step":
+			debugger.stepping = true
+			return nil
+		case "q", "quit":
+			return ErrDebuggingAborted
+		case "d", "diff":
+			debugger.printWorldDiff()
+		case "o", "output":
+			debugger.printLastOutput()
+		case "w", "world":
+			debugger.dumpWorld(args)
+		case "b", "break":
+			debugger.addBreakpointFromArgs(args)
+		case "clear":
+			debugger.breakpoints = make([]Breakpoint, 0)
+			debugger.printf("all breakpoints removed\n")
+		case "l", "list":
+			debugger.printBreakpoints()
+		case "h", "help":
+			debugger.printHelp()
+		default:
+			debugger.printf("unknown command %s, type help for the list of commands\n", command)
+		}
+	}
+}
+
+func (debugger *StepDebugger) printHelp() {
+	debugger.printf(`commands:
+  c, continue              run until the next breakpoint
+  s, step                  pause again after this step, or before the next one
+  d, diff                  show the changes made to the world by the last step
+  o, output                show the VMOutput of the last transaction
+  w, world [file]          dump the state of the world, to a file if given
+  b, break [after] <step>  add a breakpoint, by step index or txId; breaks before the step by default
+  l, list                  list the breakpoints
+  clear                    remove all the breakpoints
+  q, quit                  abort the scenario
+`)
+}
+
+func (debugger *StepDebugger) addBreakpointFromArgs(args []string) {
+	position := BreakBefore
+	if len(args) > 0 && (args[0] == "after" || args[0] == "before") {
+		if args[0] == "after" {
+			position = BreakAfter
+		}
+		args = args[1:]
+	}
+	if len(args) != 1 {
+		debugger.printf("usage: break [before|after] <step index or txId>\n")
+		return
+	}
+
+	bp := ParseBreakpoint(position, args[0])
+	debugger.breakpoints = append(debugger.breakpoints, bp)
+	debugger.printf("breakpoint added %s\n", bp)
+}
+
+func (debugger *StepDebugger) printBreakpoints() {
+	if len(debugger.breakpoints) == 0 {
+		debugger.printf("no breakpoints\n")
+		return
+	}
+	for _, bp := range debugger.breakpoints {
+		debugger.printf("  %s\n", bp)
+	}
+}
+
+func (debugger *StepDebugger) dumpWorld(args []string) {
+	worldJSON, err := debugger.executor.worldStateJSON()
+	if err != nil {
+		debugger.printf("could not dump the world: %s\n", err.Error())
+		return
+	}
+
+	if len(args) == 0 {
+		debugger.printf("%s\n", worldJSON)
+		return
+	}
+
+	err = ioutil.WriteFile(args[0], []byte(worldJSON+"\n"), 0644)
+	if err != nil {
+		debugger.printf("could not dump the world: %s\n", err.Error())
+		return
+	}
+	debugger.printf("world state written to %s\n", args[0])
+}
+
+func (debugger *StepDebugger) printLastOutput() {
+	output := debugger.executor.lastTxOutput
+	if output == nil {
+		debugger.printf("the last step did not produce a VMOutput\n")
+		return
+	}
+
+	reconstructor := &debugger.executor.exprReconstructor
+	debugger.printf("return code: %s\n", output.ReturnCode.String())
+	if len(output.ReturnMessage) > 0 {
+		debugger.printf("return message: %s\n", output.ReturnMessage)
+	}
+	debugger.printf("return data:\n")
+	for _, data := range output.ReturnData {
+		debugger.printf("  %s\n", reconstructor.Reconstruct(data, er.NoHint))
+	}
+	debugger.printf("gas remaining: %d, gas refund: %s\n", output.GasRemaining, output.GasRefund)
+
+	addresses := make([]string, 0, len(output.OutputAccounts))
+	for address := range output.OutputAccounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	debugger.printf("output accounts:\n")
+	for _, address := range addresses {
+		debugger.printOutputAccount(output.OutputAccounts[address])
+	}
+
+	debugger.printf("logs:\n")
+	for _, logEntry := range output.Logs {
+		debugger.printf("  %s from %s\n",
+			reconstructor.Reconstruct(logEntry.Identifier, er.StrHint),
+			reconstructor.Reconstruct(logEntry.Address, er.AddressHint))
+		for _, topic := range logEntry.Topics {
+			debugger.printf("    topic: %s\n", reconstructor.Reconstruct(topic, er.NoHint))
+		}
+		for _, data := range logEntry.Data {
+			debugger.printf("    data: %s\n", reconstructor.Reconstruct(data, er.NoHint))
+		}
+	}
+}
+
+func (debugger *StepDebugger) printOutputAccount(account *vmi.OutputAccount) {
+	reconstructor := &debugger.executor.exprReconstructor
+	debugger.printf("  %s\n", reconstructor.Reconstruct(account.Address, er.AddressHint))
+	if account.BalanceDelta != nil && account.BalanceDelta.Sign() != 0 {
+		debugger.printf("    balance delta: %s\n", account.BalanceDelta)
+	}
+	if len(account.Code) > 0 {
+		debugger.printf("    code: %d bytes\n", len(account.Code))
+	}
+
+	keys := make([]string, 0, len(account.StorageUpdates))
+	for key := range account.StorageUpdates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		update := account.StorageUpdates[key]
+		debugger.printf("    storage %s: %s\n",
+			reconstructor.Reconstruct(update.Offset, er.NoHint),
+			reconstructor.Reconstruct(update.Data, er.NoHint))
+	}
+
+	for _, transfer := range account.OutputTransfers {
+		debugger.printf("    transfer from %s: value %s, gas limit %d, data %s\n",
+			reconstructor.Reconstruct(transfer.SenderAddress, er.AddressHint),
+			transfer.Value, transfer.GasLimit, string(transfer.Data))
+	}
+}
+
+func (debugger *StepDebugger) printWorldDiff() {
+	if !debugger.hasWorldBefore {
+		debugger.printf("no step was executed yet\n")
+		return
+	}
+
+	debugger.printf("changes made by step %d: %s\n", debugger.lastStepIndex, describeStep(debugger.lastStep))
+	lines := diffWorlds(&debugger.executor.exprReconstructor, debugger.worldBefore, debugger.worldAfter)
+	lines = append(lines, diffBlockInfo(debugger.blockBefore, debugger.blockAfter)...)
+	if len(lines) == 0 {
+		debugger.printf("  no changes\n")
+		return
+	}
+	for _, line := range lines {
+		debugger.printf("%s\n", line)
+	}
+}
+
+func (debugger *StepDebugger) printf(format string, args ...interface{}) {
+	_, _ = fmt.Fprintf(debugger.output, format, args...)
+}
+
+func describeStep(step mj.Step) string {
+	txID := stepTxID(step)
+	if len(txID) == 0 {
+		return step.StepTypeName()
+	}
+	return fmt.Sprintf("%s (txId: %s)", step.StepTypeName(), txID)
+}
+
+func stepTxID(step mj.Step) string {
+	txStep, isTxStep := step.(*mj.TxStep)
+	if !isTxStep {
+		return ""
+	}
+	return txStep.TxIdent
+}
+
+func cloneBlockInfo(blockInfo *worldmock.BlockInfo) *worldmock.BlockInfo {
+	if blockInfo == nil {
+		return nil
+	}
+	clone := *blockInfo
+	return &clone
+}
@@ -0,0 +1,126 @@
+package scenarioexec
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	worldmock "github.com/kalyan3104/k-chain-vm-v1_2-go/mock/world"
+	er "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/expression/reconstructor"
+)
+
+// diffWorlds lists the accounts created, deleted or modified between two states of the world,
+// together with the fields and storage keys that changed
+func diffWorlds(reconstructor *er.ExprReconstructor, before worldmock.AccountMap, after worldmock.AccountMap) []string {
+	addresses := make([]string, 0, len(after))
+	for address := range after {
+		addresses = append(addresses, address)
+	}
+	for address := range before {
+		_, existsAfter := after[address]
+		if !existsAfter {
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Strings(addresses)
+
+	lines := make([]string, 0)
+	for _, address := range addresses {
+		accountBefore := before[address]
+		accountAfter := after[address]
+		addressExpr := reconstructor.Reconstruct([]byte(address), er.AddressHint)
+
+		switch {
+		case accountBefore == nil:
+			lines = append(lines, fmt.Sprintf("+ account %s", addressExpr))
+			lines = append(lines, diffAccounts(reconstructor, &worldmock.Account{}, accountAfter)...)
+		case accountAfter == nil:
+			lines = append(lines, fmt.Sprintf("- account %s", addressExpr))
+		default:
+			accountLines := diffAccounts(reconstructor, accountBefore, accountAfter)
+			if len(accountLines) > 0 {
+				lines = append(lines, fmt.Sprintf("~ account %s", addressExpr))
+				lines = append(lines, accountLines...)
+			}
+		}
+	}
+
+	return lines
+}
+
+func diffAccounts(reconstructor *er.ExprReconstructor, before *worldmock.Account, after *worldmock.Account) []string {
+	lines := make([]string, 0)
+	if before.Nonce != after.Nonce {
+		lines = append(lines, fmt.Sprintf("    nonce: %d -> %d", before.Nonce, after.Nonce))
+	}
+	if bigIntString(before.Balance) != bigIntString(after.Balance) {
+		lines = append(lines, fmt.Sprintf("    balance: %s -> %s", bigIntString(before.Balance), bigIntString(after.Balance)))
+	}
+	if !bytes.Equal(before.Code, after.Code) {
+		lines = append(lines, fmt.Sprintf("    code: %d bytes -> %d bytes", len(before.Code), len(after.Code)))
+	}
+	if !bytes.Equal(before.OwnerAddress, after.OwnerAddress) {
+		lines = append(lines, fmt.Sprintf("    owner: %s -> %s",
+			reconstructor.Reconstruct(before.OwnerAddress, er.AddressHint),
+			reconstructor.Reconstruct(after.OwnerAddress, er.AddressHint)))
+	}
+
+	keys := make([]string, 0, len(after.Storage))
+	for key := range after.Storage {
+		keys = append(keys, key)
+	}
+	for key := range before.Storage {
+		_, existsAfter := after.Storage[key]
+		if !existsAfter {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		valueBefore := before.Storage[key]
+		valueAfter := after.Storage[key]
+		if bytes.Equal(valueBefore, valueAfter) {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("    storage %s: %s -> %s",
+			reconstructor.Reconstruct([]byte(key), er.NoHint),
+			reconstructor.Reconstruct(valueBefore, er.NoHint),
+			reconstructor.Reconstruct(valueAfter, er.NoHint)))
+	}
+
+	return lines
+}
+
+func diffBlockInfo(before *worldmock.BlockInfo, after *worldmock.BlockInfo) []string {
+	if before == nil {
+		before = &worldmock.BlockInfo{}
+	}
+	if after == nil {
+		after = &worldmock.BlockInfo{}
+	}
+
+	lines := make([]string, 0)
+	if before.BlockNonce != after.BlockNonce {
+		lines = append(lines, fmt.Sprintf("~ block nonce: %d -> %d", before.BlockNonce, after.BlockNonce))
+	}
+	if before.BlockRound != after.BlockRound {
+		lines = append(lines, fmt.Sprintf("~ block round: %d -> %d", before.BlockRound, after.BlockRound))
+	}
+	if before.BlockTimestamp != after.BlockTimestamp {
+		lines = append(lines, fmt.Sprintf("~ block timestamp: %d -> %d", before.BlockTimestamp, after.BlockTimestamp))
+	}
+	if before.BlockEpoch != after.BlockEpoch {
+		lines = append(lines, fmt.Sprintf("~ block epoch: %d -> %d", before.BlockEpoch, after.BlockEpoch))
+	}
+
+	return lines
+}
+
+func bigIntString(value *big.Int) string {
+	if value == nil {
+		return "0"
+	}
+	return value.String()
+}
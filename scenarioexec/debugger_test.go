@@ -0,0 +1,203 @@
+package scenarioexec
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	worldmock "github.com/kalyan3104/k-chain-vm-v1_2-go/mock/world"
+	er "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/expression/reconstructor"
+	fr "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/fileresolver"
+	"github.com/stretchr/testify/require"
+)
+
+const debuggerTestScenario = `{
+	"gasSchedule": "dummy",
+	"steps": [
+		{
+			"step": "setState",
+			"accounts": {
+				"address:A": {
+					"nonce": "0",
+					"balance": "150"
+				},
+				"address:B": {
+					"nonce": "0",
+					"balance": "0"
+				}
+			}
+		},
+		{
+			"step": "transfer",
+			"txId": "first",
+			"tx": {
+				"from": "address:A",
+				"to": "address:B",
+				"value": "100"
+			}
+		},
+		{
+			"step": "transfer",
+			"txId": "second",
+			"tx": {
+				"from": "address:A",
+				"to": "address:B",
+				"value": "50"
+			}
+		}
+	]
+}`
+
+func runDebuggerTestScenario(t *testing.T, commands string, breakpoints ...Breakpoint) (string, error) {
+	executor, err := NewVMTestExecutor(".")
+	require.Nil(t, err)
+
+	output := &bytes.Buffer{}
+	debugger := executor.EnableStepDebugger(strings.NewReader(commands), output)
+	for _, bp := range breakpoints {
+		debugger.AddBreakpoint(bp)
+	}
+
+	err = executor.ExecuteScenario(parseTestScenario(t, debuggerTestScenario), fr.NewDefaultFileResolver())
+	return output.String(), err
+}
+
+func TestParseBreakpoint(t *testing.T) {
+	require.Equal(t, Breakpoint{Position: BreakBefore, StepIndex: 3}, ParseBreakpoint(BreakBefore, "3"))
+	require.Equal(t, Breakpoint{Position: BreakAfter, StepIndex: 0}, ParseBreakpoint(BreakAfter, "0"))
+	require.Equal(t, Breakpoint{Position: BreakBefore, StepIndex: -1, TxID: "tx-1"}, ParseBreakpoint(BreakBefore, "tx-1"))
+
+	// negative numbers cannot be step indexes, so they are txIds
+	require.Equal(t, Breakpoint{Position: BreakAfter, StepIndex: -1, TxID: "-2"}, ParseBreakpoint(BreakAfter, "-2"))
+}
+
+func TestBreakpoint_Matches(t *testing.T) {
+	byIndex := ParseBreakpoint(BreakBefore, "2")
+	require.True(t, byIndex.matches(BreakBefore, 2, ""))
+	require.True(t, byIndex.matches(BreakBefore, 2, "some tx"))
+	require.False(t, byIndex.matches(BreakAfter, 2, ""))
+	require.False(t, byIndex.matches(BreakBefore, 1, ""))
+
+	byTxID := ParseBreakpoint(BreakAfter, "first")
+	require.True(t, byTxID.matches(BreakAfter, 5, "first"))
+	require.False(t, byTxID.matches(BreakBefore, 5, "first"))
+	require.False(t, byTxID.matches(BreakAfter, 5, "second"))
+	require.False(t, byTxID.matches(BreakAfter, -1, ""))
+}
+
+func TestBreakpoint_String(t *testing.T) {
+	require.Equal(t, "before step 2", ParseBreakpoint(BreakBefore, "2").String())
+	require.Equal(t, "after txId first", ParseBreakpoint(BreakAfter, "first").String())
+}
+
+func TestStepDebugger_BreakInspectContinue(t *testing.T) {
+	output, err := runDebuggerTestScenario(t,
+		"diff\ncontinue\n",
+		ParseBreakpoint(BreakAfter, "first"))
+	require.Nil(t, err)
+
+	require.Equal(t, 1, strings.Count(output, "paused"))
+	require.Contains(t, output, "paused after step 1: transfer (txId: first)\n")
+	require.Contains(t, output, "changes made by step 1: transfer (txId: first)\n")
+	require.Contains(t, output, "~ account address:A\n    nonce: 0 -> 1\n    balance: 150 -> 50\n")
+	require.Contains(t, output, "~ account address:B\n    balance: 0 -> 100\n")
+	require.NotContains(t, output, "second")
+}
+
+func TestStepDebugger_StepThenBreakByIndex(t *testing.T) {
+	output, err := runDebuggerTestScenario(t,
+		"step\nlist\ncontinue\n",
+		ParseBreakpoint(BreakBefore, "1"))
+	require.Nil(t, err)
+
+	require.Contains(t, output, "paused before step 1: transfer (txId: first)\n")
+	require.Contains(t, output, "paused after step 1: transfer (txId: first)\n")
+	require.Contains(t, output, "  before step 1\n")
+	require.NotContains(t, output, "step 2")
+}
+
+func TestStepDebugger_PausesBeforeFirstStepWithoutBreakpoints(t *testing.T) {
+	output, err := runDebuggerTestScenario(t, "diff\nbreak after second\ncontinue\nquit\n")
+	require.Equal(t, ErrDebuggingAborted, err)
+
+	require.Contains(t, output, "paused before step 0: setState\n")
+	require.Contains(t, output, "no step was executed yet\n")
+	require.Contains(t, output, "breakpoint added after txId second\n")
+	require.Contains(t, output, "paused after step 2: transfer (txId: second)\n")
+}
+
+func TestStepDebugger_EndOfInputCarriesOn(t *testing.T) {
+	output, err := runDebuggerTestScenario(t, "", ParseBreakpoint(BreakBefore, "0"))
+	require.Nil(t, err)
+	require.Equal(t, 1, strings.Count(output, "paused"))
+}
+
+func newDiffTestAccount(address string, nonce uint64, balance int64) *worldmock.Account {
+	return &worldmock.Account{
+		Exists:          true,
+		Address:         []byte(address),
+		Nonce:           nonce,
+		Balance:         big.NewInt(balance),
+		BalanceDelta:    big.NewInt(0),
+		DeveloperReward: big.NewInt(0),
+		Storage:         make(map[string][]byte),
+	}
+}
+
+func TestDiffWorlds(t *testing.T) {
+	changedBefore := newDiffTestAccount("changed_________________________", 1, 10)
+	changedBefore.Storage["kept"] = []byte("same")
+	changedBefore.Storage["updated"] = []byte("old")
+	changedBefore.Storage["removed"] = []byte("gone")
+
+	before := worldmock.NewAccountMap()
+	before.PutAccount(changedBefore)
+	before.PutAccount(newDiffTestAccount("deleted_________________________", 0, 0))
+	before.PutAccount(newDiffTestAccount("untouched_______________________", 0, 5))
+
+	after := before.Clone()
+	after.DeleteAccount([]byte("deleted_________________________"))
+	changed := after.GetAccount([]byte("changed_________________________"))
+	changed.Nonce = 2
+	changed.Balance = big.NewInt(7)
+	changed.Code = []byte("code")
+	changed.Storage["updated"] = []byte("new")
+	delete(changed.Storage, "removed")
+	after.PutAccount(newDiffTestAccount("created_________________________", 3, 1))
+
+	lines := diffWorlds(&er.ExprReconstructor{}, before, after)
+	require.Equal(t, []string{
+		"~ account address:changed",
+		"    nonce: 1 -> 2",
+		"    balance: 10 -> 7",
+		"    code: 0 bytes -> 4 bytes",
+		"    storage 0x72656d6f766564 (str:removed): 0x676f6e65 (str:gone) -> []",
+		"    storage 0x75706461746564 (str:updated): 0x6f6c64 (str:old) -> 0x6e6577 (str:new)",
+		"+ account address:created",
+		"    nonce: 0 -> 3",
+		"    balance: 0 -> 1",
+		"- account address:deleted",
+	}, lines)
+
+	require.Empty(t, diffWorlds(&er.ExprReconstructor{}, before, before.Clone()))
+}
+
+func TestDiffBlockInfo(t *testing.T) {
+	before := &worldmock.BlockInfo{BlockNonce: 1, BlockRound: 1, BlockTimestamp: 6, BlockEpoch: 0}
+	after := &worldmock.BlockInfo{BlockNonce: 2, BlockRound: 3, BlockTimestamp: 6, BlockEpoch: 1}
+
+	require.Equal(t, []string{
+		"~ block nonce: 1 -> 2",
+		"~ block round: 1 -> 3",
+		"~ block epoch: 0 -> 1",
+	}, diffBlockInfo(before, after))
+
+	require.Equal(t, []string{
+		"~ block nonce: 0 -> 1",
+		"~ block round: 0 -> 1",
+		"~ block timestamp: 0 -> 6",
+	}, diffBlockInfo(nil, before))
+
+	require.Empty(t, diffBlockInfo(before, before))
+}
@@ -26,16 +26,23 @@ var TestVMType = []byte{0, 0}
 // VMTestExecutor parses, interprets and executes both .test.json tests and .scen.json scenarios with VM.
 type VMTestExecutor struct {
 	World                 *worldhook.MockWorld
+	shards                *worldhook.MultiShardWorld
 	vm                    vmi.VMExecutionHandler
 	checkGas              bool
 	scenarioexecPath      string
 	scenGasScheduleLoaded bool
 	fileResolver          fr.FileResolver
 	exprReconstructor     er.ExprReconstructor
+	vmHostParameters      *vmhost.VMHostParameters
+	gasProfile            *vmhost.GasProfile
+	stepResults           []*mc.StepResult
+	lastTxOutput          *vmi.VMOutput
+	debugger              *StepDebugger
 }
 
 var _ mc.TestExecutor = (*VMTestExecutor)(nil)
 var _ mc.ScenarioExecutor = (*VMTestExecutor)(nil)
+var _ mc.StepRecorder = (*VMTestExecutor)(nil)
 
 // NewVMTestExecutor prepares a new VMTestExecutor instance.
 func NewVMTestExecutor(scenarioexecPath string) (*VMTestExecutor, error) {
@@ -48,7 +55,7 @@ func NewVMTestExecutor(scenarioexecPath string) (*VMTestExecutor, error) {
 	}
 
 	blockGasLimit := uint64(10000000)
-	vm, err := hostCore.NewVMHost(world, &vmhost.VMHostParameters{
+	vmHostParameters := &vmhost.VMHostParameters{
 		VMType:                   TestVMType,
 		BlockGasLimit:            blockGasLimit,
 		GasSchedule:              gasScheduleMap,
@@ -56,22 +63,30 @@ func NewVMTestExecutor(scenarioexecPath string) (*VMTestExecutor, error) {
 		ProtectedKeyPrefix:       []byte(ProtectedKeyPrefix),
 		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
 			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-				return flag == hostCore.SCDeployFlag || flag == hostCore.AheadOfTimeGasUsageFlag || flag == hostCore.RepairCallbackFlag || flag == hostCore.BuiltInFunctionsFlag
+				return flag == hostCore.SCDeployFlag || flag == hostCore.AheadOfTimeGasUsageFlag || flag == hostCore.RepairCallbackFlag || flag == hostCore.BuiltInFunctionsFlag ||
+					flag == hostCore.SelfDestructFlag || flag == hostCore.EthereumAPIFlag || flag == hostCore.ManagedBufferAPIFlag || flag == hostCore.BigIntExtendedAPIFlag || flag == hostCore.Secp256k1ExtendedAPIFlag || flag == hostCore.BLSMultiSigAPIFlag || flag == hostCore.MultiDCDTTransferFlag
 			},
 		},
-	})
+	}
+	vm, err := hostCore.NewVMHost(world, vmHostParameters)
 	if err != nil {
 		return nil, err
 	}
 
 	return &VMTestExecutor{
 		World:                 world,
+		shards:                worldhook.NewMultiShardWorld(world),
 		vm:                    vm,
 		checkGas:              true,
 		scenarioexecPath:      scenarioexecPath,
 		scenGasScheduleLoaded: false,
 		fileResolver:          nil,
 		exprReconstructor:     er.ExprReconstructor{},
+		vmHostParameters:      vmHostParameters,
+		gasProfile:            nil,
+		stepResults:           make([]*mc.StepResult, 0),
+		lastTxOutput:          nil,
+		debugger:              nil,
 	}, nil
 }
 
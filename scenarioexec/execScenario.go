@@ -1,6 +1,8 @@
 package scenarioexec
 
 import (
+	"fmt"
+
 	vmi "github.com/kalyan3104/k-chain-vm-common-go"
 	mc "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/controller"
 	fr "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/fileresolver"
@@ -11,6 +13,9 @@ import (
 // Is called in RunAllJSONScenariosInDirectory, but not in RunSingleJSONScenario.
 func (ae *VMTestExecutor) Reset() {
 	ae.World.Clear()
+	ae.shards.Clear()
+	ae.stepResults = make([]*mc.StepResult, 0)
+	ae.lastTxOutput = nil
 }
 
 // ExecuteScenario executes an individual test.
@@ -24,7 +29,7 @@ func (ae *VMTestExecutor) ExecuteScenario(scenario *mj.Scenario, fileResolver fr
 
 	txIndex := 0
 	for _, generalStep := range scenario.Steps {
-		err := ae.ExecuteStep(generalStep)
+		err := ae.executeAndRecordStep(generalStep)
 		if err != nil {
 			return err
 		}
@@ -44,6 +49,10 @@ func (ae *VMTestExecutor) ExecuteStep(generalStep mj.Step) error {
 		err = ae.ExecuteExternalStep(step)
 	case *mj.SetStateStep:
 		err = ae.ExecuteSetStateStep(step)
+	case *mj.AdvanceBlockStep:
+		err = ae.ExecuteAdvanceBlockStep(step)
+	case *mj.CrossShardRoundStep:
+		err = ae.ExecuteCrossShardRoundStep(step)
 	case *mj.CheckStateStep:
 		err = ae.ExecuteCheckStateStep(step)
 	case *mj.TxStep:
@@ -97,11 +106,63 @@ func (ae *VMTestExecutor) ExecuteSetStateStep(step *mj.SetStateStep) error {
 	ae.World.PreviousBlockInfo = convertBlockInfo(step.PreviousBlockInfo)
 	ae.World.CurrentBlockInfo = convertBlockInfo(step.CurrentBlockInfo)
 	ae.World.Blockhashes = mj.JSONBytesFromStringValues(step.BlockHashes)
+	ae.shards.ResetBlockInfo()
 
 	// append NewAddressMocks
 	addressMocksToAdd := convertNewAddressMocks(step.NewAddressMocks)
 	ae.World.NewAddressMocks = append(ae.World.NewAddressMocks, addressMocksToAdd...)
 
+	// the state set up becomes the committed state, seen by getStateRootHash
+	return ae.World.CommitChanges()
+}
+
+// ExecuteAdvanceBlockStep executes an AdvanceBlockStep.
+func (ae *VMTestExecutor) ExecuteAdvanceBlockStep(step *mj.AdvanceBlockStep) error {
+	if len(step.Comment) > 0 {
+		log.Trace("AdvanceBlockStep", "comment", step.Comment)
+	}
+
+	delta, err := convertAdvanceBlockDelta(step)
+	if err != nil {
+		return err
+	}
+
+	ae.shards.AdvanceBlock(delta, step.BlockHash.Value)
+
+	return nil
+}
+
+// ExecuteCrossShardRoundStep executes a CrossShardRoundStep.
+func (ae *VMTestExecutor) ExecuteCrossShardRoundStep(step *mj.CrossShardRoundStep) error {
+	if len(step.Comment) > 0 {
+		log.Trace("CrossShardRoundStep", "comment", step.Comment)
+	}
+
+	rounds := uint64(1)
+	if len(step.Rounds.Original) > 0 {
+		rounds = step.Rounds.Value
+	}
+
+	for i := uint64(0); i < rounds; i++ {
+		executions := ae.shards.ExecuteRound(ae.vm)
+		for _, execution := range executions {
+			log.Trace("CrossShardRoundStep",
+				"round", ae.shards.Round,
+				"sender", execution.Transfer.Sender,
+				"recipient", execution.Transfer.Recipient,
+				"callType", execution.Transfer.CallType,
+				"error", execution.Err)
+		}
+	}
+
+	if len(step.ExpectedPending.Original) > 0 {
+		numPending := uint64(len(ae.shards.PendingTransfers))
+		if numPending != step.ExpectedPending.Value {
+			return fmt.Errorf("bad number of pending cross-shard transfers. Want: %d. Have: %d",
+				step.ExpectedPending.Value, numPending)
+		}
+	}
+
 	return nil
 }
 
@@ -116,6 +177,7 @@ func (ae *VMTestExecutor) ExecuteTxStep(step *mj.TxStep) (*vmi.VMOutput, error)
 	if err != nil {
 		return nil, err
 	}
+	ae.lastTxOutput = output
 
 	// check results
 	if step.ExpectedResult != nil {
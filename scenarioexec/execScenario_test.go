@@ -0,0 +1,77 @@
+package scenarioexec
+
+import (
+	"testing"
+
+	worldhook "github.com/kalyan3104/k-chain-vm-v1_2-go/mock/world"
+	fr "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/fileresolver"
+	mj "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/json/model"
+	mjparse "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/json/parse"
+	"github.com/stretchr/testify/require"
+)
+
+func parseTestScenario(t *testing.T, scenarioJSON string) *mj.Scenario {
+	parser := mjparse.NewParser(fr.NewDefaultFileResolver())
+	scenario, err := parser.ParseScenarioFile([]byte(scenarioJSON))
+	require.Nil(t, err)
+	return scenario
+}
+
+func executeTestScenario(t *testing.T, scenarioJSON string) *VMTestExecutor {
+	executor, err := NewVMTestExecutor(".")
+	require.Nil(t, err)
+
+	err = executor.ExecuteScenario(parseTestScenario(t, scenarioJSON), fr.NewDefaultFileResolver())
+	require.Nil(t, err)
+
+	return executor
+}
+
+func TestExecuteScenario_AdvanceBlock(t *testing.T) {
+	executor := executeTestScenario(t, `{
+		"gasSchedule": "dummy",
+		"steps": [
+			{
+				"step": "setState",
+				"accounts": {
+					"address:owner": {
+						"nonce": "0",
+						"balance": "100"
+					}
+				},
+				"currentBlockInfo": {
+					"blockNonce": "5",
+					"blockRound": "7",
+					"blockTimestamp": "100",
+					"blockEpoch": "2"
+				}
+			},
+			{
+				"step": "advanceBlock",
+				"nonceDelta": "3",
+				"timestampDelta": "18",
+				"epochDelta": "1",
+				"blockHash": "''next_block_hash_______________"
+			}
+		]
+	}`)
+
+	world := executor.World
+	require.Equal(t, uint64(8), world.CurrentNonce())
+	require.Equal(t, uint64(10), world.CurrentRound())
+	require.Equal(t, uint64(118), world.CurrentTimeStamp())
+	require.Equal(t, uint32(3), world.CurrentEpoch())
+
+	require.Equal(t, uint64(5), world.LastNonce())
+	require.Equal(t, uint64(7), world.LastRound())
+	require.Equal(t, uint64(100), world.LastTimeStamp())
+	require.Equal(t, uint32(2), world.LastEpoch())
+
+	hash, err := world.GetBlockhash(8)
+	require.Nil(t, err)
+	require.Equal(t, []byte("next_block_hash_______________"), hash)
+
+	hash, err = world.GetBlockhash(7)
+	require.Nil(t, err)
+	require.Equal(t, worldhook.GenerateMockBlockHash(7), hash)
+}
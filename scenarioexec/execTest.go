@@ -10,6 +10,7 @@ import (
 func (ae *VMTestExecutor) ExecuteTest(test *mj.Test) error {
 	// reset world
 	ae.World.Clear()
+	ae.shards.Clear()
 	ae.World.Blockhashes = mj.JSONBytesFromStringValues(test.BlockHashes)
 
 	for _, acct := range test.Pre {
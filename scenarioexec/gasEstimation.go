@@ -0,0 +1,30 @@
+package scenarioexec
+
+import (
+	"errors"
+
+	mj "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/json/model"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+)
+
+// EstimateTxGas estimates the gas needed by an scCall transaction against the
+// current state of the world, which is left unchanged. The gas limit of the
+// transaction is the one the estimation starts from and the upper bound of the
+// gas limit search, if requested.
+func (ae *VMTestExecutor) EstimateTxGas(txIndex string, tx *mj.Transaction, searchGasLimit bool) (*vmhost.GasEstimate, error) {
+	if tx.Type != mj.ScCall {
+		return nil, errors.New("gas can only be estimated for scCall transactions")
+	}
+
+	host, ok := ae.vm.(vmhost.VMHost)
+	if !ok {
+		return nil, errors.New("the VM does not support gas estimation")
+	}
+
+	input, err := ae.scCallInput(txIndex, tx, tx.GasLimit.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return host.EstimateGas(input, searchGasLimit)
+}
@@ -0,0 +1,46 @@
+package scenarioexec
+
+import (
+	"github.com/kalyan3104/k-chain-core-go/core/check"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost/hostCore"
+)
+
+// EnableGasProfiling replaces the VM of the executor with one which profiles
+// the gas consumed by each SC call; the profiles of all the calls executed
+// from now on are accumulated and can be retrieved via GasProfile().
+func (ae *VMTestExecutor) EnableGasProfiling() error {
+	ae.vmHostParameters.GasProfilingEnabled = true
+	vm, err := hostCore.NewVMHost(ae.World, ae.vmHostParameters)
+	if err != nil {
+		return err
+	}
+
+	ae.vm = vm
+	ae.gasProfile = vmhost.NewGasProfile()
+	return nil
+}
+
+// GasProfile returns the gas profile accumulated since gas profiling was
+// enabled, or nil if it is not enabled
+func (ae *VMTestExecutor) GasProfile() *vmhost.GasProfile {
+	return ae.gasProfile
+}
+
+func (ae *VMTestExecutor) collectGasProfile() {
+	if ae.gasProfile == nil {
+		return
+	}
+
+	host, ok := ae.vm.(vmhost.VMHost)
+	if !ok {
+		return
+	}
+
+	gasProfiler := host.GasProfiler()
+	if check.IfNil(gasProfiler) {
+		return
+	}
+
+	ae.gasProfile.Merge(gasProfiler.Profile())
+}
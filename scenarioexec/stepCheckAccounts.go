@@ -19,7 +19,27 @@ func (ae *VMTestExecutor) ExecuteCheckStateStep(step *mj.CheckStateStep) error {
 		log.Trace("CheckStateStep", "comment", step.Comment)
 	}
 
-	return ae.checkAccounts(step.CheckAccounts)
+	err := ae.checkAccounts(step.CheckAccounts)
+	if err != nil {
+		return err
+	}
+
+	return ae.checkStateRootHash(step.StateRootHash)
+}
+
+func (ae *VMTestExecutor) checkStateRootHash(expectedRootHash mj.JSONCheckBytes) error {
+	if expectedRootHash.IsUnspecified() {
+		return nil
+	}
+
+	rootHash := ae.World.AcctMap.ComputeRootHash()
+	if !expectedRootHash.Check(rootHash) {
+		return fmt.Errorf("bad state root hash. Want: %s. Have: 0x%s",
+			oj.JSONString(expectedRootHash.Original),
+			hex.EncodeToString(rootHash))
+	}
+
+	return nil
 }
 
 func (ae *VMTestExecutor) checkAccounts(checkAccounts *mj.CheckAccounts) error {
@@ -253,10 +273,9 @@ func checkTokenInstances(
 		accountInstance := accountInstances[nonce]
 
 		if expectedInstance == nil {
-			expectedInstance = &mj.CheckDCDTInstance{
-				Nonce:   mj.JSONCheckUint64{Value: nonce, Original: ""},
-				Balance: mj.JSONCheckBigInt{Value: big.NewInt(0), Original: ""},
-			}
+			expectedInstance = mj.NewCheckDCDTInstance()
+			expectedInstance.Nonce = mj.JSONCheckUint64{Value: nonce, Original: ""}
+			expectedInstance.Balance = mj.JSONCheckBigInt{Value: big.NewInt(0), Original: ""}
 		} else if accountInstance == nil {
 			accountInstance = &dcdt.DCDigitalToken{
 				Value: big.NewInt(0),
@@ -275,13 +294,68 @@ func checkTokenInstances(
 					accountInstance.Value))
 			}
 
-			// TODO: check metadata/properties
+			errors = append(errors, checkTokenInstanceMetadata(accountAddress, tokenName, expectedInstance, accountInstance.TokenMetaData)...)
 		}
 	}
 
 	return errors
 }
 
+func checkTokenInstanceMetadata(
+	accountAddress string,
+	tokenName string,
+	expectedInstance *mj.CheckDCDTInstance,
+	metadata *dcdt.MetaData) []error {
+
+	if metadata == nil {
+		metadata = &dcdt.MetaData{}
+	}
+
+	var errors []error
+	if !expectedInstance.Creator.IsUnspecified() && !expectedInstance.Creator.Check(metadata.Creator) {
+		errors = append(errors, fmt.Errorf("bad DCDT NFT creator. Account: %s. Token: %s. Nonce: %d. Want: %s. Have: %s",
+			accountAddress,
+			tokenName,
+			metadata.Nonce,
+			oj.JSONString(expectedInstance.Creator.Original),
+			hex.EncodeToString(metadata.Creator)))
+	}
+	if !expectedInstance.Royalties.IsUnspecified() && !expectedInstance.Royalties.Check(uint64(metadata.Royalties)) {
+		errors = append(errors, fmt.Errorf("bad DCDT NFT royalties. Account: %s. Token: %s. Nonce: %d. Want: %s. Have: %d",
+			accountAddress,
+			tokenName,
+			metadata.Nonce,
+			expectedInstance.Royalties.Original,
+			metadata.Royalties))
+	}
+	if !expectedInstance.Hash.IsUnspecified() && !expectedInstance.Hash.Check(metadata.Hash) {
+		errors = append(errors, fmt.Errorf("bad DCDT NFT hash. Account: %s. Token: %s. Nonce: %d. Want: %s. Have: %s",
+			accountAddress,
+			tokenName,
+			metadata.Nonce,
+			oj.JSONString(expectedInstance.Hash.Original),
+			hex.EncodeToString(metadata.Hash)))
+	}
+	if !expectedInstance.Uris.IsUnspecified() && !expectedInstance.Uris.Check(metadata.URIs) {
+		errors = append(errors, fmt.Errorf("bad DCDT NFT URIs. Account: %s. Token: %s. Nonce: %d. Want: %s. Have: %s",
+			accountAddress,
+			tokenName,
+			metadata.Nonce,
+			checkBytesListPretty(expectedInstance.Uris.Values),
+			mj.ResultAsString(metadata.URIs)))
+	}
+	if !expectedInstance.Attributes.IsUnspecified() && !expectedInstance.Attributes.Check(metadata.Attributes) {
+		errors = append(errors, fmt.Errorf("bad DCDT NFT attributes. Account: %s. Token: %s. Nonce: %d. Want: %s. Have: %s",
+			accountAddress,
+			tokenName,
+			metadata.Nonce,
+			oj.JSONString(expectedInstance.Attributes.Original),
+			hex.EncodeToString(metadata.Attributes)))
+	}
+
+	return errors
+}
+
 func checkTokenRoles(
 	accountAddress string,
 	tokenName string,
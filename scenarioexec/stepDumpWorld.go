@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/kalyan3104/k-chain-core-go/core"
+	"github.com/kalyan3104/k-chain-core-go/data/dcdt"
 	worldmock "github.com/kalyan3104/k-chain-vm-v1_2-go/mock/world"
 	er "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/expression/reconstructor"
 	mj "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/json/model"
@@ -60,25 +61,7 @@ func (ae *VMTestExecutor) convertMockAccountToScenarioFormat(account *worldmock.
 
 		var scenInstances []*mj.DCDTInstance
 		for _, mockInstance := range dcdtObj.Instances {
-			var uri mj.JSONBytesFromTree
-			if len(mockInstance.TokenMetaData.URIs) > 0 {
-				uri = mj.JSONBytesFromTree{
-					Value:    mockInstance.TokenMetaData.URIs[0],
-					Original: &oj.OJsonString{Value: ae.exprReconstructor.Reconstruct(mockInstance.TokenMetaData.URIs[0], er.NoHint)},
-				}
-			}
-
-			scenInstances = append(scenInstances, &mj.DCDTInstance{
-				Nonce: mj.JSONUint64{
-					Value:    mockInstance.TokenMetaData.Nonce,
-					Original: ae.exprReconstructor.ReconstructFromUint64(mockInstance.TokenMetaData.Nonce),
-				},
-				Balance: mj.JSONBigInt{
-					Value:    mockInstance.Value,
-					Original: ae.exprReconstructor.ReconstructFromBigInt(mockInstance.Value),
-				},
-				Uri: uri,
-			})
+			scenInstances = append(scenInstances, ae.convertMockDCDTInstance(mockInstance))
 		}
 
 		scenDCDT = append(scenDCDT, &mj.DCDTData{
@@ -113,22 +96,81 @@ func (ae *VMTestExecutor) convertMockAccountToScenarioFormat(account *worldmock.
 	}, nil
 }
 
+func (ae *VMTestExecutor) convertMockDCDTInstance(mockInstance *dcdt.DCDigitalToken) *mj.DCDTInstance {
+	scenInstance := &mj.DCDTInstance{
+		Nonce: mj.JSONUint64{
+			Value:    mockInstance.TokenMetaData.Nonce,
+			Original: ae.exprReconstructor.ReconstructFromUint64(mockInstance.TokenMetaData.Nonce),
+		},
+		Balance: mj.JSONBigInt{
+			Value:    mockInstance.Value,
+			Original: ae.exprReconstructor.ReconstructFromBigInt(mockInstance.Value),
+		},
+	}
+
+	// metadata fields are only written when set, fungible tokens have none
+	metadata := mockInstance.TokenMetaData
+	if len(metadata.Creator) > 0 {
+		scenInstance.Creator = mj.JSONBytesFromString{
+			Value:    metadata.Creator,
+			Original: ae.exprReconstructor.Reconstruct(metadata.Creator, er.AddressHint),
+		}
+	}
+	if metadata.Royalties > 0 {
+		scenInstance.Royalties = mj.JSONUint64{
+			Value:    uint64(metadata.Royalties),
+			Original: ae.exprReconstructor.ReconstructFromUint64(uint64(metadata.Royalties)),
+		}
+	}
+	if len(metadata.Hash) > 0 {
+		scenInstance.Hash = ae.reconstructBytesFromTree(metadata.Hash)
+	}
+	for _, uri := range metadata.URIs {
+		scenInstance.Uris = append(scenInstance.Uris, ae.reconstructBytesFromTree(uri))
+	}
+	if len(metadata.Attributes) > 0 {
+		scenInstance.Attributes = ae.reconstructBytesFromTree(metadata.Attributes)
+	}
+
+	return scenInstance
+}
+
+func (ae *VMTestExecutor) reconstructBytesFromTree(value []byte) mj.JSONBytesFromTree {
+	return mj.JSONBytesFromTree{
+		Value:    value,
+		Original: &oj.OJsonString{Value: ae.exprReconstructor.Reconstruct(value, er.NoHint)},
+	}
+}
+
 // DumpWorld prints the state of the MockWorld to stdout.
 func (ae *VMTestExecutor) DumpWorld() error {
 	fmt.Print("world state dump:\n")
-	var scenAccounts []*mj.Account
+	s, err := ae.worldStateJSON()
+	if err != nil {
+		return err
+	}
+	fmt.Println(s)
 
-	for _, account := range ae.World.AcctMap {
-		scenAccount, err := ae.convertMockAccountToScenarioFormat(account)
+	return nil
+}
+
+// worldStateJSON formats the accounts of the MockWorld in the scenario format, sorted by address
+func (ae *VMTestExecutor) worldStateJSON() (string, error) {
+	var addresses []string
+	for address := range ae.World.AcctMap {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	var scenAccounts []*mj.Account
+	for _, address := range addresses {
+		scenAccount, err := ae.convertMockAccountToScenarioFormat(ae.World.AcctMap[address])
 		if err != nil {
-			return err
+			return "", err
 		}
 		scenAccounts = append(scenAccounts, scenAccount)
 	}
 
 	ojAccount := mjwrite.AccountsToOJ(scenAccounts)
-	s := oj.JSONString(ojAccount)
-	fmt.Println(s)
-
-	return nil
+	return oj.JSONString(ojAccount), nil
 }
@@ -0,0 +1,67 @@
+package scenarioexec
+
+import (
+	"time"
+
+	mc "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/controller"
+	mj "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/json/model"
+)
+
+// StepResults returns the outcome of each step executed since the last Reset,
+// including the steps of the external scenarios, but not the external steps themselves.
+func (ae *VMTestExecutor) StepResults() []*mc.StepResult {
+	return ae.stepResults
+}
+
+func (ae *VMTestExecutor) executeAndRecordStep(generalStep mj.Step) error {
+	_, isExternalStep := generalStep.(*mj.ExternalStepsStep)
+	if isExternalStep {
+		// the steps of the external scenario are recorded individually
+		return ae.ExecuteStep(generalStep)
+	}
+
+	stepIndex := len(ae.stepResults)
+	if ae.debugger != nil {
+		debugErr := ae.debugger.beforeStep(stepIndex, generalStep)
+		if debugErr != nil {
+			return debugErr
+		}
+		ae.debugger.snapshotWorld()
+	}
+
+	ae.lastTxOutput = nil
+	startTime := time.Now()
+	err := ae.ExecuteStep(generalStep)
+
+	result := &mc.StepResult{
+		Index:    stepIndex,
+		StepType: generalStep.StepTypeName(),
+		Err:      err,
+		Duration: time.Since(startTime),
+	}
+
+	txStep, isTxStep := generalStep.(*mj.TxStep)
+	if isTxStep {
+		result.TxID = txStep.TxIdent
+		result.GasUsed = ae.gasUsedByLastTx(txStep.Tx)
+	}
+
+	ae.stepResults = append(ae.stepResults, result)
+
+	if ae.debugger != nil {
+		debugErr := ae.debugger.afterStep(stepIndex, generalStep, err)
+		if debugErr != nil {
+			return debugErr
+		}
+	}
+
+	return err
+}
+
+func (ae *VMTestExecutor) gasUsedByLastTx(tx *mj.Transaction) uint64 {
+	if ae.lastTxOutput == nil || ae.lastTxOutput.GasRemaining > tx.GasLimit.Value {
+		return 0
+	}
+
+	return tx.GasLimit.Value - ae.lastTxOutput.GasRemaining
+}
@@ -13,6 +13,9 @@ import (
 )
 
 func (ae *VMTestExecutor) executeTx(txIndex string, tx *mj.Transaction) (*vmcommon.VMOutput, error) {
+	defer ae.shards.SelectShard(ae.World.SelfShardID)
+	ae.shards.SelectShardOf(txShardAddress(tx))
+
 	ae.World.CreateStateBackup()
 
 	var err error
@@ -43,8 +46,10 @@ func (ae *VMTestExecutor) executeTx(txIndex string, tx *mj.Transaction) (*vmcomm
 		}
 
 		gasForExecution = tx.GasLimit.Value
-		if tx.DCDTValue != nil {
-			gasRemaining, err := ae.directDCDTTransferFromTx(tx)
+		if len(tx.DCDTValue) > 0 {
+			// assigning the outer err, so that a partially applied multi-transfer is rolled back
+			var gasRemaining uint64
+			gasRemaining, err = ae.directDCDTTransferFromTx(tx)
 			if err != nil {
 				return nil, err
 			}
@@ -92,7 +97,7 @@ func (ae *VMTestExecutor) executeTx(txIndex string, tx *mj.Transaction) (*vmcomm
 	}
 
 	if output.ReturnCode == vmcommon.Ok {
-		err := ae.updateStateAfterTx(tx, output)
+		err := ae.updateStateAfterTx(txIndex, tx, output)
 		if err != nil {
 			return nil, err
 		}
@@ -105,6 +110,17 @@ func (ae *VMTestExecutor) executeTx(txIndex string, tx *mj.Transaction) (*vmcomm
 	return output, nil
 }
 
+// txShardAddress returns the address whose shard executes the transaction:
+// the sender deploys contracts in its own shard, everything else runs in the
+// shard of the recipient
+func txShardAddress(tx *mj.Transaction) []byte {
+	if tx.Type == mj.ScDeploy {
+		return tx.From.Value
+	}
+
+	return tx.To.Value
+}
+
 func (ae *VMTestExecutor) senderHasEnoughBalance(tx *mj.Transaction) bool {
 	if !tx.Type.HasSender() {
 		return true
@@ -196,7 +212,7 @@ func (ae *VMTestExecutor) scCreate(txIndex string, tx *mj.Transaction, gasLimit
 		CurrentTxHash:  txHash,
 		DCDTTransfers:  make([]*vmcommon.DCDTTransfer, 0),
 	}
-	addDCDTToVMInput(tx.DCDTValue, &vmInput)
+	ae.addDCDTToVMInput(tx, &vmInput)
 	input := &vmcommon.ContractCreateInput{
 		ContractCode: tx.Code.Value,
 		VMInput:      vmInput,
@@ -206,6 +222,18 @@ func (ae *VMTestExecutor) scCreate(txIndex string, tx *mj.Transaction, gasLimit
 }
 
 func (ae *VMTestExecutor) scCall(txIndex string, tx *mj.Transaction, gasLimit uint64) (*vmcommon.VMOutput, error) {
+	input, err := ae.scCallInput(txIndex, tx, gasLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput, err := ae.vm.RunSmartContractCall(input)
+	ae.collectGasProfile()
+
+	return vmOutput, err
+}
+
+func (ae *VMTestExecutor) scCallInput(txIndex string, tx *mj.Transaction, gasLimit uint64) (*vmcommon.ContractCallInput, error) {
 	recipient := ae.World.AcctMap.GetAccount(tx.To.Value)
 	if recipient == nil {
 		return nil, fmt.Errorf("tx recipient (address: %s) does not exist", hex.EncodeToString(tx.To.Value))
@@ -224,29 +252,28 @@ func (ae *VMTestExecutor) scCall(txIndex string, tx *mj.Transaction, gasLimit ui
 		CurrentTxHash:  txHash,
 		DCDTTransfers:  make([]*vmcommon.DCDTTransfer, 0),
 	}
-	addDCDTToVMInput(tx.DCDTValue, &vmInput)
+	ae.addDCDTToVMInput(tx, &vmInput)
 	input := &vmcommon.ContractCallInput{
 		RecipientAddr: tx.To.Value,
 		Function:      tx.Function,
 		VMInput:       vmInput,
 	}
 
-	return ae.vm.RunSmartContractCall(input)
+	return input, nil
 }
 
 func (ae *VMTestExecutor) directDCDTTransferFromTx(tx *mj.Transaction) (uint64, error) {
-	return ae.World.BuiltinFuncs.PerformDirectDCDTTransfer(
+	return ae.World.BuiltinFuncs.PerformDirectMultiDCDTTransfer(
 		tx.From.Value,
 		tx.To.Value,
-		tx.DCDTValue.TokenIdentifier.Value,
-		tx.DCDTValue.Nonce.Value,
-		tx.DCDTValue.Value.Value,
+		convertDCDTTxData(tx.DCDTValue),
 		vm.DirectCall,
 		tx.GasLimit.Value,
 		tx.GasPrice.Value)
 }
 
 func (ae *VMTestExecutor) updateStateAfterTx(
+	txIndex string,
 	tx *mj.Transaction,
 	output *vmcommon.VMOutput) error {
 
@@ -256,11 +283,14 @@ func (ae *VMTestExecutor) updateStateAfterTx(
 		_ = ae.World.UpdateBalanceWithDelta(tx.From.Value, big.NewInt(0).Neg(tx.Value.Value))
 	}
 
-	// update accounts based on deltas
-	updErr := ae.World.UpdateAccounts(output.OutputAccounts, output.DeletedAccounts)
-	if updErr != nil {
-		return updErr
-	}
+	// update accounts based on deltas; transfers to other shards are queued
+	txHash := generateTxHash(txIndex)
+	ae.shards.UpdateAccounts(&vmcommon.VMInput{
+		CallerAddr:     tx.From.Value,
+		GasPrice:       tx.GasPrice.Value,
+		OriginalTxHash: txHash,
+		CurrentTxHash:  txHash,
+	}, output)
 
 	// sum of all balance deltas should equal call value (unless we got an error)
 	// (unless it is validatorReward, when funds just pop into existence)
@@ -2,6 +2,7 @@ package scenarioexec
 
 import (
 	"errors"
+	"math"
 	"math/big"
 
 	"github.com/kalyan3104/k-chain-core-go/core"
@@ -55,8 +56,13 @@ func convertAccount(testAcct *mj.Account) (*worldmock.Account, error) {
 				Type:       uint32(core.Fungible),
 				Properties: makeDCDTUserMetadataBytes(isFrozen),
 				TokenMetaData: &dcdt.MetaData{
-					Name:  tokenName,
-					Nonce: tokenNonce,
+					Name:       tokenName,
+					Nonce:      tokenNonce,
+					Creator:    instance.Creator.Value,
+					Royalties:  uint32(instance.Royalties.Value),
+					Hash:       instance.Hash.Value,
+					URIs:       mj.JSONBytesFromTreeValues(instance.Uris),
+					Attributes: instance.Attributes.Value,
 				},
 			}
 			err := account.SetTokenData(tokenKey, tokenData)
@@ -118,6 +124,39 @@ func convertBlockInfo(testBlockInfo *mj.BlockInfo) *worldmock.BlockInfo {
 	return result
 }
 
+// convertAdvanceBlockDelta interprets the deltas of an AdvanceBlockStep;
+// by default, the block nonce and round are advanced by one
+func convertAdvanceBlockDelta(step *mj.AdvanceBlockStep) (*worldmock.BlockInfo, error) {
+	nonceDelta := uint64(1)
+	if len(step.NonceDelta.Original) > 0 {
+		nonceDelta = step.NonceDelta.Value
+	}
+
+	roundDelta := nonceDelta
+	if len(step.RoundDelta.Original) > 0 {
+		roundDelta = step.RoundDelta.Value
+	}
+
+	if step.EpochDelta.Value > math.MaxUint32 {
+		return nil, errors.New("bad test: epoch delta should fit in 32 bits")
+	}
+
+	delta := &worldmock.BlockInfo{
+		BlockNonce:     nonceDelta,
+		BlockRound:     roundDelta,
+		BlockTimestamp: step.TimestampDelta.Value,
+		BlockEpoch:     uint32(step.EpochDelta.Value),
+	}
+
+	if step.BlockRandomSeed != nil {
+		var randomSeed [48]byte
+		copy(randomSeed[:], step.BlockRandomSeed.Value)
+		delta.RandomSeed = &randomSeed
+	}
+
+	return delta, nil
+}
+
 // this is a small hack, so we can reuse JSON printing in error messages
 func convertLogToTestFormat(outputLog *vmcommon.LogEntry) *mj.LogEntry {
 	testLog := mj.LogEntry{
@@ -144,17 +183,28 @@ func generateTxHash(txIndex string) []byte {
 	return txIndexBytes
 }
 
-func addDCDTToVMInput(dcdtData *mj.DCDTTxData, vmInput *vmcommon.VMInput) {
-	if dcdtData != nil {
-		vmInput.DCDTTransfers = make([]*vmcommon.DCDTTransfer, 1)
-		vmInput.DCDTTransfers[0] = &vmcommon.DCDTTransfer{}
-		vmInput.DCDTTransfers[0].DCDTTokenName = dcdtData.TokenIdentifier.Value
-		vmInput.DCDTTransfers[0].DCDTValue = dcdtData.Value.Value
-		vmInput.DCDTTransfers[0].DCDTTokenNonce = dcdtData.Nonce.Value
-		if vmInput.DCDTTransfers[0].DCDTTokenNonce != 0 {
-			vmInput.DCDTTransfers[0].DCDTTokenType = uint32(core.NonFungible)
-		} else {
-			vmInput.DCDTTransfers[0].DCDTTokenType = uint32(core.Fungible)
+// addDCDTToVMInput sets the DCDT payments of a transaction on its VM input; as
+// the payments were already transferred, the NFT / SFT types are taken from the
+// token data of the recipient, or of the sender if they are still there
+func (ae *VMTestExecutor) addDCDTToVMInput(tx *mj.Transaction, vmInput *vmcommon.VMInput) {
+	if len(tx.DCDTValue) > 0 {
+		vmInput.DCDTTransfers = convertDCDTTxData(tx.DCDTValue)
+		ae.World.BuiltinFuncs.SetTokenTypesFromData(vmInput.DCDTTransfers, tx.To.Value, tx.From.Value)
+	}
+}
+
+func convertDCDTTxData(dcdtData []*mj.DCDTTxData) []*vmcommon.DCDTTransfer {
+	dcdtTransfers := make([]*vmcommon.DCDTTransfer, len(dcdtData))
+	for i, dcdtItem := range dcdtData {
+		dcdtTransfers[i] = &vmcommon.DCDTTransfer{
+			DCDTTokenName:  dcdtItem.TokenIdentifier.Value,
+			DCDTValue:      dcdtItem.Value.Value,
+			DCDTTokenNonce: dcdtItem.Nonce.Value,
+			DCDTTokenType:  uint32(core.Fungible),
+		}
+		if dcdtItem.Nonce.Value != 0 {
+			dcdtTransfers[i].DCDTTokenType = uint32(core.NonFungible)
 		}
 	}
+	return dcdtTransfers
 }
@@ -0,0 +1,281 @@
+package scencontroller
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// ReportFormat is the format of a machine-readable scenario report.
+type ReportFormat string
+
+const (
+	// ReportFormatJUnit produces JUnit XML, with a test suite for each
+	// scenario file and a test case for each of its steps.
+	ReportFormatJUnit ReportFormat = "junit"
+
+	// ReportFormatJSON produces a JSON document with the results of all the scenarios and of their steps.
+	ReportFormatJSON ReportFormat = "json"
+)
+
+// WriteReport writes the results of the scenarios in the requested format;
+// the paths of the scenarios are shortened relative to generalTestPath.
+func WriteReport(out io.Writer, format ReportFormat, results []*ScenarioResult, generalTestPath string) error {
+	switch format {
+	case ReportFormatJUnit:
+		return WriteJUnitReport(out, results, generalTestPath)
+	case ReportFormatJSON:
+		return WriteJSONReport(out, results, generalTestPath)
+	default:
+		return fmt.Errorf("unknown report format: %s", format)
+	}
+}
+
+type jsonStepReport struct {
+	Index    int     `json:"index"`
+	Step     string  `json:"step"`
+	TxID     string  `json:"txId,omitempty"`
+	Status   string  `json:"status"`
+	Message  string  `json:"message,omitempty"`
+	GasUsed  uint64  `json:"gasUsed"`
+	Duration float64 `json:"durationSeconds"`
+}
+
+type jsonScenarioReport struct {
+	Path       string            `json:"path"`
+	Status     string            `json:"status"`
+	Message    string            `json:"message,omitempty"`
+	FailedStep *int              `json:"failedStep,omitempty"`
+	FailedTxID string            `json:"failedTxId,omitempty"`
+	GasUsed    uint64            `json:"gasUsed"`
+	Duration   float64           `json:"durationSeconds"`
+	Steps      []*jsonStepReport `json:"steps"`
+}
+
+type jsonReport struct {
+	Passed    int                   `json:"passed"`
+	Failed    int                   `json:"failed"`
+	Skipped   int                   `json:"skipped"`
+	Scenarios []*jsonScenarioReport `json:"scenarios"`
+}
+
+// WriteJSONReport writes the results of the scenarios as a JSON document.
+func WriteJSONReport(out io.Writer, results []*ScenarioResult, generalTestPath string) error {
+	report := &jsonReport{
+		Scenarios: make([]*jsonScenarioReport, 0, len(results)),
+	}
+
+	for _, result := range results {
+		scenarioReport := &jsonScenarioReport{
+			Path:     shortenTestPath(result.Path, generalTestPath),
+			Status:   scenarioStatus(result),
+			Message:  errorMessage(result.Err),
+			Duration: result.Duration.Seconds(),
+			Steps:    make([]*jsonStepReport, 0, len(result.Steps)),
+		}
+
+		for _, step := range result.Steps {
+			scenarioReport.GasUsed += step.GasUsed
+			scenarioReport.Steps = append(scenarioReport.Steps, &jsonStepReport{
+				Index:    step.Index,
+				Step:     step.StepType,
+				TxID:     step.TxID,
+				Status:   stepStatus(step),
+				Message:  errorMessage(step.Err),
+				GasUsed:  step.GasUsed,
+				Duration: step.Duration.Seconds(),
+			})
+		}
+
+		failedStep := result.FailedStep()
+		if failedStep != nil {
+			scenarioReport.FailedStep = &failedStep.Index
+			scenarioReport.FailedTxID = failedStep.TxID
+		}
+
+		switch {
+		case result.Skipped:
+			report.Skipped++
+		case result.Err == nil:
+			report.Passed++
+		default:
+			report.Failed++
+		}
+
+		report.Scenarios = append(report.Scenarios, scenarioReport)
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Contents string `xml:",chardata"`
+}
+
+type junitSkipped struct{}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestSuite struct {
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties []junitProperty  `xml:"properties>property,omitempty"`
+	TestCases  []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name          `xml:"testsuites"`
+	Tests      int               `xml:"tests,attr"`
+	Failures   int               `xml:"failures,attr"`
+	Skipped    int               `xml:"skipped,attr"`
+	TestSuites []*junitTestSuite `xml:"testsuite"`
+}
+
+// WriteJUnitReport writes the results of the scenarios as JUnit XML.
+// Each scenario file is a test suite and each of its executed steps is a test case.
+// The scenarios which fail before any step is executed (e.g. because they cannot be parsed),
+// or whose executor does not record steps, are reported as a single test case.
+func WriteJUnitReport(out io.Writer, results []*ScenarioResult, generalTestPath string) error {
+	report := &junitTestSuites{
+		TestSuites: make([]*junitTestSuite, 0, len(results)),
+	}
+
+	for _, result := range results {
+		suite := newJUnitTestSuite(result, generalTestPath)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+		report.TestSuites = append(report.TestSuites, suite)
+	}
+
+	_, err := io.WriteString(out, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(out)
+	encoder.Indent("", "  ")
+	err = encoder.Encode(report)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(out, "\n")
+	return err
+}
+
+func newJUnitTestSuite(result *ScenarioResult, generalTestPath string) *junitTestSuite {
+	suiteName := shortenTestPath(result.Path, generalTestPath)
+	className := strings.TrimSuffix(filepath.ToSlash(suiteName), ".json")
+	suite := &junitTestSuite{
+		Name:      suiteName,
+		Time:      formatSeconds(result.Duration.Seconds()),
+		TestCases: make([]*junitTestCase, 0, len(result.Steps)),
+	}
+
+	gasUsed := uint64(0)
+	for _, step := range result.Steps {
+		gasUsed += step.GasUsed
+		testCase := &junitTestCase{
+			Name:      stepName(step),
+			ClassName: className,
+			Time:      formatSeconds(step.Duration.Seconds()),
+		}
+		if step.Err != nil {
+			testCase.Failure = &junitFailure{
+				Message:  step.Err.Error(),
+				Contents: fmt.Sprintf("step %d (%s) failed, gas used: %d", step.Index, step.StepType, step.GasUsed),
+			}
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	scenarioFailedOutsideSteps := result.Err != nil && result.FailedStep() == nil
+	if result.Skipped || scenarioFailedOutsideSteps || len(result.Steps) == 0 {
+		testCase := &junitTestCase{
+			Name:      "scenario",
+			ClassName: className,
+			Time:      formatSeconds(result.Duration.Seconds()),
+		}
+		switch {
+		case result.Skipped:
+			testCase.Skipped = &junitSkipped{}
+			suite.Skipped++
+		case result.Err != nil:
+			testCase.Failure = &junitFailure{
+				Message:  result.Err.Error(),
+				Contents: result.Err.Error(),
+			}
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	suite.Tests = len(suite.TestCases)
+	suite.Properties = []junitProperty{
+		{Name: "gasUsed", Value: fmt.Sprintf("%d", gasUsed)},
+	}
+
+	return suite
+}
+
+// stepName identifies a step within its scenario, e.g. "3 scCall (txId: transfer-1)"
+func stepName(step *StepResult) string {
+	if len(step.TxID) == 0 {
+		return fmt.Sprintf("%d %s", step.Index, step.StepType)
+	}
+
+	return fmt.Sprintf("%d %s (txId: %s)", step.Index, step.StepType, step.TxID)
+}
+
+func scenarioStatus(result *ScenarioResult) string {
+	switch {
+	case result.Skipped:
+		return "skipped"
+	case result.Err != nil:
+		return "failed"
+	default:
+		return "passed"
+	}
+}
+
+func stepStatus(step *StepResult) string {
+	if step.Err != nil {
+		return "failed"
+	}
+
+	return "passed"
+}
+
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
+
+func formatSeconds(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
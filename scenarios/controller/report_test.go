@@ -0,0 +1,99 @@
+package scencontroller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createTestScenarioResults() []*ScenarioResult {
+	return []*ScenarioResult{
+		{
+			Path:     "/tests/adder.scen.json",
+			Duration: 30 * time.Millisecond,
+			Steps: []*StepResult{
+				{Index: 0, StepType: "setState", Duration: time.Millisecond},
+				{Index: 1, StepType: "scDeploy", TxID: "deploy", GasUsed: 1000, Duration: 10 * time.Millisecond},
+				{Index: 2, StepType: "scCall", TxID: "add", GasUsed: 500, Duration: 10 * time.Millisecond},
+			},
+		},
+		{
+			Path:     "/tests/sub/failing.scen.json",
+			Err:      errors.New("result mismatch"),
+			Duration: 20 * time.Millisecond,
+			Steps: []*StepResult{
+				{Index: 0, StepType: "setState"},
+				{Index: 1, StepType: "scCall", TxID: "transfer", Err: errors.New("result mismatch"), GasUsed: 300},
+			},
+		},
+		{
+			Path: "/tests/invalid.scen.json",
+			Err:  errors.New("unknown scenario field: unknown"),
+		},
+		{
+			Path:    "/tests/excluded.scen.json",
+			Skipped: true,
+		},
+	}
+}
+
+func TestWriteJSONReport(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	err := WriteReport(buffer, ReportFormatJSON, createTestScenarioResults(), "/tests")
+	require.Nil(t, err)
+
+	report := &jsonReport{}
+	err = json.Unmarshal(buffer.Bytes(), report)
+	require.Nil(t, err)
+	require.Equal(t, 1, report.Passed)
+	require.Equal(t, 2, report.Failed)
+	require.Equal(t, 1, report.Skipped)
+	require.Len(t, report.Scenarios, 4)
+
+	passed := report.Scenarios[0]
+	require.Equal(t, "adder.scen.json", passed.Path)
+	require.Equal(t, "passed", passed.Status)
+	require.Equal(t, uint64(1500), passed.GasUsed)
+	require.Nil(t, passed.FailedStep)
+	require.Len(t, passed.Steps, 3)
+	require.Equal(t, "deploy", passed.Steps[1].TxID)
+
+	failed := report.Scenarios[1]
+	require.Equal(t, "sub/failing.scen.json", failed.Path)
+	require.Equal(t, "failed", failed.Status)
+	require.Equal(t, "result mismatch", failed.Message)
+	require.Equal(t, 1, *failed.FailedStep)
+	require.Equal(t, "transfer", failed.FailedTxID)
+	require.Equal(t, "failed", failed.Steps[1].Status)
+
+	require.Equal(t, "failed", report.Scenarios[2].Status)
+	require.Empty(t, report.Scenarios[2].Steps)
+	require.Equal(t, "skipped", report.Scenarios[3].Status)
+}
+
+func TestWriteJUnitReport(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	err := WriteReport(buffer, ReportFormatJUnit, createTestScenarioResults(), "/tests")
+	require.Nil(t, err)
+
+	report := buffer.String()
+	require.True(t, strings.HasPrefix(report, `<?xml version="1.0" encoding="UTF-8"?>`))
+	require.Contains(t, report, `<testsuites tests="7" failures="2" skipped="1">`)
+	require.Contains(t, report, `<testsuite name="adder.scen.json" tests="3" failures="0" skipped="0" time="0.030">`)
+	require.Contains(t, report, `<property name="gasUsed" value="1500"></property>`)
+	require.Contains(t, report, `<testcase name="2 scCall (txId: add)" classname="adder.scen" time="0.010"></testcase>`)
+	require.Contains(t, report, `<testcase name="1 scCall (txId: transfer)" classname="sub/failing.scen" time="0.000">`)
+	require.Contains(t, report, `<failure message="result mismatch">step 1 (scCall) failed, gas used: 300</failure>`)
+	require.Contains(t, report, `<failure message="unknown scenario field: unknown">`)
+	require.Contains(t, report, `<skipped></skipped>`)
+}
+
+func TestWriteReport_UnknownFormat(t *testing.T) {
+	err := WriteReport(&bytes.Buffer{}, ReportFormat("html"), createTestScenarioResults(), "/tests")
+	require.NotNil(t, err)
+}
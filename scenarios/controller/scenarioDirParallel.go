@@ -0,0 +1,223 @@
+package scencontroller
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	fr "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/fileresolver"
+	mj "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/json/model"
+)
+
+// ScenarioExecutorFactory creates a new, independent ScenarioExecutor,
+// with its own world and VM, for each worker of a ParallelScenarioRunner.
+type ScenarioExecutorFactory func() (ScenarioExecutor, error)
+
+// ParallelScenarioRunner runs the scenarios of a directory concurrently,
+// on a pool of executors created by its ExecutorFactory.
+//
+// The WASM opcode costs are global to the process, so the scenarios are run in
+// batches, one for each gas schedule, each batch on a fresh pool of executors.
+// This way, every scenario is run with the gas schedule it declares.
+type ParallelScenarioRunner struct {
+	ExecutorFactory ScenarioExecutorFactory
+	FileResolver    fr.FileResolver
+	NumWorkers      int
+}
+
+// NewParallelScenarioRunner creates new ParallelScenarioRunner instance.
+// If numWorkers is not positive, the number of CPUs is used instead.
+func NewParallelScenarioRunner(
+	executorFactory ScenarioExecutorFactory,
+	fileResolver fr.FileResolver,
+	numWorkers int,
+) *ParallelScenarioRunner {
+	if numWorkers <= 0 {
+		numWorkers = runtime.NumCPU()
+	}
+
+	return &ParallelScenarioRunner{
+		ExecutorFactory: executorFactory,
+		FileResolver:    fileResolver,
+		NumWorkers:      numWorkers,
+	}
+}
+
+type parallelScenarioJob struct {
+	result       *ScenarioResult
+	scenario     *mj.Scenario
+	fileResolver fr.FileResolver
+}
+
+// RunAllJSONScenariosInDirectory walks directory, then parses and executes all json scenarios
+// concurrently. The results are printed in the order of the files, regardless of the order
+// in which the scenarios complete.
+func (r *ParallelScenarioRunner) RunAllJSONScenariosInDirectory(
+	generalTestPath string,
+	specificTestPath string,
+	allowedSuffix string,
+	excludedFilePatterns []string) error {
+
+	startTime := time.Now()
+	results, err := r.RunJSONScenariosInDirectory(generalTestPath, specificTestPath, allowedSuffix, excludedFilePatterns)
+	if err != nil {
+		return err
+	}
+
+	err = SummarizeScenarioResults(os.Stdout, results, generalTestPath)
+	fmt.Printf("Workers: %d. Time: %s.\n", r.NumWorkers, formatDuration(time.Since(startTime)))
+
+	return err
+}
+
+// RunJSONScenariosInDirectory walks directory, then parses and executes all json scenarios
+// concurrently, without printing anything. The results are sorted by file path.
+// An error is only returned if the directory cannot be walked or the executors cannot be created,
+// scenario failures are reported in the results.
+func (r *ParallelScenarioRunner) RunJSONScenariosInDirectory(
+	generalTestPath string,
+	specificTestPath string,
+	allowedSuffix string,
+	excludedFilePatterns []string) ([]*ScenarioResult, error) {
+
+	mainDirPath := path.Join(generalTestPath, specificTestPath)
+	results := make([]*ScenarioResult, 0)
+	err := filepath.Walk(mainDirPath, func(testFilePath string, info os.FileInfo, err error) error {
+		if strings.HasSuffix(testFilePath, allowedSuffix) {
+			results = append(results, &ScenarioResult{
+				Path:    testFilePath,
+				Skipped: isExcluded(excludedFilePatterns, testFilePath, generalTestPath),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := r.parseAll(results)
+	for _, batch := range batchJobsByGasSchedule(jobs) {
+		err = r.executeBatch(batch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// parseAll parses the scenarios which are not skipped, concurrently;
+// parse errors are recorded in the results
+func (r *ParallelScenarioRunner) parseAll(results []*ScenarioResult) []*parallelScenarioJob {
+	parsedJobs := make([]*parallelScenarioJob, len(results))
+	r.runConcurrently(len(results), func(_ int, index int) {
+		result := results[index]
+		if result.Skipped {
+			return
+		}
+
+		startTime := time.Now()
+		runner := NewScenarioRunner(nil, r.FileResolver.Clone())
+		scenario, err := runner.ParseSingleJSONScenario(result.Path)
+		result.Duration = time.Since(startTime)
+		if err != nil {
+			result.Err = err
+			return
+		}
+
+		parsedJobs[index] = &parallelScenarioJob{
+			result:       result,
+			scenario:     scenario,
+			fileResolver: runner.Parser.ExprInterpreter.FileResolver,
+		}
+	})
+
+	jobs := make([]*parallelScenarioJob, 0, len(parsedJobs))
+	for _, job := range parsedJobs {
+		if job != nil {
+			jobs = append(jobs, job)
+		}
+	}
+
+	return jobs
+}
+
+// executeBatch runs scenarios sharing the same gas schedule on a new pool of executors
+func (r *ParallelScenarioRunner) executeBatch(batch []*parallelScenarioJob) error {
+	numWorkers := r.NumWorkers
+	if numWorkers > len(batch) {
+		numWorkers = len(batch)
+	}
+
+	executors := make([]ScenarioExecutor, numWorkers)
+	for i := range executors {
+		executor, err := r.ExecutorFactory()
+		if err != nil {
+			return err
+		}
+		executors[i] = executor
+	}
+
+	r.runConcurrently(len(batch), func(worker int, index int) {
+		job := batch[index]
+		executor := executors[worker]
+
+		startTime := time.Now()
+		executor.Reset()
+		job.result.Err = executor.ExecuteScenario(job.scenario, job.fileResolver)
+		job.result.Duration += time.Since(startTime)
+		job.result.collectSteps(executor)
+	})
+
+	return nil
+}
+
+// runConcurrently calls handler for each index in [0, count), on at most NumWorkers goroutines;
+// handler also receives the index of the worker, in [0, NumWorkers)
+func (r *ParallelScenarioRunner) runConcurrently(count int, handler func(worker int, index int)) {
+	indexes := make(chan int, count)
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+
+	numWorkers := r.NumWorkers
+	if numWorkers > count {
+		numWorkers = count
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(numWorkers)
+	for worker := 0; worker < numWorkers; worker++ {
+		go func(worker int) {
+			defer wg.Done()
+			for index := range indexes {
+				handler(worker, index)
+			}
+		}(worker)
+	}
+	wg.Wait()
+}
+
+// batchJobsByGasSchedule groups the jobs by gas schedule,
+// in the order in which the gas schedules first appear
+func batchJobsByGasSchedule(jobs []*parallelScenarioJob) [][]*parallelScenarioJob {
+	batchIndexes := make(map[mj.GasSchedule]int)
+	batches := make([][]*parallelScenarioJob, 0)
+	for _, job := range jobs {
+		batchIndex, found := batchIndexes[job.scenario.GasSchedule]
+		if !found {
+			batchIndex = len(batches)
+			batchIndexes[job.scenario.GasSchedule] = batchIndex
+			batches = append(batches, make([]*parallelScenarioJob, 0))
+		}
+		batches[batchIndex] = append(batches[batchIndex], job)
+	}
+
+	return batches
+}
@@ -0,0 +1,110 @@
+package scencontroller
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	fr "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/fileresolver"
+	mj "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/json/model"
+	"github.com/stretchr/testify/require"
+)
+
+type scenarioExecutorStub struct {
+	mutex         *sync.Mutex
+	executedNames *[]string
+	gasSchedules  map[mj.GasSchedule]struct{}
+}
+
+func (executor *scenarioExecutorStub) Reset() {
+}
+
+func (executor *scenarioExecutorStub) ExecuteScenario(scenario *mj.Scenario, _ fr.FileResolver) error {
+	executor.mutex.Lock()
+	*executor.executedNames = append(*executor.executedNames, scenario.Name)
+	executor.mutex.Unlock()
+
+	executor.gasSchedules[scenario.GasSchedule] = struct{}{}
+	if scenario.Name == "failing" {
+		return errors.New("scenario failed")
+	}
+
+	return nil
+}
+
+func TestParallelScenarioRunner_RunJSONScenariosInDirectory(t *testing.T) {
+	testDir := t.TempDir()
+	scenarios := map[string]string{
+		"a.scen.json":        `{"name": "a", "gasSchedule": "dummy", "steps": []}`,
+		"b.scen.json":        `{"name": "failing", "gasSchedule": "v3", "steps": []}`,
+		"c.scen.json":        `{"name": "c", "gasSchedule": "dummy", "steps": []}`,
+		"d.scen.json":        `{"name": "d", "gasSchedule": "v3", "steps": []}`,
+		"excluded.scen.json": `{"name": "excluded", "steps": []}`,
+		"invalid.scen.json":  `{"name": "invalid", "unknown": "field"}`,
+		"sub/e.scen.json":    `{"name": "e", "gasSchedule": "v2", "steps": []}`,
+		"not-a-scenario.txt": `{}`,
+	}
+	for fileName, contents := range scenarios {
+		filePath := filepath.Join(testDir, fileName)
+		require.Nil(t, os.MkdirAll(filepath.Dir(filePath), os.ModePerm))
+		require.Nil(t, ioutil.WriteFile(filePath, []byte(contents), 0644))
+	}
+
+	mutex := &sync.Mutex{}
+	executedNames := make([]string, 0)
+	executors := make([]*scenarioExecutorStub, 0)
+	runner := NewParallelScenarioRunner(
+		func() (ScenarioExecutor, error) {
+			executor := &scenarioExecutorStub{
+				mutex:         mutex,
+				executedNames: &executedNames,
+				gasSchedules:  make(map[mj.GasSchedule]struct{}),
+			}
+			mutex.Lock()
+			executors = append(executors, executor)
+			mutex.Unlock()
+			return executor, nil
+		},
+		NewDefaultFileResolver(),
+		2,
+	)
+
+	results, err := runner.RunJSONScenariosInDirectory(testDir, "", ".scen.json", []string{"excluded.scen.json"})
+	require.Nil(t, err)
+	require.ElementsMatch(t, []string{"a", "failing", "c", "d", "e"}, executedNames)
+
+	// one batch per gas schedule, each executor sees at most one gas schedule
+	require.Len(t, executors, 5)
+	for _, executor := range executors {
+		require.True(t, len(executor.gasSchedules) <= 1)
+	}
+
+	paths := make([]string, 0, len(results))
+	for _, result := range results {
+		paths = append(paths, shortenTestPath(result.Path, testDir))
+	}
+	require.Equal(t, []string{
+		"a.scen.json",
+		"b.scen.json",
+		"c.scen.json",
+		"d.scen.json",
+		"excluded.scen.json",
+		"invalid.scen.json",
+		"sub/e.scen.json",
+	}, paths)
+
+	require.True(t, results[0].Passed())
+	require.False(t, results[1].Passed())
+	require.Equal(t, "scenario failed", results[1].Err.Error())
+	require.True(t, results[3].Passed())
+	require.True(t, results[4].Skipped)
+	require.False(t, results[4].Passed())
+	require.NotNil(t, results[5].Err)
+	require.True(t, results[6].Passed())
+
+	err = runner.RunAllJSONScenariosInDirectory(testDir, "", ".scen.json", []string{"excluded.scen.json"})
+	require.NotNil(t, err)
+}
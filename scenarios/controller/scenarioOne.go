@@ -11,10 +11,21 @@ import (
 
 // RunSingleJSONScenario parses and prepares test, then calls testCallback.
 func (r *ScenarioRunner) RunSingleJSONScenario(contextPath string) error {
+	scenario, err := r.ParseSingleJSONScenario(contextPath)
+	if err != nil {
+		return err
+	}
+
+	return r.Executor.ExecuteScenario(scenario, r.Parser.ExprInterpreter.FileResolver)
+}
+
+// ParseSingleJSONScenario reads and parses a scenario file, without executing it.
+// The file resolver of the parser is left in the context of the scenario.
+func (r *ScenarioRunner) ParseSingleJSONScenario(contextPath string) (*mj.Scenario, error) {
 	var err error
 	contextPath, err = filepath.Abs(contextPath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Open our jsonFile
@@ -22,7 +33,7 @@ func (r *ScenarioRunner) RunSingleJSONScenario(contextPath string) error {
 	jsonFile, err = os.Open(contextPath)
 	// if we os.Open returns an error then handle it
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// defer the closing of our jsonFile so that we can parse it later on
@@ -30,16 +41,11 @@ func (r *ScenarioRunner) RunSingleJSONScenario(contextPath string) error {
 
 	byteValue, err := ioutil.ReadAll(jsonFile)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	r.Parser.ExprInterpreter.FileResolver.SetContext(contextPath)
-	scenario, parseErr := r.Parser.ParseScenarioFile(byteValue)
-	if parseErr != nil {
-		return parseErr
-	}
-
-	return r.Executor.ExecuteScenario(scenario, r.Parser.ExprInterpreter.FileResolver)
+	return r.Parser.ParseScenarioFile(byteValue)
 }
 
 // tool to modify scenarios
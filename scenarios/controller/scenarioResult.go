@@ -0,0 +1,148 @@
+package scencontroller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// StepResult holds the outcome of a single scenario step.
+// TxID and GasUsed are only set for transaction steps.
+type StepResult struct {
+	Index    int
+	StepType string
+	TxID     string
+	Err      error
+	GasUsed  uint64
+	Duration time.Duration
+}
+
+// Passed returns true if the step ran without errors.
+func (result *StepResult) Passed() bool {
+	return result.Err == nil
+}
+
+// StepRecorder is implemented by the scenario executors which can report
+// the outcome of each step executed since the last Reset.
+// The steps of external scenarios are reported individually, in the order of execution.
+type StepRecorder interface {
+	StepResults() []*StepResult
+}
+
+// ScenarioResult holds the outcome of running a single scenario file.
+// Steps is only filled in if the executor is a StepRecorder.
+type ScenarioResult struct {
+	Path     string
+	Skipped  bool
+	Err      error
+	Duration time.Duration
+	Steps    []*StepResult
+}
+
+// Passed returns true if the scenario ran without errors.
+func (result *ScenarioResult) Passed() bool {
+	return !result.Skipped && result.Err == nil
+}
+
+// FailedStep returns the first step that failed, or nil if none did.
+func (result *ScenarioResult) FailedStep() *StepResult {
+	for _, step := range result.Steps {
+		if !step.Passed() {
+			return step
+		}
+	}
+
+	return nil
+}
+
+func (result *ScenarioResult) collectSteps(executor ScenarioExecutor) {
+	recorder, ok := executor.(StepRecorder)
+	if !ok {
+		return
+	}
+
+	result.Steps = recorder.StepResults()
+}
+
+// RunJSONScenario resets the executor, then runs a scenario file, collecting its result.
+func (r *ScenarioRunner) RunJSONScenario(scenarioPath string) *ScenarioResult {
+	result := &ScenarioResult{
+		Path: scenarioPath,
+	}
+
+	startTime := time.Now()
+	r.Executor.Reset()
+	result.Err = r.RunSingleJSONScenario(scenarioPath)
+	result.Duration = time.Since(startTime)
+	result.collectSteps(r.Executor)
+
+	return result
+}
+
+// RunJSONScenariosInDirectory walks directory, then runs all json scenarios one after the other,
+// continuing past failures and without printing anything. The results are sorted by file path.
+// An error is only returned if the directory cannot be walked.
+func (r *ScenarioRunner) RunJSONScenariosInDirectory(
+	generalTestPath string,
+	specificTestPath string,
+	allowedSuffix string,
+	excludedFilePatterns []string) ([]*ScenarioResult, error) {
+
+	mainDirPath := path.Join(generalTestPath, specificTestPath)
+	results := make([]*ScenarioResult, 0)
+	err := filepath.Walk(mainDirPath, func(testFilePath string, info os.FileInfo, err error) error {
+		if strings.HasSuffix(testFilePath, allowedSuffix) {
+			if isExcluded(excludedFilePatterns, testFilePath, generalTestPath) {
+				results = append(results, &ScenarioResult{
+					Path:    testFilePath,
+					Skipped: true,
+				})
+			} else {
+				results = append(results, r.RunJSONScenario(testFilePath))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// SummarizeScenarioResults prints the outcome of each scenario, followed by the totals,
+// in the same format as RunAllJSONScenariosInDirectory.
+// Returns an error if any of the scenarios failed.
+func SummarizeScenarioResults(out io.Writer, results []*ScenarioResult, generalTestPath string) error {
+	var nrPassed, nrFailed, nrSkipped int
+	for _, result := range results {
+		_, _ = fmt.Fprintf(out, "Scenario: %s ... ", shortenTestPath(result.Path, generalTestPath))
+		switch {
+		case result.Skipped:
+			nrSkipped++
+			_, _ = fmt.Fprint(out, "  skip\n")
+		case result.Err == nil:
+			nrPassed++
+			_, _ = fmt.Fprintf(out, "  ok (%s)\n", formatDuration(result.Duration))
+		default:
+			nrFailed++
+			_, _ = fmt.Fprintf(out, "  FAIL (%s): %s\n", formatDuration(result.Duration), result.Err.Error())
+		}
+	}
+
+	_, _ = fmt.Fprintf(out, "Done. Passed: %d. Failed: %d. Skipped: %d.\n", nrPassed, nrFailed, nrSkipped)
+	if nrFailed > 0 {
+		return errors.New("Some tests failed")
+	}
+
+	return nil
+}
+
+func formatDuration(duration time.Duration) string {
+	return duration.Round(time.Millisecond).String()
+}
@@ -9,9 +9,13 @@ type DCDTTxData struct {
 
 // DCDTInstance models an instance of an NFT/SFT, with its own nonce
 type DCDTInstance struct {
-	Nonce   JSONUint64
-	Balance JSONBigInt
-	Uri     JSONBytesFromTree
+	Nonce      JSONUint64
+	Balance    JSONBigInt
+	Creator    JSONBytesFromString
+	Royalties  JSONUint64
+	Hash       JSONBytesFromTree
+	Uris       []JSONBytesFromTree
+	Attributes JSONBytesFromTree
 }
 
 // DCDTData models an account holding an DCDT token
@@ -25,9 +29,26 @@ type DCDTData struct {
 
 // CheckDCDTInstance checks an instance of an NFT/SFT, with its own nonce
 type CheckDCDTInstance struct {
-	Nonce   JSONCheckUint64
-	Balance JSONCheckBigInt
-	Uri     JSONCheckBytes
+	Nonce      JSONCheckUint64
+	Balance    JSONCheckBigInt
+	Creator    JSONCheckBytes
+	Royalties  JSONCheckUint64
+	Hash       JSONCheckBytes
+	Uris       JSONCheckValueList
+	Attributes JSONCheckBytes
+}
+
+// NewCheckDCDTInstance creates an instance with all fields unspecified.
+func NewCheckDCDTInstance() *CheckDCDTInstance {
+	return &CheckDCDTInstance{
+		Nonce:      JSONCheckUint64Unspecified(),
+		Balance:    JSONCheckBigIntUnspecified(),
+		Creator:    JSONCheckBytesUnspecified(),
+		Royalties:  JSONCheckUint64Unspecified(),
+		Hash:       JSONCheckBytesUnspecified(),
+		Uris:       JSONCheckValueListUnspecified(),
+		Attributes: JSONCheckBytesUnspecified(),
+	}
 }
 
 // CheckDCDTData checks the DCDT tokens held by an account
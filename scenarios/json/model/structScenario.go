@@ -46,10 +46,41 @@ type SetStateStep struct {
 	NewAddressMocks   []*NewAddressMock
 }
 
+// AdvanceBlockStep is a step where the blockchain mock moves on to a later block.
+// The current block info becomes the previous block info, while the new
+// current block info is obtained by adding the deltas to the old one.
+// Unspecified deltas mean advancing by one nonce and one round, with the same
+// timestamp and epoch. BlockHash is the hash of the new current block; the
+// hashes of any skipped blocks are generated.
+type AdvanceBlockStep struct {
+	Comment         string
+	NonceDelta      JSONUint64
+	RoundDelta      JSONUint64
+	TimestampDelta  JSONUint64
+	EpochDelta      JSONUint64
+	BlockRandomSeed *JSONBytesFromTree
+	BlockHash       JSONBytesFromString
+}
+
+// CrossShardRoundStep is a step where the cross-shard transfers produced by the
+// previous steps are delivered. Each round advances all the shards by one block,
+// then executes the transfers pending at its start on their destination shards;
+// the transfers produced meanwhile, such as callbacks, wait for the next round.
+// Unspecified Rounds means a single round. If ExpectedPending is specified, the
+// number of transfers still pending after the last round is verified.
+type CrossShardRoundStep struct {
+	Comment         string
+	Rounds          JSONUint64
+	ExpectedPending JSONUint64
+}
+
 // CheckStateStep is a step where the state of the blockchain mock is verified.
+// StateRootHash is checked against the root hash of all the accounts, as they
+// are at the time of the check.
 type CheckStateStep struct {
 	Comment       string
 	CheckAccounts *CheckAccounts
+	StateRootHash JSONCheckBytes
 }
 
 // DumpStateStep is a step that simply prints the entire state to console. Useful for debugging.
@@ -67,6 +98,8 @@ type TxStep struct {
 
 var _ Step = (*ExternalStepsStep)(nil)
 var _ Step = (*SetStateStep)(nil)
+var _ Step = (*AdvanceBlockStep)(nil)
+var _ Step = (*CrossShardRoundStep)(nil)
 var _ Step = (*CheckStateStep)(nil)
 var _ Step = (*DumpStateStep)(nil)
 var _ Step = (*TxStep)(nil)
@@ -87,6 +120,22 @@ func (*SetStateStep) StepTypeName() string {
 	return StepNameSetState
 }
 
+// StepNameAdvanceBlock is a json step type name.
+const StepNameAdvanceBlock = "advanceBlock"
+
+// StepTypeName type as string
+func (*AdvanceBlockStep) StepTypeName() string {
+	return StepNameAdvanceBlock
+}
+
+// StepNameCrossShardRound is a json step type name.
+const StepNameCrossShardRound = "crossShardRound"
+
+// StepTypeName type as string
+func (*CrossShardRoundStep) StepTypeName() string {
+	return StepNameCrossShardRound
+}
+
 // StepNameCheckState is a json step type name.
 const StepNameCheckState = "checkState"
 
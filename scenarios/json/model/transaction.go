@@ -62,7 +62,7 @@ type Transaction struct {
 	Type      TransactionType
 	Nonce     JSONUint64
 	Value     JSONBigInt
-	DCDTValue *DCDTTxData
+	DCDTValue []*DCDTTxData
 	From      JSONBytesFromString
 	To        JSONBytesFromString
 	Function  string
@@ -145,3 +145,51 @@ func (jcu JSONCheckUint64) CheckBool(other bool) bool {
 	}
 	return jcu.Value > 0 == other
 }
+
+// JSONCheckValueList holds a condition on a list of byte slices.
+// The list must have the same length and each item is checked separately.
+// "*" allows all lists.
+type JSONCheckValueList struct {
+	Values      []JSONCheckBytes
+	IsStar      bool
+	Unspecified bool
+}
+
+// JSONCheckValueListUnspecified yields JSONCheckValueList default "*" value.
+func JSONCheckValueListUnspecified() JSONCheckValueList {
+	return JSONCheckValueList{
+		Values:      nil,
+		IsStar:      true,
+		Unspecified: true,
+	}
+}
+
+// JSONCheckValueListExplicitStar yields JSONCheckValueList explicit "*" value.
+func JSONCheckValueListExplicitStar() JSONCheckValueList {
+	return JSONCheckValueList{
+		Values:      nil,
+		IsStar:      true,
+		Unspecified: false,
+	}
+}
+
+// IsUnspecified yields true if the field was originally unspecified.
+func (jcvl JSONCheckValueList) IsUnspecified() bool {
+	return jcvl.Unspecified
+}
+
+// Check returns true if condition expressed in object holds for another list.
+func (jcvl JSONCheckValueList) Check(other [][]byte) bool {
+	if jcvl.IsStar {
+		return true
+	}
+	if len(jcvl.Values) != len(other) {
+		return false
+	}
+	for i, value := range jcvl.Values {
+		if !value.Check(other[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -54,3 +54,87 @@ func (p *Parser) processBlockInfo(blockInfoRaw oj.OJsonObject) (*mj.BlockInfo, e
 
 	return blockInfo, nil
 }
+
+func (p *Parser) parseAdvanceBlockStep(stepMap *oj.OJsonMap) (*mj.AdvanceBlockStep, error) {
+	step := &mj.AdvanceBlockStep{}
+	var err error
+
+	for _, kvp := range stepMap.OrderedKV {
+		switch kvp.Key {
+		case "step":
+		case "comment":
+			step.Comment, err = p.parseString(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("bad advance block step comment: %w", err)
+			}
+		case "nonceDelta":
+			step.NonceDelta, err = p.processUint64(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing nonceDelta: %w", err)
+			}
+		case "roundDelta":
+			step.RoundDelta, err = p.processUint64(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing roundDelta: %w", err)
+			}
+		case "timestampDelta":
+			step.TimestampDelta, err = p.processUint64(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing timestampDelta: %w", err)
+			}
+		case "epochDelta":
+			step.EpochDelta, err = p.processUint64(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing epochDelta: %w", err)
+			}
+		case "blockRandomSeed":
+			blockRandomSeed, err := p.processSubTreeAsByteArray(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing blockRandomSeed: %w", err)
+			}
+			if len(blockRandomSeed.Value) != 48 {
+				return nil, fmt.Errorf("blockRandomSeed must be 48 bytes long. Actual length: %d", len(blockRandomSeed.Value))
+			}
+			step.BlockRandomSeed = &blockRandomSeed
+		case "blockHash":
+			step.BlockHash, err = p.processStringAsByteArray(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing blockHash: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("invalid advance block field: %s", kvp.Key)
+		}
+	}
+
+	return step, nil
+}
+
+func (p *Parser) parseCrossShardRoundStep(stepMap *oj.OJsonMap) (*mj.CrossShardRoundStep, error) {
+	step := &mj.CrossShardRoundStep{}
+	var err error
+
+	for _, kvp := range stepMap.OrderedKV {
+		switch kvp.Key {
+		case "step":
+		case "comment":
+			step.Comment, err = p.parseString(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("bad cross shard round step comment: %w", err)
+			}
+		case "rounds":
+			step.Rounds, err = p.processUint64(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing rounds: %w", err)
+			}
+		case "expectPending":
+			step.ExpectedPending, err = p.processUint64(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing expectPending: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("invalid cross shard round field: %s", kvp.Key)
+		}
+	}
+
+	return step, nil
+}
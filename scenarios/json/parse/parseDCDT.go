@@ -3,6 +3,7 @@ package scenjsonparse
 import (
 	"errors"
 	"fmt"
+	"math"
 
 	mj "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/json/model"
 	oj "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/orderedjson"
@@ -108,6 +109,34 @@ func (p *Parser) tryProcessDCDTInstanceField(kvp *oj.OJsonKeyValuePair, targetIn
 		if err != nil {
 			return false, fmt.Errorf("invalid DCDT balance: %w", err)
 		}
+	case "creator":
+		targetInstance.Creator, err = p.processStringAsByteArray(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid DCDT NFT creator: %w", err)
+		}
+	case "royalties":
+		targetInstance.Royalties, err = p.processUint64(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid DCDT NFT royalties: %w", err)
+		}
+		if targetInstance.Royalties.Value > math.MaxUint32 {
+			return false, errors.New("invalid DCDT NFT royalties: value exceeds uint32")
+		}
+	case "hash":
+		targetInstance.Hash, err = p.processSubTreeAsByteArray(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid DCDT NFT hash: %w", err)
+		}
+	case "uri":
+		targetInstance.Uris, err = p.parseSubTreeList(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid DCDT NFT URIs: %w", err)
+		}
+	case "attributes":
+		targetInstance.Attributes, err = p.processSubTreeAsByteArray(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid DCDT NFT attributes: %w", err)
+		}
 	default:
 		return false, nil
 	}
@@ -3,6 +3,7 @@ package scenjsonparse
 import (
 	"errors"
 	"fmt"
+	"math"
 
 	mj "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/json/model"
 	oj "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/orderedjson"
@@ -22,12 +23,10 @@ func (p *Parser) processCheckDCDTData(
 		if err != nil {
 			return nil, fmt.Errorf("invalid DCDT balance: %w", err)
 		}
-		dcdtData.Instances = []*mj.CheckDCDTInstance{
-			{
-				Nonce:   mj.JSONCheckUint64{Value: 0, Original: ""},
-				Balance: balance,
-			},
-		}
+		instance := mj.NewCheckDCDTInstance()
+		instance.Nonce = mj.JSONCheckUint64{Value: 0, Original: ""}
+		instance.Balance = balance
+		dcdtData.Instances = []*mj.CheckDCDTInstance{instance}
 		return &dcdtData, nil
 	case *oj.OJsonMap:
 		return p.processCheckDCDTDataMap(tokenName, data)
@@ -47,7 +46,7 @@ func (p *Parser) processCheckDCDTDataMap(tokenName mj.JSONBytesFromString, dcdtD
 		TokenIdentifier: tokenName,
 	}
 	// var err error
-	firstInstance := &mj.CheckDCDTInstance{}
+	firstInstance := mj.NewCheckDCDTInstance()
 	firstInstanceLoaded := false
 	var explicitInstances []*mj.CheckDCDTInstance
 
@@ -108,6 +107,34 @@ func (p *Parser) tryProcessCheckDCDTInstanceField(kvp *oj.OJsonKeyValuePair, tar
 		if err != nil {
 			return false, fmt.Errorf("invalid DCDT balance: %w", err)
 		}
+	case "creator":
+		targetInstance.Creator, err = p.parseCheckBytes(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid DCDT NFT creator: %w", err)
+		}
+	case "royalties":
+		targetInstance.Royalties, err = p.processCheckUint64(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid DCDT NFT royalties: %w", err)
+		}
+		if targetInstance.Royalties.Value > math.MaxUint32 {
+			return false, errors.New("invalid DCDT NFT royalties: value exceeds uint32")
+		}
+	case "hash":
+		targetInstance.Hash, err = p.parseCheckBytes(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid DCDT NFT hash: %w", err)
+		}
+	case "uri":
+		targetInstance.Uris, err = p.parseCheckValueList(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid DCDT NFT URIs: %w", err)
+		}
+	case "attributes":
+		targetInstance.Attributes, err = p.parseCheckBytes(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid DCDT NFT attributes: %w", err)
+		}
 	default:
 		return false, nil
 	}
@@ -126,7 +153,7 @@ func (p *Parser) processCheckDCDTInstances(dcdtInstancesRaw oj.OJsonObject) ([]*
 			return nil, errors.New("JSON map expected as dcdt instances list item")
 		}
 
-		instance := &mj.CheckDCDTInstance{}
+		instance := mj.NewCheckDCDTInstance()
 
 		for _, kvp := range instanceAsMap.OrderedKV {
 			instanceFieldLoaded, err := p.tryProcessCheckDCDTInstanceField(kvp, instance)
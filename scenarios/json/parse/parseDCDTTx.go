@@ -8,6 +8,44 @@ import (
 	oj "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/orderedjson"
 )
 
+// the DCDT value of a transaction is either a single token transfer, or a list of them, e.g.:
+//
+//	[
+//		{
+//			"tokenIdentifier": "str:FUNG-123456",
+//			"value": "100"
+//		},
+//		{
+//			"tokenIdentifier": "str:NFT-123456",
+//			"nonce": "5",
+//			"value": "1"
+//		}
+//	]
+func (p *Parser) processTxDCDTList(txDcdtRaw oj.OJsonObject) ([]*mj.DCDTTxData, error) {
+	dcdtList, isList := txDcdtRaw.(*oj.OJsonList)
+	if !isList {
+		dcdtData, err := p.processTxDCDT(txDcdtRaw)
+		if err != nil {
+			return nil, err
+		}
+		return []*mj.DCDTTxData{dcdtData}, nil
+	}
+
+	var dcdtDataList []*mj.DCDTTxData
+	for _, dcdtItemRaw := range dcdtList.AsList() {
+		dcdtData, err := p.processTxDCDT(dcdtItemRaw)
+		if err != nil {
+			return nil, err
+		}
+		dcdtDataList = append(dcdtDataList, dcdtData)
+	}
+	if len(dcdtDataList) == 0 {
+		return nil, errors.New("empty DCDT transfer list")
+	}
+
+	return dcdtDataList, nil
+}
+
 func (p *Parser) processTxDCDT(txDcdtRaw oj.OJsonObject) (*mj.DCDTTxData, error) {
 	fieldMap, isMap := txDcdtRaw.(*oj.OJsonMap)
 	if !isMap {
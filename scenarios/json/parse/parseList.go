@@ -70,3 +70,20 @@ func (p *Parser) parseCheckBytesList(obj interface{}) ([]mj.JSONCheckBytes, erro
 	}
 	return result, nil
 }
+
+func (p *Parser) parseCheckValueList(obj oj.OJsonObject) (mj.JSONCheckValueList, error) {
+	if IsStar(obj) {
+		// "*" means any list, skip checking it
+		return mj.JSONCheckValueListExplicitStar(), nil
+	}
+
+	values, err := p.parseCheckBytesList(obj)
+	if err != nil {
+		return mj.JSONCheckValueList{}, err
+	}
+	return mj.JSONCheckValueList{
+		Values:      values,
+		IsStar:      false,
+		Unspecified: false,
+	}, nil
+}
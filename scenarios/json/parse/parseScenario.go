@@ -188,8 +188,14 @@ func (p *Parser) processScenarioStep(stepObj oj.OJsonObject) (mj.Step, error) {
 			}
 		}
 		return step, nil
+	case mj.StepNameAdvanceBlock:
+		return p.parseAdvanceBlockStep(stepMap)
+	case mj.StepNameCrossShardRound:
+		return p.parseCrossShardRoundStep(stepMap)
 	case mj.StepNameCheckState:
-		step := &mj.CheckStateStep{}
+		step := &mj.CheckStateStep{
+			StateRootHash: mj.JSONCheckBytesUnspecified(),
+		}
 		for _, kvp := range stepMap.OrderedKV {
 			switch kvp.Key {
 			case "step":
@@ -203,6 +209,11 @@ func (p *Parser) processScenarioStep(stepObj oj.OJsonObject) (mj.Step, error) {
 				if err != nil {
 					return nil, fmt.Errorf("cannot parse check state step: %w", err)
 				}
+			case "stateRootHash":
+				step.StateRootHash, err = p.parseCheckBytes(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid check state root hash: %w", err)
+				}
 			default:
 				return nil, fmt.Errorf("invalid check state field: %s", kvp.Key)
 			}
@@ -3,6 +3,7 @@ package scenjsonparse
 import (
 	"testing"
 
+	mj "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/json/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,3 +52,176 @@ func TestParseScenario(t *testing.T) {
 	require.NotNil(t, step)
 	require.Equal(t, "scCall", step.StepTypeName())
 }
+
+func TestParseAdvanceBlockStep(t *testing.T) {
+	snippet := `
+	{
+		"step": "advanceBlock",
+		"comment": "skip a few blocks",
+		"nonceDelta": "5",
+		"timestampDelta": "30",
+		"epochDelta": "1",
+		"blockHash": "''next_block_hash_______________"
+	}`
+
+	p := Parser{}
+	step, parseErr := p.ParseScenarioStep(snippet)
+	require.Nil(t, parseErr)
+	require.Equal(t, "advanceBlock", step.StepTypeName())
+
+	advanceBlockStep := step.(*mj.AdvanceBlockStep)
+	require.Equal(t, uint64(5), advanceBlockStep.NonceDelta.Value)
+	require.Empty(t, advanceBlockStep.RoundDelta.Original)
+	require.Equal(t, uint64(30), advanceBlockStep.TimestampDelta.Value)
+	require.Equal(t, uint64(1), advanceBlockStep.EpochDelta.Value)
+	require.Nil(t, advanceBlockStep.BlockRandomSeed)
+	require.Equal(t, []byte("next_block_hash_______________"), advanceBlockStep.BlockHash.Value)
+}
+
+func TestParseCrossShardRoundStep(t *testing.T) {
+	snippet := `
+	{
+		"step": "crossShardRound",
+		"comment": "deliver the callback",
+		"expectPending": "1"
+	}`
+
+	p := Parser{}
+	step, parseErr := p.ParseScenarioStep(snippet)
+	require.Nil(t, parseErr)
+	require.Equal(t, "crossShardRound", step.StepTypeName())
+
+	crossShardRoundStep := step.(*mj.CrossShardRoundStep)
+	require.Equal(t, "deliver the callback", crossShardRoundStep.Comment)
+	require.Empty(t, crossShardRoundStep.Rounds.Original)
+	require.Equal(t, uint64(1), crossShardRoundStep.ExpectedPending.Value)
+
+	_, parseErr = p.ParseScenarioStep(`{"step": "crossShardRound", "round": "1"}`)
+	require.NotNil(t, parseErr)
+}
+
+func TestParseCheckStateRootHash(t *testing.T) {
+	p := Parser{}
+	step, parseErr := p.ParseScenarioStep(`{"step": "checkState", "accounts": {}}`)
+	require.Nil(t, parseErr)
+	require.True(t, step.(*mj.CheckStateStep).StateRootHash.IsUnspecified())
+
+	step, parseErr = p.ParseScenarioStep(`{"step": "checkState", "accounts": {}, "stateRootHash": "0x0102"}`)
+	require.Nil(t, parseErr)
+
+	stateRootHash := step.(*mj.CheckStateStep).StateRootHash
+	require.False(t, stateRootHash.IsUnspecified())
+	require.True(t, stateRootHash.Check([]byte{1, 2}))
+	require.False(t, stateRootHash.Check([]byte{1, 3}))
+}
+
+func TestParseSetStateDCDTInstanceMetadata(t *testing.T) {
+	snippet := `
+	{
+		"step": "setState",
+		"accounts": {
+			"address:owner": {
+				"dcdt": {
+					"str:NFT-123456": {
+						"instances": [
+							{
+								"nonce": "1",
+								"balance": "1",
+								"creator": "0x02",
+								"royalties": "1000",
+								"hash": "0x0304",
+								"uri": ["str:first", "str:second"],
+								"attributes": "str:attr"
+							}
+						]
+					}
+				}
+			}
+		}
+	}`
+
+	p := Parser{}
+	step, parseErr := p.ParseScenarioStep(snippet)
+	require.Nil(t, parseErr)
+
+	instance := step.(*mj.SetStateStep).Accounts[0].DCDTData[0].Instances[0]
+	require.Equal(t, []byte{2}, instance.Creator.Value)
+	require.Equal(t, uint64(1000), instance.Royalties.Value)
+	require.Equal(t, []byte{3, 4}, instance.Hash.Value)
+	require.Equal(t, [][]byte{[]byte("first"), []byte("second")}, mj.JSONBytesFromTreeValues(instance.Uris))
+	require.Equal(t, []byte("attr"), instance.Attributes.Value)
+
+	_, parseErr = p.ParseScenarioStep(`{"step": "setState", "accounts": {"address:owner": {"dcdt": {"str:NFT-123456": {"royalties": "0x100000000"}}}}}`)
+	require.NotNil(t, parseErr)
+}
+
+func TestParseCheckStateDCDTInstanceMetadata(t *testing.T) {
+	snippet := `
+	{
+		"step": "checkState",
+		"accounts": {
+			"address:owner": {
+				"dcdt": {
+					"str:NFT-123456": {
+						"nonce": "1",
+						"royalties": "*",
+						"uri": ["str:first", "*"],
+						"attributes": "str:attr"
+					}
+				}
+			}
+		}
+	}`
+
+	p := Parser{}
+	step, parseErr := p.ParseScenarioStep(snippet)
+	require.Nil(t, parseErr)
+
+	instance := step.(*mj.CheckStateStep).CheckAccounts.Accounts[0].CheckDCDTData[0].Instances[0]
+	require.True(t, instance.Creator.IsUnspecified())
+	require.True(t, instance.Hash.IsUnspecified())
+	require.False(t, instance.Royalties.IsUnspecified())
+	require.True(t, instance.Royalties.Check(250))
+	require.True(t, instance.Uris.Check([][]byte{[]byte("first"), []byte("anything")}))
+	require.False(t, instance.Uris.Check([][]byte{[]byte("first")}))
+	require.True(t, instance.Attributes.Check([]byte("attr")))
+	require.False(t, instance.Attributes.Check([]byte("other")))
+}
+
+func TestParseTxMultiDCDTTransfer(t *testing.T) {
+	snippet := `
+	{
+		"step": "transfer",
+		"tx": {
+			"from": "address:owner",
+			"to": "address:receiver",
+			"value": "0",
+			"dcdt": [
+				{
+					"tokenIdentifier": "str:FUNG-123456",
+					"value": "100"
+				},
+				{
+					"tokenIdentifier": "str:NFT-123456",
+					"nonce": "5",
+					"value": "1"
+				}
+			]
+		}
+	}`
+
+	p := Parser{}
+	step, parseErr := p.ParseScenarioStep(snippet)
+	require.Nil(t, parseErr)
+
+	dcdtValue := step.(*mj.TxStep).Tx.DCDTValue
+	require.Len(t, dcdtValue, 2)
+	require.Equal(t, []byte("FUNG-123456"), dcdtValue[0].TokenIdentifier.Value)
+	require.Equal(t, uint64(0), dcdtValue[0].Nonce.Value)
+	require.Equal(t, int64(100), dcdtValue[0].Value.Value.Int64())
+	require.Equal(t, []byte("NFT-123456"), dcdtValue[1].TokenIdentifier.Value)
+	require.Equal(t, uint64(5), dcdtValue[1].Nonce.Value)
+
+	_, parseErr = p.ParseScenarioStep(`{"step": "transfer", "tx": {"from": "address:owner", "to": "address:receiver", "dcdt": []}}`)
+	require.NotNil(t, parseErr)
+}
@@ -78,7 +78,7 @@ func (p *Parser) processTx(txType mj.TransactionType, blrRaw oj.OJsonObject) (*m
 			if !txType.HasDCDT() {
 				return nil, errors.New("`dcdt` not allowed in this context")
 			}
-			blt.DCDTValue, err = p.processTxDCDT(kvp.Value)
+			blt.DCDTValue, err = p.processTxDCDTList(kvp.Value)
 			if err != nil {
 				return nil, fmt.Errorf("invalid transaction DCDT value: %w", err)
 			}
@@ -143,6 +143,18 @@ func checkBytesToOJ(checkBytes mj.JSONCheckBytes) oj.OJsonObject {
 	return checkBytes.Original
 }
 
+func checkValueListToOJ(checkList mj.JSONCheckValueList) oj.OJsonObject {
+	if checkList.IsStar {
+		return &oj.OJsonString{Value: "*"}
+	}
+	convertedList := make([]oj.OJsonObject, 0, len(checkList.Values))
+	for _, value := range checkList.Values {
+		convertedList = append(convertedList, checkBytesToOJ(value))
+	}
+	checkOJList := oj.OJsonList(convertedList)
+	return &checkOJList
+}
+
 func uint64ToOJ(i mj.JSONUint64) oj.OJsonObject {
 	return &oj.OJsonString{Value: i.Original}
 }
@@ -5,6 +5,20 @@ import (
 	oj "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/orderedjson"
 )
 
+// a single token transfer is written as a map, several as a list
+func dcdtTxDataListToOJ(dcdtItems []*mj.DCDTTxData) oj.OJsonObject {
+	if len(dcdtItems) == 1 {
+		return dcdtTxDataToOJ(dcdtItems[0])
+	}
+
+	var convertedList []oj.OJsonObject
+	for _, dcdtItem := range dcdtItems {
+		convertedList = append(convertedList, dcdtTxDataToOJ(dcdtItem))
+	}
+	dcdtOJList := oj.OJsonList(convertedList)
+	return &dcdtOJList
+}
+
 func dcdtTxDataToOJ(dcdtItem *mj.DCDTTxData) *oj.OJsonMap {
 	dcdtItemOJ := oj.NewMap()
 	if len(dcdtItem.TokenIdentifier.Original) > 0 {
@@ -75,6 +89,26 @@ func appendDCDTInstanceToOJ(dcdtInstance *mj.DCDTInstance, targetOj *oj.OJsonMap
 	if len(dcdtInstance.Balance.Original) > 0 {
 		targetOj.Put("balance", bigIntToOJ(dcdtInstance.Balance))
 	}
+	if len(dcdtInstance.Creator.Original) > 0 {
+		targetOj.Put("creator", bytesFromStringToOJ(dcdtInstance.Creator))
+	}
+	if len(dcdtInstance.Royalties.Original) > 0 {
+		targetOj.Put("royalties", uint64ToOJ(dcdtInstance.Royalties))
+	}
+	if dcdtInstance.Hash.Original != nil {
+		targetOj.Put("hash", bytesFromTreeToOJ(dcdtInstance.Hash))
+	}
+	if len(dcdtInstance.Uris) > 0 {
+		var convertedList []oj.OJsonObject
+		for _, uri := range dcdtInstance.Uris {
+			convertedList = append(convertedList, bytesFromTreeToOJ(uri))
+		}
+		urisOJList := oj.OJsonList(convertedList)
+		targetOj.Put("uri", &urisOJList)
+	}
+	if dcdtInstance.Attributes.Original != nil {
+		targetOj.Put("attributes", bytesFromTreeToOJ(dcdtInstance.Attributes))
+	}
 }
 
 func isCompactDCDT(dcdtItem *mj.DCDTData) bool {
@@ -84,6 +118,9 @@ func isCompactDCDT(dcdtItem *mj.DCDTData) bool {
 	if len(dcdtItem.Instances[0].Nonce.Original) > 0 {
 		return false
 	}
+	if hasDCDTInstanceMetadata(dcdtItem.Instances[0]) {
+		return false
+	}
 	if len(dcdtItem.Roles) > 0 {
 		return false
 	}
@@ -92,3 +129,11 @@ func isCompactDCDT(dcdtItem *mj.DCDTData) bool {
 	}
 	return true
 }
+
+func hasDCDTInstanceMetadata(dcdtInstance *mj.DCDTInstance) bool {
+	return len(dcdtInstance.Creator.Original) > 0 ||
+		len(dcdtInstance.Royalties.Original) > 0 ||
+		dcdtInstance.Hash.Original != nil ||
+		len(dcdtInstance.Uris) > 0 ||
+		dcdtInstance.Attributes.Original != nil
+}
@@ -61,6 +61,21 @@ func appendCheckDCDTInstanceToOJ(dcdtInstance *mj.CheckDCDTInstance, targetOj *o
 	if len(dcdtInstance.Balance.Original) > 0 {
 		targetOj.Put("balance", checkBigIntToOJ(dcdtInstance.Balance))
 	}
+	if !dcdtInstance.Creator.IsUnspecified() {
+		targetOj.Put("creator", checkBytesToOJ(dcdtInstance.Creator))
+	}
+	if !dcdtInstance.Royalties.IsUnspecified() {
+		targetOj.Put("royalties", checkUint64ToOJ(dcdtInstance.Royalties))
+	}
+	if !dcdtInstance.Hash.IsUnspecified() {
+		targetOj.Put("hash", checkBytesToOJ(dcdtInstance.Hash))
+	}
+	if !dcdtInstance.Uris.IsUnspecified() {
+		targetOj.Put("uri", checkValueListToOJ(dcdtInstance.Uris))
+	}
+	if !dcdtInstance.Attributes.IsUnspecified() {
+		targetOj.Put("attributes", checkBytesToOJ(dcdtInstance.Attributes))
+	}
 }
 
 func isCompactCheckDCDT(dcdtItem *mj.CheckDCDTData) bool {
@@ -70,6 +85,9 @@ func isCompactCheckDCDT(dcdtItem *mj.CheckDCDTData) bool {
 	if len(dcdtItem.Instances[0].Nonce.Original) > 0 {
 		return false
 	}
+	if hasCheckDCDTInstanceMetadata(dcdtItem.Instances[0]) {
+		return false
+	}
 	if len(dcdtItem.Roles) > 0 {
 		return false
 	}
@@ -78,3 +96,11 @@ func isCompactCheckDCDT(dcdtItem *mj.CheckDCDTData) bool {
 	}
 	return true
 }
+
+func hasCheckDCDTInstanceMetadata(dcdtInstance *mj.CheckDCDTInstance) bool {
+	return !dcdtInstance.Creator.IsUnspecified() ||
+		!dcdtInstance.Royalties.IsUnspecified() ||
+		!dcdtInstance.Hash.IsUnspecified() ||
+		!dcdtInstance.Uris.IsUnspecified() ||
+		!dcdtInstance.Attributes.IsUnspecified()
+}
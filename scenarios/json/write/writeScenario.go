@@ -60,11 +60,29 @@ func ScenarioToOrderedJSON(scenario *mj.Scenario) oj.OJsonObject {
 			if len(step.BlockHashes) > 0 {
 				stepOJ.Put("blockHashes", blockHashesToOJ(step.BlockHashes))
 			}
+		case *mj.AdvanceBlockStep:
+			if len(step.Comment) > 0 {
+				stepOJ.Put("comment", stringToOJ(step.Comment))
+			}
+			appendAdvanceBlockToOJ(step, stepOJ)
+		case *mj.CrossShardRoundStep:
+			if len(step.Comment) > 0 {
+				stepOJ.Put("comment", stringToOJ(step.Comment))
+			}
+			if len(step.Rounds.Original) > 0 {
+				stepOJ.Put("rounds", uint64ToOJ(step.Rounds))
+			}
+			if len(step.ExpectedPending.Original) > 0 {
+				stepOJ.Put("expectPending", uint64ToOJ(step.ExpectedPending))
+			}
 		case *mj.CheckStateStep:
 			if len(step.Comment) > 0 {
 				stepOJ.Put("comment", stringToOJ(step.Comment))
 			}
 			stepOJ.Put("accounts", checkAccountsToOJ(step.CheckAccounts))
+			if !step.StateRootHash.IsUnspecified() {
+				stepOJ.Put("stateRootHash", checkBytesToOJ(step.StateRootHash))
+			}
 		case *mj.DumpStateStep:
 			if len(step.Comment) > 0 {
 				stepOJ.Put("comment", stringToOJ(step.Comment))
@@ -102,9 +120,8 @@ func transactionToScenarioOJ(tx *mj.Transaction) oj.OJsonObject {
 	if tx.Type.HasValue() {
 		transactionOJ.Put("value", bigIntToOJ(tx.Value))
 	}
-	if tx.DCDTValue != nil {
-		dcdtItemOJ := dcdtTxDataToOJ(tx.DCDTValue)
-		transactionOJ.Put("dcdt", dcdtItemOJ)
+	if len(tx.DCDTValue) > 0 {
+		transactionOJ.Put("dcdt", dcdtTxDataListToOJ(tx.DCDTValue))
 	}
 	if tx.Type.HasFunction() {
 		transactionOJ.Put("function", stringToOJ(tx.Function))
@@ -164,6 +181,27 @@ func blockInfoToOJ(blockInfo *mj.BlockInfo) oj.OJsonObject {
 	return blockInfoOJ
 }
 
+func appendAdvanceBlockToOJ(step *mj.AdvanceBlockStep, stepOJ *oj.OJsonMap) {
+	if len(step.NonceDelta.Original) > 0 {
+		stepOJ.Put("nonceDelta", uint64ToOJ(step.NonceDelta))
+	}
+	if len(step.RoundDelta.Original) > 0 {
+		stepOJ.Put("roundDelta", uint64ToOJ(step.RoundDelta))
+	}
+	if len(step.TimestampDelta.Original) > 0 {
+		stepOJ.Put("timestampDelta", uint64ToOJ(step.TimestampDelta))
+	}
+	if len(step.EpochDelta.Original) > 0 {
+		stepOJ.Put("epochDelta", uint64ToOJ(step.EpochDelta))
+	}
+	if step.BlockRandomSeed != nil {
+		stepOJ.Put("blockRandomSeed", bytesFromTreeToOJ(*step.BlockRandomSeed))
+	}
+	if len(step.BlockHash.Original) > 0 {
+		stepOJ.Put("blockHash", bytesFromStringToOJ(step.BlockHash))
+	}
+}
+
 func gasScheduleToOJ(gasSchedule mj.GasSchedule) oj.OJsonObject {
 	switch gasSchedule {
 	case mj.GasScheduleDefault:
@@ -0,0 +1,57 @@
+;; A counter written against the EWASM environment interface, as compiled
+;; Solidity contracts are: it imports only the ethereum namespace, exports its
+;; constructor as "solidity.ctor" and dispatches all the calls from "main", on
+;; the 4 byte selector at the start of the call data. The selectors are the
+;; first bytes of the Keccak-256 of the function names:
+;;   increment = 0xe07a44dd, get = 0x6817c00f
+;;
+;; Memory layout:
+;;   [0, 4)    the selector of the call
+;;   [32, 64)  the storage key of the counter, all zeros
+;;   [64, 96)  the counter, a big endian word
+;;   [96, 112) the error message
+(module
+  (type $i32_result (func (result i32)))
+  (type $i32_i32_i32 (func (param i32 i32 i32)))
+  (type $i32_i32 (func (param i32 i32)))
+  (type $void (func))
+  (import "ethereum" "getCallDataSize" (func $getCallDataSize (type $i32_result)))
+  (import "ethereum" "callDataCopy" (func $callDataCopy (type $i32_i32_i32)))
+  (import "ethereum" "storageLoad" (func $storageLoad (type $i32_i32)))
+  (import "ethereum" "storageStore" (func $storageStore (type $i32_i32)))
+  (import "ethereum" "finish" (func $finish (type $i32_i32)))
+  (import "ethereum" "revert" (func $revert (type $i32_i32)))
+  (memory 1)
+  (export "memory" (memory 0))
+  (export "main" (func $main))
+  (export "solidity.ctor" (func $ctor))
+  (data (i32.const 96) "unknown function")
+
+  ;; the constructor receives the initial value of the counter as its only argument
+  (func $ctor (type $void)
+    (call $callDataCopy (i32.const 64) (i32.const 0) (i32.const 32))
+    (call $storageStore (i32.const 32) (i32.const 64)))
+
+  (func $main (type $void)
+    (if (i32.lt_u (call $getCallDataSize) (i32.const 4))
+      (then
+        (call $revert (i32.const 96) (i32.const 16))
+        (return)))
+    (call $callDataCopy (i32.const 0) (i32.const 0) (i32.const 4))
+    (call $storageLoad (i32.const 32) (i32.const 64))
+
+    ;; increment: only the last byte of the counter is incremented
+    (if (i32.eq (i32.load (i32.const 0)) (i32.const 0xdd447ae0))
+      (then
+        (i32.store8 (i32.const 95)
+          (i32.add (i32.load8_u (i32.const 95)) (i32.const 1)))
+        (call $storageStore (i32.const 32) (i32.const 64))
+        (return)))
+
+    ;; get
+    (if (i32.eq (i32.load (i32.const 0)) (i32.const 0x0fc01768))
+      (then
+        (call $finish (i32.const 64) (i32.const 32))
+        (return)))
+
+    (call $revert (i32.const 96) (i32.const 16))))
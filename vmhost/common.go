@@ -3,6 +3,7 @@ package vmhost
 import (
 	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/config"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/crypto"
 )
 
 const VMVersion = "v1.2"
@@ -25,6 +26,10 @@ const (
 
 	// BreakpointOutOfGas means that Wasmer must stop immediately due to gas being exhausted
 	BreakpointOutOfGas
+
+	// BreakpointExit means that Wasmer must stop immediately because the contract
+	// has finished its execution successfully, without returning from its function
+	BreakpointExit
 )
 
 // AsyncCallExecutionMode encodes the execution modes of an AsyncCall
@@ -54,6 +59,9 @@ const (
 // function of a smart contract
 const CallbackFunctionName = "callBack"
 
+// EEINamespace is the import namespace of the native hooks of the VM
+const EEINamespace = "env"
+
 // ProtectedStoragePrefix is the storage key prefix that will be protected by
 // VM explicitly, and implicitly by the node due to '@'; the
 // protection can be disabled temporarily by the StorageContext
@@ -96,6 +104,10 @@ const (
 	// InitFunctionNameEth specifies the name for the init function on Ethereum
 	InitFunctionNameEth = "solidity.ctor"
 
+	// MainFunctionNameEth specifies the name of the function which dispatches
+	// all the calls to an EWASM contract
+	MainFunctionNameEth = "main"
+
 	// UpgradeFunctionName specifies if the call is an upgradeContract call
 	UpgradeFunctionName = "upgradeContract"
 )
@@ -117,7 +129,35 @@ type VMHostParameters struct {
 	ProtectedKeyPrefix       []byte
 	WasmerSIGSEGVPassthrough bool
 	UseWarmInstance          bool
+	MaxWarmInstances         uint64
 	EnableEpochsHandler      EnableEpochsHandler
+	HookTracer               HookTracer `json:"-"`
+	GasProfilingEnabled      bool
+	VMCrypto                 crypto.VMCrypto `json:"-"`
+}
+
+// WarmInstanceCacheStats holds the statistics of the warm Wasmer instances kept by the runtime,
+// keyed by code hash; Hits and Misses only count the lookups made for existing contract code
+type WarmInstanceCacheStats struct {
+	Capacity  uint64
+	Size      uint64
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// GasEstimate holds the gas needed by a contract call, as measured by VMHost.EstimateGas.
+// GasConsumed includes the gas forwarded to other contracts and the gas locked for
+// async callbacks, which are also reported separately.
+type GasEstimate struct {
+	ReturnCode    vmcommon.ReturnCode
+	VMOutput      *vmcommon.VMOutput
+	GasProvided   uint64
+	GasConsumed   uint64
+	GasForwarded  uint64
+	GasLocked     uint64
+	GasLimit      uint64
+	NumExecutions int
 }
 
 // AsyncCallInfo contains the information required to handle the asynchronous call of another SmartContract
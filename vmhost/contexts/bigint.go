@@ -92,6 +92,13 @@ func (context *bigIntContext) GetOne(handle int32) *big.Int {
 	return context.values[handle]
 }
 
+// PeekOne returns the value at the given handle, if there is one, without
+// creating it otherwise; the handles given to new values are not affected.
+func (context *bigIntContext) PeekOne(handle int32) (*big.Int, bool) {
+	value, ok := context.values[handle]
+	return value, ok
+}
+
 // GetTwo returns the values at the given handles.
 func (context *bigIntContext) GetTwo(handle1 int32, handle2 int32) (*big.Int, *big.Int) {
 	return context.GetOne(handle1), context.GetOne(handle2)
@@ -143,3 +143,22 @@ func TestBigIntContext_PopDiscardIfStackIsEmptyShouldNotPanic(t *testing.T) {
 
 	require.Equal(t, 0, len(bigIntContext.stateStack))
 }
+
+func TestBigIntContext_PeekOne(t *testing.T) {
+	t.Parallel()
+
+	bigIntContext, _ := NewBigIntContext()
+	index1 := bigIntContext.Put(100)
+
+	value, ok := bigIntContext.PeekOne(index1)
+	require.True(t, ok)
+	require.Equal(t, big.NewInt(100), value)
+
+	value, ok = bigIntContext.PeekOne(5)
+	require.False(t, ok)
+	require.Nil(t, value)
+
+	// peeking does not create the handle, so the next handle is unchanged
+	index2 := bigIntContext.Put(200)
+	require.Equal(t, int32(1), index2)
+}
@@ -175,6 +175,25 @@ func (context *blockchainContext) GetCodeSize(address []byte) (int32, error) {
 	return result, nil
 }
 
+// GetCodeMetadata returns the code metadata of the contract at the given address,
+// taking into account a deployment in the current output state.
+func (context *blockchainContext) GetCodeMetadata(address []byte) (vmcommon.CodeMetadata, error) {
+	outputAccount, isNew := context.host.Output().GetOutputAccount(address)
+	if !isNew && len(outputAccount.CodeMetadata) > 0 {
+		return vmcommon.CodeMetadataFromBytes(outputAccount.CodeMetadata), nil
+	}
+
+	account, err := context.blockChainHook.GetUserAccount(address)
+	if err != nil {
+		return vmcommon.CodeMetadata{}, err
+	}
+	if vmhost.IfNil(account) {
+		return vmcommon.CodeMetadata{}, vmhost.ErrInvalidAccount
+	}
+
+	return vmcommon.CodeMetadataFromBytes(account.GetCodeMetadata()), nil
+}
+
 // BlockHash returns the hash of the block that has the given nonce
 func (context *blockchainContext) BlockHash(number int64) []byte {
 	if number < 0 {
@@ -0,0 +1,138 @@
+package contexts
+
+import (
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+)
+
+type managedBufferMap map[int32][]byte
+
+type managedBufferContext struct {
+	values     managedBufferMap
+	stateStack []managedBufferMap
+}
+
+// NewManagedBufferContext creates a new managedBufferContext
+func NewManagedBufferContext() (*managedBufferContext, error) {
+	context := &managedBufferContext{
+		values:     make(managedBufferMap),
+		stateStack: make([]managedBufferMap, 0),
+	}
+
+	return context, nil
+}
+
+// InitState initializes the underlying values map
+func (context *managedBufferContext) InitState() {
+	context.values = make(managedBufferMap)
+}
+
+// PushState appends the values map to the state stack
+func (context *managedBufferContext) PushState() {
+	newState := context.clone()
+	context.stateStack = append(context.stateStack, newState)
+}
+
+// PopSetActiveState removes the latest entry from the state stack and sets it as the current values map
+func (context *managedBufferContext) PopSetActiveState() {
+	stateStackLen := len(context.stateStack)
+	if stateStackLen == 0 {
+		return
+	}
+
+	prevValues := context.stateStack[stateStackLen-1]
+	context.stateStack = context.stateStack[:stateStackLen-1]
+
+	context.values = prevValues
+}
+
+// PopDiscard removes the latest entry from the state stack
+func (context *managedBufferContext) PopDiscard() {
+	stateStackLen := len(context.stateStack)
+	if stateStackLen == 0 {
+		return
+	}
+
+	context.stateStack = context.stateStack[:stateStackLen-1]
+}
+
+// ClearStateStack initializes the state stack
+func (context *managedBufferContext) ClearStateStack() {
+	context.stateStack = make([]managedBufferMap, 0)
+}
+
+func (context *managedBufferContext) clone() managedBufferMap {
+	newState := make(managedBufferMap, len(context.values))
+	for handle, buffer := range context.values {
+		newState[handle] = copyBytes(buffer)
+	}
+	return newState
+}
+
+// NewBuffer adds an empty buffer to the current values map and returns its handle
+func (context *managedBufferContext) NewBuffer() int32 {
+	return context.NewBufferFromBytes(nil)
+}
+
+// NewBufferFromBytes adds a copy of the given bytes to the current values map and returns its handle
+func (context *managedBufferContext) NewBufferFromBytes(bytes []byte) int32 {
+	newHandle := int32(len(context.values))
+	for {
+		if _, ok := context.values[newHandle]; !ok {
+			break
+		}
+		newHandle++
+	}
+
+	context.values[newHandle] = copyBytes(bytes)
+
+	return newHandle
+}
+
+// SetBytes replaces the contents of the buffer at the given handle, creating the buffer if needed
+func (context *managedBufferContext) SetBytes(handle int32, bytes []byte) {
+	context.values[handle] = copyBytes(bytes)
+}
+
+// GetBytes returns the contents of the buffer at the given handle
+func (context *managedBufferContext) GetBytes(handle int32) ([]byte, error) {
+	buffer, ok := context.values[handle]
+	if !ok {
+		return nil, vmhost.ErrNoManagedBufferUnderThisHandle
+	}
+
+	return buffer, nil
+}
+
+// AppendBytes appends the given bytes to the buffer at the given handle;
+// returns false if there is no buffer under that handle
+func (context *managedBufferContext) AppendBytes(handle int32, bytes []byte) bool {
+	buffer, ok := context.values[handle]
+	if !ok {
+		return false
+	}
+
+	context.values[handle] = append(buffer, bytes...)
+	return true
+}
+
+// GetLength returns the length of the buffer at the given handle, or -1 if
+// there is no buffer under that handle
+func (context *managedBufferContext) GetLength(handle int32) int32 {
+	buffer, ok := context.values[handle]
+	if !ok {
+		return -1
+	}
+
+	return int32(len(buffer))
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (context *managedBufferContext) IsInterfaceNil() bool {
+	return context == nil
+}
+
+func copyBytes(bytes []byte) []byte {
+	result := make([]byte, len(bytes))
+	copy(result, bytes)
+	return result
+}
@@ -0,0 +1,144 @@
+package contexts
+
+import (
+	"testing"
+
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewManagedBuffer(t *testing.T) {
+	t.Parallel()
+
+	managedBufferContext, err := NewManagedBufferContext()
+
+	require.Nil(t, err)
+	require.False(t, managedBufferContext.IsInterfaceNil())
+	require.NotNil(t, managedBufferContext.values)
+	require.NotNil(t, managedBufferContext.stateStack)
+	require.Equal(t, 0, len(managedBufferContext.values))
+	require.Equal(t, 0, len(managedBufferContext.stateStack))
+}
+
+func TestManagedBufferContext_InitPushPopState(t *testing.T) {
+	t.Parallel()
+
+	bytes1, bytes2 := []byte("abc"), []byte("defg")
+	managedBufferContext, _ := NewManagedBufferContext()
+	managedBufferContext.InitState()
+
+	// Create 2 buffers on the active state
+	handle1 := managedBufferContext.NewBufferFromBytes(bytes1)
+	require.Equal(t, int32(0), handle1)
+
+	handle2 := managedBufferContext.NewBufferFromBytes(bytes2)
+	require.Equal(t, int32(1), handle2)
+
+	// Copy active state to stack, then clean it. The previous 2 buffers should
+	// not be accessible.
+	managedBufferContext.PushState()
+	require.Equal(t, 1, len(managedBufferContext.stateStack))
+	managedBufferContext.InitState()
+
+	_, err := managedBufferContext.GetBytes(handle1)
+	require.Equal(t, vmhost.ErrNoManagedBufferUnderThisHandle, err)
+	require.Equal(t, int32(-1), managedBufferContext.GetLength(handle2))
+
+	// Modify the new active state; the pushed state must remain untouched
+	handle3 := managedBufferContext.NewBuffer()
+	require.Equal(t, int32(0), handle3)
+	managedBufferContext.SetBytes(handle3, []byte("xyz"))
+
+	// Restore the first state
+	managedBufferContext.PopSetActiveState()
+	require.Equal(t, 0, len(managedBufferContext.stateStack))
+
+	buffer1, err := managedBufferContext.GetBytes(handle1)
+	require.Nil(t, err)
+	require.Equal(t, bytes1, buffer1)
+
+	buffer2, err := managedBufferContext.GetBytes(handle2)
+	require.Nil(t, err)
+	require.Equal(t, bytes2, buffer2)
+
+	// Pushing and discarding keeps the active state
+	managedBufferContext.PushState()
+	require.True(t, managedBufferContext.AppendBytes(handle1, []byte("d")))
+	managedBufferContext.PopDiscard()
+	require.Equal(t, 0, len(managedBufferContext.stateStack))
+
+	buffer1, _ = managedBufferContext.GetBytes(handle1)
+	require.Equal(t, []byte("abcd"), buffer1)
+
+	managedBufferContext.PushState()
+	managedBufferContext.ClearStateStack()
+	require.Equal(t, 0, len(managedBufferContext.stateStack))
+}
+
+func TestManagedBufferContext_PushStateCopiesBuffers(t *testing.T) {
+	t.Parallel()
+
+	managedBufferContext, _ := NewManagedBufferContext()
+	handle := managedBufferContext.NewBufferFromBytes([]byte("abc"))
+
+	managedBufferContext.PushState()
+	require.True(t, managedBufferContext.AppendBytes(handle, []byte("def")))
+	managedBufferContext.PopSetActiveState()
+
+	buffer, err := managedBufferContext.GetBytes(handle)
+	require.Nil(t, err)
+	require.Equal(t, []byte("abc"), buffer)
+}
+
+func TestManagedBufferContext_SetGetAppend(t *testing.T) {
+	t.Parallel()
+
+	managedBufferContext, _ := NewManagedBufferContext()
+
+	handle := managedBufferContext.NewBuffer()
+	require.Equal(t, int32(0), managedBufferContext.GetLength(handle))
+
+	data := []byte("abc")
+	managedBufferContext.SetBytes(handle, data)
+	data[0] = 'z'
+
+	buffer, err := managedBufferContext.GetBytes(handle)
+	require.Nil(t, err)
+	require.Equal(t, []byte("abc"), buffer)
+
+	require.True(t, managedBufferContext.AppendBytes(handle, []byte("def")))
+	require.Equal(t, int32(6), managedBufferContext.GetLength(handle))
+	buffer, _ = managedBufferContext.GetBytes(handle)
+	require.Equal(t, []byte("abcdef"), buffer)
+
+	// SetBytes creates the buffer if the handle is unused
+	managedBufferContext.SetBytes(int32(5), []byte("g"))
+	require.Equal(t, int32(1), managedBufferContext.GetLength(int32(5)))
+
+	// NewBuffer skips handles which are already in use
+	newHandle := managedBufferContext.NewBuffer()
+	require.Equal(t, int32(2), newHandle)
+
+	require.False(t, managedBufferContext.AppendBytes(int32(42), []byte("h")))
+	require.Equal(t, int32(-1), managedBufferContext.GetLength(int32(42)))
+	_, err = managedBufferContext.GetBytes(int32(42))
+	require.Equal(t, vmhost.ErrNoManagedBufferUnderThisHandle, err)
+}
+
+func TestManagedBufferContext_PopSetActiveStateIfStackIsEmptyShouldNotPanic(t *testing.T) {
+	t.Parallel()
+
+	managedBufferContext, _ := NewManagedBufferContext()
+	managedBufferContext.PopSetActiveState()
+
+	require.Equal(t, 0, len(managedBufferContext.stateStack))
+}
+
+func TestManagedBufferContext_PopDiscardIfStackIsEmptyShouldNotPanic(t *testing.T) {
+	t.Parallel()
+
+	managedBufferContext, _ := NewManagedBufferContext()
+	managedBufferContext.PopDiscard()
+
+	require.Equal(t, 0, len(managedBufferContext.stateStack))
+}
@@ -3,6 +3,7 @@ package contexts
 import (
 	"bytes"
 
+	"github.com/kalyan3104/k-chain-core-go/core/check"
 	"github.com/kalyan3104/k-chain-core-go/data/vm"
 	logger "github.com/kalyan3104/k-chain-logger-go"
 	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
@@ -148,6 +149,17 @@ func (context *meteringContext) UseGas(gas uint64) {
 	context.host.Runtime().SetPointsUsed(gasUsed)
 }
 
+// UseGasForCategory sets in the runtime context the given gas as gas used,
+// attributing it to the given category if gas profiling is enabled
+func (context *meteringContext) UseGasForCategory(gas uint64, category vmhost.GasCategory) {
+	context.UseGas(gas)
+
+	gasProfiler := context.host.GasProfiler()
+	if !check.IfNil(gasProfiler) {
+		gasProfiler.AddCategorizedGas(gas, category)
+	}
+}
+
 // RestoreGas subtracts the given gas from the gas used that is set in the runtime context.
 func (context *meteringContext) RestoreGas(gas uint64) {
 	gasUsed := context.host.Runtime().GetPointsUsed()
@@ -1,6 +1,7 @@
 package contexts
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"math/big"
@@ -211,9 +212,81 @@ func (context *outputContext) ClearReturnData() {
 	context.outputState.ReturnData = make([][]byte, 0)
 }
 
-// SelfDestruct does nothing
-// TODO change comment when the function is implemented
-func (context *outputContext) SelfDestruct(_ []byte, _ []byte) {
+// SelfDestruct transfers the remaining balance of the given contract to the
+// beneficiary and marks the contract as deleted in the current output state.
+// Only the contract being executed can destroy itself, and only if it is
+// upgradeable and the caller is its owner, the same rules as for upgrades.
+func (context *outputContext) SelfDestruct(address []byte, beneficiary []byte) error {
+	if context.host.Runtime().ReadOnly() {
+		logOutput.Trace("self destruct", "error", vmhost.ErrInvalidCallOnReadOnlyMode)
+		return vmhost.ErrInvalidCallOnReadOnlyMode
+	}
+
+	err := context.checkSelfDestructPermission(address)
+	if err != nil {
+		logOutput.Trace("self destruct", "error", err)
+		return err
+	}
+
+	if len(beneficiary) == 0 || bytes.Equal(address, beneficiary) {
+		logOutput.Trace("self destruct", "error", vmhost.ErrInvalidBeneficiary)
+		return vmhost.ErrInvalidBeneficiary
+	}
+
+	remainingBalance := context.host.Blockchain().GetBalanceBigInt(address)
+	if remainingBalance.Cmp(vmhost.Zero) > 0 {
+		contractAcc, _ := context.GetOutputAccount(address)
+		beneficiaryAcc, _ := context.GetOutputAccount(beneficiary)
+
+		contractAcc.BalanceDelta = big.NewInt(0).Sub(contractAcc.BalanceDelta, remainingBalance)
+		beneficiaryAcc.BalanceDelta = big.NewInt(0).Add(beneficiaryAcc.BalanceDelta, remainingBalance)
+		beneficiaryAcc.OutputTransfers = append(beneficiaryAcc.OutputTransfers, vmcommon.OutputTransfer{
+			Value:         big.NewInt(0).Set(remainingBalance),
+			CallType:      vm.DirectCall,
+			SenderAddress: address,
+		})
+	}
+
+	context.outputState.DeletedAccounts = appendDeletedAccount(context.outputState.DeletedAccounts, address)
+
+	logOutput.Trace("self destruct", "address", address, "beneficiary", beneficiary, "balance", remainingBalance)
+	return nil
+}
+
+func (context *outputContext) checkSelfDestructPermission(address []byte) error {
+	runtime := context.host.Runtime()
+	blockchain := context.host.Blockchain()
+
+	if !bytes.Equal(address, runtime.GetSCAddress()) {
+		return vmhost.ErrSelfDestructNotAllowed
+	}
+
+	codeMetadata, err := blockchain.GetCodeMetadata(address)
+	if err != nil {
+		return err
+	}
+
+	ownerAddress, err := blockchain.GetOwnerAddress()
+	if err != nil {
+		return err
+	}
+
+	isCallerOwner := bytes.Equal(runtime.GetVMInput().CallerAddr, ownerAddress)
+	if codeMetadata.Upgradeable && isCallerOwner {
+		return nil
+	}
+
+	return vmhost.ErrSelfDestructNotAllowed
+}
+
+func appendDeletedAccount(deletedAccounts [][]byte, address []byte) [][]byte {
+	for _, deletedAddress := range deletedAccounts {
+		if bytes.Equal(deletedAddress, address) {
+			return deletedAccounts
+		}
+	}
+
+	return append(deletedAccounts, address)
 }
 
 // Finish appends the given data to the return data of the current output state.
@@ -617,6 +690,10 @@ func mergeVMOutputs(leftOutput *vmcommon.VMOutput, rightOutput *vmcommon.VMOutpu
 		mergeOutputAccounts(leftAccount, rightAccount)
 	}
 
+	for _, deletedAddress := range rightOutput.DeletedAccounts {
+		leftOutput.DeletedAccounts = appendDeletedAccount(leftOutput.DeletedAccounts, deletedAddress)
+	}
+
 	leftOutput.Logs = append(leftOutput.Logs, rightOutput.Logs...)
 	leftOutput.ReturnData = append(leftOutput.ReturnData, rightOutput.ReturnData...)
 	leftOutput.GasRemaining = rightOutput.GasRemaining
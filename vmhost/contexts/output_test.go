@@ -586,3 +586,127 @@ func TestOutputContext_PopDiscardIfStackIsEmptyShouldNotPanic(t *testing.T) {
 
 	require.Equal(t, 0, len(bigIntContext.stateStack))
 }
+
+func createSelfDestructTestOutputContext(readOnly bool) (*outputContext, *contextmock.RuntimeContextMock, *worldmock.MockWorld) {
+	owner := []byte("owner")
+	contract := []byte("contract")
+
+	host := &contextmock.VMHostMock{}
+	runtime := &contextmock.RuntimeContextMock{
+		VMInput:      &vmcommon.VMInput{CallerAddr: owner},
+		SCAddress:    contract,
+		ReadOnlyFlag: readOnly,
+	}
+	host.RuntimeContext = runtime
+
+	mockWorld := worldmock.NewMockWorld()
+	mockWorld.AcctMap.PutAccount(&worldmock.Account{
+		Address:         contract,
+		Balance:         big.NewInt(1000),
+		Code:            []byte("code"),
+		CodeMetadata:    []byte{vmcommon.MetadataUpgradeable, 0},
+		OwnerAddress:    owner,
+		IsSmartContract: true,
+	})
+
+	blockchainContext, _ := NewBlockchainContext(host, mockWorld)
+	outputContext, _ := NewOutputContext(host)
+
+	host.OutputContext = outputContext
+	host.BlockchainContext = blockchainContext
+
+	return outputContext, runtime, mockWorld
+}
+
+func TestOutputContext_SelfDestruct(t *testing.T) {
+	t.Parallel()
+
+	contract := []byte("contract")
+	beneficiary := []byte("beneficiary")
+
+	outputContext, _, mockWorld := createSelfDestructTestOutputContext(false)
+
+	err := outputContext.TransferValueOnly(beneficiary, contract, big.NewInt(100), false)
+	require.Nil(t, err)
+
+	err = outputContext.SelfDestruct(contract, beneficiary)
+	require.Nil(t, err)
+
+	vmOutput := outputContext.outputState
+	require.Equal(t, [][]byte{contract}, vmOutput.DeletedAccounts)
+	require.Equal(t, big.NewInt(-1000), vmOutput.OutputAccounts[string(contract)].BalanceDelta)
+
+	beneficiaryAccount := vmOutput.OutputAccounts[string(beneficiary)]
+	require.Equal(t, big.NewInt(1000), beneficiaryAccount.BalanceDelta)
+	require.Equal(t, big.NewInt(900), beneficiaryAccount.OutputTransfers[0].Value)
+	require.Equal(t, contract, beneficiaryAccount.OutputTransfers[0].SenderAddress)
+
+	// destroying the contract a second time moves nothing and deletes it once
+	err = outputContext.SelfDestruct(contract, beneficiary)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{contract}, vmOutput.DeletedAccounts)
+	require.Len(t, beneficiaryAccount.OutputTransfers, 1)
+
+	err = mockWorld.UpdateAccounts(vmOutput.OutputAccounts, vmOutput.DeletedAccounts)
+	require.Nil(t, err)
+	require.Nil(t, mockWorld.AcctMap.GetAccount(contract))
+	require.Equal(t, big.NewInt(1000), mockWorld.AcctMap.GetAccount(beneficiary).Balance)
+}
+
+func TestOutputContext_SelfDestruct_Errors(t *testing.T) {
+	t.Parallel()
+
+	contract := []byte("contract")
+	beneficiary := []byte("beneficiary")
+
+	outputContext, _, _ := createSelfDestructTestOutputContext(true)
+	err := outputContext.SelfDestruct(contract, beneficiary)
+	require.Equal(t, vmhost.ErrInvalidCallOnReadOnlyMode, err)
+
+	outputContext, _, _ = createSelfDestructTestOutputContext(false)
+	err = outputContext.SelfDestruct(beneficiary, contract)
+	require.Equal(t, vmhost.ErrSelfDestructNotAllowed, err)
+
+	err = outputContext.SelfDestruct(contract, contract)
+	require.Equal(t, vmhost.ErrInvalidBeneficiary, err)
+
+	err = outputContext.SelfDestruct(contract, nil)
+	require.Equal(t, vmhost.ErrInvalidBeneficiary, err)
+
+	outputContext, runtime, _ := createSelfDestructTestOutputContext(false)
+	runtime.VMInput.CallerAddr = []byte("notTheOwner")
+	err = outputContext.SelfDestruct(contract, beneficiary)
+	require.Equal(t, vmhost.ErrSelfDestructNotAllowed, err)
+
+	outputContext, _, mockWorld := createSelfDestructTestOutputContext(false)
+	mockWorld.AcctMap.GetAccount(contract).CodeMetadata = []byte{0, vmcommon.MetadataPayable}
+	err = outputContext.SelfDestruct(contract, beneficiary)
+	require.Equal(t, vmhost.ErrSelfDestructNotAllowed, err)
+
+	require.Empty(t, outputContext.outputState.DeletedAccounts)
+}
+
+func TestOutputContext_SelfDestruct_NestedStates(t *testing.T) {
+	t.Parallel()
+
+	contract := []byte("contract")
+	beneficiary := []byte("beneficiary")
+
+	outputContext, _, _ := createSelfDestructTestOutputContext(false)
+
+	// a failed nested call must not leave the account deleted
+	outputContext.PushState()
+	err := outputContext.SelfDestruct(contract, beneficiary)
+	require.Nil(t, err)
+	outputContext.PopSetActiveState()
+	require.Empty(t, outputContext.outputState.DeletedAccounts)
+
+	// a successful nested call merges the deletion into the parent state
+	outputContext.PushState()
+	outputContext.CensorVMOutput()
+	err = outputContext.SelfDestruct(contract, beneficiary)
+	require.Nil(t, err)
+	outputContext.PopMergeActiveState()
+	require.Equal(t, [][]byte{contract}, outputContext.outputState.DeletedAccounts)
+	require.Equal(t, big.NewInt(1000), outputContext.outputState.OutputAccounts[string(beneficiary)].BalanceDelta)
+}
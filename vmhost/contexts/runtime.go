@@ -22,6 +22,9 @@ var _ vmhost.RuntimeContext = (*runtimeContext)(nil)
 const MaxMemoryGrow = uint64(10)
 const MaxMemoryGrowDelta = uint64(10)
 
+// the size of the pages by which the WASM memory grows
+const wasmPageSize = uint64(65536)
+
 type runtimeContext struct {
 	host         vmhost.VMHost
 	instance     wasmer.InstanceHandler
@@ -44,27 +47,27 @@ type runtimeContext struct {
 
 	validator *wasmValidator
 
-	useWarmInstance     bool
-	warmInstanceAddress []byte
-	warmInstance        wasmer.InstanceHandler
+	useWarmInstance bool
+	warmInstances   *warmInstanceCache
 
 	instanceBuilder vmhost.InstanceBuilder
 }
 
 // NewRuntimeContext creates a new runtimeContext
 func NewRuntimeContext(host vmhost.VMHost, vmType []byte, useWarmInstance bool) (*runtimeContext, error) {
-	scAPINames := host.GetAPIMethods().Names()
+	// only the names of the native hooks are reserved, the hooks of the
+	// ethereum namespace must not prevent contracts from exporting e.g. "call"
+	scAPINames := host.GetAPIMethods().NamespaceNames(vmhost.EEINamespace)
 	protocolBuiltinFunctions := host.GetProtocolBuiltinFunctions()
 
 	context := &runtimeContext{
-		host:                host,
-		vmType:              vmType,
-		stateStack:          make([]*runtimeContext, 0),
-		instanceStack:       make([]wasmer.InstanceHandler, 0),
-		validator:           newWASMValidator(scAPINames, protocolBuiltinFunctions),
-		useWarmInstance:     useWarmInstance,
-		warmInstanceAddress: nil,
-		warmInstance:        nil,
+		host:            host,
+		vmType:          vmType,
+		stateStack:      make([]*runtimeContext, 0),
+		instanceStack:   make([]wasmer.InstanceHandler, 0),
+		validator:       newWASMValidator(scAPINames, protocolBuiltinFunctions),
+		useWarmInstance: useWarmInstance,
+		warmInstances:   newWarmInstanceCache(DefaultMaxWarmInstances),
 	}
 
 	context.instanceBuilder = &wasmerInstanceBuilder{}
@@ -96,21 +99,28 @@ func (context *runtimeContext) ReplaceInstanceBuilder(builder vmhost.InstanceBui
 	context.instanceBuilder = builder
 }
 
-func (context *runtimeContext) setWarmInstanceWhenNeeded(gasLimit uint64) bool {
-	scAddress := context.GetSCAddress()
-	useWarm := context.useWarmInstance && context.warmInstanceAddress != nil && bytes.Equal(scAddress, context.warmInstanceAddress)
-	if scAddress != nil && useWarm {
-		logRuntime.Trace("reusing warm instance")
-
-		context.instance = context.warmInstance
-		context.SetPointsUsed(0)
-		context.instance.SetGasLimit(gasLimit)
+func (context *runtimeContext) setWarmInstanceWhenNeeded(codeHash []byte, gasLimit uint64) bool {
+	if !context.useWarmInstance || len(codeHash) == 0 {
+		return false
+	}
 
-		context.SetRuntimeBreakpointValue(vmhost.BreakpointNone)
-		return true
+	// the instances of the callers are on the instance stack, so the current
+	// instance has finished its execution and can be reused
+	warmInstance, found := context.warmInstances.get(codeHash)
+	if !found || context.isInstancePaused(warmInstance) {
+		context.warmInstances.recordMiss()
+		return false
 	}
 
-	return false
+	logRuntime.Trace("reusing warm instance")
+	context.warmInstances.recordHit()
+
+	context.instance = warmInstance
+	context.SetPointsUsed(0)
+	context.instance.SetGasLimit(gasLimit)
+
+	context.SetRuntimeBreakpointValue(vmhost.BreakpointNone)
+	return true
 }
 
 // StartWasmerInstance creates a new wasmer instance if the maxWasmerInstances has not been reached.
@@ -121,13 +131,19 @@ func (context *runtimeContext) StartWasmerInstance(contract []byte, gasLimit uin
 		return vmhost.ErrMaxInstancesReached
 	}
 
-	warmInstanceUsed := context.setWarmInstanceWhenNeeded(gasLimit)
-	if warmInstanceUsed {
-		return nil
-	}
-
 	blockchain := context.host.Blockchain()
 	codeHash := blockchain.GetCodeHash(context.GetSCAddress())
+
+	if newCode {
+		// on upgrade, the code hash still belongs to the code being replaced
+		context.invalidateWarmInstance(codeHash)
+	} else {
+		warmInstanceUsed := context.setWarmInstanceWhenNeeded(codeHash, gasLimit)
+		if warmInstanceUsed {
+			return nil
+		}
+	}
+
 	compiledCodeUsed := context.makeInstanceFromCompiledCode(codeHash, gasLimit, newCode)
 	if compiledCodeUsed {
 		return nil
@@ -173,6 +189,7 @@ func (context *runtimeContext) makeInstanceFromCompiledCode(codeHash []byte, gas
 	hostReference := uintptr(unsafe.Pointer(&context.host))
 	context.instance.SetContextData(hostReference)
 	context.verifyCode = false
+	context.addWarmInstance(codeHash)
 
 	logRuntime.Trace("new instance created", "code", "cached compilation")
 	return true
@@ -180,6 +197,15 @@ func (context *runtimeContext) makeInstanceFromCompiledCode(codeHash []byte, gas
 
 func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte, codeHash []byte, gasLimit uint64, newCode bool) error {
 	gasSchedule := context.host.Metering().GasSchedule()
+	if newCode {
+		err := context.validator.verifyCodePolicy(contract, &gasSchedule.WASMCodePolicy)
+		if err != nil {
+			context.instance = nil
+			logRuntime.Trace("instance creation", "code", "bytecode", "error", err)
+			return err
+		}
+	}
+
 	options := wasmer.CompilationOptions{
 		GasLimit:           gasLimit,
 		UnmeteredLocals:    uint64(gasSchedule.WASMOpcodeCost.LocalsUnmetered),
@@ -221,11 +247,7 @@ func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte,
 		}
 	}
 
-	if context.useWarmInstance {
-		context.warmInstanceAddress = context.GetSCAddress()
-		context.warmInstance = context.instance
-		logRuntime.Trace("updated warm instance")
-	}
+	context.addWarmInstance(codeHash)
 
 	logRuntime.Trace("new instance created", "code", "bytecode")
 
@@ -260,27 +282,100 @@ func (context *runtimeContext) saveCompiledCode(codeHash []byte) {
 	blockchain.SaveCompiledCode(codeHash, compiledCode)
 }
 
-// IsWarmInstance returns true if there is a warm instance equal to the current wasmer instance.
+// IsWarmInstance returns true if the current wasmer instance is one of the warm instances.
 func (context *runtimeContext) IsWarmInstance() bool {
-	if context.instance != nil && context.instance == context.warmInstance {
+	if context.instance != nil && context.warmInstances.contains(context.instance) {
 		return true
 	}
 
 	return false
 }
 
-// ResetWarmInstance clears the fields for the current wasmer instance, warm instance, and warm instance address
+// ResetWarmInstance cleans the current wasmer instance and all the warm instances
 func (context *runtimeContext) ResetWarmInstance() {
-	if context.instance == nil {
-		return
+	if context.instance != nil {
+		context.instance.Clean()
 	}
 
-	context.instance.Clean()
+	for _, warmInstance := range context.warmInstances.clear() {
+		if warmInstance != context.instance {
+			context.cleanUnusedInstance(warmInstance)
+		}
+	}
 
 	context.instance = nil
-	context.warmInstanceAddress = nil
-	context.warmInstance = nil
-	logRuntime.Trace("warm instance cleaned")
+	logRuntime.Trace("warm instances cleaned")
+}
+
+// SetMaxWarmInstanceCount sets the number of warm instances kept, cleaning the
+// least recently used ones which no longer fit
+func (context *runtimeContext) SetMaxWarmInstanceCount(maxWarmInstances uint64) {
+	for _, evicted := range context.warmInstances.setCapacity(maxWarmInstances) {
+		context.cleanUnusedInstance(evicted)
+	}
+}
+
+// WarmInstanceCacheStats returns the statistics of the warm instances
+func (context *runtimeContext) WarmInstanceCacheStats() vmhost.WarmInstanceCacheStats {
+	return context.warmInstances.statistics()
+}
+
+// addWarmInstance makes the current instance the warm instance for the given code
+func (context *runtimeContext) addWarmInstance(codeHash []byte) {
+	if !context.useWarmInstance || len(codeHash) == 0 {
+		return
+	}
+
+	for _, evicted := range context.warmInstances.put(codeHash, context.instance) {
+		context.cleanUnusedInstance(evicted)
+	}
+	logRuntime.Trace("updated warm instance")
+}
+
+// invalidateWarmInstance discards the warm instance for the given code, if any
+func (context *runtimeContext) invalidateWarmInstance(codeHash []byte) {
+	if len(codeHash) == 0 {
+		return
+	}
+
+	warmInstance, found := context.warmInstances.remove(codeHash)
+	if !found {
+		return
+	}
+
+	if warmInstance == context.instance {
+		// the replaced code ran last, its instance is about to be replaced
+		context.instance = nil
+	}
+	context.cleanUnusedInstance(warmInstance)
+	logRuntime.Trace("warm instance invalidated")
+}
+
+// cleanUnusedInstance cleans an instance which is no longer warm; the instances
+// still in use are cleaned when their execution ends, like any cold instance
+func (context *runtimeContext) cleanUnusedInstance(instance wasmer.InstanceHandler) {
+	if context.isInstanceInUse(instance) {
+		return
+	}
+
+	instance.Clean()
+}
+
+// isInstanceInUse returns true if the instance is the current one or
+// one of the instances whose execution is paused by a nested call
+func (context *runtimeContext) isInstanceInUse(instance wasmer.InstanceHandler) bool {
+	return instance == context.instance || context.isInstancePaused(instance)
+}
+
+// isInstancePaused returns true if the execution of the instance is paused by a nested call
+func (context *runtimeContext) isInstancePaused(instance wasmer.InstanceHandler) bool {
+	for _, stackedInstance := range context.instanceStack {
+		if instance == stackedInstance {
+			return true
+		}
+	}
+
+	return false
 }
 
 // MustVerifyNextContractCode sets the verifyCode field to true
@@ -593,6 +688,11 @@ func (context *runtimeContext) VerifyContractCode() error {
 }
 
 func (context *runtimeContext) checkBackwardCompatibility() error {
+	err := context.checkOptionalImports()
+	if err != nil {
+		return err
+	}
+
 	if context.host.IsDCDTFunctionsEnabled() {
 		return nil
 	}
@@ -637,6 +737,120 @@ func (context *runtimeContext) checkBackwardCompatibility() error {
 	return nil
 }
 
+// ethereumOnlyImports are the ethereum VM hooks whose names no other namespace
+// uses; the ones sharing their name with a VM hook of the environment cannot be
+// told apart by name, but the EWASM contracts also need the main entry point,
+// which is disabled together with them
+var ethereumOnlyImports = []string{
+	"call",
+	"callCode",
+	"callDataCopy",
+	"callDelegate",
+	"callStatic",
+	"codeCopy",
+	"create",
+	"externalCodeCopy",
+	"getAddress",
+	"getBlockCoinbase",
+	"getBlockDifficulty",
+	"getBlockGasLimit",
+	"getBlockNumber",
+	"getCallDataSize",
+	"getCodeSize",
+	"getExternalCodeSize",
+	"getTxGasPrice",
+	"getTxOrigin",
+	"log",
+	"returnDataCopy",
+	"revert",
+	"useGas",
+}
+
+// bigIntExtendedImports are the big int VM hooks added after the basic arithmetic ones
+var bigIntExtendedImports = []string{
+	"bigIntPow",
+	"bigIntSqrt",
+	"bigIntLog2",
+	"bigIntMin",
+	"bigIntMax",
+}
+
+// secp256k1ExtendedImports are the secp256k1 VM hooks added after the signature verification
+var secp256k1ExtendedImports = []string{
+	"recoverSecp256k1",
+	"encodeSecp256k1DerSignature",
+	"decodeSecp256k1DerSignature",
+}
+
+// blsMultiSigImports are the BLS VM hooks verifying the signatures of several keys
+var blsMultiSigImports = []string{
+	"verifyBLSAggregatedSignature",
+	"verifyBLSMultiSig",
+}
+
+// multiDCDTTransferImports are the VM hooks reading the DCDT transfers by index
+var multiDCDTTransferImports = []string{
+	"getNumDCDTTransfers",
+	"getDCDTValueByIndex",
+	"getDCDTTokenNameByIndex",
+	"getDCDTTokenNonceByIndex",
+	"getDCDTTokenTypeByIndex",
+}
+
+// managedBufferImports are the managed buffer VM hooks
+var managedBufferImports = []string{
+	"mBufferNew",
+	"mBufferNewFromBytes",
+	"mBufferGetLength",
+	"mBufferGetBytes",
+	"mBufferSetBytes",
+	"mBufferAppend",
+	"mBufferAppendBytes",
+	"mBufferStorageStore",
+	"mBufferStorageLoad",
+	"mBufferGetArgument",
+	"mBufferFinish",
+}
+
+// checkOptionalImports rejects the contracts importing the VM hooks of the
+// features whose optional flags are not enabled yet
+func (context *runtimeContext) checkOptionalImports() error {
+	if !context.host.IsSelfDestructEnabled() && context.instance.IsFunctionImported("selfDestruct") {
+		return vmhost.ErrContractInvalid
+	}
+	if !context.host.IsEthereumAPIEnabled() && context.isAnyFunctionImported(ethereumOnlyImports) {
+		return vmhost.ErrContractInvalid
+	}
+	if !context.host.IsManagedBufferAPIEnabled() && context.isAnyFunctionImported(managedBufferImports) {
+		return vmhost.ErrContractInvalid
+	}
+	if !context.host.IsBigIntExtendedAPIEnabled() && context.isAnyFunctionImported(bigIntExtendedImports) {
+		return vmhost.ErrContractInvalid
+	}
+	if !context.host.IsSecp256k1ExtendedAPIEnabled() && context.isAnyFunctionImported(secp256k1ExtendedImports) {
+		return vmhost.ErrContractInvalid
+	}
+	if !context.host.IsBLSMultiSigAPIEnabled() && context.isAnyFunctionImported(blsMultiSigImports) {
+		return vmhost.ErrContractInvalid
+	}
+	if !context.host.IsMultiDCDTTransferEnabled() && context.isAnyFunctionImported(multiDCDTTransferImports) {
+		return vmhost.ErrContractInvalid
+	}
+
+	return nil
+}
+
+// isAnyFunctionImported returns true if the current instance imports any of the given functions
+func (context *runtimeContext) isAnyFunctionImported(names []string) bool {
+	for _, name := range names {
+		if context.instance.IsFunctionImported(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // BaseOpsErrorShouldFailExecution returns true
 func (context *runtimeContext) BaseOpsErrorShouldFailExecution() bool {
 	return true
@@ -725,6 +939,12 @@ func (context *runtimeContext) GetFunctionToCall() (wasmer.ExportedFunctionCallb
 		return nil, vmhost.ErrNilCallbackFunction
 	}
 
+	// EWASM contracts dispatch all the calls from their main function, by
+	// reading the selector from the call data
+	if context.host.IsEthereumAPIEnabled() && context.isEthereumContract() {
+		return exports[vmhost.MainFunctionNameEth], nil
+	}
+
 	return nil, vmhost.ErrFuncNotFound
 }
 
@@ -734,10 +954,29 @@ func (context *runtimeContext) GetInitFunction() wasmer.ExportedFunctionCallback
 	if init, ok := exports[vmhost.InitFunctionName]; ok {
 		return init
 	}
+	if !context.host.IsEthereumAPIEnabled() {
+		return nil
+	}
+	if init, ok := exports[vmhost.InitFunctionNameEth]; ok {
+		return init
+	}
 
 	return nil
 }
 
+// isEthereumContract returns true if the current instance is an EWASM
+// contract, which exports a main function and reads its call data through
+// the ethereum namespace
+func (context *runtimeContext) isEthereumContract() bool {
+	_, hasMain := context.instance.GetExports()[vmhost.MainFunctionNameEth]
+	if !hasMain {
+		return false
+	}
+
+	return context.instance.IsFunctionImported("getCallDataSize") ||
+		context.instance.IsFunctionImported("callDataCopy")
+}
+
 // ExecuteAsyncCall locks the necessary gas and sets the async call info and a runtime breakpoint value.
 func (context *runtimeContext) ExecuteAsyncCall(address []byte, data []byte, value []byte) error {
 	metering := context.host.Metering()
@@ -916,6 +1155,29 @@ func (context *runtimeContext) MemStore(offset int32, data []byte) error {
 	return nil
 }
 
+// CheckMemStoreBounds returns the error MemStore would return for storing
+// length bytes at the given offset, without allocating or storing them.
+// MemStore grows the memory by at most one page.
+func (context *runtimeContext) CheckMemStoreBounds(offset int32, length int32) error {
+	if length < 0 {
+		return vmhost.ErrNegativeLength
+	}
+	if length == 0 {
+		return nil
+	}
+	if offset < 0 {
+		return vmhost.ErrBadLowerBounds
+	}
+
+	memoryLength := uint64(context.instance.GetInstanceCtxMemory().Length())
+	requestedEnd := uint64(offset) + uint64(length)
+	if requestedEnd > memoryLength+wasmPageSize {
+		return vmhost.ErrBadUpperBounds
+	}
+
+	return nil
+}
+
 // SetWarmInstance overwrites the warm Wasmer instance with the provided one.
 // TODO remove after implementing proper mocking of Wasmer instances; this is
 // used for tests only
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"testing"
 
@@ -22,12 +23,15 @@ import (
 
 const WASMPageSize = 65536
 const counterWasmCode = "./../../test/contracts/counter/output/counter.wasm"
+const ewasmCounterWasmCode = "./../../test/features/ewasm-counter/output/ewasm-counter.wasm"
 
 func MakeAPIImports() *wasmer.Imports {
 	imports, _ := vmhooks.BaseOpsAPIImports()
 	imports, _ = vmhooks.BigIntImports(imports)
 	imports, _ = vmhooks.SmallIntImports(imports)
+	imports, _ = vmhooks.ManagedBufferImports(imports)
 	imports, _ = cryptoapi.CryptoImports(imports)
+	imports, _ = vmhooks.EthereumImports(imports)
 	return imports
 }
 
@@ -153,6 +157,132 @@ func TestRuntimeContext_IsFunctionImported(t *testing.T) {
 	require.False(t, runtimeContext.IsFunctionImported("doesNotExist"))
 }
 
+// optionalFlagsHostMock is a VMHostMock whose optional flags are all disabled,
+// unless listed in enabledFlags
+type optionalFlagsHostMock struct {
+	*contextmock.VMHostMock
+	enabledFlags map[string]bool
+}
+
+func (host *optionalFlagsHostMock) IsSelfDestructEnabled() bool {
+	return host.enabledFlags["selfDestruct"]
+}
+
+func (host *optionalFlagsHostMock) IsEthereumAPIEnabled() bool {
+	return host.enabledFlags["ethereum"]
+}
+
+func (host *optionalFlagsHostMock) IsManagedBufferAPIEnabled() bool {
+	return host.enabledFlags["managedBuffer"]
+}
+
+func (host *optionalFlagsHostMock) IsBigIntExtendedAPIEnabled() bool {
+	return host.enabledFlags["bigIntExtended"]
+}
+
+func (host *optionalFlagsHostMock) IsSecp256k1ExtendedAPIEnabled() bool {
+	return host.enabledFlags["secp256k1Extended"]
+}
+
+func (host *optionalFlagsHostMock) IsBLSMultiSigAPIEnabled() bool {
+	return host.enabledFlags["blsMultiSig"]
+}
+
+func (host *optionalFlagsHostMock) IsMultiDCDTTransferEnabled() bool {
+	return host.enabledFlags["multiDCDTTransfer"]
+}
+
+func newOptionalFlagsRuntime(t *testing.T) (*runtimeContext, *optionalFlagsHostMock) {
+	host := &optionalFlagsHostMock{
+		VMHostMock:   InitializeVMAndWasmer(),
+		enabledFlags: make(map[string]bool),
+	}
+	runtimeContext, err := NewRuntimeContext(host, []byte("type"), false)
+	require.Nil(t, err)
+	return runtimeContext, host
+}
+
+func TestRuntimeContext_CheckBackwardCompatibility_OptionalImports(t *testing.T) {
+	optionalImports := map[string][]string{
+		"selfDestruct":      {"selfDestruct"},
+		"ethereum":          ethereumOnlyImports,
+		"managedBuffer":     managedBufferImports,
+		"bigIntExtended":    bigIntExtendedImports,
+		"secp256k1Extended": secp256k1ExtendedImports,
+		"blsMultiSig":       blsMultiSigImports,
+		"multiDCDTTransfer": multiDCDTTransferImports,
+	}
+
+	for flag, imports := range optionalImports {
+		for _, imported := range imports {
+			runtimeContext, host := newOptionalFlagsRuntime(t)
+
+			instance := contextmock.NewInstanceMock([]byte("contract"))
+			instance.AddMockMethod(imported, func() {})
+			runtimeContext.instance = instance
+
+			require.Equal(t, vmhost.ErrContractInvalid, runtimeContext.checkBackwardCompatibility(), imported)
+
+			host.enabledFlags[flag] = true
+			require.Nil(t, runtimeContext.checkBackwardCompatibility(), imported)
+		}
+	}
+}
+
+func TestRuntimeContext_EthereumEntryPointsNeedTheirFlag(t *testing.T) {
+	runtimeContext, host := newOptionalFlagsRuntime(t)
+
+	instance := contextmock.NewInstanceMock([]byte("contract"))
+	instance.AddMockMethod(vmhost.InitFunctionNameEth, func() {})
+	instance.AddMockMethod(vmhost.MainFunctionNameEth, func() {})
+	instance.AddMockMethod("getCallDataSize", func() {})
+	runtimeContext.instance = instance
+	runtimeContext.SetCustomCallFunction("transfer")
+
+	require.Nil(t, runtimeContext.GetInitFunction())
+	function, err := runtimeContext.GetFunctionToCall()
+	require.Nil(t, function)
+	require.Equal(t, vmhost.ErrFuncNotFound, err)
+
+	host.enabledFlags["ethereum"] = true
+	require.NotNil(t, runtimeContext.GetInitFunction())
+	function, err = runtimeContext.GetFunctionToCall()
+	require.Nil(t, err)
+	require.NotNil(t, function)
+}
+
+func TestRuntimeContext_EthereumContract(t *testing.T) {
+	host := InitializeVMAndWasmer()
+	vmType := []byte("type")
+
+	runtimeContext, err := NewRuntimeContext(host, vmType, false)
+	require.Nil(t, err)
+
+	runtimeContext.SetMaxInstanceCount(1)
+
+	gasLimit := uint64(100000000)
+	contractCode := vmhost.GetSCCode(ewasmCounterWasmCode)
+	err = runtimeContext.StartWasmerInstance(contractCode, gasLimit, false)
+	require.Nil(t, err)
+
+	require.True(t, runtimeContext.IsFunctionImported("getCallDataSize"))
+	require.NotNil(t, runtimeContext.GetInitFunction())
+
+	// any function is dispatched by main
+	runtimeContext.SetCustomCallFunction("increment")
+	function, err := runtimeContext.GetFunctionToCall()
+	require.Nil(t, err)
+	require.NotNil(t, function)
+
+	runtimeContext.SetCustomCallFunction(vmhost.CallbackFunctionName)
+	_, err = runtimeContext.GetFunctionToCall()
+	require.Equal(t, vmhost.ErrNilCallbackFunction, err)
+
+	// the hooks of the ethereum namespace are not reserved names
+	require.False(t, runtimeContext.validator.reserved.IsReserved("getCallDataSize"))
+	require.True(t, runtimeContext.validator.reserved.IsReserved("int64finish"))
+}
+
 func TestRuntimeContext_StateSettersAndGetters(t *testing.T) {
 	imports := MakeAPIImports()
 	host := &contextmock.VMHostMock{}
@@ -588,6 +718,35 @@ func TestRuntimeContext_MemStoreCases(t *testing.T) {
 	require.Equal(t, []byte("this is something"), memContents)
 }
 
+func TestRuntimeContext_CheckMemStoreBounds(t *testing.T) {
+	host := InitializeVMAndWasmer()
+
+	vmType := []byte("type")
+	runtimeContext, _ := NewRuntimeContext(host, vmType, false)
+	runtimeContext.SetMaxInstanceCount(1)
+
+	gasLimit := uint64(100000000)
+	path := counterWasmCode
+	contractCode := vmhost.GetSCCode(path)
+	err := runtimeContext.StartWasmerInstance(contractCode, gasLimit, false)
+	require.Nil(t, err)
+
+	pageSize := int32(65536)
+	memory := runtimeContext.instance.GetMemory()
+	require.Equal(t, uint32(2*pageSize), memory.Length())
+
+	require.Nil(t, runtimeContext.CheckMemStoreBounds(0, 2*pageSize))
+	require.Nil(t, runtimeContext.CheckMemStoreBounds(2*pageSize-4, pageSize+4))
+	require.Nil(t, runtimeContext.CheckMemStoreBounds(-1, 0))
+	require.True(t, errors.Is(runtimeContext.CheckMemStoreBounds(-1, 4), vmhost.ErrBadLowerBounds))
+	require.True(t, errors.Is(runtimeContext.CheckMemStoreBounds(2*pageSize-4, pageSize+5), vmhost.ErrBadUpperBounds))
+	require.True(t, errors.Is(runtimeContext.CheckMemStoreBounds(0, math.MaxInt32), vmhost.ErrBadUpperBounds))
+	require.True(t, errors.Is(runtimeContext.CheckMemStoreBounds(0, -1), vmhost.ErrNegativeLength))
+
+	// the memory is left untouched
+	require.Equal(t, uint32(2*pageSize), memory.Length())
+}
+
 func TestRuntimeContext_MemLoadStoreVsInstanceStack(t *testing.T) {
 	host := InitializeVMAndWasmer()
 
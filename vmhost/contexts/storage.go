@@ -98,13 +98,13 @@ func (context *storageContext) GetStorage(key []byte) []byte {
 	extraBytes := len(key) - vmhost.AddressLen
 	if extraBytes > 0 {
 		gasToUse := math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(extraBytes))
-		metering.UseGas(gasToUse)
+		metering.UseGasForCategory(gasToUse, vmhost.GasCategoryStoragePerByte)
 	}
 
 	value := context.GetStorageUnmetered(key)
 
 	gasToUse := math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(value)))
-	metering.UseGas(gasToUse)
+	metering.UseGasForCategory(gasToUse, vmhost.GasCategoryStoragePerByte)
 
 	logStorage.Trace("get", "key", key, "value", value)
 
@@ -118,7 +118,7 @@ func (context *storageContext) GetStorageFromAddress(address []byte, key []byte)
 	extraBytes := len(key) - vmhost.AddressLen
 	if extraBytes > 0 {
 		gasToUse := math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(extraBytes))
-		metering.UseGas(gasToUse)
+		metering.UseGasForCategory(gasToUse, vmhost.GasCategoryStoragePerByte)
 	}
 
 	if !bytes.Equal(address, context.address) {
@@ -147,7 +147,7 @@ func (context *storageContext) GetStorageFromAddress(address []byte, key []byte)
 
 	costPerByte := metering.GasSchedule().BaseOperationCost.DataCopyPerByte
 	gasToUse := math.MulUint64(costPerByte, uint64(len(value)))
-	metering.UseGas(gasToUse)
+	metering.UseGasForCategory(gasToUse, vmhost.GasCategoryStoragePerByte)
 
 	logStorage.Trace("get from address", "address", address, "key", key, "value", value)
 	return value
@@ -220,7 +220,7 @@ func (context *storageContext) SetStorage(key []byte, value []byte) (vmhost.Stor
 	extraBytes := len(key) - vmhost.AddressLen
 	if extraBytes > 0 {
 		gasToUse := math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(extraBytes))
-		metering.UseGas(gasToUse)
+		metering.UseGasForCategory(gasToUse, vmhost.GasCategoryStoragePerByte)
 	}
 
 	var zero []byte
@@ -242,7 +242,7 @@ func (context *storageContext) SetStorage(key []byte, value []byte) (vmhost.Stor
 	lengthOldValue := len(oldValue)
 	if bytes.Equal(oldValue, value) {
 		useGas := math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(length))
-		metering.UseGas(useGas)
+		metering.UseGasForCategory(useGas, vmhost.GasCategoryStoragePerByte)
 		logStorage.Trace("storage set to identical value")
 		return vmhost.StorageUnchanged, nil
 	}
@@ -256,7 +256,7 @@ func (context *storageContext) SetStorage(key []byte, value []byte) (vmhost.Stor
 
 	if bytes.Equal(oldValue, zero) {
 		useGas := math.MulUint64(metering.GasSchedule().BaseOperationCost.StorePerByte, uint64(length))
-		metering.UseGas(useGas)
+		metering.UseGasForCategory(useGas, vmhost.GasCategoryStoragePerByte)
 		logStorage.Trace("storage added", "key", key, "value", value)
 		return vmhost.StorageAdded, nil
 	}
@@ -274,14 +274,14 @@ func (context *storageContext) SetStorage(key []byte, value []byte) (vmhost.Stor
 		newValStoreUseGas := math.MulUint64(metering.GasSchedule().BaseOperationCost.StorePerByte, uint64(newValueExtraLength))
 		gasUsed := math.AddUint64(useGas, newValStoreUseGas)
 
-		metering.UseGas(gasUsed)
+		metering.UseGasForCategory(gasUsed, vmhost.GasCategoryStoragePerByte)
 	}
 
 	if newValueExtraLength < 0 {
 		newValueExtraLength = -newValueExtraLength
 
 		useGas := math.MulUint64(metering.GasSchedule().BaseOperationCost.PersistPerByte, uint64(length))
-		metering.UseGas(useGas)
+		metering.UseGasForCategory(useGas, vmhost.GasCategoryStoragePerByte)
 
 		freeGas := math.MulUint64(metering.GasSchedule().BaseOperationCost.ReleasePerByte, uint64(newValueExtraLength))
 		metering.FreeGas(freeGas)
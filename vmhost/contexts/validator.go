@@ -5,6 +5,7 @@ import (
 	"unicode"
 
 	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/config"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/wasmer"
 )
@@ -31,6 +32,74 @@ func (validator *wasmValidator) verifyMemoryDeclaration(instance wasmer.Instance
 	return nil
 }
 
+// verifyCodePolicy checks the bytecode of a contract being deployed or upgraded
+// against the WASM code policy, naming the first violation found
+func (validator *wasmValidator) verifyCodePolicy(code []byte, policy *config.WASMCodePolicy) error {
+	if policy.IsEmpty() {
+		return nil
+	}
+
+	module, err := readWASMModule(code, policy.RejectFloatingPoint)
+	if err != nil {
+		return err
+	}
+
+	if len(module.floatingPointUse) > 0 {
+		return fmt.Errorf("%w: floating point not allowed, found %s", vmhost.ErrCodePolicyViolation, module.floatingPointUse)
+	}
+
+	for _, imported := range module.imports {
+		_, forbidden := policy.ForbiddenImports[imported.name]
+		if forbidden && imported.kind == wasmImportFunction {
+			return fmt.Errorf("%w: forbidden import %s", vmhost.ErrCodePolicyViolation, imported.name)
+		}
+	}
+
+	err = checkCodePolicyLimit("exports", module.numExports, policy.MaxExports)
+	if err != nil {
+		return err
+	}
+	err = checkCodePolicyLimit("functions", module.numFunctions, policy.MaxFunctions)
+	if err != nil {
+		return err
+	}
+	err = checkCodePolicyLimit("globals", module.numGlobals, policy.MaxGlobals)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range module.tables {
+		err = checkCodePolicyLimit("table size", declaredLimit(table), policy.MaxTableSize)
+		if err != nil {
+			return err
+		}
+	}
+	for _, memory := range module.memories {
+		err = checkCodePolicyLimit("memory pages", declaredLimit(memory), policy.MaxMemoryPages)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkCodePolicyLimit(name string, value uint64, limit uint64) error {
+	if limit == 0 || value <= limit {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %d %s declared, at most %d allowed", vmhost.ErrCodePolicyViolation, value, name, limit)
+}
+
+// declaredLimit returns the maximum of the limits if declared, otherwise the minimum
+func declaredLimit(limits wasmLimits) uint64 {
+	if limits.hasMax {
+		return limits.max
+	}
+	return limits.min
+}
+
 func (validator *wasmValidator) verifyFunctions(instance wasmer.InstanceHandler) error {
 	for functionName := range instance.GetExports() {
 		err := validator.verifyValidFunctionName(functionName)
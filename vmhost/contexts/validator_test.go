@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/config"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/wasmer"
 	"github.com/stretchr/testify/require"
@@ -95,3 +96,81 @@ func TestFunctionsGuard_Arity(t *testing.T) {
 	err = validator.verifyVoidFunction(instance, "wrongParamsAndReturn")
 	require.NotNil(t, err)
 }
+
+func wasmSectionForTests(id byte, payload ...byte) []byte {
+	return append([]byte{id, byte(len(payload))}, payload...)
+}
+
+func wasmModuleForTests(body ...byte) []byte {
+	module := append([]byte{}, wasmMagicAndVersion...)
+	// (type (func)) imported as env.getGasLeft and defined once
+	module = append(module, wasmSectionForTests(wasmSectionType, 0x01, 0x60, 0x00, 0x00)...)
+	module = append(module, wasmSectionForTests(wasmSectionImport,
+		0x01, 0x03, 'e', 'n', 'v', 0x0A, 'g', 'e', 't', 'G', 'a', 's', 'L', 'e', 'f', 't', wasmImportFunction, 0x00)...)
+	module = append(module, wasmSectionForTests(wasmSectionFunction, 0x01, 0x00)...)
+	// (table 3 funcref) (memory 2 4)
+	module = append(module, wasmSectionForTests(wasmSectionTable, 0x01, 0x70, 0x00, 0x03)...)
+	module = append(module, wasmSectionForTests(wasmSectionMemory, 0x01, 0x01, 0x02, 0x04)...)
+	// (global i32 (i32.const 42))
+	module = append(module, wasmSectionForTests(wasmSectionGlobal, 0x01, 0x7F, 0x00, 0x41, 0x2A, wasmOpcodeEnd)...)
+	module = append(module, wasmSectionForTests(wasmSectionExport, 0x01, 0x01, 'f', 0x00, 0x01)...)
+
+	functionBody := append([]byte{0x00}, body...)
+	functionBody = append(functionBody, wasmOpcodeEnd)
+	codeSection := append([]byte{0x01, byte(len(functionBody))}, functionBody...)
+	return append(module, wasmSectionForTests(wasmSectionCode, codeSection...)...)
+}
+
+func TestWASMValidator_CodePolicy_IntegerModule(t *testing.T) {
+	validator := newWASMValidator(make(vmcommon.FunctionNames), make(vmcommon.FunctionNames))
+
+	// (block (i32.load offset=8 (i32.const 0)) (drop)) (call 0)
+	code := wasmModuleForTests(0x02, 0x40, 0x41, 0x00, 0x28, 0x02, 0x08, 0x1A, wasmOpcodeEnd, 0x10, 0x00)
+	policy := &config.WASMCodePolicy{
+		RejectFloatingPoint: true,
+		MaxExports:          1,
+		MaxFunctions:        1,
+		MaxGlobals:          1,
+		MaxTableSize:        3,
+		MaxMemoryPages:      4,
+	}
+	require.Nil(t, validator.verifyCodePolicy(code, policy))
+
+	module, err := readWASMModule(code, true)
+	require.Nil(t, err)
+	require.Equal(t, []wasmImport{{module: "env", name: "getGasLeft", kind: wasmImportFunction}}, module.imports)
+	require.Equal(t, []wasmLimits{{min: 3}}, module.tables)
+	require.Equal(t, []wasmLimits{{min: 2, max: 4, hasMax: true}}, module.memories)
+	require.Empty(t, module.floatingPointUse)
+}
+
+func TestWASMValidator_CodePolicy_Violations(t *testing.T) {
+	validator := newWASMValidator(make(vmcommon.FunctionNames), make(vmcommon.FunctionNames))
+
+	// (drop (f64.const 0))
+	floatCode := wasmModuleForTests(0x44, 0, 0, 0, 0, 0, 0, 0, 0, 0x1A)
+	integerCode := wasmModuleForTests(0x41, 0x00, 0x1A)
+
+	require.Nil(t, validator.verifyCodePolicy(floatCode, &config.WASMCodePolicy{}))
+
+	err := validator.verifyCodePolicy(floatCode, &config.WASMCodePolicy{RejectFloatingPoint: true})
+	require.ErrorIs(t, err, vmhost.ErrCodePolicyViolation)
+	require.ErrorIs(t, err, vmhost.ErrContractInvalid)
+	require.Contains(t, err.Error(), "opcode 0x44 in function 0")
+
+	err = validator.verifyCodePolicy(integerCode, &config.WASMCodePolicy{
+		ForbiddenImports: map[string]struct{}{"getGasLeft": {}},
+	})
+	require.ErrorIs(t, err, vmhost.ErrCodePolicyViolation)
+	require.Contains(t, err.Error(), "forbidden import getGasLeft")
+
+	err = validator.verifyCodePolicy(integerCode, &config.WASMCodePolicy{MaxMemoryPages: 3})
+	require.ErrorIs(t, err, vmhost.ErrCodePolicyViolation)
+	require.Contains(t, err.Error(), "4 memory pages declared, at most 3 allowed")
+
+	err = validator.verifyCodePolicy(integerCode, &config.WASMCodePolicy{MaxTableSize: 2})
+	require.Contains(t, err.Error(), "3 table size declared, at most 2 allowed")
+
+	err = validator.verifyCodePolicy(integerCode[:len(integerCode)-2], &config.WASMCodePolicy{MaxExports: 1})
+	require.ErrorIs(t, err, vmhost.ErrMalformedWASMModule)
+}
@@ -0,0 +1,136 @@
+package contexts
+
+import (
+	"container/list"
+
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/wasmer"
+)
+
+// DefaultMaxWarmInstances is the number of warm instances kept by the runtime,
+// unless configured otherwise
+const DefaultMaxWarmInstances = uint64(1)
+
+type warmInstanceEntry struct {
+	codeHash string
+	instance wasmer.InstanceHandler
+}
+
+// warmInstanceCache keeps the most recently used Wasmer instances, keyed by
+// the hash of the code they were created from, evicting the least recently
+// used instance when full. The cache does not clean the instances itself,
+// it returns the evicted instances to the caller instead.
+type warmInstanceCache struct {
+	capacity   uint64
+	entries    *list.List
+	byCodeHash map[string]*list.Element
+	byInstance map[wasmer.InstanceHandler]*list.Element
+	stats      vmhost.WarmInstanceCacheStats
+}
+
+func newWarmInstanceCache(capacity uint64) *warmInstanceCache {
+	return &warmInstanceCache{
+		capacity:   capacity,
+		entries:    list.New(),
+		byCodeHash: make(map[string]*list.Element),
+		byInstance: make(map[wasmer.InstanceHandler]*list.Element),
+	}
+}
+
+// get returns the instance created from the given code, marking it as the most recently used
+func (cache *warmInstanceCache) get(codeHash []byte) (wasmer.InstanceHandler, bool) {
+	element, found := cache.byCodeHash[string(codeHash)]
+	if !found {
+		return nil, false
+	}
+
+	cache.entries.MoveToFront(element)
+	return element.Value.(*warmInstanceEntry).instance, true
+}
+
+// put adds an instance to the cache, replacing the instance previously created from the same code;
+// returns the replaced and the evicted instances
+func (cache *warmInstanceCache) put(codeHash []byte, instance wasmer.InstanceHandler) []wasmer.InstanceHandler {
+	removed := make([]wasmer.InstanceHandler, 0)
+	if cache.capacity == 0 {
+		return removed
+	}
+
+	previous, found := cache.remove(codeHash)
+	if found && previous != instance {
+		removed = append(removed, previous)
+	}
+
+	element := cache.entries.PushFront(&warmInstanceEntry{
+		codeHash: string(codeHash),
+		instance: instance,
+	})
+	cache.byCodeHash[string(codeHash)] = element
+	cache.byInstance[instance] = element
+
+	return append(removed, cache.evictOverCapacity()...)
+}
+
+// remove takes the instance created from the given code out of the cache
+func (cache *warmInstanceCache) remove(codeHash []byte) (wasmer.InstanceHandler, bool) {
+	element, found := cache.byCodeHash[string(codeHash)]
+	if !found {
+		return nil, false
+	}
+
+	return cache.removeElement(element), true
+}
+
+// clear takes all the instances out of the cache and returns them
+func (cache *warmInstanceCache) clear() []wasmer.InstanceHandler {
+	removed := make([]wasmer.InstanceHandler, 0, cache.entries.Len())
+	for cache.entries.Len() > 0 {
+		removed = append(removed, cache.removeElement(cache.entries.Front()))
+	}
+
+	return removed
+}
+
+// contains returns true if the instance is one of the warm instances
+func (cache *warmInstanceCache) contains(instance wasmer.InstanceHandler) bool {
+	_, found := cache.byInstance[instance]
+	return found
+}
+
+// setCapacity changes the maximum number of instances, returning the evicted instances
+func (cache *warmInstanceCache) setCapacity(capacity uint64) []wasmer.InstanceHandler {
+	cache.capacity = capacity
+	return cache.evictOverCapacity()
+}
+
+func (cache *warmInstanceCache) recordHit() {
+	cache.stats.Hits++
+}
+
+func (cache *warmInstanceCache) recordMiss() {
+	cache.stats.Misses++
+}
+
+func (cache *warmInstanceCache) statistics() vmhost.WarmInstanceCacheStats {
+	stats := cache.stats
+	stats.Capacity = cache.capacity
+	stats.Size = uint64(cache.entries.Len())
+	return stats
+}
+
+func (cache *warmInstanceCache) evictOverCapacity() []wasmer.InstanceHandler {
+	evicted := make([]wasmer.InstanceHandler, 0)
+	for uint64(cache.entries.Len()) > cache.capacity {
+		evicted = append(evicted, cache.removeElement(cache.entries.Back()))
+		cache.stats.Evictions++
+	}
+
+	return evicted
+}
+
+func (cache *warmInstanceCache) removeElement(element *list.Element) wasmer.InstanceHandler {
+	entry := cache.entries.Remove(element).(*warmInstanceEntry)
+	delete(cache.byCodeHash, entry.codeHash)
+	delete(cache.byInstance, entry.instance)
+	return entry.instance
+}
@@ -0,0 +1,208 @@
+package contexts
+
+import (
+	"testing"
+
+	contextmock "github.com/kalyan3104/k-chain-vm-v1_2-go/mock/context"
+	worldmock "github.com/kalyan3104/k-chain-vm-v1_2-go/mock/world"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/wasmer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarmInstanceCache_LeastRecentlyUsedIsEvicted(t *testing.T) {
+	t.Parallel()
+
+	router := contextmock.NewInstanceMock([]byte("router"))
+	pair := contextmock.NewInstanceMock([]byte("pair"))
+	token := contextmock.NewInstanceMock([]byte("token"))
+
+	cache := newWarmInstanceCache(2)
+	require.Empty(t, cache.put([]byte("routerHash"), router))
+	require.Empty(t, cache.put([]byte("pairHash"), pair))
+
+	instance, found := cache.get([]byte("routerHash"))
+	require.True(t, found)
+	require.Equal(t, router, instance)
+
+	// pair is now the least recently used
+	evicted := cache.put([]byte("tokenHash"), token)
+	require.Equal(t, []wasmer.InstanceHandler{pair}, evicted)
+	require.False(t, cache.contains(pair))
+	require.True(t, cache.contains(router))
+	require.True(t, cache.contains(token))
+
+	_, found = cache.get([]byte("pairHash"))
+	require.False(t, found)
+
+	require.Equal(t, vmhost.WarmInstanceCacheStats{
+		Capacity:  2,
+		Size:      2,
+		Evictions: 1,
+	}, cache.statistics())
+}
+
+func TestWarmInstanceCache_PutReplacesInstanceOfSameCode(t *testing.T) {
+	t.Parallel()
+
+	oldInstance := contextmock.NewInstanceMock([]byte("code"))
+	newInstance := contextmock.NewInstanceMock([]byte("code"))
+
+	cache := newWarmInstanceCache(2)
+	cache.put([]byte("codeHash"), oldInstance)
+	replaced := cache.put([]byte("codeHash"), newInstance)
+	require.Equal(t, []wasmer.InstanceHandler{oldInstance}, replaced)
+
+	instance, found := cache.get([]byte("codeHash"))
+	require.True(t, found)
+	require.Equal(t, newInstance, instance)
+	require.Equal(t, uint64(1), cache.statistics().Size)
+	require.Equal(t, uint64(0), cache.statistics().Evictions)
+}
+
+func TestWarmInstanceCache_RemoveClearAndCapacity(t *testing.T) {
+	t.Parallel()
+
+	first := contextmock.NewInstanceMock([]byte("first"))
+	second := contextmock.NewInstanceMock([]byte("second"))
+	third := contextmock.NewInstanceMock([]byte("third"))
+
+	cache := newWarmInstanceCache(3)
+	cache.put([]byte("first"), first)
+	cache.put([]byte("second"), second)
+	cache.put([]byte("third"), third)
+
+	removed, found := cache.remove([]byte("second"))
+	require.True(t, found)
+	require.Equal(t, second, removed)
+	_, found = cache.remove([]byte("second"))
+	require.False(t, found)
+
+	evicted := cache.setCapacity(1)
+	require.Equal(t, []wasmer.InstanceHandler{first}, evicted)
+	require.True(t, cache.contains(third))
+
+	require.Equal(t, []wasmer.InstanceHandler{third}, cache.clear())
+	require.Equal(t, uint64(0), cache.statistics().Size)
+
+	cache.setCapacity(0)
+	require.Empty(t, cache.put([]byte("first"), first))
+	require.False(t, cache.contains(first))
+}
+
+// cleanCountingInstance is an instance mock which records how many times it was cleaned
+type cleanCountingInstance struct {
+	*contextmock.InstanceMock
+	cleaned int
+}
+
+func (instance *cleanCountingInstance) Clean() {
+	instance.cleaned++
+}
+
+func newWarmInstanceRuntime(t *testing.T) (*runtimeContext, *contextmock.InstanceBuilderMock) {
+	host := InitializeVMAndWasmer()
+	world := worldmock.NewMockWorld()
+	host.BlockchainContext, _ = NewBlockchainContext(host, world)
+
+	runtime, err := NewRuntimeContext(host, []byte("type"), true)
+	require.Nil(t, err)
+	runtime.SetMaxInstanceCount(1)
+	host.RuntimeContext = runtime
+
+	builder := contextmock.NewInstanceBuilderMock(world)
+	runtime.ReplaceInstanceBuilder(builder)
+	return runtime, builder
+}
+
+// storeCountingInstance registers the contract at the address equal to its code,
+// with the given code hash; the builder returns the instance for that code
+func storeCountingInstance(builder *contextmock.InstanceBuilderMock, code []byte, codeHash []byte) *cleanCountingInstance {
+	builder.CreateAndStoreInstanceMock(code, 0)
+	builder.World.AcctMap.GetAccount(code).CodeHash = codeHash
+
+	instance := &cleanCountingInstance{InstanceMock: contextmock.NewInstanceMock(code)}
+	builder.InstanceMap[string(code)] = instance
+	return instance
+}
+
+func startInstance(t *testing.T, runtime *runtimeContext, address []byte, code []byte, newCode bool) wasmer.InstanceHandler {
+	runtime.SetSCAddress(address)
+	err := runtime.StartWasmerInstance(code, 1000, newCode)
+	require.Nil(t, err)
+	return runtime.instance
+}
+
+func TestRuntimeContext_WarmInstanceReusedAcrossCalls(t *testing.T) {
+	runtime, builder := newWarmInstanceRuntime(t)
+	contract := storeCountingInstance(builder, []byte("contract"), []byte("contractHash"))
+
+	require.Equal(t, contract, startInstance(t, runtime, []byte("contract"), []byte("contract"), false))
+	require.True(t, runtime.IsWarmInstance())
+	runtime.CleanWasmerInstance()
+	require.Equal(t, 0, contract.cleaned)
+
+	// the next call starts after the previous one has ended
+	builder.InstanceMap = make(map[string]wasmer.InstanceHandler)
+	require.Equal(t, contract, startInstance(t, runtime, []byte("contract"), []byte("contract"), false))
+
+	stats := runtime.WarmInstanceCacheStats()
+	require.Equal(t, uint64(1), stats.Hits)
+	require.Equal(t, uint64(1), stats.Misses)
+}
+
+func TestRuntimeContext_WarmInstanceInvalidatedOnUpgrade(t *testing.T) {
+	runtime, builder := newWarmInstanceRuntime(t)
+	oldCode := storeCountingInstance(builder, []byte("contract"), []byte("oldHash"))
+	newCode := &cleanCountingInstance{InstanceMock: contextmock.NewInstanceMock([]byte("newCode"))}
+	builder.InstanceMap["newCode"] = newCode
+
+	startInstance(t, runtime, []byte("contract"), []byte("contract"), false)
+	runtime.CleanWasmerInstance()
+
+	// on upgrade, the account still holds the hash of the replaced code
+	require.Equal(t, newCode, startInstance(t, runtime, []byte("contract"), []byte("newCode"), true))
+	require.Equal(t, 1, oldCode.cleaned)
+	require.False(t, runtime.warmInstances.contains(oldCode))
+
+	_, found := runtime.warmInstances.get([]byte("oldHash"))
+	require.False(t, found)
+
+	newCodeHash, _ := runtime.host.Crypto().Sha256([]byte("newCode"))
+	instance, found := runtime.warmInstances.get(newCodeHash)
+	require.True(t, found)
+	require.Equal(t, newCode, instance)
+}
+
+func TestRuntimeContext_ResetWarmInstance(t *testing.T) {
+	runtime, builder := newWarmInstanceRuntime(t)
+	runtime.SetMaxWarmInstanceCount(2)
+	first := storeCountingInstance(builder, []byte("first"), []byte("firstHash"))
+	second := storeCountingInstance(builder, []byte("second"), []byte("secondHash"))
+
+	startInstance(t, runtime, []byte("first"), []byte("first"), false)
+	startInstance(t, runtime, []byte("second"), []byte("second"), false)
+	require.Equal(t, uint64(2), runtime.WarmInstanceCacheStats().Size)
+
+	runtime.ResetWarmInstance()
+	require.Nil(t, runtime.instance)
+	require.Equal(t, 1, first.cleaned)
+	require.Equal(t, 1, second.cleaned)
+	require.Equal(t, uint64(0), runtime.WarmInstanceCacheStats().Size)
+}
+
+func TestRuntimeContext_ResetWarmInstanceWithoutCurrentInstance(t *testing.T) {
+	runtime, builder := newWarmInstanceRuntime(t)
+	contract := storeCountingInstance(builder, []byte("contract"), []byte("contractHash"))
+
+	startInstance(t, runtime, []byte("contract"), []byte("contract"), false)
+
+	// e.g. the next instance could not be created
+	runtime.instance = nil
+	runtime.ResetWarmInstance()
+	require.Equal(t, 1, contract.cleaned)
+	require.Equal(t, uint64(0), runtime.WarmInstanceCacheStats().Size)
+
+	_, found := runtime.warmInstances.get([]byte("contractHash"))
+	require.False(t, found)
+}
@@ -0,0 +1,670 @@
+package contexts
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+)
+
+const (
+	wasmSectionType     = 1
+	wasmSectionImport   = 2
+	wasmSectionFunction = 3
+	wasmSectionTable    = 4
+	wasmSectionMemory   = 5
+	wasmSectionGlobal   = 6
+	wasmSectionExport   = 7
+	wasmSectionCode     = 10
+
+	wasmImportFunction = 0
+	wasmImportTable    = 1
+	wasmImportMemory   = 2
+	wasmImportGlobal   = 3
+
+	wasmTypeF32 = 0x7D
+	wasmTypeF64 = 0x7C
+
+	wasmOpcodeEnd        = 0x0B
+	wasmPrefixMisc       = 0xFC
+	wasmPrefixSIMD       = 0xFD
+	wasmBlockTypeEmpty   = 0x40
+	wasmFunctionTypeForm = 0x60
+)
+
+var wasmMagicAndVersion = []byte{0x00, 0x61, 0x73, 0x6D, 0x01, 0x00, 0x00, 0x00}
+
+type wasmImport struct {
+	module string
+	name   string
+	kind   byte
+}
+
+type wasmLimits struct {
+	min    uint64
+	max    uint64
+	hasMax bool
+}
+
+// wasmModuleInfo holds what the code policy needs to know about a WASM module
+type wasmModuleInfo struct {
+	imports      []wasmImport
+	numFunctions uint64
+	numGlobals   uint64
+	numExports   uint64
+	tables       []wasmLimits
+	memories     []wasmLimits
+
+	// floatingPointUse describes the first use of floating point found in
+	// the module, if any; empty unless the module was read with scanCode set
+	floatingPointUse string
+}
+
+// wasmModuleReader decodes the sections of a WASM binary module which are
+// relevant to the code policy, skipping the others
+type wasmModuleReader struct {
+	code     []byte
+	offset   int
+	scanCode bool
+	info     *wasmModuleInfo
+}
+
+// readWASMModule decodes a WASM module; the types, globals and function bodies
+// are scanned for floating point use only when scanCode is set
+func readWASMModule(code []byte, scanCode bool) (*wasmModuleInfo, error) {
+	if !bytes.HasPrefix(code, wasmMagicAndVersion) {
+		return nil, fmt.Errorf("%w: invalid header", vmhost.ErrMalformedWASMModule)
+	}
+
+	reader := &wasmModuleReader{
+		code:     code,
+		offset:   len(wasmMagicAndVersion),
+		scanCode: scanCode,
+		info:     &wasmModuleInfo{},
+	}
+
+	for reader.offset < len(reader.code) {
+		err := reader.readSection()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return reader.info, nil
+}
+
+func (reader *wasmModuleReader) readSection() error {
+	sectionID, err := reader.readByte()
+	if err != nil {
+		return err
+	}
+	sectionSize, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+	sectionEnd := reader.offset + int(sectionSize)
+	if sectionEnd > len(reader.code) {
+		return reader.malformed("section %d exceeds the module", sectionID)
+	}
+
+	switch sectionID {
+	case wasmSectionType:
+		err = reader.readTypeSection()
+	case wasmSectionImport:
+		err = reader.readImportSection()
+	case wasmSectionFunction:
+		reader.info.numFunctions, err = reader.readU32()
+	case wasmSectionTable:
+		err = reader.readTableSection()
+	case wasmSectionMemory:
+		err = reader.readMemorySection()
+	case wasmSectionGlobal:
+		err = reader.readGlobalSection()
+	case wasmSectionExport:
+		reader.info.numExports, err = reader.readU32()
+	case wasmSectionCode:
+		err = reader.readCodeSection()
+	}
+	if err != nil {
+		return err
+	}
+
+	// the count-only sections and the unknown ones are not read completely
+	reader.offset = sectionEnd
+	return nil
+}
+
+func (reader *wasmModuleReader) readTypeSection() error {
+	if !reader.scanCode {
+		return nil
+	}
+
+	numTypes, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for typeIndex := uint64(0); typeIndex < numTypes; typeIndex++ {
+		form, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+		if form != wasmFunctionTypeForm {
+			return reader.malformed("unknown type form 0x%x", form)
+		}
+
+		for _, kind := range []string{"parameter", "result"} {
+			valueTypes, err := reader.readValueTypes()
+			if err != nil {
+				return err
+			}
+			reader.checkFloatingPointTypes(valueTypes, fmt.Sprintf("%s of function type %d", kind, typeIndex))
+		}
+	}
+
+	return nil
+}
+
+func (reader *wasmModuleReader) readImportSection() error {
+	numImports, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint64(0); i < numImports; i++ {
+		module, err := reader.readName()
+		if err != nil {
+			return err
+		}
+		name, err := reader.readName()
+		if err != nil {
+			return err
+		}
+		kind, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+
+		switch kind {
+		case wasmImportFunction:
+			_, err = reader.readU32()
+		case wasmImportTable:
+			err = reader.readTable()
+		case wasmImportMemory:
+			err = reader.readMemory()
+		case wasmImportGlobal:
+			err = reader.readGlobalType(fmt.Sprintf("imported global %s", name))
+		default:
+			err = reader.malformed("unknown import kind 0x%x", kind)
+		}
+		if err != nil {
+			return err
+		}
+
+		reader.info.imports = append(reader.info.imports, wasmImport{
+			module: module,
+			name:   name,
+			kind:   kind,
+		})
+	}
+
+	return nil
+}
+
+func (reader *wasmModuleReader) readTableSection() error {
+	numTables, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint64(0); i < numTables; i++ {
+		err = reader.readTable()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (reader *wasmModuleReader) readTable() error {
+	// the element type is irrelevant to the policy
+	_, err := reader.readByte()
+	if err != nil {
+		return err
+	}
+
+	limits, err := reader.readLimits()
+	if err != nil {
+		return err
+	}
+
+	reader.info.tables = append(reader.info.tables, limits)
+	return nil
+}
+
+func (reader *wasmModuleReader) readMemorySection() error {
+	numMemories, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint64(0); i < numMemories; i++ {
+		err = reader.readMemory()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (reader *wasmModuleReader) readMemory() error {
+	limits, err := reader.readLimits()
+	if err != nil {
+		return err
+	}
+
+	reader.info.memories = append(reader.info.memories, limits)
+	return nil
+}
+
+func (reader *wasmModuleReader) readGlobalSection() error {
+	numGlobals, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+	reader.info.numGlobals = numGlobals
+
+	if !reader.scanCode {
+		return nil
+	}
+
+	for globalIndex := uint64(0); globalIndex < numGlobals; globalIndex++ {
+		if reader.hasFloatingPointUse() {
+			// the initializers are not decoded past the first use
+			return nil
+		}
+
+		location := fmt.Sprintf("global %d", globalIndex)
+		err = reader.readGlobalType(location)
+		if err != nil {
+			return err
+		}
+
+		err = reader.scanInstructions(-1, location)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (reader *wasmModuleReader) readGlobalType(location string) error {
+	valueType, err := reader.readByte()
+	if err != nil {
+		return err
+	}
+	reader.checkFloatingPointTypes([]byte{valueType}, location)
+
+	// mutability
+	_, err = reader.readByte()
+	return err
+}
+
+func (reader *wasmModuleReader) readCodeSection() error {
+	if !reader.scanCode {
+		return nil
+	}
+
+	numBodies, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for functionIndex := uint64(0); functionIndex < numBodies; functionIndex++ {
+		bodySize, err := reader.readU32()
+		if err != nil {
+			return err
+		}
+		bodyEnd := reader.offset + int(bodySize)
+		if bodyEnd > len(reader.code) {
+			return reader.malformed("body of function %d exceeds the module", functionIndex)
+		}
+
+		location := fmt.Sprintf("function %d", functionIndex)
+		err = reader.readLocals(location)
+		if err != nil {
+			return err
+		}
+
+		err = reader.scanInstructions(bodyEnd, location)
+		if err != nil {
+			return err
+		}
+
+		reader.offset = bodyEnd
+	}
+
+	return nil
+}
+
+func (reader *wasmModuleReader) readLocals(location string) error {
+	numLocalGroups, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint64(0); i < numLocalGroups; i++ {
+		_, err = reader.readU32()
+		if err != nil {
+			return err
+		}
+		valueType, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+		reader.checkFloatingPointTypes([]byte{valueType}, "local of "+location)
+	}
+
+	return nil
+}
+
+// scanInstructions decodes instructions until end, or until the first end
+// opcode when end is negative, as for the constant expressions
+func (reader *wasmModuleReader) scanInstructions(end int, location string) error {
+	for end < 0 || reader.offset < end {
+		if reader.hasFloatingPointUse() {
+			// only the first use is reported, the rest of the code is irrelevant
+			if end >= 0 {
+				reader.offset = end
+			}
+			return nil
+		}
+
+		opcode, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+		if end < 0 && opcode == wasmOpcodeEnd {
+			return nil
+		}
+
+		if isFloatingPointOpcode(opcode) {
+			reader.recordFloatingPointUse(fmt.Sprintf("opcode 0x%x in %s", opcode, location))
+		}
+
+		err = reader.skipImmediates(opcode, location)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (reader *wasmModuleReader) skipImmediates(opcode byte, location string) error {
+	var err error
+
+	switch {
+	case opcode >= 0x02 && opcode <= 0x04:
+		err = reader.skipBlockType(location)
+	case opcode == 0x0C || opcode == 0x0D || opcode == 0x10 || opcode == 0xD2:
+		_, err = reader.readU32()
+	case opcode >= 0x20 && opcode <= 0x26:
+		_, err = reader.readU32()
+	case opcode == 0x0E:
+		err = reader.skipBranchTable()
+	case opcode == 0x11:
+		err = reader.skipU32s(2)
+	case opcode == 0x1C:
+		var valueTypes []byte
+		valueTypes, err = reader.readValueTypes()
+		reader.checkFloatingPointTypes(valueTypes, "select in "+location)
+	case opcode >= 0x28 && opcode <= 0x3E:
+		err = reader.skipU32s(2)
+	case opcode == 0x3F || opcode == 0x40 || opcode == 0xD0:
+		_, err = reader.readByte()
+	case opcode == 0x41 || opcode == 0x42:
+		err = reader.skipLEB128()
+	case opcode == 0x43:
+		err = reader.skipBytes(4)
+	case opcode == 0x44:
+		err = reader.skipBytes(8)
+	case opcode == wasmPrefixMisc:
+		err = reader.skipMiscImmediates(location)
+	case opcode == wasmPrefixSIMD:
+		// SIMD instructions may operate on floating point lanes
+		reader.recordFloatingPointUse("SIMD instruction in " + location)
+	case opcode <= 0x01, opcode == 0x05, opcode == wasmOpcodeEnd, opcode == 0x0F, opcode == 0x1A, opcode == 0x1B:
+	case opcode >= 0x45 && opcode <= 0xC4, opcode == 0xD1:
+	default:
+		err = reader.malformed("unknown opcode 0x%x in %s", opcode, location)
+	}
+
+	return err
+}
+
+func (reader *wasmModuleReader) skipMiscImmediates(location string) error {
+	subOpcode, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case subOpcode <= 7:
+		// the saturating float to int truncations
+		reader.recordFloatingPointUse(fmt.Sprintf("opcode 0xfc 0x%x in %s", subOpcode, location))
+		return nil
+	case subOpcode == 8:
+		_, err = reader.readU32()
+		if err != nil {
+			return err
+		}
+		return reader.skipBytes(1)
+	case subOpcode == 10:
+		return reader.skipBytes(2)
+	case subOpcode == 11:
+		return reader.skipBytes(1)
+	case subOpcode == 12 || subOpcode == 14:
+		return reader.skipU32s(2)
+	case subOpcode == 9 || subOpcode == 13 || (subOpcode >= 15 && subOpcode <= 17):
+		return reader.skipU32s(1)
+	default:
+		return reader.malformed("unknown opcode 0xfc 0x%x in %s", subOpcode, location)
+	}
+}
+
+func (reader *wasmModuleReader) skipBlockType(location string) error {
+	if reader.offset >= len(reader.code) {
+		return reader.malformed("unexpected end of module")
+	}
+
+	blockType := reader.code[reader.offset]
+	if blockType == wasmBlockTypeEmpty || isWASMValueType(blockType) {
+		reader.offset++
+		reader.checkFloatingPointTypes([]byte{blockType}, "block in "+location)
+		return nil
+	}
+
+	// a type index, encoded as a signed LEB128
+	return reader.skipLEB128()
+}
+
+func (reader *wasmModuleReader) skipBranchTable() error {
+	numTargets, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	return reader.skipU32s(numTargets + 1)
+}
+
+func (reader *wasmModuleReader) checkFloatingPointTypes(valueTypes []byte, location string) {
+	for _, valueType := range valueTypes {
+		if valueType == wasmTypeF32 || valueType == wasmTypeF64 {
+			reader.recordFloatingPointUse("floating point type in " + location)
+			return
+		}
+	}
+}
+
+func (reader *wasmModuleReader) hasFloatingPointUse() bool {
+	return len(reader.info.floatingPointUse) > 0
+}
+
+func (reader *wasmModuleReader) recordFloatingPointUse(use string) {
+	if !reader.hasFloatingPointUse() {
+		reader.info.floatingPointUse = use
+	}
+}
+
+func (reader *wasmModuleReader) readValueTypes() ([]byte, error) {
+	numTypes, err := reader.readU32()
+	if err != nil {
+		return nil, err
+	}
+	if numTypes > uint64(len(reader.code)-reader.offset) {
+		return nil, reader.malformed("unexpected end of module")
+	}
+
+	valueTypes := reader.code[reader.offset : reader.offset+int(numTypes)]
+	reader.offset += int(numTypes)
+	return valueTypes, nil
+}
+
+func (reader *wasmModuleReader) readLimits() (wasmLimits, error) {
+	flags, err := reader.readByte()
+	if err != nil {
+		return wasmLimits{}, err
+	}
+
+	limits := wasmLimits{}
+	limits.min, err = reader.readU32()
+	if err != nil {
+		return wasmLimits{}, err
+	}
+
+	limits.hasMax = flags&0x01 != 0
+	if limits.hasMax {
+		limits.max, err = reader.readU32()
+		if err != nil {
+			return wasmLimits{}, err
+		}
+	}
+
+	return limits, nil
+}
+
+func (reader *wasmModuleReader) readName() (string, error) {
+	length, err := reader.readU32()
+	if err != nil {
+		return "", err
+	}
+	if length > uint64(len(reader.code)-reader.offset) {
+		return "", reader.malformed("unexpected end of module")
+	}
+
+	name := string(reader.code[reader.offset : reader.offset+int(length)])
+	reader.offset += int(length)
+	return name, nil
+}
+
+func (reader *wasmModuleReader) readByte() (byte, error) {
+	if reader.offset >= len(reader.code) {
+		return 0, reader.malformed("unexpected end of module")
+	}
+
+	value := reader.code[reader.offset]
+	reader.offset++
+	return value, nil
+}
+
+// readU32 decodes an unsigned LEB128 of at most 32 bits
+func (reader *wasmModuleReader) readU32() (uint64, error) {
+	value := uint64(0)
+	for shift := uint(0); shift < 35; shift += 7 {
+		b, err := reader.readByte()
+		if err != nil {
+			return 0, err
+		}
+
+		value |= uint64(b&0x7F) << shift
+		if b&0x80 == 0 {
+			return value, nil
+		}
+	}
+
+	return 0, reader.malformed("integer too long")
+}
+
+// skipLEB128 skips a LEB128 of at most 64 bits, signed or unsigned
+func (reader *wasmModuleReader) skipLEB128() error {
+	for i := 0; i < 10; i++ {
+		b, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+		if b&0x80 == 0 {
+			return nil
+		}
+	}
+
+	return reader.malformed("integer too long")
+}
+
+func (reader *wasmModuleReader) skipU32s(count uint64) error {
+	for i := uint64(0); i < count; i++ {
+		_, err := reader.readU32()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (reader *wasmModuleReader) skipBytes(count int) error {
+	if count > len(reader.code)-reader.offset {
+		return reader.malformed("unexpected end of module")
+	}
+
+	reader.offset += count
+	return nil
+}
+
+func (reader *wasmModuleReader) malformed(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", vmhost.ErrMalformedWASMModule, fmt.Sprintf(format, args...))
+}
+
+func isWASMValueType(valueType byte) bool {
+	switch valueType {
+	case 0x7F, 0x7E, wasmTypeF32, wasmTypeF64, 0x7B, 0x70, 0x6F:
+		return true
+	default:
+		return false
+	}
+}
+
+func isFloatingPointOpcode(opcode byte) bool {
+	switch {
+	case opcode == 0x2A || opcode == 0x2B || opcode == 0x38 || opcode == 0x39:
+		// f32/f64 loads and stores
+		return true
+	case opcode == 0x43 || opcode == 0x44:
+		// f32/f64 constants
+		return true
+	case opcode >= 0x5B && opcode <= 0x66:
+		// f32/f64 comparisons
+		return true
+	case opcode >= 0x8B && opcode <= 0xA6:
+		// f32/f64 arithmetic
+		return true
+	case opcode >= 0xA8 && opcode <= 0xAB, opcode >= 0xAE && opcode <= 0xBF:
+		// conversions from and to f32/f64, except the integer only ones
+		return true
+	default:
+		return false
+	}
+}
@@ -10,8 +10,13 @@ package cryptoapi
 // extern int32_t v1_2_keccak256(void *context, int32_t dataOffset, int32_t length, int32_t resultOffset);
 // extern int32_t v1_2_ripemd160(void *context, int32_t dataOffset, int32_t length, int32_t resultOffset);
 // extern int32_t v1_2_verifyBLS(void *context, int32_t keyOffset, int32_t messageOffset, int32_t messageLength, int32_t sigOffset);
+// extern int32_t v1_2_verifyBLSAggregatedSignature(void *context, int32_t keysOffset, int32_t numKeys, int32_t messageOffset, int32_t messageLength, int32_t sigOffset);
+// extern int32_t v1_2_verifyBLSMultiSig(void *context, int32_t keysOffset, int32_t numKeys, int32_t messageOffset, int32_t messageLength, int32_t sigOffset);
 // extern int32_t v1_2_verifyEd25519(void *context, int32_t keyOffset, int32_t messageOffset, int32_t messageLength, int32_t sigOffset);
 // extern int32_t v1_2_verifySecp256k1(void *context, int32_t keyOffset, int32_t keyLength, int32_t messageOffset, int32_t messageLength, int32_t sigOffset);
+// extern int32_t v1_2_recoverSecp256k1(void *context, int32_t hashOffset, int32_t recoveryID, int32_t rOffset, int32_t sOffset, int32_t resultOffset);
+// extern int32_t v1_2_encodeSecp256k1DerSignature(void *context, int32_t rOffset, int32_t sOffset, int32_t sigOffset);
+// extern int32_t v1_2_decodeSecp256k1DerSignature(void *context, int32_t sigOffset, int32_t rOffset, int32_t sOffset);
 import "C"
 
 import (
@@ -24,11 +29,15 @@ import (
 
 const blsPublicKeyLength = 96
 const blsSignatureLength = 48
+const maxBLSKeysPerVerification = 1024
 const ed25519PublicKeyLength = 32
 const ed25519SignatureLength = 64
 const secp256k1CompressedPublicKeyLength = 33
 const secp256k1UncompressedPublicKeyLength = 65
 const secp256k1SignatureLength = 64
+const secp256k1HashLength = 32
+const secp256k1SignatureComponentLength = 32
+const ripemd160HashLength = 20
 
 // CryptoImports adds some crypto imports to the Wasmer Imports map
 func CryptoImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
@@ -53,6 +62,16 @@ func CryptoImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("verifyBLSAggregatedSignature", v1_2_verifyBLSAggregatedSignature, C.v1_2_verifyBLSAggregatedSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("verifyBLSMultiSig", v1_2_verifyBLSMultiSig, C.v1_2_verifyBLSMultiSig)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("verifyEd25519", v1_2_verifyEd25519, C.v1_2_verifyEd25519)
 	if err != nil {
 		return nil, err
@@ -63,11 +82,32 @@ func CryptoImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("recoverSecp256k1", v1_2_recoverSecp256k1, C.v1_2_recoverSecp256k1)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("encodeSecp256k1DerSignature", v1_2_encodeSecp256k1DerSignature, C.v1_2_encodeSecp256k1DerSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("decodeSecp256k1DerSignature", v1_2_decodeSecp256k1DerSignature, C.v1_2_decodeSecp256k1DerSignature)
+	if err != nil {
+		return nil, err
+	}
+
 	return imports, nil
 }
 
 //export v1_2_sha256
 func v1_2_sha256(context unsafe.Pointer, dataOffset int32, length int32, resultOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "sha256",
+			"data", vmhost.TraceMemory(dataOffset, length),
+			"result", vmhost.TraceMemoryResult(resultOffset, vmhost.HashLen))()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	crypto := vmhost.GetCryptoContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -96,6 +136,12 @@ func v1_2_sha256(context unsafe.Pointer, dataOffset int32, length int32, resultO
 
 //export v1_2_keccak256
 func v1_2_keccak256(context unsafe.Pointer, dataOffset int32, length int32, resultOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "keccak256",
+			"data", vmhost.TraceMemory(dataOffset, length),
+			"result", vmhost.TraceMemoryResult(resultOffset, vmhost.HashLen))()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	crypto := vmhost.GetCryptoContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -124,6 +170,12 @@ func v1_2_keccak256(context unsafe.Pointer, dataOffset int32, length int32, resu
 
 //export v1_2_ripemd160
 func v1_2_ripemd160(context unsafe.Pointer, dataOffset int32, length int32, resultOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ripemd160",
+			"data", vmhost.TraceMemory(dataOffset, length),
+			"result", vmhost.TraceMemoryResult(resultOffset, ripemd160HashLength))()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	crypto := vmhost.GetCryptoContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -158,6 +210,13 @@ func v1_2_verifyBLS(
 	messageLength int32,
 	sigOffset int32,
 ) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "verifyBLS",
+			"key", vmhost.TraceMemory(keyOffset, blsPublicKeyLength),
+			"message", vmhost.TraceMemory(messageOffset, messageLength),
+			"sig", vmhost.TraceMemory(sigOffset, blsSignatureLength))()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	crypto := vmhost.GetCryptoContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -191,6 +250,128 @@ func v1_2_verifyBLS(
 	return 0
 }
 
+//export v1_2_verifyBLSAggregatedSignature
+func v1_2_verifyBLSAggregatedSignature(
+	context unsafe.Pointer,
+	keysOffset int32,
+	numKeys int32,
+	messageOffset int32,
+	messageLength int32,
+	sigOffset int32,
+) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "verifyBLSAggregatedSignature",
+			"keys", vmhost.TraceMemory(keysOffset, numKeys*blsPublicKeyLength),
+			"message", vmhost.TraceMemory(messageOffset, messageLength),
+			"sig", vmhost.TraceMemory(sigOffset, blsSignatureLength))()
+	}
+
+	crypto := vmhost.GetCryptoContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasPerKey := metering.GasSchedule().CryptoAPICost.VerifyBLSAggregatedPerKey
+	return verifyBLSWithMultipleKeys(
+		context,
+		keysOffset,
+		numKeys,
+		messageOffset,
+		messageLength,
+		sigOffset,
+		gasPerKey,
+		crypto.VerifyBLSAggregatedSignature,
+	)
+}
+
+//export v1_2_verifyBLSMultiSig
+func v1_2_verifyBLSMultiSig(
+	context unsafe.Pointer,
+	keysOffset int32,
+	numKeys int32,
+	messageOffset int32,
+	messageLength int32,
+	sigOffset int32,
+) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "verifyBLSMultiSig",
+			"keys", vmhost.TraceMemory(keysOffset, numKeys*blsPublicKeyLength),
+			"message", vmhost.TraceMemory(messageOffset, messageLength),
+			"sig", vmhost.TraceMemory(sigOffset, blsSignatureLength))()
+	}
+
+	crypto := vmhost.GetCryptoContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasPerKey := metering.GasSchedule().CryptoAPICost.VerifyBLSMultiSigPerKey
+	return verifyBLSWithMultipleKeys(
+		context,
+		keysOffset,
+		numKeys,
+		messageOffset,
+		messageLength,
+		sigOffset,
+		gasPerKey,
+		crypto.VerifyBLSMultiSig,
+	)
+}
+
+// verifyBLSWithMultipleKeys loads numKeys consecutive BLS public keys and
+// checks the signature against them; the pairing is charged once, as in
+// verifyBLS, and the aggregation of the keys is charged per key
+func verifyBLSWithMultipleKeys(
+	context unsafe.Pointer,
+	keysOffset int32,
+	numKeys int32,
+	messageOffset int32,
+	messageLength int32,
+	sigOffset int32,
+	gasPerKey uint64,
+	verify func(keys [][]byte, msg []byte, sig []byte) error,
+) int32 {
+	runtime := vmhost.GetRuntimeContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	if numKeys <= 0 || numKeys > maxBLSKeysPerVerification {
+		vmhost.WithFault(vmhost.ErrInvalidNumberOfPublicKeys, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	gasToUse := math.AddUint64(
+		metering.GasSchedule().CryptoAPICost.VerifyBLS,
+		math.MulUint64(gasPerKey, uint64(numKeys)),
+	)
+	metering.UseGas(gasToUse)
+
+	allKeys, err := runtime.MemLoad(keysOffset, numKeys*blsPublicKeyLength)
+	if vmhost.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	keys := make([][]byte, numKeys)
+	for i := range keys {
+		keys[i] = allKeys[i*blsPublicKeyLength : (i+1)*blsPublicKeyLength]
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(messageLength))
+	metering.UseGas(gasToUse)
+
+	message, err := runtime.MemLoad(messageOffset, messageLength)
+	if vmhost.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	sig, err := runtime.MemLoad(sigOffset, blsSignatureLength)
+	if vmhost.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	invalidSigErr := verify(keys, message, sig)
+	if invalidSigErr != nil {
+		return -1
+	}
+
+	return 0
+}
+
 //export v1_2_verifyEd25519
 func v1_2_verifyEd25519(
 	context unsafe.Pointer,
@@ -199,6 +380,13 @@ func v1_2_verifyEd25519(
 	messageLength int32,
 	sigOffset int32,
 ) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "verifyEd25519",
+			"key", vmhost.TraceMemory(keyOffset, ed25519PublicKeyLength),
+			"message", vmhost.TraceMemory(messageOffset, messageLength),
+			"sig", vmhost.TraceMemory(sigOffset, ed25519SignatureLength))()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	crypto := vmhost.GetCryptoContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -241,6 +429,13 @@ func v1_2_verifySecp256k1(
 	messageLength int32,
 	sigOffset int32,
 ) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "verifySecp256k1",
+			"key", vmhost.TraceMemory(keyOffset, keyLength),
+			"message", vmhost.TraceMemory(messageOffset, messageLength),
+			"sigOffset", sigOffset)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	crypto := vmhost.GetCryptoContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -287,3 +482,155 @@ func v1_2_verifySecp256k1(
 
 	return 0
 }
+
+//export v1_2_recoverSecp256k1
+func v1_2_recoverSecp256k1(
+	context unsafe.Pointer,
+	hashOffset int32,
+	recoveryID int32,
+	rOffset int32,
+	sOffset int32,
+	resultOffset int32,
+) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "recoverSecp256k1",
+			"hash", vmhost.TraceMemory(hashOffset, secp256k1HashLength),
+			"recoveryID", recoveryID,
+			"r", vmhost.TraceMemory(rOffset, secp256k1SignatureComponentLength),
+			"s", vmhost.TraceMemory(sOffset, secp256k1SignatureComponentLength),
+			"resultOffset", resultOffset)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	crypto := vmhost.GetCryptoContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().CryptoAPICost.RecoverSecp256k1
+	metering.UseGas(gasToUse)
+
+	hash, err := runtime.MemLoad(hashOffset, secp256k1HashLength)
+	if vmhost.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	r, err := runtime.MemLoad(rOffset, secp256k1SignatureComponentLength)
+	if vmhost.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	s, err := runtime.MemLoad(sOffset, secp256k1SignatureComponentLength)
+	if vmhost.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	if recoveryID < 0 || recoveryID > 0xff {
+		return -1
+	}
+
+	key, invalidSigErr := crypto.Ecrecover(hash, []byte{byte(recoveryID)}, r, s)
+	if invalidSigErr != nil {
+		return -1
+	}
+
+	err = runtime.MemStore(resultOffset, key)
+	if vmhost.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return 0
+}
+
+//export v1_2_encodeSecp256k1DerSignature
+func v1_2_encodeSecp256k1DerSignature(
+	context unsafe.Pointer,
+	rOffset int32,
+	sOffset int32,
+	sigOffset int32,
+) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "encodeSecp256k1DerSignature",
+			"r", vmhost.TraceMemory(rOffset, secp256k1SignatureComponentLength),
+			"s", vmhost.TraceMemory(sOffset, secp256k1SignatureComponentLength),
+			"sigOffset", sigOffset)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	crypto := vmhost.GetCryptoContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().CryptoAPICost.EncodeSecp256k1DerSignature
+	metering.UseGas(gasToUse)
+
+	r, err := runtime.MemLoad(rOffset, secp256k1SignatureComponentLength)
+	if vmhost.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	s, err := runtime.MemLoad(sOffset, secp256k1SignatureComponentLength)
+	if vmhost.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	sig, err := crypto.EncodeSecp256k1DERSignature(r, s)
+	if err != nil {
+		return -1
+	}
+
+	err = runtime.MemStore(sigOffset, sig)
+	if vmhost.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(sig))
+}
+
+//export v1_2_decodeSecp256k1DerSignature
+func v1_2_decodeSecp256k1DerSignature(
+	context unsafe.Pointer,
+	sigOffset int32,
+	rOffset int32,
+	sOffset int32,
+) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "decodeSecp256k1DerSignature",
+			"sigOffset", sigOffset,
+			"r", vmhost.TraceMemoryResult(rOffset, secp256k1SignatureComponentLength),
+			"s", vmhost.TraceMemoryResult(sOffset, secp256k1SignatureComponentLength))()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	crypto := vmhost.GetCryptoContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().CryptoAPICost.DecodeSecp256k1DerSignature
+	metering.UseGas(gasToUse)
+
+	// same layout as in verifySecp256k1: 0x30 and the remaining buffer length
+	const sigHeaderLength = 2
+	sigHeader, err := runtime.MemLoad(sigOffset, sigHeaderLength)
+	if vmhost.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+	sigLength := int32(sigHeader[1]) + sigHeaderLength
+	sig, err := runtime.MemLoad(sigOffset, sigLength)
+	if vmhost.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	r, s, invalidSigErr := crypto.DecodeSecp256k1DERSignature(sig)
+	if invalidSigErr != nil {
+		return -1
+	}
+
+	err = runtime.MemStore(rOffset, r)
+	if vmhost.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	err = runtime.MemStore(sOffset, s)
+	if vmhost.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return 0
+}
@@ -80,6 +80,12 @@ var ErrContractNotFound = fmt.Errorf("%w (not found)", ErrContractInvalid)
 // ErrMemoryDeclarationMissing signals that a memory declaration is missing
 var ErrMemoryDeclarationMissing = fmt.Errorf("%w (missing memory declaration)", ErrContractInvalid)
 
+// ErrMalformedWASMModule signals that the contract code is not a well-formed WASM module
+var ErrMalformedWASMModule = fmt.Errorf("%w (malformed WASM module)", ErrContractInvalid)
+
+// ErrCodePolicyViolation signals that the contract code breaks the WASM code policy
+var ErrCodePolicyViolation = fmt.Errorf("%w (code policy violation)", ErrContractInvalid)
+
 // ErrMaxInstancesReached signals that the max number of Wasmer instances has been reached.
 var ErrMaxInstancesReached = fmt.Errorf("%w (max instances reached)", ErrExecutionFailed)
 
@@ -98,6 +104,9 @@ var ErrNonPayableFunctionDcdt = errors.New("function does not accept DCDT paymen
 // ErrArgIndexOutOfRange signals that the argument index is out of range
 var ErrArgIndexOutOfRange = errors.New("argument index out of range")
 
+// ErrInvalidTokenIndex signals that the DCDT transfer index is out of range
+var ErrInvalidTokenIndex = errors.New("invalid token index")
+
 // ErrArgOutOfRange signals that the argument is out of range
 var ErrArgOutOfRange = errors.New("argument out of range")
 
@@ -113,6 +122,18 @@ var ErrBitwiseNegative = errors.New("bitwise operations only allowed on positive
 // ErrShiftNegative signals that an attempt to apply a bitwise shift operation on negative numbers has been made
 var ErrShiftNegative = errors.New("bitwise shift operations only allowed on positive integers and by a positive amount")
 
+// ErrNegativeExponent signals that an attempt to raise a number to a negative power has been made
+var ErrNegativeExponent = errors.New("exponent must not be negative")
+
+// ErrSqrtNegative signals that an attempt to compute the square root of a negative number has been made
+var ErrSqrtNegative = errors.New("square root only allowed on non-negative integers")
+
+// ErrLog2NotPositive signals that an attempt to compute the logarithm of a non-positive number has been made
+var ErrLog2NotPositive = errors.New("logarithm only allowed on positive integers")
+
+// ErrBigIntResultTooLarge signals that the result of a big int operation would exceed the maximum allowed length
+var ErrBigIntResultTooLarge = errors.New("big int operation result is too large")
+
 // ErrAsyncContextDoesNotExist signals that the async context does not exist
 var ErrAsyncContextDoesNotExist = errors.New("async context does not exist")
 
@@ -128,6 +149,9 @@ var ErrAccountNotPayable = errors.New("sending value to non payable contract")
 // ErrInvalidPublicKeySize signals that the public key size is invalid
 var ErrInvalidPublicKeySize = errors.New("invalid public key size")
 
+// ErrInvalidNumberOfPublicKeys signals that the number of public keys passed to an aggregated signature verification is invalid
+var ErrInvalidNumberOfPublicKeys = errors.New("invalid number of public keys")
+
 // ErrNilCallbackFunction signals that a nil callback function has been provided
 var ErrNilCallbackFunction = errors.New("nil callback function")
 
@@ -152,3 +176,21 @@ var ErrTransferValueOnDCDTCall = errors.New("transfer value on dcdt call")
 
 // ErrNilEnableEpochsHandler signals that enable epochs handler is nil
 var ErrNilEnableEpochsHandler = errors.New("nil enable epochs handler")
+
+// ErrSelfDestructNotAllowed signals that the contract is not allowed to self destruct
+var ErrSelfDestructNotAllowed = errors.New("self destruct not allowed")
+
+// ErrInvalidBeneficiary signals that the beneficiary of a self destruct is invalid
+var ErrInvalidBeneficiary = fmt.Errorf("%w (invalid beneficiary)", ErrSelfDestructNotAllowed)
+
+// ErrInvalidNumberOfTopics signals that an ethereum log has more than four topics
+var ErrInvalidNumberOfTopics = errors.New("invalid number of topics")
+
+// ErrEthereumValueTooLarge signals that a value does not fit the 128 bits of the ethereum values
+var ErrEthereumValueTooLarge = errors.New("value does not fit in 128 bits")
+
+// ErrEthereumWordTooLong signals that a storage value does not fit the 32 bytes of an ethereum word
+var ErrEthereumWordTooLong = errors.New("storage value longer than 32 bytes")
+
+// ErrNoManagedBufferUnderThisHandle signals that there is no managed buffer under the given handle
+var ErrNoManagedBufferUnderThisHandle = errors.New("no managed buffer under the given handle")
@@ -0,0 +1,258 @@
+package vmhost
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// GasCategory identifies the kind of operation for which gas has been consumed
+type GasCategory string
+
+const (
+	// GasCategoryInitialCost is the gas consumed for compiling or deploying a contract
+	GasCategoryInitialCost GasCategory = "InitialCost"
+
+	// GasCategoryWasmOpcodes is the gas consumed by the WASM opcodes of a contract
+	GasCategoryWasmOpcodes GasCategory = "WASMOpcodes"
+
+	// GasCategoryBaseOpsAPI is the gas consumed by the hooks priced in BaseOpsAPICost
+	GasCategoryBaseOpsAPI GasCategory = "BaseOpsAPICost"
+
+	// GasCategoryBigIntAPI is the gas consumed by the hooks priced in BigIntAPICost
+	GasCategoryBigIntAPI GasCategory = "BigIntAPICost"
+
+	// GasCategoryManagedBufferAPI is the gas consumed by the hooks priced in ManagedBufferAPICost
+	GasCategoryManagedBufferAPI GasCategory = "ManagedBufferAPICost"
+
+	// GasCategoryCryptoAPI is the gas consumed by the hooks priced in CryptoAPICost
+	GasCategoryCryptoAPI GasCategory = "CryptoAPICost"
+
+	// GasCategoryEthAPI is the gas consumed by the hooks of the ethereum
+	// namespace, priced in EthAPICost
+	GasCategoryEthAPI GasCategory = "EthAPICost"
+
+	// GasCategoryStoragePerByte is the gas consumed proportionally to the
+	// length of the values read from or written to the storage
+	GasCategoryStoragePerByte GasCategory = "StoragePerByte"
+)
+
+// GasProfileFrame identifies a function of a contract on the call stack
+type GasProfileFrame struct {
+	Address  []byte
+	Function string
+}
+
+// GasProfileEntry holds the gas consumed for a specific category while a
+// specific call stack was active; HookName is empty for the categories which
+// are not attributed to hooks
+type GasProfileEntry struct {
+	Stack    []GasProfileFrame
+	Category GasCategory
+	HookName string
+	Gas      uint64
+}
+
+// AddressFormatter converts a contract address to the text used in gas profile reports
+type AddressFormatter func(address []byte) string
+
+// GasProfile accumulates the gas consumed during the execution of smart
+// contracts, attributed to call stacks and gas categories
+type GasProfile struct {
+	entries map[string]*GasProfileEntry
+}
+
+// NewGasProfile creates a new, empty GasProfile
+func NewGasProfile() *GasProfile {
+	return &GasProfile{
+		entries: make(map[string]*GasProfileEntry),
+	}
+}
+
+// AddGas attributes the provided gas to the given call stack, category and hook
+func (profile *GasProfile) AddGas(stack []GasProfileFrame, category GasCategory, hookName string, gas uint64) {
+	if gas == 0 {
+		return
+	}
+
+	key := profileEntryKey(stack, category, hookName)
+	entry, exists := profile.entries[key]
+	if !exists {
+		entry = &GasProfileEntry{
+			Stack:    copyProfileStack(stack),
+			Category: category,
+			HookName: hookName,
+		}
+		profile.entries[key] = entry
+	}
+
+	entry.Gas += gas
+}
+
+// Merge adds all the entries of the provided GasProfile to the current one
+func (profile *GasProfile) Merge(other *GasProfile) {
+	if other == nil {
+		return
+	}
+
+	for _, entry := range other.entries {
+		profile.AddGas(entry.Stack, entry.Category, entry.HookName, entry.Gas)
+	}
+}
+
+// Entries returns the entries of the GasProfile, sorted by their call stacks
+func (profile *GasProfile) Entries() []*GasProfileEntry {
+	keys := make([]string, 0, len(profile.entries))
+	for key := range profile.entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]*GasProfileEntry, 0, len(keys))
+	for _, key := range keys {
+		entries = append(entries, profile.entries[key])
+	}
+
+	return entries
+}
+
+// TotalGas returns the sum of the gas of all entries
+func (profile *GasProfile) TotalGas() uint64 {
+	total := uint64(0)
+	for _, entry := range profile.entries {
+		total += entry.Gas
+	}
+
+	return total
+}
+
+// GasByContract returns the gas consumed by each contract, keyed by the
+// contract address; the gas is attributed to the innermost contract only
+func (profile *GasProfile) GasByContract() map[string]uint64 {
+	result := make(map[string]uint64)
+	for _, entry := range profile.entries {
+		frame := innermostFrame(entry.Stack)
+		result[string(frame.Address)] += entry.Gas
+	}
+
+	return result
+}
+
+// GasByFunction returns the gas consumed by each contract function, keyed by
+// the hex-encoded contract address and the function name, separated by "::"
+func (profile *GasProfile) GasByFunction() map[string]uint64 {
+	result := make(map[string]uint64)
+	for _, entry := range profile.entries {
+		frame := innermostFrame(entry.Stack)
+		result[formatProfileFrame(frame, hex.EncodeToString)] += entry.Gas
+	}
+
+	return result
+}
+
+// GasByCategory returns the gas consumed for each GasCategory
+func (profile *GasProfile) GasByCategory() map[GasCategory]uint64 {
+	result := make(map[GasCategory]uint64)
+	for _, entry := range profile.entries {
+		result[entry.Category] += entry.Gas
+	}
+
+	return result
+}
+
+// GasByHook returns the gas consumed by each EEI hook
+func (profile *GasProfile) GasByHook() map[string]uint64 {
+	result := make(map[string]uint64)
+	for _, entry := range profile.entries {
+		if len(entry.HookName) == 0 {
+			continue
+		}
+		result[entry.HookName] += entry.Gas
+	}
+
+	return result
+}
+
+// FoldedStacks returns the GasProfile in the folded stack format consumed by
+// flame graph tools, one sorted line per entry; addresses are hex-encoded if
+// no AddressFormatter is provided
+func (profile *GasProfile) FoldedStacks(formatAddress AddressFormatter) []string {
+	if formatAddress == nil {
+		formatAddress = hex.EncodeToString
+	}
+
+	gasByLine := make(map[string]uint64)
+	for _, entry := range profile.entries {
+		parts := make([]string, 0, len(entry.Stack)+2)
+		for _, frame := range entry.Stack {
+			parts = append(parts, formatProfileFrame(frame, formatAddress))
+		}
+		parts = append(parts, string(entry.Category))
+		if len(entry.HookName) > 0 {
+			parts = append(parts, entry.HookName)
+		}
+
+		gasByLine[strings.Join(parts, ";")] += entry.Gas
+	}
+
+	lines := make([]string, 0, len(gasByLine))
+	for stack, gas := range gasByLine {
+		lines = append(lines, fmt.Sprintf("%s %d", stack, gas))
+	}
+	sort.Strings(lines)
+
+	return lines
+}
+
+// WriteFoldedStacks writes the GasProfile to the provided writer, in the folded stack format
+func (profile *GasProfile) WriteFoldedStacks(writer io.Writer, formatAddress AddressFormatter) error {
+	for _, line := range profile.FoldedStacks(formatAddress) {
+		_, err := fmt.Fprintln(writer, line)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func profileEntryKey(stack []GasProfileFrame, category GasCategory, hookName string) string {
+	var builder strings.Builder
+	for _, frame := range stack {
+		builder.WriteString(formatProfileFrame(frame, hex.EncodeToString))
+		builder.WriteString(";")
+	}
+	builder.WriteString(string(category))
+	builder.WriteString(";")
+	builder.WriteString(hookName)
+
+	return builder.String()
+}
+
+func formatProfileFrame(frame GasProfileFrame, formatAddress AddressFormatter) string {
+	return formatAddress(frame.Address) + "::" + frame.Function
+}
+
+func innermostFrame(stack []GasProfileFrame) GasProfileFrame {
+	if len(stack) == 0 {
+		return GasProfileFrame{}
+	}
+
+	return stack[len(stack)-1]
+}
+
+func copyProfileStack(stack []GasProfileFrame) []GasProfileFrame {
+	stackCopy := make([]GasProfileFrame, len(stack))
+	for i, frame := range stack {
+		address := make([]byte, len(frame.Address))
+		copy(address, frame.Address)
+		stackCopy[i] = GasProfileFrame{
+			Address:  address,
+			Function: frame.Function,
+		}
+	}
+
+	return stackCopy
+}
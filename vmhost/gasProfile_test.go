@@ -0,0 +1,58 @@
+package vmhost
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasProfile_AddGasAndMerge(t *testing.T) {
+	t.Parallel()
+
+	stack := []GasProfileFrame{{Address: []byte("sc"), Function: "foo"}}
+
+	first := NewGasProfile()
+	first.AddGas(stack, GasCategoryWasmOpcodes, "", 100)
+	first.AddGas(stack, GasCategoryBaseOpsAPI, "getCaller", 10)
+	first.AddGas(stack, GasCategoryBaseOpsAPI, "getCaller", 0)
+
+	// the entries keep their own copy of the stack
+	stack[0].Function = "bar"
+
+	second := NewGasProfile()
+	second.AddGas(stack, GasCategoryBaseOpsAPI, "getCaller", 5)
+	second.AddGas(stack, GasCategoryWasmOpcodes, "", 50)
+
+	first.Merge(second)
+	first.Merge(nil)
+
+	require.Len(t, first.Entries(), 4)
+	require.Equal(t, uint64(165), first.TotalGas())
+	require.Equal(t, map[string]uint64{"sc": 165}, first.GasByContract())
+	require.Equal(t, map[string]uint64{"7363::foo": 110, "7363::bar": 55}, first.GasByFunction())
+	require.Equal(t, map[string]uint64{"getCaller": 15}, first.GasByHook())
+	require.Equal(t, map[GasCategory]uint64{
+		GasCategoryWasmOpcodes: 150,
+		GasCategoryBaseOpsAPI:  15,
+	}, first.GasByCategory())
+}
+
+func TestGasProfile_WriteFoldedStacks(t *testing.T) {
+	t.Parallel()
+
+	profile := NewGasProfile()
+	parent := GasProfileFrame{Address: []byte("parent"), Function: "run"}
+	child := GasProfileFrame{Address: []byte("child"), Function: "add"}
+	profile.AddGas([]GasProfileFrame{parent}, GasCategoryWasmOpcodes, "", 7)
+	profile.AddGas([]GasProfileFrame{parent, child}, GasCategoryBigIntAPI, "bigIntAdd", 3)
+
+	formatAddress := func(address []byte) string {
+		return string(address)
+	}
+
+	buffer := &bytes.Buffer{}
+	err := profile.WriteFoldedStacks(buffer, formatAddress)
+	require.Nil(t, err)
+	require.Equal(t, "parent::run;WASMOpcodes 7\nparent::run;child::add;BigIntAPICost;bigIntAdd 3\n", buffer.String())
+}
@@ -150,6 +150,11 @@ func GetBigIntContext(vmHostPtr unsafe.Pointer) BigIntContext {
 	return GetVMHost(vmHostPtr).BigInt()
 }
 
+// GetManagedBufferContext returns the managed buffer context
+func GetManagedBufferContext(vmHostPtr unsafe.Pointer) ManagedBufferContext {
+	return GetVMHost(vmHostPtr).ManagedBuffer()
+}
+
 // GetOutputContext returns the output context
 func GetOutputContext(vmHostPtr unsafe.Pointer) OutputContext {
 	return GetVMHost(vmHostPtr).Output()
@@ -171,9 +176,18 @@ func WithFault(err error, vmHostPtr unsafe.Pointer, failExecution bool) bool {
 		return false
 	}
 
+	return WithFaultAndHost(GetVMHost(vmHostPtr), err, failExecution)
+}
+
+// WithFaultAndHost is WithFault for the callers already holding the VMHost
+func WithFaultAndHost(host VMHost, err error, failExecution bool) bool {
+	if err == nil {
+		return false
+	}
+
 	if failExecution {
-		runtime := GetRuntimeContext(vmHostPtr)
-		metering := GetMeteringContext(vmHostPtr)
+		runtime := host.Runtime()
+		metering := host.Metering()
 
 		metering.UseGas(metering.GasLeft())
 		runtime.FailExecution(err)
@@ -0,0 +1,264 @@
+package hostCore
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kalyan3104/k-chain-core-go/core"
+	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/config"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/mock"
+	contextmock "github.com/kalyan3104/k-chain-vm-v1_2-go/mock/context"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost/vmhooks"
+	"github.com/stretchr/testify/require"
+)
+
+// the number of bits of the largest result allowed for the big int operations
+const maxBigIntResultBitLen = 4096 * 8
+
+type bigIntOpsTestConfig struct {
+	sizeLimitEnabled bool
+	failExecution    bool
+}
+
+func runBigIntOpsMocked(t *testing.T, testConfig bigIntOpsTestConfig, method func(host *vmHost)) *vmcommon.VMOutput {
+	host, _, ibm := defaultTestVMForCallWithInstanceMocks(t)
+	host.enableEpochsHandler = &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			if flag == BigIntResultSizeLimitFlag {
+				return testConfig.sizeLimitEnabled
+			}
+			return flag == SCDeployFlag || flag == AheadOfTimeGasUsageFlag || flag == RepairCallbackFlag || flag == BuiltInFunctionsFlag
+		},
+	}
+	if !testConfig.failExecution {
+		runtimeWrapper := contextmock.NewRuntimeContextWrapper(&host.runtimeContext)
+		runtimeWrapper.BigIntAPIErrorShouldFailExecutionFunc = func() bool {
+			return false
+		}
+		host.runtimeContext = runtimeWrapper
+	}
+
+	instance := ibm.CreateAndStoreInstanceMock(parentAddress, 0)
+	instance.AddMockMethod("bigIntOps", func() {
+		method(host)
+	})
+
+	input := DefaultTestContractCallInput()
+	input.Function = "bigIntOps"
+	input.GasProvided = 1000000
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	return vmOutput
+}
+
+func putBigInt(host *vmHost, value *big.Int) int32 {
+	handle := host.BigInt().Put(0)
+	host.BigInt().GetOne(handle).Set(value)
+	return handle
+}
+
+func requireBigIntFault(t *testing.T, vmOutput *vmcommon.VMOutput, expectedErr error) {
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, expectedErr.Error(), vmOutput.ReturnMessage)
+}
+
+func requireBigIntEqual(t *testing.T, expected *big.Int, actual *big.Int, msgAndArgs ...interface{}) {
+	require.Equal(t, expected.String(), actual.String(), msgAndArgs...)
+}
+
+func testGasCost(t *testing.T) *config.GasCost {
+	gasCost, err := config.CreateGasConfig(config.MakeGasMapForTests())
+	require.Nil(t, err)
+	return gasCost
+}
+
+func TestBigIntOps_Pow_Mocked(t *testing.T) {
+	hugeExponent := big.NewInt(0).Lsh(big.NewInt(1), 100)
+	hugeOddExponent := big.NewInt(0).Add(hugeExponent, big.NewInt(1))
+
+	testCases := []struct {
+		base     *big.Int
+		exponent *big.Int
+		expected *big.Int
+	}{
+		{base: big.NewInt(7), exponent: big.NewInt(0), expected: big.NewInt(1)},
+		{base: big.NewInt(0), exponent: big.NewInt(0), expected: big.NewInt(1)},
+		{base: big.NewInt(7), exponent: big.NewInt(1), expected: big.NewInt(7)},
+		{base: big.NewInt(-7), exponent: big.NewInt(1), expected: big.NewInt(-7)},
+		{base: big.NewInt(-3), exponent: big.NewInt(3), expected: big.NewInt(-27)},
+		{base: big.NewInt(0), exponent: hugeExponent, expected: big.NewInt(0)},
+		{base: big.NewInt(1), exponent: hugeExponent, expected: big.NewInt(1)},
+		{base: big.NewInt(-1), exponent: hugeExponent, expected: big.NewInt(1)},
+		{base: big.NewInt(-1), exponent: hugeOddExponent, expected: big.NewInt(-1)},
+	}
+
+	vmOutput := runBigIntOpsMocked(t, bigIntOpsTestConfig{failExecution: true}, func(host *vmHost) {
+		for _, testCase := range testCases {
+			destination := host.BigInt().Put(42)
+			base := putBigInt(host, testCase.base)
+			exponent := putBigInt(host, testCase.exponent)
+
+			vmhooks.BigIntPowWithHost(host, destination, base, exponent)
+			requireBigIntEqual(t, testCase.expected, host.BigInt().GetOne(destination),
+				"%s ^ %s", testCase.base, testCase.exponent)
+		}
+	})
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
+
+func TestBigIntOps_Pow_ResultTooLarge_Mocked(t *testing.T) {
+	// 2 has 2 bits, so 2^16384 is estimated at exactly the maximum length
+	vmOutput := runBigIntOpsMocked(t, bigIntOpsTestConfig{failExecution: true}, func(host *vmHost) {
+		destination := host.BigInt().Put(0)
+		vmhooks.BigIntPowWithHost(host, destination, host.BigInt().Put(2), host.BigInt().Put(maxBigIntResultBitLen/2))
+		require.Equal(t, maxBigIntResultBitLen/2+1, host.BigInt().GetOne(destination).BitLen())
+	})
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	// pow is capped even without BigIntResultSizeLimitFlag
+	vmOutput = runBigIntOpsMocked(t, bigIntOpsTestConfig{failExecution: true}, func(host *vmHost) {
+		destination := host.BigInt().Put(0)
+		vmhooks.BigIntPowWithHost(host, destination, host.BigInt().Put(2), host.BigInt().Put(maxBigIntResultBitLen/2+1))
+	})
+	requireBigIntFault(t, vmOutput, vmhost.ErrBigIntResultTooLarge)
+
+	hugeExponent := big.NewInt(0).Lsh(big.NewInt(1), 100)
+	vmOutput = runBigIntOpsMocked(t, bigIntOpsTestConfig{failExecution: true}, func(host *vmHost) {
+		destination := host.BigInt().Put(0)
+		vmhooks.BigIntPowWithHost(host, destination, host.BigInt().Put(2), putBigInt(host, hugeExponent))
+	})
+	requireBigIntFault(t, vmOutput, vmhost.ErrBigIntResultTooLarge)
+}
+
+func TestBigIntOps_Pow_NegativeExponent_Mocked(t *testing.T) {
+	vmOutput := runBigIntOpsMocked(t, bigIntOpsTestConfig{failExecution: true}, func(host *vmHost) {
+		destination := host.BigInt().Put(0)
+		vmhooks.BigIntPowWithHost(host, destination, host.BigInt().Put(2), host.BigInt().Put(-1))
+	})
+	requireBigIntFault(t, vmOutput, vmhost.ErrNegativeExponent)
+
+	vmOutput = runBigIntOpsMocked(t, bigIntOpsTestConfig{}, func(host *vmHost) {
+		destination := host.BigInt().Put(42)
+		vmhooks.BigIntPowWithHost(host, destination, host.BigInt().Put(2), host.BigInt().Put(-1))
+		requireBigIntEqual(t, big.NewInt(42), host.BigInt().GetOne(destination))
+	})
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
+
+func TestBigIntOps_Sqrt_Mocked(t *testing.T) {
+	vmOutput := runBigIntOpsMocked(t, bigIntOpsTestConfig{failExecution: true}, func(host *vmHost) {
+		destination := host.BigInt().Put(42)
+		vmhooks.BigIntSqrtWithHost(host, destination, host.BigInt().Put(0))
+		requireBigIntEqual(t, big.NewInt(0), host.BigInt().GetOne(destination))
+
+		vmhooks.BigIntSqrtWithHost(host, destination, host.BigInt().Put(17))
+		requireBigIntEqual(t, big.NewInt(4), host.BigInt().GetOne(destination))
+	})
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	vmOutput = runBigIntOpsMocked(t, bigIntOpsTestConfig{failExecution: true}, func(host *vmHost) {
+		vmhooks.BigIntSqrtWithHost(host, host.BigInt().Put(0), host.BigInt().Put(-4))
+	})
+	requireBigIntFault(t, vmOutput, vmhost.ErrSqrtNegative)
+}
+
+func TestBigIntOps_Log2_Mocked(t *testing.T) {
+	vmOutput := runBigIntOpsMocked(t, bigIntOpsTestConfig{failExecution: true}, func(host *vmHost) {
+		require.Equal(t, int32(0), vmhooks.BigIntLog2WithHost(host, host.BigInt().Put(1)))
+		require.Equal(t, int32(4), vmhooks.BigIntLog2WithHost(host, host.BigInt().Put(31)))
+		require.Equal(t, int32(5), vmhooks.BigIntLog2WithHost(host, host.BigInt().Put(32)))
+	})
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	for _, value := range []int64{0, -8} {
+		vmOutput = runBigIntOpsMocked(t, bigIntOpsTestConfig{failExecution: true}, func(host *vmHost) {
+			require.Equal(t, int32(-1), vmhooks.BigIntLog2WithHost(host, host.BigInt().Put(value)))
+		})
+		requireBigIntFault(t, vmOutput, vmhost.ErrLog2NotPositive)
+
+		vmOutput = runBigIntOpsMocked(t, bigIntOpsTestConfig{}, func(host *vmHost) {
+			require.Equal(t, int32(-1), vmhooks.BigIntLog2WithHost(host, host.BigInt().Put(value)))
+		})
+		require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	}
+}
+
+func TestBigIntOps_Mul_ResultTooLarge_Mocked(t *testing.T) {
+	// 4096 bytes multiplied by 2 need one more bit than allowed
+	largeValue := big.NewInt(0).Lsh(big.NewInt(1), maxBigIntResultBitLen-1)
+	mul := func(host *vmHost) *big.Int {
+		destination := host.BigInt().Put(42)
+		vmhooks.BigIntMulWithHost(host, destination, putBigInt(host, largeValue), host.BigInt().Put(2))
+		return host.BigInt().GetOne(destination)
+	}
+
+	vmOutput := runBigIntOpsMocked(t, bigIntOpsTestConfig{sizeLimitEnabled: true, failExecution: true}, func(host *vmHost) {
+		_ = mul(host)
+	})
+	requireBigIntFault(t, vmOutput, vmhost.ErrBigIntResultTooLarge)
+
+	vmOutput = runBigIntOpsMocked(t, bigIntOpsTestConfig{sizeLimitEnabled: true}, func(host *vmHost) {
+		requireBigIntEqual(t, big.NewInt(42), mul(host))
+	})
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	vmOutput = runBigIntOpsMocked(t, bigIntOpsTestConfig{failExecution: true}, func(host *vmHost) {
+		require.Equal(t, maxBigIntResultBitLen+1, mul(host).BitLen())
+	})
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
+
+func TestBigIntOps_Shl_ResultTooLarge_Mocked(t *testing.T) {
+	shl := func(host *vmHost, bits int32) *big.Int {
+		destination := host.BigInt().Put(42)
+		vmhooks.BigIntShlWithHost(host, destination, host.BigInt().Put(1), bits)
+		return host.BigInt().GetOne(destination)
+	}
+
+	vmOutput := runBigIntOpsMocked(t, bigIntOpsTestConfig{sizeLimitEnabled: true, failExecution: true}, func(host *vmHost) {
+		require.Equal(t, maxBigIntResultBitLen, shl(host, maxBigIntResultBitLen-1).BitLen())
+	})
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	vmOutput = runBigIntOpsMocked(t, bigIntOpsTestConfig{sizeLimitEnabled: true, failExecution: true}, func(host *vmHost) {
+		_ = shl(host, maxBigIntResultBitLen)
+	})
+	requireBigIntFault(t, vmOutput, vmhost.ErrBigIntResultTooLarge)
+
+	vmOutput = runBigIntOpsMocked(t, bigIntOpsTestConfig{sizeLimitEnabled: true}, func(host *vmHost) {
+		requireBigIntEqual(t, big.NewInt(42), shl(host, maxBigIntResultBitLen))
+	})
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	vmOutput = runBigIntOpsMocked(t, bigIntOpsTestConfig{failExecution: true}, func(host *vmHost) {
+		require.Equal(t, maxBigIntResultBitLen+1, shl(host, maxBigIntResultBitLen).BitLen())
+	})
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
+
+func TestBigIntOps_Mul_GasProportionalToSize_Mocked(t *testing.T) {
+	gasCost := testGasCost(t)
+	var smallGasUsed, largeGasUsed uint64
+
+	vmOutput := runBigIntOpsMocked(t, bigIntOpsTestConfig{sizeLimitEnabled: true, failExecution: true}, func(host *vmHost) {
+		metering := host.Metering()
+
+		gasLeft := metering.GasLeft()
+		vmhooks.BigIntMulWithHost(host, host.BigInt().Put(0), host.BigInt().Put(3), host.BigInt().Put(5))
+		smallGasUsed = gasLeft - metering.GasLeft()
+
+		// 64 bytes each, with a result of 1023 bits, counted as 127 bytes
+		largeValue := big.NewInt(0).Lsh(big.NewInt(1), 511)
+		gasLeft = metering.GasLeft()
+		vmhooks.BigIntMulWithHost(host, host.BigInt().Put(0), putBigInt(host, largeValue), putBigInt(host, largeValue))
+		largeGasUsed = gasLeft - metering.GasLeft()
+	})
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	require.Equal(t, gasCost.BigIntAPICost.BigIntMul, smallGasUsed)
+	expectedLargeGasUsed := gasCost.BigIntAPICost.BigIntMul + (64+64+127)*gasCost.BaseOperationCost.DataCopyPerByte
+	require.Equal(t, expectedLargeGasUsed, largeGasUsed)
+}
@@ -31,6 +31,9 @@ func (host *vmHost) handleBreakpoint(breakpointValue vmhost.BreakpointValue) err
 	if breakpointValue == vmhost.BreakpointOutOfGas {
 		return vmhost.ErrNotEnoughGas
 	}
+	if breakpointValue == vmhost.BreakpointExit {
+		return nil
+	}
 
 	return vmhost.ErrUnhandledRuntimeBreakpoint
 }
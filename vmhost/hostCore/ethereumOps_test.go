@@ -0,0 +1,84 @@
+package hostCore
+
+import (
+	"testing"
+
+	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost/vmhooks"
+	"github.com/stretchr/testify/require"
+)
+
+const wasmPageSize = 65536
+
+func runCallDataCopyMocked(t *testing.T, resultOffset int32, dataOffset int32, length int32) (*vmcommon.VMOutput, []byte, uint64) {
+	host, _, ibm := defaultTestVMForCallWithInstanceMocks(t)
+
+	var copied []byte
+	var gasUsed uint64
+	instance := ibm.CreateAndStoreInstanceMock(parentAddress, 0)
+	instance.AddMockMethod("copy", func() {
+		metering := host.Metering()
+		gasLeft := metering.GasLeft()
+		vmhooks.EthereumCallDataCopyWithHost(host, resultOffset, dataOffset, length)
+		gasUsed = gasLeft - metering.GasLeft()
+
+		if length > 0 && host.Runtime().GetRuntimeBreakpointValue() == vmhost.BreakpointNone {
+			var err error
+			copied, err = host.Runtime().MemLoad(resultOffset, length)
+			require.Nil(t, err)
+		}
+	})
+
+	input := DefaultTestContractCallInput()
+	input.Function = "copy"
+	input.Arguments = [][]byte{{0x01, 0x02}}
+	input.GasProvided = 1000000
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+
+	return vmOutput, copied, gasUsed
+}
+
+func TestEthereumOps_CallDataCopy_Mocked(t *testing.T) {
+	vmOutput, copied, gasUsed := runCallDataCopyMocked(t, 100, 2, 40)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	// the 4 bytes of the function selector are followed by the argument, padded to 32 bytes
+	require.Len(t, copied, 40)
+	require.Equal(t, make([]byte, 30), copied[2:32])
+	require.Equal(t, []byte{0x01, 0x02}, copied[32:34])
+	require.Equal(t, make([]byte, 6), copied[34:])
+
+	gasCost := testGasCost(t)
+	expectedGas := gasCost.EthAPICost.CallDataCopy + 40*gasCost.BaseOperationCost.DataCopyPerByte
+	require.Equal(t, expectedGas, gasUsed)
+}
+
+func TestEthereumOps_CallDataCopy_GrowsMemoryByOnePage_Mocked(t *testing.T) {
+	vmOutput, copied, _ := runCallDataCopyMocked(t, 2*wasmPageSize+100, 0, 4)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Len(t, copied, 4)
+}
+
+func TestEthereumOps_CallDataCopy_OutOfBounds_Mocked(t *testing.T) {
+	vmOutput, _, _ := runCallDataCopyMocked(t, 3*wasmPageSize-10, 0, 20)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, vmhost.ErrBadUpperBounds.Error(), vmOutput.ReturnMessage)
+
+	// the copied bytes are paid for before the destination is checked
+	vmOutput, _, _ = runCallDataCopyMocked(t, 0, 0, 0x7fffffff)
+	require.Equal(t, vmcommon.OutOfGas, vmOutput.ReturnCode)
+	require.Equal(t, vmhost.ErrBadUpperBounds.Error(), vmOutput.ReturnMessage)
+
+	vmOutput, _, _ = runCallDataCopyMocked(t, -1, 0, 4)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, vmhost.ErrBadLowerBounds.Error(), vmOutput.ReturnMessage)
+}
+
+func TestEthereumOps_CallDataCopy_NegativeLength_Mocked(t *testing.T) {
+	vmOutput, _, _ := runCallDataCopyMocked(t, 0, 0, -1)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, vmhost.ErrNegativeLength.Error(), vmOutput.ReturnMessage)
+}
@@ -70,6 +70,9 @@ func (host *vmHost) performCodeDeployment(input vmhost.CodeDeployInput) (*vmcomm
 	err = runtime.StartWasmerInstance(input.ContractCode, metering.GetGasForExecution(), true)
 	if err != nil {
 		log.Debug("performCodeDeployment/StartWasmerInstance", "err", err)
+		if errors.Is(err, vmhost.ErrCodePolicyViolation) {
+			return nil, err
+		}
 		return nil, vmhost.ErrContractInvalid
 	}
 
@@ -141,6 +144,9 @@ func (host *vmHost) doRunSmartContractCall(input *vmcommon.ContractCallInput) (v
 	output.AddTxValueToAccount(input.RecipientAddr, input.CallValue)
 	storage.SetAddress(runtime.GetSCAddress())
 
+	host.startGasProfilingFrame()
+	defer host.finishGasProfiling()
+
 	err := host.checkGasForGetCode(input, metering)
 	if err != nil {
 		log.Trace("doRunSmartContractCall get code", "error", vmhost.ErrNotEnoughGas)
@@ -163,6 +169,7 @@ func (host *vmHost) doRunSmartContractCall(input *vmcommon.ContractCallInput) (v
 	if err != nil {
 		return output.CreateVMOutputInCaseOfError(vmhost.ErrContractInvalid)
 	}
+	host.startGasProfilingInstance()
 
 	err = host.callSCMethod()
 	if err != nil {
@@ -177,6 +184,7 @@ func (host *vmHost) doRunSmartContractCall(input *vmcommon.ContractCallInput) (v
 		"message", vmOutput.ReturnMessage,
 		"data", vmOutput.ReturnData)
 
+	host.finishGasProfiling()
 	runtime.CleanWasmerInstance()
 	return
 }
@@ -208,6 +216,10 @@ func (host *vmHost) ExecuteOnDestContext(input *vmcommon.ContractCallInput) (vmO
 	bigInt.PushState()
 	bigInt.InitState()
 
+	managedBuffer := host.ManagedBuffer()
+	managedBuffer.PushState()
+	managedBuffer.InitState()
+
 	output.PushState()
 	output.CensorVMOutput()
 
@@ -222,6 +234,8 @@ func (host *vmHost) ExecuteOnDestContext(input *vmcommon.ContractCallInput) (vmO
 	storage.PushState()
 	storage.SetAddress(runtime.GetSCAddress())
 
+	host.startGasProfilingFrame()
+
 	defer func() {
 		vmOutput = host.finishExecuteOnDestContext(err)
 		metering.SetTotalUsedGas(0)
@@ -273,10 +287,13 @@ func (host *vmHost) finishExecuteOnDestContext(executeErr error) *vmcommon.VMOut
 		gasSpentByChildContract = 0
 	}
 
+	host.endGasProfilingFrame()
+
 	// Restore the previous context states, except Output, which will be merged
 	// into the initial state (VMOutput), but only if it the child execution
 	// returned vmcommon.Ok.
 	bigInt.PopSetActiveState()
+	host.ManagedBuffer().PopSetActiveState()
 	metering.PopSetActiveState()
 	runtime.PopSetActiveState()
 	storage.PopSetActiveState()
@@ -310,6 +327,7 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) (asy
 	// Back up the states of the contexts (except Storage, which isn't affected
 	// by ExecuteOnSameContext())
 	bigInt.PushState()
+	host.ManagedBuffer().PushState()
 	output.PushState()
 
 	copyTxHashesFromContext(host.IsDCDTFunctionsEnabled(), runtime, input)
@@ -319,6 +337,8 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) (asy
 	metering.PushState()
 	metering.InitStateFromContractCallInput(&input.VMInput)
 
+	host.startGasProfilingFrame()
+
 	defer func() {
 		host.finishExecuteOnSameContext(err)
 	}()
@@ -342,9 +362,12 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) (asy
 func (host *vmHost) finishExecuteOnSameContext(executeErr error) {
 	bigInt, _, metering, output, runtime, _ := host.GetContexts()
 
+	host.endGasProfilingFrame()
+
 	if output.ReturnCode() != vmcommon.Ok || executeErr != nil {
 		// Execution failed: restore contexts as if the execution didn't happen.
 		bigInt.PopSetActiveState()
+		host.ManagedBuffer().PopSetActiveState()
 		metering.PopSetActiveState()
 		output.PopSetActiveState()
 		runtime.PopSetActiveState()
@@ -366,6 +389,7 @@ func (host *vmHost) finishExecuteOnSameContext(executeErr error) {
 	// resume from the new state. However, output.PopDiscard() will ensure that
 	// all GasUsed records will be restored, undoing the action of output.ResetGas()
 	bigInt.PopDiscard()
+	host.ManagedBuffer().PopDiscard()
 	output.PopDiscard()
 	metering.PopSetActiveState()
 	runtime.PopSetActiveState()
@@ -512,6 +536,9 @@ func (host *vmHost) executeUpgrade(input *vmcommon.ContractCallInput) error {
 	err = runtime.StartWasmerInstance(codeDeployInput.ContractCode, metering.GetGasForExecution(), true)
 	if err != nil {
 		log.Debug("performCodeDeployment/StartWasmerInstance", "err", err)
+		if errors.Is(err, vmhost.ErrCodePolicyViolation) {
+			return err
+		}
 		return vmhost.ErrContractInvalid
 	}
 
@@ -581,6 +608,7 @@ func (host *vmHost) executeSmartContractCall(
 	if err != nil {
 		return err
 	}
+	host.startGasProfilingInstance()
 
 	err = host.callSCMethodIndirect()
 	if err != nil {
@@ -607,6 +635,7 @@ func (host *vmHost) execute(input *vmcommon.ContractCallInput) (uint64, error) {
 			runtime.InitStateFromContractCallInput(newVMInput)
 			metering.InitStateFromContractCallInput(&newVMInput.VMInput)
 			storage.SetAddress(runtime.GetSCAddress())
+			host.updateGasProfilingFrame()
 			err = host.executeSmartContractCall(newVMInput, metering, runtime, output, false)
 			if err != nil {
 				host.RevertDCDTTransfer(input)
@@ -992,10 +1021,14 @@ func (host *vmHost) isSCExecutionAfterBuiltInFunc(
 	if vmOutput.ReturnCode != vmcommon.Ok {
 		return nil, nil
 	}
+	isMultiDCDTTransferEnabled := host.IsMultiDCDTTransferEnabled()
 	recipient := vmInput.RecipientAddr
 	if vmInput.Function == core.BuiltInFunctionDCDTNFTTransfer && bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
 		recipient = vmInput.Arguments[3]
 	}
+	if isMultiDCDTTransferEnabled && vmInput.Function == core.BuiltInFunctionMultiDCDTNFTTransfer && bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		recipient = vmInput.Arguments[0]
+	}
 	if !host.AreInSameShard(vmInput.CallerAddr, recipient) {
 		return nil, nil
 	}
@@ -1037,12 +1070,20 @@ func (host *vmHost) isSCExecutionAfterBuiltInFunc(
 		AllowInitFunction: false,
 	}
 
-	fillWithDCDTValue(vmInput, newVMInput)
+	fillWithDCDTValue(vmInput, newVMInput, isMultiDCDTTransferEnabled)
+	if isMultiDCDTTransferEnabled {
+		host.setDCDTTokenTypesFromData(newVMInput, vmInput.CallerAddr)
+	}
 
 	return newVMInput, nil
 }
 
-func fillWithDCDTValue(fullVMInput *vmcommon.ContractCallInput, newVMInput *vmcommon.ContractCallInput) {
+func fillWithDCDTValue(fullVMInput *vmcommon.ContractCallInput, newVMInput *vmcommon.ContractCallInput, isMultiDCDTTransferEnabled bool) {
+	if isMultiDCDTTransferEnabled && fullVMInput.Function == core.BuiltInFunctionMultiDCDTNFTTransfer {
+		newVMInput.DCDTTransfers = parseMultiDCDTTransfers(fullVMInput)
+		return
+	}
+
 	isDCDTTransfer := fullVMInput.Function == core.BuiltInFunctionDCDTTransfer || fullVMInput.Function == core.BuiltInFunctionDCDTNFTTransfer
 	if !isDCDTTransfer {
 		return
@@ -1062,3 +1103,59 @@ func fillWithDCDTValue(fullVMInput *vmcommon.ContractCallInput, newVMInput *vmco
 	newVMInput.DCDTTransfers = make([]*vmcommon.DCDTTransfer, 1)
 	newVMInput.DCDTTransfers[0] = dcdtTransfer
 }
+
+// parseMultiDCDTTransfers extracts the tokens transferred by a MultiDCDTNFTTransfer;
+// on the sender shard the first argument is the destination, followed by the
+// number of transfers and a (token, nonce, value) triplet for each of them
+func parseMultiDCDTTransfers(vmInput *vmcommon.ContractCallInput) []*vmcommon.DCDTTransfer {
+	startIndex := 0
+	if bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		startIndex = 1
+	}
+	if len(vmInput.Arguments) <= startIndex {
+		return nil
+	}
+
+	numTransfers := big.NewInt(0).SetBytes(vmInput.Arguments[startIndex]).Uint64()
+	if numTransfers > uint64(len(vmInput.Arguments)-startIndex-1)/3 {
+		return nil
+	}
+
+	dcdtTransfers := make([]*vmcommon.DCDTTransfer, numTransfers)
+	for i := uint64(0); i < numTransfers; i++ {
+		tokenStartIndex := uint64(startIndex+1) + i*3
+		dcdtTransfers[i] = &vmcommon.DCDTTransfer{
+			DCDTTokenName:  vmInput.Arguments[tokenStartIndex],
+			DCDTTokenNonce: big.NewInt(0).SetBytes(vmInput.Arguments[tokenStartIndex+1]).Uint64(),
+			DCDTValue:      big.NewInt(0).SetBytes(vmInput.Arguments[tokenStartIndex+2]),
+			DCDTTokenType:  uint32(core.Fungible),
+		}
+		if dcdtTransfers[i].DCDTTokenNonce > 0 {
+			dcdtTransfers[i].DCDTTokenType = uint32(core.NonFungible)
+		}
+	}
+
+	return dcdtTransfers
+}
+
+// setDCDTTokenTypesFromData replaces the type guessed from the nonce of each
+// NFT / SFT transfer with the type recorded in the token data, since a nonce
+// alone does not tell the two apart; the data is looked up at the recipient
+// first, in case the builtin function already moved the tokens, then at the sender
+func (host *vmHost) setDCDTTokenTypesFromData(vmInput *vmcommon.ContractCallInput, sender []byte) {
+	for _, dcdtTransfer := range vmInput.DCDTTransfers {
+		if dcdtTransfer.DCDTTokenNonce == 0 {
+			continue
+		}
+
+		for _, holder := range [][]byte{vmInput.RecipientAddr, sender} {
+			dcdtToken, err := host.Blockchain().GetDCDTToken(holder, dcdtTransfer.DCDTTokenName, dcdtTransfer.DCDTTokenNonce)
+			if err != nil || dcdtToken == nil || dcdtToken.Value == nil || dcdtToken.Value.Sign() == 0 {
+				continue
+			}
+
+			dcdtTransfer.DCDTTokenType = dcdtToken.Type
+			break
+		}
+	}
+}
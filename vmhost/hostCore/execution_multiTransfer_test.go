@@ -0,0 +1,260 @@
+package hostCore
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kalyan3104/k-chain-core-go/core"
+	"github.com/kalyan3104/k-chain-core-go/data/dcdt"
+	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/mock"
+	worldmock "github.com/kalyan3104/k-chain-vm-v1_2-go/mock/world"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost/vmhooks"
+	"github.com/stretchr/testify/require"
+)
+
+var multiTransferFungibleToken = []byte("FUNG-123456")
+var multiTransferNFT = []byte("NFT-123456")
+
+const multiTransferNFTNonce = uint64(5)
+
+// the core package defines no type for the semi-fungible tokens, so the
+// token data may record any type, which must reach the contract unchanged
+const multiTransferSemiFungibleType = uint32(2)
+
+// receivedDCDTTransfer is a DCDT transfer as read by a contract through the
+// getDCDT*ByIndex hooks
+type receivedDCDTTransfer struct {
+	value     []byte
+	tokenName []byte
+	nonce     int64
+	tokenType int32
+}
+
+func multiTransferArguments(destination []byte, function string) [][]byte {
+	return [][]byte{
+		destination,
+		big.NewInt(2).Bytes(),
+		multiTransferFungibleToken,
+		{},
+		big.NewInt(100).Bytes(),
+		multiTransferNFT,
+		big.NewInt(int64(multiTransferNFTNonce)).Bytes(),
+		big.NewInt(1).Bytes(),
+		[]byte(function),
+	}
+}
+
+func setMultiTransferTokens(t *testing.T, account *worldmock.Account, nftType uint32) {
+	err := account.SetTokenBalance(worldmock.MakeTokenKey(multiTransferFungibleToken, 0), big.NewInt(1000))
+	require.Nil(t, err)
+
+	err = account.SetTokenData(worldmock.MakeTokenKey(multiTransferNFT, multiTransferNFTNonce), &dcdt.DCDigitalToken{
+		Type:  nftType,
+		Value: big.NewInt(1),
+		TokenMetaData: &dcdt.MetaData{
+			Nonce:   multiTransferNFTNonce,
+			Name:    multiTransferNFT,
+			Creator: userAddress,
+		},
+	})
+	require.Nil(t, err)
+}
+
+func readDCDTTransferByIndex(t *testing.T, host vmhost.VMHost, index int32) receivedDCDTTransfer {
+	runtime := host.Runtime()
+	received := receivedDCDTTransfer{}
+
+	valueLength := vmhooks.GetDCDTValueByIndexWithHost(host, 0, index)
+	value, err := runtime.MemLoad(0, valueLength)
+	require.Nil(t, err)
+	received.value = big.NewInt(0).SetBytes(value).Bytes()
+
+	nameLength := vmhooks.GetDCDTTokenNameByIndexWithHost(host, 100, index)
+	received.tokenName, err = runtime.MemLoad(100, nameLength)
+	require.Nil(t, err)
+
+	received.nonce = vmhooks.GetDCDTTokenNonceByIndexWithHost(host, index)
+	received.tokenType = vmhooks.GetDCDTTokenTypeByIndexWithHost(host, index)
+
+	return received
+}
+
+// runMultiTransferMocked makes the parent contract send both tokens to the
+// child contract with a synchronous MultiDCDTNFTTransfer, which calls the
+// receive method of the child; the VM output of the child is returned
+func runMultiTransferMocked(t *testing.T, nftType uint32, receive func(host vmhost.VMHost)) *vmcommon.VMOutput {
+	host, world, ibm := defaultTestVMForCallWithInstanceMocks(t)
+	err := world.InitBuiltinFunctions(host.GetGasScheduleMap())
+	require.Nil(t, err)
+	host.protocolBuiltinFunctions = world.BuiltinFuncs.GetBuiltinFunctionNames()
+
+	var childOutput *vmcommon.VMOutput
+	parentInstance := ibm.CreateAndStoreInstanceMock(parentAddress, 0)
+	parentInstance.AddMockMethod("transfer", func() {
+		input := DefaultTestContractCallInput()
+		input.CallerAddr = parentAddress
+		input.RecipientAddr = parentAddress
+		input.Function = core.BuiltInFunctionMultiDCDTNFTTransfer
+		input.Arguments = multiTransferArguments(childAddress, "receive")
+		input.GasProvided = host.Metering().GasLeft() / 2
+
+		childOutput, _, _, _ = host.ExecuteOnDestContext(input)
+	})
+	setMultiTransferTokens(t, world.AcctMap.GetAccount(parentAddress), nftType)
+
+	childInstance := ibm.CreateAndStoreInstanceMock(childAddress, 0)
+	childInstance.AddMockMethod("receive", func() {
+		receive(host)
+	})
+
+	input := DefaultTestContractCallInput()
+	input.Function = "transfer"
+	input.GasProvided = 1000000
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	require.NotNil(t, childOutput)
+
+	return childOutput
+}
+
+func TestExecution_MultiDCDTTransfer_ReadByIndex_Mocked(t *testing.T) {
+	numTransfers := int32(-1)
+	received := make([]receivedDCDTTransfer, 0, 2)
+	childOutput := runMultiTransferMocked(t, uint32(core.NonFungible), func(host vmhost.VMHost) {
+		numTransfers = vmhooks.GetNumDCDTTransfersWithHost(host)
+		received = append(received, readDCDTTransferByIndex(t, host, 0))
+		received = append(received, readDCDTTransferByIndex(t, host, 1))
+	})
+	require.Equal(t, vmcommon.Ok, childOutput.ReturnCode, childOutput.ReturnMessage)
+	require.Equal(t, int32(2), numTransfers)
+
+	require.Equal(t, []receivedDCDTTransfer{
+		{
+			value:     big.NewInt(100).Bytes(),
+			tokenName: multiTransferFungibleToken,
+			nonce:     0,
+			tokenType: int32(core.Fungible),
+		},
+		{
+			value:     big.NewInt(1).Bytes(),
+			tokenName: multiTransferNFT,
+			nonce:     int64(multiTransferNFTNonce),
+			tokenType: int32(core.NonFungible),
+		},
+	}, received)
+}
+
+func TestExecution_MultiDCDTTransfer_TypeFromTokenData_Mocked(t *testing.T) {
+	tokenType := int32(-1)
+	childOutput := runMultiTransferMocked(t, multiTransferSemiFungibleType, func(host vmhost.VMHost) {
+		tokenType = vmhooks.GetDCDTTokenTypeByIndexWithHost(host, 1)
+	})
+	require.Equal(t, vmcommon.Ok, childOutput.ReturnCode, childOutput.ReturnMessage)
+	require.Equal(t, int32(multiTransferSemiFungibleType), tokenType)
+}
+
+func TestExecution_MultiDCDTTransfer_InvalidIndex_Mocked(t *testing.T) {
+	hooks := map[string]func(host vmhost.VMHost, index int32){
+		"value": func(host vmhost.VMHost, index int32) {
+			vmhooks.GetDCDTValueByIndexWithHost(host, 0, index)
+		},
+		"token name": func(host vmhost.VMHost, index int32) {
+			vmhooks.GetDCDTTokenNameByIndexWithHost(host, 0, index)
+		},
+		"nonce": func(host vmhost.VMHost, index int32) {
+			vmhooks.GetDCDTTokenNonceByIndexWithHost(host, index)
+		},
+		"type": func(host vmhost.VMHost, index int32) {
+			vmhooks.GetDCDTTokenTypeByIndexWithHost(host, index)
+		},
+	}
+
+	for name, hook := range hooks {
+		for _, index := range []int32{2, -1} {
+			childOutput := runMultiTransferMocked(t, uint32(core.NonFungible), func(host vmhost.VMHost) {
+				hook(host, index)
+			})
+			require.Equal(t, vmcommon.ExecutionFailed, childOutput.ReturnCode, "%s %d", name, index)
+			require.Equal(t, vmhost.ErrInvalidTokenIndex.Error(), childOutput.ReturnMessage, "%s %d", name, index)
+		}
+	}
+}
+
+func TestExecution_MultiDCDTTransfer_NeedsItsFlag_Mocked(t *testing.T) {
+	host, _, ibm := defaultTestVMForCallWithInstanceMocks(t)
+	ibm.CreateAndStoreInstanceMock(parentAddress, 0)
+	ibm.CreateAndStoreInstanceMock(childAddress, 0)
+
+	input := DefaultTestContractCallInput()
+	input.CallerAddr = parentAddress
+	input.RecipientAddr = parentAddress
+	input.Function = core.BuiltInFunctionMultiDCDTNFTTransfer
+	input.Arguments = multiTransferArguments(childAddress, "receive")
+
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode: vmcommon.Ok,
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			string(childAddress): {
+				Address:         childAddress,
+				OutputTransfers: []vmcommon.OutputTransfer{{Data: []byte("receive"), GasLimit: 1000}},
+			},
+		},
+	}
+
+	newVMInput, err := host.isSCExecutionAfterBuiltInFunc(input, vmOutput)
+	require.Nil(t, err)
+	require.NotNil(t, newVMInput)
+	require.Equal(t, childAddress, newVMInput.RecipientAddr)
+	require.Len(t, newVMInput.DCDTTransfers, 2)
+
+	enableEpochsHandler := host.enableEpochsHandler
+	host.enableEpochsHandler = &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag != MultiDCDTTransferFlag && enableEpochsHandler.IsFlagEnabled(flag)
+		},
+	}
+
+	newVMInput, err = host.isSCExecutionAfterBuiltInFunc(input, vmOutput)
+	require.Nil(t, err)
+	require.Nil(t, newVMInput)
+}
+
+func TestParseMultiDCDTTransfers(t *testing.T) {
+	input := DefaultTestContractCallInput()
+	input.RecipientAddr = userAddress
+	input.Function = core.BuiltInFunctionMultiDCDTNFTTransfer
+	input.Arguments = multiTransferArguments(parentAddress, "receive")
+
+	// on the sender shard, the first argument is the destination
+	expected := []*vmcommon.DCDTTransfer{
+		{
+			DCDTTokenName:  multiTransferFungibleToken,
+			DCDTTokenNonce: 0,
+			DCDTValue:      big.NewInt(100),
+			DCDTTokenType:  uint32(core.Fungible),
+		},
+		{
+			DCDTTokenName:  multiTransferNFT,
+			DCDTTokenNonce: multiTransferNFTNonce,
+			DCDTValue:      big.NewInt(1),
+			DCDTTokenType:  uint32(core.NonFungible),
+		},
+	}
+	require.Equal(t, expected, parseMultiDCDTTransfers(input))
+
+	// on the destination shard, the arguments start with the number of transfers
+	input.CallerAddr = parentAddress
+	input.Arguments = input.Arguments[1:]
+	require.Equal(t, expected, parseMultiDCDTTransfers(input))
+
+	// more transfers announced than the arguments hold
+	input.Arguments = input.Arguments[:6]
+	require.Nil(t, parseMultiDCDTTransfers(input))
+
+	input.Arguments = nil
+	require.Nil(t, parseMultiDCDTTransfers(input))
+}
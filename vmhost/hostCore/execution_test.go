@@ -10,9 +10,11 @@ import (
 
 	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/config"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/crypto/fake"
 	contextmock "github.com/kalyan3104/k-chain-vm-v1_2-go/mock/context"
 	worldmock "github.com/kalyan3104/k-chain-vm-v1_2-go/mock/world"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost/tracing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -30,6 +32,23 @@ const (
 	parentFunctionChildCall = "parentFunctionChildCall"
 )
 
+func TestNewVMHost_InjectedVMCrypto(t *testing.T) {
+	defaultHost := defaultTestVM(t, &contextmock.BlockchainHookStub{})
+	require.NotNil(t, defaultHost.Crypto())
+
+	vmCrypto := fake.NewFakeVMCrypto()
+	host, err := NewVMHost(&contextmock.BlockchainHookStub{}, &vmhost.VMHostParameters{
+		VMType:                   defaultVMType,
+		BlockGasLimit:            uint64(1000),
+		GasSchedule:              config.MakeGasMapForTests(),
+		ProtocolBuiltinFunctions: make(vmcommon.FunctionNames),
+		EnableEpochsHandler:      defaultHost.enableEpochsHandler,
+		VMCrypto:                 vmCrypto,
+	})
+	require.Nil(t, err)
+	require.True(t, host.Crypto() == vmCrypto)
+}
+
 func TestSCMem(t *testing.T) {
 	code := GetTestSCCode("misc", "../../")
 	host, _ := defaultTestVMForCall(t, code, nil)
@@ -369,6 +388,35 @@ func TestExecution_Deploy_DisallowFloatingPoint(t *testing.T) {
 	require.Equal(t, vmcommon.ContractInvalid, vmOutput.ReturnCode)
 }
 
+func TestExecution_Deploy_CodePolicy(t *testing.T) {
+	newAddress := []byte("new smartcontract")
+	host := defaultTestVMForDeployment(t, 24, newAddress)
+	host.Metering().GasSchedule().WASMCodePolicy.RejectFloatingPoint = true
+
+	input := DefaultTestContractCreateInput()
+	input.GasProvided = 1000
+	input.ContractCode = GetTestSCCode("num-with-fp", "../../")
+
+	vmOutput, err := host.RunSmartContractCreate(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.ContractInvalid, vmOutput.ReturnCode)
+	require.Contains(t, vmOutput.ReturnMessage, vmhost.ErrCodePolicyViolation.Error())
+	require.Contains(t, vmOutput.ReturnMessage, "floating point")
+
+	host = defaultTestVMForDeployment(t, 24, newAddress)
+	host.Metering().GasSchedule().WASMCodePolicy.ForbiddenImports = map[string]struct{}{"finish": {}}
+
+	input = DefaultTestContractCreateInput()
+	input.GasProvided = 1000
+	input.ContractCode = GetTestSCCode("init-correct", "../../")
+
+	vmOutput, err = host.RunSmartContractCreate(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.ContractInvalid, vmOutput.ReturnCode)
+	require.Contains(t, vmOutput.ReturnMessage, "forbidden import finish")
+}
+
 func TestExecution_CallGetUserAccountErr(t *testing.T) {
 	stubBlockchainHook := &contextmock.BlockchainHookStub{}
 
@@ -477,6 +525,128 @@ func TestExecution_Call_Successful(t *testing.T) {
 	require.Equal(t, big.NewInt(1002).Bytes(), storedBytes)
 }
 
+func TestExecution_Call_HookTracer(t *testing.T) {
+	code := GetTestSCCode("counter", "../../")
+	host, stubBlockchainHook := defaultTestVMForCall(t, code, nil)
+	stubBlockchainHook.GetStorageDataCalled = func(scAddress []byte, key []byte) ([]byte, uint32, error) {
+		return big.NewInt(1001).Bytes(), 0, nil
+	}
+	tracer := tracing.NewMemoryTracer()
+	host.hookTracer = tracer
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 100000
+	input.Function = increment
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	expectedHooks := []string{"int64storageLoad", "int64storageStore", "int64finish"}
+	require.Equal(t, expectedHooks, tracer.HookNames())
+
+	gasSchedule := host.Metering().GasSchedule()
+	events := tracer.Events()
+	for _, event := range events {
+		require.Equal(t, parentAddress, event.SCAddress)
+		require.Equal(t, uint64(0), event.CallDepth)
+		require.True(t, event.GasAfter <= event.GasBefore)
+	}
+	require.Equal(t, gasSchedule.BaseOpsAPICost.Int64Finish, events[2].GasUsed())
+	require.Equal(t, "value", events[2].Arguments[0].Name)
+	require.Equal(t, int64(1002), events[2].Arguments[0].Value)
+	require.Equal(t, "key", events[1].Arguments[0].Name)
+	require.Equal(t, counterKey, events[1].Arguments[0].Value)
+}
+
+func TestExecution_HookTracer_DecodedArguments_Mocked(t *testing.T) {
+	host, _, ibm := defaultTestVMForCallWithInstanceMocks(t)
+	tracer := tracing.NewMemoryTracer()
+	host.hookTracer = tracer
+
+	parentInstance := ibm.CreateAndStoreInstanceMock(parentAddress, 0)
+	parentInstance.AddMockMethod("traced", func() {
+		runtime := host.Runtime()
+		bigInt := host.BigInt()
+
+		// "key" at 0, then the lengths of two arguments at 16, followed by the arguments
+		require.Nil(t, runtime.MemStore(0, []byte("key")))
+		require.Nil(t, runtime.MemStore(16, []byte{1, 0, 0, 0, 2, 0, 0, 0}))
+		require.Nil(t, runtime.MemStore(24, []byte{0xaa, 0xbb, 0xcc}))
+		op := bigInt.Put(42)
+		destination := int32(10)
+
+		done := vmhost.TraceHookCallWithHost(host, "traced",
+			"key", vmhost.TraceMemory(0, 3),
+			"arguments", vmhost.TraceMemoryArguments(2, 16, 24),
+			"op", vmhost.TraceBigInt(op),
+			"destination", vmhost.TraceBigIntResult(destination),
+			"result", vmhost.TraceMemoryResult(32, 2),
+			"outOfBounds", vmhost.TraceMemory(-1, 3))
+
+		bigInt.GetOne(destination).SetInt64(-7)
+		require.Nil(t, runtime.MemStore(32, []byte{0x01, 0x02}))
+		done()
+	})
+
+	input := DefaultTestContractCallInput()
+	input.Function = "traced"
+	input.GasProvided = 1000
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	events := tracer.Events()
+	require.Len(t, events, 1)
+	arguments := events[0].Arguments
+	require.Equal(t, []byte("key"), arguments[0].Value)
+	require.Equal(t, [][]byte{{0xaa}, {0xbb, 0xcc}}, arguments[1].Value)
+	require.Equal(t, big.NewInt(42), arguments[2].Value)
+	require.Equal(t, big.NewInt(-7), arguments[3].Value)
+	require.Equal(t, []byte{0x01, 0x02}, arguments[4].Value)
+	require.Equal(t, vmhost.TraceMemory(-1, 3), arguments[5].Value)
+}
+
+func TestExecution_Call_GasProfile(t *testing.T) {
+	code := GetTestSCCode("counter", "../../")
+	host, stubBlockchainHook := defaultTestVMForCall(t, code, nil)
+	stubBlockchainHook.GetStorageDataCalled = func(scAddress []byte, key []byte) ([]byte, uint32, error) {
+		return big.NewInt(1001).Bytes(), 0, nil
+	}
+	hookCategories := map[string]vmhost.GasCategory{
+		"int64storageLoad":  vmhost.GasCategoryBaseOpsAPI,
+		"int64storageStore": vmhost.GasCategoryBaseOpsAPI,
+		"int64finish":       vmhost.GasCategoryBaseOpsAPI,
+	}
+	gasProfiler, err := tracing.NewGasProfiler(host, hookCategories, nil)
+	require.Nil(t, err)
+	host.gasProfiler = gasProfiler
+	host.hookTracer = gasProfiler
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 100000
+	input.Function = increment
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	profile := host.GasProfiler().Profile()
+	require.Equal(t, input.GasProvided-vmOutput.GasRemaining, profile.TotalGas())
+	require.Equal(t, map[string]uint64{string(parentAddress): profile.TotalGas()}, profile.GasByContract())
+
+	gasSchedule := host.Metering().GasSchedule()
+	require.Equal(t, gasSchedule.BaseOpsAPICost.Int64Finish, profile.GasByHook()["int64finish"])
+	require.NotZero(t, profile.GasByCategory()[vmhost.GasCategoryWasmOpcodes])
+	require.NotZero(t, profile.GasByCategory()[vmhost.GasCategoryInitialCost])
+	for _, entry := range profile.Entries() {
+		require.Equal(t, increment, entry.Stack[0].Function)
+	}
+}
+
 func TestExecution_Call_GasConsumptionOnLocals(t *testing.T) {
 	gasWithZeroLocals, gasSchedule := callCustomSCAndGetGasUsed(t, 0)
 	costPerLocal := uint64(gasSchedule.WASMOpcodeCost.LocalAllocate)
@@ -1579,6 +1749,73 @@ func TestExecution_Mocked_Wasmer_Instances(t *testing.T) {
 	require.Equal(t, expectedVMOutput, vmOutput)
 }
 
+func TestExecution_SelfDestruct_Mocked(t *testing.T) {
+	host, world, ibm := defaultTestVMForCallWithInstanceMocks(t)
+
+	parentInstance := ibm.CreateAndStoreInstanceMock(parentAddress, 1000)
+	parentInstance.AddMockMethod("callChild", func() {
+		childInput := DefaultTestContractCallInput()
+		childInput.CallerAddr = parentAddress
+		childInput.RecipientAddr = childAddress
+		childInput.Function = "destroy"
+		childInput.GasProvided = 1000
+		_, _, _, err := host.ExecuteOnDestContext(childInput)
+		require.Nil(t, err)
+	})
+
+	childInstance := ibm.CreateAndStoreInstanceMock(childAddress, 500)
+	childAccount := world.AcctMap.GetAccount(childAddress)
+	childAccount.OwnerAddress = parentAddress
+	childAccount.CodeMetadata = []byte{vmcommon.MetadataUpgradeable, vmcommon.MetadataPayable}
+	childInstance.AddMockMethod("destroy", func() {
+		err := host.Output().SelfDestruct(childAddress, thirdPartyAddress)
+		require.Nil(t, err)
+	})
+
+	input := DefaultTestContractCallInput()
+	input.Function = "callChild"
+	input.GasProvided = 10000
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{childAddress}, vmOutput.DeletedAccounts)
+	require.Equal(t, big.NewInt(-500), vmOutput.OutputAccounts[string(childAddress)].BalanceDelta)
+
+	beneficiaryAccount := vmOutput.OutputAccounts[string(thirdPartyAddress)]
+	require.Equal(t, big.NewInt(500), beneficiaryAccount.BalanceDelta)
+	require.Equal(t, childAddress, beneficiaryAccount.OutputTransfers[0].SenderAddress)
+
+	err = world.UpdateAccounts(vmOutput.OutputAccounts, vmOutput.DeletedAccounts)
+	require.Nil(t, err)
+	require.Nil(t, world.AcctMap.GetAccount(childAddress))
+	require.Equal(t, big.NewInt(500), world.AcctMap.GetAccount(thirdPartyAddress).Balance)
+}
+
+func TestExecution_SelfDestruct_NotOwner_Mocked(t *testing.T) {
+	host, world, ibm := defaultTestVMForCallWithInstanceMocks(t)
+
+	parentInstance := ibm.CreateAndStoreInstanceMock(parentAddress, 1000)
+	parentAccount := world.AcctMap.GetAccount(parentAddress)
+	parentAccount.OwnerAddress = thirdPartyAddress
+	parentAccount.CodeMetadata = []byte{vmcommon.MetadataUpgradeable, vmcommon.MetadataPayable}
+	parentInstance.AddMockMethod("destroy", func() {
+		err := host.Output().SelfDestruct(parentAddress, userAddress)
+		require.Equal(t, vmhost.ErrSelfDestructNotAllowed, err)
+	})
+
+	input := DefaultTestContractCallInput()
+	input.Function = "destroy"
+	input.GasProvided = 1000
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Empty(t, vmOutput.DeletedAccounts)
+}
+
 func TestExecution_GasUsed_SingleContract(t *testing.T) {
 	host, _, ibm := defaultTestVMForCallWithInstanceMocks(t)
 	host.Metering().GasSchedule().BaseOperationCost.CompilePerByte = 0
@@ -11,6 +11,22 @@ const (
 	RepairCallbackFlag core.EnableEpochFlag = "RepairCallbackFlag"
 	// AheadOfTimeGasUsageFlag defines the flag that activates the ahead of time gas usage fix
 	AheadOfTimeGasUsageFlag core.EnableEpochFlag = "AheadOfTimeGasUsageFlag"
+	// BigIntResultSizeLimitFlag defines the flag that activates the size limit of the big int multiplication and left shift results
+	BigIntResultSizeLimitFlag core.EnableEpochFlag = "BigIntResultSizeLimitFlag"
+	// SelfDestructFlag defines the flag that activates the selfDestruct VM hook
+	SelfDestructFlag core.EnableEpochFlag = "SelfDestructFlag"
+	// EthereumAPIFlag defines the flag that activates the ethereum VM hooks and the entry points of the EWASM contracts
+	EthereumAPIFlag core.EnableEpochFlag = "EthereumAPIFlag"
+	// ManagedBufferAPIFlag defines the flag that activates the managed buffer VM hooks
+	ManagedBufferAPIFlag core.EnableEpochFlag = "ManagedBufferAPIFlag"
+	// BigIntExtendedAPIFlag defines the flag that activates the bigIntPow, bigIntSqrt, bigIntLog2, bigIntMin and bigIntMax VM hooks
+	BigIntExtendedAPIFlag core.EnableEpochFlag = "BigIntExtendedAPIFlag"
+	// Secp256k1ExtendedAPIFlag defines the flag that activates the recoverSecp256k1, encodeSecp256k1DerSignature and decodeSecp256k1DerSignature VM hooks
+	Secp256k1ExtendedAPIFlag core.EnableEpochFlag = "Secp256k1ExtendedAPIFlag"
+	// BLSMultiSigAPIFlag defines the flag that activates the verifyBLSAggregatedSignature and verifyBLSMultiSig VM hooks
+	BLSMultiSigAPIFlag core.EnableEpochFlag = "BLSMultiSigAPIFlag"
+	// MultiDCDTTransferFlag defines the flag that activates the multi-token DCDT transfers to the smart contracts and their VM hooks
+	MultiDCDTTransferFlag core.EnableEpochFlag = "MultiDCDTTransferFlag"
 )
 
 // allFlags must have all flags used by drt-chain-vm-v1_2-go in the current version
@@ -20,3 +36,30 @@ var allFlags = []core.EnableEpochFlag{
 	RepairCallbackFlag,
 	AheadOfTimeGasUsageFlag,
 }
+
+// optionalFlags are the flags used by drt-chain-vm-v1_2-go which the enable epochs handler
+// may not define; their features stay disabled until the node defines and enables them
+var optionalFlags = []core.EnableEpochFlag{
+	BigIntResultSizeLimitFlag,
+	SelfDestructFlag,
+	EthereumAPIFlag,
+	ManagedBufferAPIFlag,
+	BigIntExtendedAPIFlag,
+	Secp256k1ExtendedAPIFlag,
+	BLSMultiSigAPIFlag,
+	MultiDCDTTransferFlag,
+}
+
+// AllFlags returns all the flags used by drt-chain-vm-v1_2-go in the current version
+func AllFlags() []core.EnableEpochFlag {
+	flags := make([]core.EnableEpochFlag, len(allFlags))
+	copy(flags, allFlags)
+	return flags
+}
+
+// OptionalFlags returns the flags used by drt-chain-vm-v1_2-go which the enable epochs handler may not define
+func OptionalFlags() []core.EnableEpochFlag {
+	flags := make([]core.EnableEpochFlag, len(optionalFlags))
+	copy(flags, optionalFlags)
+	return flags
+}
@@ -0,0 +1,72 @@
+package hostCore
+
+import (
+	"testing"
+
+	"github.com/kalyan3104/k-chain-core-go/core"
+	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/config"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/mock"
+	worldmock "github.com/kalyan3104/k-chain-vm-v1_2-go/mock/world"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestVMWithFlags(t *testing.T, isFlagDefined func(flag core.EnableEpochFlag) bool) *vmHost {
+	host, err := NewVMHost(worldmock.NewMockWorld(), &vmhost.VMHostParameters{
+		VMType:                   defaultVMType,
+		BlockGasLimit:            uint64(1000),
+		GasSchedule:              config.MakeGasMapForTests(),
+		ProtocolBuiltinFunctions: make(vmcommon.FunctionNames),
+		ProtectedKeyPrefix:       []byte("E" + "L" + "R" + "O" + "N" + "D"),
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagDefinedCalled: isFlagDefined,
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return true
+			},
+		},
+	})
+	require.Nil(t, err)
+	return host
+}
+
+func isMandatoryFlag(flag core.EnableEpochFlag) bool {
+	for _, mandatoryFlag := range AllFlags() {
+		if flag == mandatoryFlag {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFlags_OptionalFlagsAreNotMandatory(t *testing.T) {
+	for _, flag := range OptionalFlags() {
+		require.False(t, isMandatoryFlag(flag), flag)
+	}
+}
+
+func TestNewVMHost_UndefinedOptionalFlagsAreDisabled(t *testing.T) {
+	optionalFeatures := map[core.EnableEpochFlag]func(host *vmHost) bool{
+		BigIntResultSizeLimitFlag: (*vmHost).IsBigIntResultSizeLimitEnabled,
+		SelfDestructFlag:          (*vmHost).IsSelfDestructEnabled,
+		EthereumAPIFlag:           (*vmHost).IsEthereumAPIEnabled,
+		ManagedBufferAPIFlag:      (*vmHost).IsManagedBufferAPIEnabled,
+		BigIntExtendedAPIFlag:     (*vmHost).IsBigIntExtendedAPIEnabled,
+		Secp256k1ExtendedAPIFlag:  (*vmHost).IsSecp256k1ExtendedAPIEnabled,
+		BLSMultiSigAPIFlag:        (*vmHost).IsBLSMultiSigAPIEnabled,
+		MultiDCDTTransferFlag:     (*vmHost).IsMultiDCDTTransferEnabled,
+	}
+	require.Len(t, optionalFeatures, len(OptionalFlags()))
+
+	host := newTestVMWithFlags(t, isMandatoryFlag)
+	for flag, isEnabled := range optionalFeatures {
+		require.False(t, isEnabled(host), flag)
+	}
+
+	host = newTestVMWithFlags(t, func(flag core.EnableEpochFlag) bool {
+		return true
+	})
+	for flag, isEnabled := range optionalFeatures {
+		require.True(t, isEnabled(host), flag)
+	}
+}
@@ -0,0 +1,158 @@
+package hostCore
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+
+	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/math"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+)
+
+// EstimateGas executes the given call and reports the gas it consumed,
+// reverting the blockchain state to a snapshot after each execution. When
+// searchGasLimit is set, it also binary-searches the smallest gas limit which
+// yields the same return code and outputs as the execution with the gas
+// provided in the input.
+func (host *vmHost) EstimateGas(input *vmcommon.ContractCallInput, searchGasLimit bool) (*vmhost.GasEstimate, error) {
+	vmOutput, err := host.runCallOnThrowawayState(input, input.GasProvided)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := newGasEstimate(input.GasProvided, vmOutput)
+	if !searchGasLimit || vmOutput.ReturnCode == vmcommon.OutOfGas {
+		return estimate, nil
+	}
+
+	// the gas consumed by the contracts themselves is a lower bound, the gas
+	// forwarded and locked may depend on the gas left
+	lowGasLimit, _ := math.SubUint64(estimate.GasConsumed, math.AddUint64(estimate.GasForwarded, estimate.GasLocked))
+	highGasLimit := input.GasProvided
+	for lowGasLimit < highGasLimit {
+		gasLimit := lowGasLimit + (highGasLimit-lowGasLimit)/2
+
+		candidateOutput, errRun := host.runCallOnThrowawayState(input, gasLimit)
+		if errRun != nil {
+			return nil, errRun
+		}
+		estimate.NumExecutions++
+
+		if haveSameOutcome(vmOutput, candidateOutput) {
+			highGasLimit = gasLimit
+		} else {
+			lowGasLimit = gasLimit + 1
+		}
+	}
+
+	estimate.GasLimit = highGasLimit
+	log.Trace("EstimateGas", "gas provided", input.GasProvided, "gas limit", estimate.GasLimit, "executions", estimate.NumExecutions)
+
+	return estimate, nil
+}
+
+func (host *vmHost) runCallOnThrowawayState(input *vmcommon.ContractCallInput, gasLimit uint64) (*vmcommon.VMOutput, error) {
+	callInput := *input
+	callInput.GasProvided = gasLimit
+
+	snapshot := host.blockChainHook.GetSnapshot()
+	vmOutput, err := host.RunSmartContractCall(&callInput)
+	errRevert := host.blockChainHook.RevertToSnapshot(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if errRevert != nil {
+		return nil, errRevert
+	}
+
+	return vmOutput, nil
+}
+
+func newGasEstimate(gasProvided uint64, vmOutput *vmcommon.VMOutput) *vmhost.GasEstimate {
+	estimate := &vmhost.GasEstimate{
+		ReturnCode:    vmOutput.ReturnCode,
+		VMOutput:      vmOutput,
+		GasProvided:   gasProvided,
+		GasLimit:      gasProvided,
+		NumExecutions: 1,
+	}
+	estimate.GasConsumed, _ = math.SubUint64(gasProvided, vmOutput.GasRemaining)
+
+	for _, outputAccount := range vmOutput.OutputAccounts {
+		for _, outputTransfer := range outputAccount.OutputTransfers {
+			estimate.GasForwarded = math.AddUint64(estimate.GasForwarded, outputTransfer.GasLimit)
+			estimate.GasLocked = math.AddUint64(estimate.GasLocked, outputTransfer.GasLocked)
+		}
+	}
+
+	return estimate
+}
+
+// haveSameOutcome compares the output of a candidate gas limit to the reference
+// output of the same call, ignoring the gas used, which depends on the gas
+// provided; the candidate must forward and lock at least the gas of the
+// reference, otherwise the transfers could fail at their destination
+func haveSameOutcome(reference *vmcommon.VMOutput, candidate *vmcommon.VMOutput) bool {
+	if reference.ReturnCode != candidate.ReturnCode || reference.ReturnMessage != candidate.ReturnMessage {
+		return false
+	}
+	if !reflect.DeepEqual(reference.ReturnData, candidate.ReturnData) {
+		return false
+	}
+	if !reflect.DeepEqual(reference.DeletedAccounts, candidate.DeletedAccounts) {
+		return false
+	}
+	if !reflect.DeepEqual(reference.Logs, candidate.Logs) {
+		return false
+	}
+	if len(reference.OutputAccounts) != len(candidate.OutputAccounts) {
+		return false
+	}
+
+	for address, referenceAccount := range reference.OutputAccounts {
+		candidateAccount, ok := candidate.OutputAccounts[address]
+		if !ok || !haveSameAccountOutcome(referenceAccount, candidateAccount) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func haveSameAccountOutcome(reference *vmcommon.OutputAccount, candidate *vmcommon.OutputAccount) bool {
+	if reference.Nonce != candidate.Nonce || !bytes.Equal(reference.Code, candidate.Code) {
+		return false
+	}
+	if bigIntOrZero(reference.BalanceDelta).Cmp(bigIntOrZero(candidate.BalanceDelta)) != 0 {
+		return false
+	}
+	if !reflect.DeepEqual(reference.StorageUpdates, candidate.StorageUpdates) {
+		return false
+	}
+	if len(reference.OutputTransfers) != len(candidate.OutputTransfers) {
+		return false
+	}
+
+	for i, referenceTransfer := range reference.OutputTransfers {
+		candidateTransfer := candidate.OutputTransfers[i]
+		if !bytes.Equal(referenceTransfer.Data, candidateTransfer.Data) || referenceTransfer.CallType != candidateTransfer.CallType {
+			return false
+		}
+		if bigIntOrZero(referenceTransfer.Value).Cmp(bigIntOrZero(candidateTransfer.Value)) != 0 {
+			return false
+		}
+		if candidateTransfer.GasLimit < referenceTransfer.GasLimit || candidateTransfer.GasLocked < referenceTransfer.GasLocked {
+			return false
+		}
+	}
+
+	return true
+}
+
+func bigIntOrZero(value *big.Int) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+	return value
+}
@@ -0,0 +1,95 @@
+package hostCore
+
+import (
+	"math/big"
+	"testing"
+
+	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecution_EstimateGas_Mocked(t *testing.T) {
+	host, world, ibm := defaultTestVMForCallWithInstanceMocks(t)
+	host.Metering().GasSchedule().BaseOperationCost.CompilePerByte = 0
+	host.Metering().GasSchedule().BaseOperationCost.AoTPreparePerByte = 0
+
+	gasProvided := uint64(100000)
+	gasUsedByParent := uint64(400)
+
+	parentInstance := ibm.CreateAndStoreInstanceMock(parentAddress, 1000)
+	parentInstance.AddMockMethod("function", func() {
+		host.Metering().UseGas(gasUsedByParent)
+		host.Output().Finish([]byte("done"))
+	})
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = gasProvided
+
+	estimate, err := host.EstimateGas(input, true)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, estimate.ReturnCode)
+	require.Equal(t, [][]byte{[]byte("done")}, estimate.VMOutput.ReturnData)
+	require.Equal(t, gasProvided, estimate.GasProvided)
+	require.Equal(t, gasUsedByParent+1, estimate.GasConsumed)
+	require.Equal(t, uint64(0), estimate.GasForwarded)
+	require.Equal(t, uint64(0), estimate.GasLocked)
+	require.Equal(t, estimate.GasConsumed, estimate.GasLimit)
+	require.Greater(t, estimate.NumExecutions, 1)
+
+	// the input and the world are left unchanged
+	require.Equal(t, gasProvided, input.GasProvided)
+	require.Equal(t, big.NewInt(1000), world.AcctMap.GetAccount(parentAddress).Balance)
+
+	input.GasProvided = estimate.GasLimit - 1
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.OutOfGas, vmOutput.ReturnCode)
+}
+
+func TestExecution_EstimateGas_OutOfGasDoesNotSearch(t *testing.T) {
+	host, _, ibm := defaultTestVMForCallWithInstanceMocks(t)
+
+	parentInstance := ibm.CreateAndStoreInstanceMock(parentAddress, 1000)
+	parentInstance.AddMockMethod("function", func() {
+		host.Metering().UseGas(1000)
+	})
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 100
+
+	estimate, err := host.EstimateGas(input, true)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.OutOfGas, estimate.ReturnCode)
+	require.Equal(t, uint64(100), estimate.GasLimit)
+	require.Equal(t, 1, estimate.NumExecutions)
+}
+
+func createGasEstimationOutput(gasRemaining uint64, gasForwarded uint64, value int64) *vmcommon.VMOutput {
+	vmOutput := MakeVMOutput()
+	vmOutput.GasRemaining = gasRemaining
+	account := AddNewOutputAccount(vmOutput, parentAddress, childAddress, value, []byte("data"))
+	account.GasUsed = gasForwarded
+	account.OutputTransfers[0].GasLimit = gasForwarded
+	AddFinishData(vmOutput, []byte("done"))
+	return vmOutput
+}
+
+func TestHaveSameOutcome_IgnoresGasUsed(t *testing.T) {
+	require.True(t, haveSameOutcome(createGasEstimationOutput(10, 500, 4), createGasEstimationOutput(0, 500, 4)))
+	require.False(t, haveSameOutcome(createGasEstimationOutput(10, 500, 4), createGasEstimationOutput(10, 500, 5)))
+
+	outOfGas := createGasEstimationOutput(10, 500, 4)
+	outOfGas.ReturnCode = vmcommon.OutOfGas
+	require.False(t, haveSameOutcome(createGasEstimationOutput(10, 500, 4), outOfGas))
+}
+
+func TestHaveSameOutcome_CandidateForwardsAtLeastTheReferenceGas(t *testing.T) {
+	reference := createGasEstimationOutput(10, 500, 4)
+	require.True(t, haveSameOutcome(reference, createGasEstimationOutput(0, 600, 4)))
+	require.False(t, haveSameOutcome(reference, createGasEstimationOutput(0, 100, 4)))
+
+	reference.OutputAccounts[string(childAddress)].OutputTransfers[0].GasLocked = 200
+	candidate := createGasEstimationOutput(0, 500, 4)
+	candidate.OutputAccounts[string(childAddress)].OutputTransfers[0].GasLocked = 100
+	require.False(t, haveSameOutcome(reference, candidate))
+}
@@ -0,0 +1,60 @@
+package hostCore
+
+import (
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/wasmer"
+)
+
+// addHookCategories assigns the provided category to all the hooks in
+// imports which have not been assigned a category yet
+func addHookCategories(hookCategories map[string]vmhost.GasCategory, imports *wasmer.Imports, category vmhost.GasCategory) {
+	for name := range imports.Names() {
+		_, exists := hookCategories[name]
+		if !exists {
+			hookCategories[name] = category
+		}
+	}
+}
+
+// addNamespacedHookCategories assigns the provided category to the hooks in
+// the given namespace, which are traced as "namespace.name"
+func addNamespacedHookCategories(hookCategories map[string]vmhost.GasCategory, imports *wasmer.Imports, namespace string, category vmhost.GasCategory) {
+	for name := range imports.NamespaceNames(namespace) {
+		hookCategories[namespace+"."+name] = category
+	}
+}
+
+func (host *vmHost) startGasProfilingFrame() {
+	if host.gasProfiler == nil {
+		return
+	}
+	host.gasProfiler.StartFrame()
+}
+
+func (host *vmHost) updateGasProfilingFrame() {
+	if host.gasProfiler == nil {
+		return
+	}
+	host.gasProfiler.UpdateFrame()
+}
+
+func (host *vmHost) startGasProfilingInstance() {
+	if host.gasProfiler == nil {
+		return
+	}
+	host.gasProfiler.StartInstance()
+}
+
+func (host *vmHost) endGasProfilingFrame() {
+	if host.gasProfiler == nil {
+		return
+	}
+	host.gasProfiler.EndFrame()
+}
+
+func (host *vmHost) finishGasProfiling() {
+	if host.gasProfiler == nil {
+		return
+	}
+	host.gasProfiler.Finish()
+}
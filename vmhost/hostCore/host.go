@@ -14,6 +14,7 @@ import (
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost/contexts"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost/cryptoapi"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost/tracing"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost/vmhooks"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/wasmer"
 )
@@ -35,14 +36,16 @@ type vmHost struct {
 	cryptoHook     crypto.VMCrypto
 	mutExecution   sync.RWMutex
 
-	ethInput []byte
+	blockchainContext    vmhost.BlockchainContext
+	runtimeContext       vmhost.RuntimeContext
+	outputContext        vmhost.OutputContext
+	meteringContext      vmhost.MeteringContext
+	storageContext       vmhost.StorageContext
+	bigIntContext        vmhost.BigIntContext
+	managedBufferContext vmhost.ManagedBufferContext
 
-	blockchainContext vmhost.BlockchainContext
-	runtimeContext    vmhost.RuntimeContext
-	outputContext     vmhost.OutputContext
-	meteringContext   vmhost.MeteringContext
-	storageContext    vmhost.StorageContext
-	bigIntContext     vmhost.BigIntContext
+	hookTracer  vmhost.HookTracer
+	gasProfiler vmhost.GasProfiler
 
 	gasSchedule              config.GasScheduleMap
 	scAPIMethods             *wasmer.Imports
@@ -63,7 +66,11 @@ func NewVMHost(
 		return nil, err
 	}
 
-	cryptoHook := factory.NewVMCrypto()
+	cryptoHook := hostParameters.VMCrypto
+	if cryptoHook == nil {
+		cryptoHook = factory.NewVMCrypto()
+	}
+
 	host := &vmHost{
 		blockChainHook:           blockChainHook,
 		cryptoHook:               cryptoHook,
@@ -72,31 +79,52 @@ func NewVMHost(
 		blockchainContext:        nil,
 		storageContext:           nil,
 		bigIntContext:            nil,
+		managedBufferContext:     nil,
+		hookTracer:               hostParameters.HookTracer,
 		gasSchedule:              hostParameters.GasSchedule,
 		scAPIMethods:             nil,
 		protocolBuiltinFunctions: hostParameters.ProtocolBuiltinFunctions,
 		enableEpochsHandler:      hostParameters.EnableEpochsHandler,
 	}
 
+	hookCategories := make(map[string]vmhost.GasCategory)
+
 	imports, err := vmhooks.BaseOpsAPIImports()
 	if err != nil {
 		return nil, err
 	}
+	addHookCategories(hookCategories, imports, vmhost.GasCategoryBaseOpsAPI)
 
 	imports, err = vmhooks.BigIntImports(imports)
 	if err != nil {
 		return nil, err
 	}
+	addHookCategories(hookCategories, imports, vmhost.GasCategoryBigIntAPI)
 
+	// the small int hooks are priced in BaseOpsAPICost
 	imports, err = vmhooks.SmallIntImports(imports)
 	if err != nil {
 		return nil, err
 	}
+	addHookCategories(hookCategories, imports, vmhost.GasCategoryBaseOpsAPI)
+
+	imports, err = vmhooks.ManagedBufferImports(imports)
+	if err != nil {
+		return nil, err
+	}
+	addHookCategories(hookCategories, imports, vmhost.GasCategoryManagedBufferAPI)
 
 	imports, err = cryptoapi.CryptoImports(imports)
 	if err != nil {
 		return nil, err
 	}
+	addHookCategories(hookCategories, imports, vmhost.GasCategoryCryptoAPI)
+
+	imports, err = vmhooks.EthereumImports(imports)
+	if err != nil {
+		return nil, err
+	}
+	addNamespacedHookCategories(hookCategories, imports, vmhooks.EthereumNamespace, vmhost.GasCategoryEthAPI)
 
 	err = wasmer.SetImports(imports)
 	if err != nil {
@@ -105,6 +133,16 @@ func NewVMHost(
 
 	host.scAPIMethods = imports
 
+	if hostParameters.GasProfilingEnabled {
+		gasProfiler, errProfiler := tracing.NewGasProfiler(host, hookCategories, hostParameters.HookTracer)
+		if errProfiler != nil {
+			return nil, errProfiler
+		}
+
+		host.gasProfiler = gasProfiler
+		host.hookTracer = gasProfiler
+	}
+
 	host.blockchainContext, err = contexts.NewBlockchainContext(host, blockChainHook)
 	if err != nil {
 		return nil, err
@@ -139,12 +177,20 @@ func NewVMHost(
 		return nil, err
 	}
 
+	host.managedBufferContext, err = contexts.NewManagedBufferContext()
+	if err != nil {
+		return nil, err
+	}
+
 	gasCostConfig, err := config.CreateGasConfig(host.gasSchedule)
 	if err != nil {
 		return nil, err
 	}
 
 	host.runtimeContext.SetMaxInstanceCount(MaximumWasmerInstanceCount)
+	if hostParameters.MaxWarmInstances > 0 {
+		host.runtimeContext.SetMaxWarmInstanceCount(hostParameters.MaxWarmInstances)
+	}
 
 	opcodeCosts := gasCostConfig.WASMOpcodeCost.ToOpcodeCostsArray()
 	wasmer.SetOpcodeCosts(&opcodeCosts)
@@ -200,6 +246,22 @@ func (host *vmHost) BigInt() vmhost.BigIntContext {
 	return host.bigIntContext
 }
 
+// ManagedBuffer returns the ManagedBufferContext instance of the host
+func (host *vmHost) ManagedBuffer() vmhost.ManagedBufferContext {
+	return host.managedBufferContext
+}
+
+// Tracer returns the HookTracer of the host, which may be nil
+func (host *vmHost) Tracer() vmhost.HookTracer {
+	return host.hookTracer
+}
+
+// GasProfiler returns the GasProfiler of the host, which is nil unless gas
+// profiling has been enabled through the VMHostParameters
+func (host *vmHost) GasProfiler() vmhost.GasProfiler {
+	return host.gasProfiler
+}
+
 // IsVMV2Enabled returns whether the VM V2 mode is enabled
 func (host *vmHost) IsVMV2Enabled() bool {
 	return host.enableEpochsHandler.IsFlagEnabled(SCDeployFlag)
@@ -225,6 +287,57 @@ func (host *vmHost) IsDCDTFunctionsEnabled() bool {
 	return host.enableEpochsHandler.IsFlagEnabled(BuiltInFunctionsFlag)
 }
 
+// IsBigIntResultSizeLimitEnabled returns whether the results of the big int
+// multiplication and left shift are limited in size
+func (host *vmHost) IsBigIntResultSizeLimitEnabled() bool {
+	return host.isOptionalFlagEnabled(BigIntResultSizeLimitFlag)
+}
+
+// IsSelfDestructEnabled returns whether the contracts may import the selfDestruct VM hook
+func (host *vmHost) IsSelfDestructEnabled() bool {
+	return host.isOptionalFlagEnabled(SelfDestructFlag)
+}
+
+// IsEthereumAPIEnabled returns whether the contracts may import the ethereum VM hooks
+// and be called through the entry points of the EWASM contracts
+func (host *vmHost) IsEthereumAPIEnabled() bool {
+	return host.isOptionalFlagEnabled(EthereumAPIFlag)
+}
+
+// IsManagedBufferAPIEnabled returns whether the contracts may import the managed buffer VM hooks
+func (host *vmHost) IsManagedBufferAPIEnabled() bool {
+	return host.isOptionalFlagEnabled(ManagedBufferAPIFlag)
+}
+
+// IsBigIntExtendedAPIEnabled returns whether the contracts may import the bigIntPow, bigIntSqrt, bigIntLog2,
+// bigIntMin and bigIntMax VM hooks
+func (host *vmHost) IsBigIntExtendedAPIEnabled() bool {
+	return host.isOptionalFlagEnabled(BigIntExtendedAPIFlag)
+}
+
+// IsSecp256k1ExtendedAPIEnabled returns whether the contracts may import the recoverSecp256k1,
+// encodeSecp256k1DerSignature and decodeSecp256k1DerSignature VM hooks
+func (host *vmHost) IsSecp256k1ExtendedAPIEnabled() bool {
+	return host.isOptionalFlagEnabled(Secp256k1ExtendedAPIFlag)
+}
+
+// IsBLSMultiSigAPIEnabled returns whether the contracts may import the verifyBLSAggregatedSignature
+// and verifyBLSMultiSig VM hooks
+func (host *vmHost) IsBLSMultiSigAPIEnabled() bool {
+	return host.isOptionalFlagEnabled(BLSMultiSigAPIFlag)
+}
+
+// IsMultiDCDTTransferEnabled returns whether the smart contracts may receive several DCDT tokens in one call,
+// read them with the VM hooks indexing the transfers and get the NFT / SFT types from the token data
+func (host *vmHost) IsMultiDCDTTransferEnabled() bool {
+	return host.isOptionalFlagEnabled(MultiDCDTTransferFlag)
+}
+
+// isOptionalFlagEnabled returns whether an optional flag is both defined and enabled
+func (host *vmHost) isOptionalFlagEnabled(flag core.EnableEpochFlag) bool {
+	return host.enableEpochsHandler.IsFlagDefined(flag) && host.enableEpochsHandler.IsFlagEnabled(flag)
+}
+
 // GetContexts returns the main contexts of the host
 func (host *vmHost) GetContexts() (
 	vmhost.BigIntContext,
@@ -250,16 +363,21 @@ func (host *vmHost) InitState() {
 func (host *vmHost) initContexts() {
 	host.ClearContextStateStack()
 	host.bigIntContext.InitState()
+	host.managedBufferContext.InitState()
 	host.outputContext.InitState()
 	host.meteringContext.InitState()
 	host.runtimeContext.InitState()
 	host.storageContext.InitState()
-	host.ethInput = nil
+
+	if host.gasProfiler != nil {
+		host.gasProfiler.Reset()
+	}
 }
 
 // ClearContextStateStack cleans the state stacks of all the contexts of the host
 func (host *vmHost) ClearContextStateStack() {
 	host.bigIntContext.ClearStateStack()
+	host.managedBufferContext.ClearStateStack()
 	host.outputContext.ClearStateStack()
 	host.meteringContext.ClearStateStack()
 	host.runtimeContext.ClearStateStack()
@@ -145,7 +145,8 @@ func DefaultTestVMForCallSigSegv(tb testing.TB, code []byte, balance *big.Int, p
 		UseWarmInstance:          false,
 		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
 			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-				return flag == SCDeployFlag || flag == AheadOfTimeGasUsageFlag || flag == RepairCallbackFlag || flag == BuiltInFunctionsFlag
+				return flag == SCDeployFlag || flag == AheadOfTimeGasUsageFlag || flag == RepairCallbackFlag || flag == BuiltInFunctionsFlag ||
+					flag == SelfDestructFlag || flag == EthereumAPIFlag || flag == ManagedBufferAPIFlag || flag == BigIntExtendedAPIFlag || flag == Secp256k1ExtendedAPIFlag || flag == BLSMultiSigAPIFlag || flag == MultiDCDTTransferFlag
 			},
 		},
 		WasmerSIGSEGVPassthrough: passthrough,
@@ -251,7 +252,8 @@ func defaultTestVM(tb testing.TB, blockchain vmcommon.BlockchainHook) *vmHost {
 		UseWarmInstance:          false,
 		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
 			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-				return flag == SCDeployFlag || flag == AheadOfTimeGasUsageFlag || flag == RepairCallbackFlag || flag == BuiltInFunctionsFlag
+				return flag == SCDeployFlag || flag == AheadOfTimeGasUsageFlag || flag == RepairCallbackFlag || flag == BuiltInFunctionsFlag ||
+					flag == SelfDestructFlag || flag == EthereumAPIFlag || flag == ManagedBufferAPIFlag || flag == BigIntExtendedAPIFlag || flag == Secp256k1ExtendedAPIFlag || flag == BLSMultiSigAPIFlag || flag == MultiDCDTTransferFlag
 			},
 		},
 	})
@@ -33,14 +33,25 @@ type VMHost interface {
 	Blockchain() BlockchainContext
 	Runtime() RuntimeContext
 	BigInt() BigIntContext
+	ManagedBuffer() ManagedBufferContext
 	Output() OutputContext
 	Metering() MeteringContext
 	Storage() StorageContext
+	Tracer() HookTracer
+	GasProfiler() GasProfiler
 	IsVMV2Enabled() bool
 	IsAheadOfTimeCompileEnabled() bool
 	IsDynamicGasLockingEnabled() bool
 	IsVMV3Enabled() bool
 	IsDCDTFunctionsEnabled() bool
+	IsBigIntResultSizeLimitEnabled() bool
+	IsSelfDestructEnabled() bool
+	IsEthereumAPIEnabled() bool
+	IsManagedBufferAPIEnabled() bool
+	IsBigIntExtendedAPIEnabled() bool
+	IsSecp256k1ExtendedAPIEnabled() bool
+	IsBLSMultiSigAPIEnabled() bool
+	IsMultiDCDTTransferEnabled() bool
 
 	ExecuteDCDTTransfer(destination []byte, sender []byte, tokenIdentifier []byte, nonce uint64, value *big.Int, callType vm.CallType, isRevert bool) (*vmcommon.VMOutput, uint64, error)
 	RevertDCDTTransfer(input *vmcommon.ContractCallInput)
@@ -51,6 +62,7 @@ type VMHost interface {
 	GetProtocolBuiltinFunctions() vmcommon.FunctionNames
 	IsBuiltinFunctionName(functionName string) bool
 	AreInSameShard(leftAddress []byte, rightAddress []byte) bool
+	EstimateGas(input *vmcommon.ContractCallInput, searchGasLimit bool) (*GasEstimate, error)
 }
 
 // BlockchainContext defines the functionality needed for interacting with the blockchain context
@@ -75,6 +87,7 @@ type BlockchainContext interface {
 	GetCodeHash(addr []byte) []byte
 	GetCode(addr []byte) ([]byte, error)
 	GetCodeSize(addr []byte) (int32, error)
+	GetCodeMetadata(addr []byte) (vmcommon.CodeMetadata, error)
 	BlockHash(number int64) []byte
 	GetOwnerAddress() ([]byte, error)
 	GetShardOfAddress(addr []byte) uint32
@@ -118,6 +131,8 @@ type RuntimeContext interface {
 	IsFunctionImported(name string) bool
 	IsWarmInstance() bool
 	ResetWarmInstance()
+	SetMaxWarmInstanceCount(uint64)
+	WarmInstanceCacheStats() WarmInstanceCacheStats
 	ReadOnly() bool
 	SetReadOnly(readOnly bool)
 	StartWasmerInstance(contract []byte, gasLimit uint64, newCode bool) error
@@ -130,6 +145,7 @@ type RuntimeContext interface {
 	GetPointsUsed() uint64
 	SetPointsUsed(gasPoints uint64)
 	MemStore(offset int32, data []byte) error
+	CheckMemStoreBounds(offset int32, length int32) error
 	MemLoad(offset int32, length int32) ([]byte, error)
 	MemLoadMultiple(offset int32, lengths []int32) ([][]byte, error)
 	BaseOpsErrorShouldFailExecution() bool
@@ -149,10 +165,23 @@ type BigIntContext interface {
 
 	Put(value int64) int32
 	GetOne(id int32) *big.Int
+	PeekOne(id int32) (*big.Int, bool)
 	GetTwo(id1, id2 int32) (*big.Int, *big.Int)
 	GetThree(id1, id2, id3 int32) (*big.Int, *big.Int, *big.Int)
 }
 
+// ManagedBufferContext defines the functionality needed for interacting with the managed buffer context
+type ManagedBufferContext interface {
+	StateStack
+
+	NewBuffer() int32
+	NewBufferFromBytes(bytes []byte) int32
+	SetBytes(handle int32, bytes []byte)
+	GetBytes(handle int32) ([]byte, error)
+	AppendBytes(handle int32, bytes []byte) bool
+	GetLength(handle int32) int32
+}
+
 // OutputContext defines the functionality needed for interacting with the output context
 type OutputContext interface {
 	StateStack
@@ -167,7 +196,7 @@ type OutputContext interface {
 	TransferValueOnly(destination []byte, sender []byte, value *big.Int, checkPayable bool) error
 	Transfer(destination []byte, sender []byte, gasLimit uint64, gasLocked uint64, value *big.Int, input []byte, callType vm.CallType) error
 	TransferDCDT(destination []byte, sender []byte, tokenIdentifier []byte, nonce uint64, value *big.Int, callInput *vmcommon.ContractCallInput) (uint64, error)
-	SelfDestruct(address []byte, beneficiary []byte)
+	SelfDestruct(address []byte, beneficiary []byte) error
 	GetRefund() uint64
 	SetRefund(refund uint64)
 	ReturnCode() vmcommon.ReturnCode
@@ -193,6 +222,7 @@ type MeteringContext interface {
 	SetGasSchedule(gasMap config.GasScheduleMap)
 	GasSchedule() *config.GasCost
 	UseGas(gas uint64)
+	UseGasForCategory(gas uint64, category GasCategory)
 	FreeGas(gas uint64)
 	RestoreGas(gas uint64)
 	GasLeft() uint64
@@ -269,3 +299,31 @@ type EnableEpochsHandler interface {
 	GetActivationEpoch(flag core.EnableEpochFlag) uint32
 	IsInterfaceNil() bool
 }
+
+// HookTracer defines the functionality needed to observe the calls made by
+// smart contracts to the EEI hooks
+type HookTracer interface {
+	TraceHookCall(event *HookCallEvent)
+	IsInterfaceNil() bool
+}
+
+// HookCallStartTracer is implemented by the HookTracers which also need to be
+// notified when a hook starts executing, not only when it finishes
+type HookCallStartTracer interface {
+	TraceHookCallStart(event *HookCallEvent)
+}
+
+// GasProfiler defines the functionality needed to attribute the gas consumed
+// during an execution to contracts, functions and gas categories
+type GasProfiler interface {
+	HookTracer
+	HookCallStartTracer
+	StartFrame()
+	UpdateFrame()
+	StartInstance()
+	EndFrame()
+	Finish()
+	AddCategorizedGas(gas uint64, category GasCategory)
+	Profile() *GasProfile
+	Reset()
+}
@@ -0,0 +1,224 @@
+package vmhost
+
+import (
+	"encoding/binary"
+	"math/big"
+	"unsafe"
+)
+
+// maxTracedMemoryLength is the largest memory argument decoded by the tracer;
+// longer arguments are recorded with their raw offset and length
+const maxTracedMemoryLength = 1 << 20
+
+// HookArgument is a named argument received by an EEI hook
+type HookArgument struct {
+	Name  string
+	Value interface{}
+}
+
+// HookMemoryArgument is a hook argument stored in the memory of the contract,
+// recorded as the bytes found at Offset when the hook is called; results are
+// only written by the hook, so they are read after it finishes
+type HookMemoryArgument struct {
+	Offset   int32
+	Length   int32
+	IsResult bool
+}
+
+// HookMemoryArgumentsList is the list of arguments passed in memory to the
+// hooks calling other contracts, recorded as a list of byte slices
+type HookMemoryArgumentsList struct {
+	NumArguments          int32
+	ArgumentsLengthOffset int32
+	DataOffset            int32
+}
+
+// HookBigIntArgument is a big int handle passed to a hook, recorded as the
+// value it refers to; results are read after the hook finishes
+type HookBigIntArgument struct {
+	Handle   int32
+	IsResult bool
+}
+
+// TraceMemory describes a hook argument read from memory, for TraceHookCall
+func TraceMemory(offset int32, length int32) HookMemoryArgument {
+	return HookMemoryArgument{Offset: offset, Length: length}
+}
+
+// TraceMemoryResult describes a hook result written to memory, for TraceHookCall
+func TraceMemoryResult(offset int32, length int32) HookMemoryArgument {
+	return HookMemoryArgument{Offset: offset, Length: length, IsResult: true}
+}
+
+// TraceMemoryArguments describes the call arguments read from memory, for TraceHookCall
+func TraceMemoryArguments(numArguments int32, argumentsLengthOffset int32, dataOffset int32) HookMemoryArgumentsList {
+	return HookMemoryArgumentsList{
+		NumArguments:          numArguments,
+		ArgumentsLengthOffset: argumentsLengthOffset,
+		DataOffset:            dataOffset,
+	}
+}
+
+// TraceBigInt describes a big int handle read by a hook, for TraceHookCall
+func TraceBigInt(handle int32) HookBigIntArgument {
+	return HookBigIntArgument{Handle: handle}
+}
+
+// TraceBigIntResult describes a big int handle written by a hook, for TraceHookCall
+func TraceBigIntResult(handle int32) HookBigIntArgument {
+	return HookBigIntArgument{Handle: handle, IsResult: true}
+}
+
+// HookCallEvent contains the details of a single call made by a smart
+// contract to an EEI hook
+type HookCallEvent struct {
+	HookName  string
+	Arguments []HookArgument
+	GasBefore uint64
+	GasAfter  uint64
+	SCAddress []byte
+	CallDepth uint64
+}
+
+// GasUsed returns the gas consumed while the hook was executing
+func (event *HookCallEvent) GasUsed() uint64 {
+	if event.GasAfter > event.GasBefore {
+		return 0
+	}
+
+	return event.GasBefore - event.GasAfter
+}
+
+// IsHookTracingEnabled returns true if the VM host has a HookTracer set
+func IsHookTracingEnabled(vmHostPtr unsafe.Pointer) bool {
+	return !IfNil(GetVMHost(vmHostPtr).Tracer())
+}
+
+// TraceHookCall captures the state of the VM host before an EEI hook is
+// executed and returns a function which must be called after the hook
+// finishes, in order to complete the event and pass it to the HookTracer; the
+// arguments are expected as alternating names and values. Values created with
+// TraceMemory, TraceMemoryArguments and TraceBigInt are decoded before the
+// hook executes, while results are decoded after it finishes. HookTracers
+// which also implement HookCallStartTracer are notified before the hook
+// executes.
+func TraceHookCall(vmHostPtr unsafe.Pointer, hookName string, namesAndValues ...interface{}) func() {
+	return TraceHookCallWithHost(GetVMHost(vmHostPtr), hookName, namesAndValues...)
+}
+
+// TraceHookCallWithHost is TraceHookCall for an already resolved VM host
+func TraceHookCallWithHost(host VMHost, hookName string, namesAndValues ...interface{}) func() {
+	tracer := host.Tracer()
+	if IfNil(tracer) {
+		return func() {}
+	}
+
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	scAddress := make([]byte, len(runtime.GetSCAddress()))
+	copy(scAddress, runtime.GetSCAddress())
+
+	event := &HookCallEvent{
+		HookName:  hookName,
+		Arguments: makeHookArguments(namesAndValues),
+		GasBefore: metering.GasLeft(),
+		SCAddress: scAddress,
+		CallDepth: runtime.RunningInstancesCount(),
+	}
+
+	decodeHookArguments(host, event.Arguments, false)
+
+	startTracer, ok := tracer.(HookCallStartTracer)
+	if ok {
+		startTracer.TraceHookCallStart(event)
+	}
+
+	return func() {
+		decodeHookArguments(host, event.Arguments, true)
+		event.GasAfter = metering.GasLeft()
+		tracer.TraceHookCall(event)
+	}
+}
+
+func makeHookArguments(namesAndValues []interface{}) []HookArgument {
+	arguments := make([]HookArgument, 0, len(namesAndValues)/2)
+	for i := 0; i+1 < len(namesAndValues); i += 2 {
+		name, ok := namesAndValues[i].(string)
+		if !ok {
+			continue
+		}
+
+		arguments = append(arguments, HookArgument{
+			Name:  name,
+			Value: namesAndValues[i+1],
+		})
+	}
+
+	return arguments
+}
+
+// decodeHookArguments replaces the memory and big int arguments with the
+// values they refer to; arguments which cannot be decoded are left unchanged
+func decodeHookArguments(host VMHost, arguments []HookArgument, results bool) {
+	for i, argument := range arguments {
+		switch value := argument.Value.(type) {
+		case HookMemoryArgument:
+			if value.IsResult != results || value.Length > maxTracedMemoryLength {
+				continue
+			}
+			data, err := host.Runtime().MemLoad(value.Offset, value.Length)
+			if err == nil {
+				arguments[i].Value = data
+			}
+		case HookMemoryArgumentsList:
+			if results {
+				continue
+			}
+			data, ok := decodeMemoryArgumentsList(host, value)
+			if ok {
+				arguments[i].Value = data
+			}
+		case HookBigIntArgument:
+			if value.IsResult != results {
+				continue
+			}
+			// a missing handle would be created by the hook with the value 0
+			decoded := big.NewInt(0)
+			bigIntValue, ok := host.BigInt().PeekOne(value.Handle)
+			if ok {
+				decoded.Set(bigIntValue)
+			}
+			arguments[i].Value = decoded
+		}
+	}
+}
+
+func decodeMemoryArgumentsList(host VMHost, list HookMemoryArgumentsList) ([][]byte, bool) {
+	if list.NumArguments < 0 || list.NumArguments > maxTracedMemoryLength/4 {
+		return nil, false
+	}
+
+	runtime := host.Runtime()
+	lengthsData, err := runtime.MemLoad(list.ArgumentsLengthOffset, list.NumArguments*4)
+	if err != nil {
+		return nil, false
+	}
+
+	lengths := make([]int32, list.NumArguments)
+	totalLength := int64(0)
+	for i := range lengths {
+		lengths[i] = int32(binary.LittleEndian.Uint32(lengthsData[i*4 : i*4+4]))
+		totalLength += int64(lengths[i])
+		if lengths[i] < 0 || totalLength > maxTracedMemoryLength {
+			return nil, false
+		}
+	}
+
+	data, err := runtime.MemLoadMultiple(list.DataOffset, lengths)
+	if err != nil {
+		return nil, false
+	}
+
+	return data, true
+}
@@ -0,0 +1,12 @@
+package tracing
+
+import "errors"
+
+// ErrNilWriter signals that a nil io.Writer has been provided
+var ErrNilWriter = errors.New("nil writer")
+
+// ErrNilVMHost signals that a nil VMHost has been provided
+var ErrNilVMHost = errors.New("nil VMHost")
+
+// ErrNilHookCategories signals that nil hook categories have been provided
+var ErrNilHookCategories = errors.New("nil hook categories")
@@ -0,0 +1,227 @@
+package tracing
+
+import (
+	"github.com/kalyan3104/k-chain-core-go/core/check"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/math"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+)
+
+var _ vmhost.GasProfiler = (*GasProfiler)(nil)
+
+type profilerFrame struct {
+	stack       []vmhost.GasProfileFrame
+	hasInstance bool
+
+	inHook               bool
+	hookName             string
+	nestedGasInHook      uint64
+	categorizedGasInHook uint64
+
+	hookGas                    uint64
+	nestedGasOutsideHooks      uint64
+	categorizedGasOutsideHooks uint64
+}
+
+// GasProfiler attributes the gas consumed during the execution of smart
+// contracts to the call stacks of contract functions and to gas categories.
+// It observes the EEI hooks as a HookTracer, forwarding the hook calls to
+// another HookTracer, if provided.
+type GasProfiler struct {
+	host           vmhost.VMHost
+	hookCategories map[string]vmhost.GasCategory
+	tracer         vmhost.HookTracer
+	frames         []*profilerFrame
+	profile        *vmhost.GasProfile
+}
+
+// NewGasProfiler creates a new GasProfiler; hookCategories maps the names of
+// the EEI hooks to their gas categories, while tracer is optional
+func NewGasProfiler(
+	host vmhost.VMHost,
+	hookCategories map[string]vmhost.GasCategory,
+	tracer vmhost.HookTracer,
+) (*GasProfiler, error) {
+	if host == nil {
+		return nil, ErrNilVMHost
+	}
+	if hookCategories == nil {
+		return nil, ErrNilHookCategories
+	}
+
+	return &GasProfiler{
+		host:           host,
+		hookCategories: hookCategories,
+		tracer:         tracer,
+		frames:         make([]*profilerFrame, 0),
+		profile:        vmhost.NewGasProfile(),
+	}, nil
+}
+
+// StartFrame pushes a new frame for the contract function currently set in the runtime
+func (profiler *GasProfiler) StartFrame() {
+	stack := make([]vmhost.GasProfileFrame, 0, len(profiler.frames)+1)
+	parent := profiler.currentFrame()
+	if parent != nil {
+		stack = append(stack, parent.stack...)
+	}
+	stack = append(stack, profiler.currentRuntimeFrame())
+
+	profiler.frames = append(profiler.frames, &profilerFrame{
+		stack: stack,
+	})
+}
+
+// UpdateFrame replaces the contract function of the current frame with the
+// one currently set in the runtime (e.g. after a built-in function call)
+func (profiler *GasProfiler) UpdateFrame() {
+	frame := profiler.currentFrame()
+	if frame == nil {
+		return
+	}
+
+	frame.stack[len(frame.stack)-1] = profiler.currentRuntimeFrame()
+}
+
+// StartInstance marks that the current frame runs its own Wasmer instance
+func (profiler *GasProfiler) StartInstance() {
+	frame := profiler.currentFrame()
+	if frame == nil {
+		return
+	}
+
+	frame.hasInstance = true
+}
+
+// EndFrame pops the current frame, attributing the gas it consumed outside
+// the EEI hooks to the WASM opcodes and to the initial cost
+func (profiler *GasProfiler) EndFrame() {
+	frame := profiler.currentFrame()
+	if frame == nil {
+		return
+	}
+	profiler.frames = profiler.frames[:len(profiler.frames)-1]
+
+	pointsUsed := uint64(0)
+	if frame.hasInstance {
+		pointsUsed = profiler.host.Runtime().GetPointsUsed()
+	}
+	initialCost := profiler.host.Metering().GetSCPrepareInitialCost()
+
+	opcodesGas, _ := math.SubUint64(pointsUsed, frame.hookGas)
+	opcodesGas, _ = math.SubUint64(opcodesGas, frame.nestedGasOutsideHooks)
+	opcodesGas, _ = math.SubUint64(opcodesGas, frame.categorizedGasOutsideHooks)
+
+	profiler.profile.AddGas(frame.stack, vmhost.GasCategoryInitialCost, "", initialCost)
+	profiler.profile.AddGas(frame.stack, vmhost.GasCategoryWasmOpcodes, "", opcodesGas)
+
+	parent := profiler.currentFrame()
+	if parent == nil {
+		return
+	}
+
+	frameGas := math.AddUint64(pointsUsed, initialCost)
+	if parent.inHook {
+		parent.nestedGasInHook = math.AddUint64(parent.nestedGasInHook, frameGas)
+		return
+	}
+	parent.nestedGasOutsideHooks = math.AddUint64(parent.nestedGasOutsideHooks, frameGas)
+}
+
+// Finish ends all the frames which are still open
+func (profiler *GasProfiler) Finish() {
+	for len(profiler.frames) > 0 {
+		profiler.EndFrame()
+	}
+}
+
+// AddCategorizedGas attributes gas to a specific category, separately from
+// the EEI hook during which it was consumed
+func (profiler *GasProfiler) AddCategorizedGas(gas uint64, category vmhost.GasCategory) {
+	frame := profiler.currentFrame()
+	if frame == nil {
+		return
+	}
+
+	profiler.profile.AddGas(frame.stack, category, frame.hookName, gas)
+	if frame.inHook {
+		frame.categorizedGasInHook = math.AddUint64(frame.categorizedGasInHook, gas)
+		return
+	}
+	frame.categorizedGasOutsideHooks = math.AddUint64(frame.categorizedGasOutsideHooks, gas)
+}
+
+// TraceHookCallStart marks the start of an EEI hook in the current frame
+func (profiler *GasProfiler) TraceHookCallStart(event *vmhost.HookCallEvent) {
+	frame := profiler.currentFrame()
+	if frame == nil {
+		return
+	}
+
+	frame.inHook = true
+	frame.hookName = event.HookName
+	frame.nestedGasInHook = 0
+	frame.categorizedGasInHook = 0
+}
+
+// TraceHookCall attributes the gas consumed by an EEI hook to its category,
+// excluding the gas consumed by nested executions and categorized separately
+func (profiler *GasProfiler) TraceHookCall(event *vmhost.HookCallEvent) {
+	if !check.IfNil(profiler.tracer) {
+		profiler.tracer.TraceHookCall(event)
+	}
+
+	frame := profiler.currentFrame()
+	if frame == nil || !frame.inHook {
+		return
+	}
+
+	hookGas := event.GasUsed()
+	selfGas, _ := math.SubUint64(hookGas, frame.nestedGasInHook)
+	selfGas, _ = math.SubUint64(selfGas, frame.categorizedGasInHook)
+	profiler.profile.AddGas(frame.stack, profiler.hookCategory(event.HookName), event.HookName, selfGas)
+
+	frame.hookGas = math.AddUint64(frame.hookGas, hookGas)
+	frame.inHook = false
+	frame.hookName = ""
+}
+
+// Profile returns the GasProfile accumulated since the last Reset
+func (profiler *GasProfiler) Profile() *vmhost.GasProfile {
+	return profiler.profile
+}
+
+// Reset discards the open frames and starts a new GasProfile
+func (profiler *GasProfiler) Reset() {
+	profiler.frames = make([]*profilerFrame, 0)
+	profiler.profile = vmhost.NewGasProfile()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (profiler *GasProfiler) IsInterfaceNil() bool {
+	return profiler == nil
+}
+
+func (profiler *GasProfiler) currentFrame() *profilerFrame {
+	if len(profiler.frames) == 0 {
+		return nil
+	}
+
+	return profiler.frames[len(profiler.frames)-1]
+}
+
+func (profiler *GasProfiler) currentRuntimeFrame() vmhost.GasProfileFrame {
+	runtime := profiler.host.Runtime()
+	return vmhost.GasProfileFrame{
+		Address:  runtime.GetSCAddress(),
+		Function: runtime.Function(),
+	}
+}
+
+func (profiler *GasProfiler) hookCategory(hookName string) vmhost.GasCategory {
+	category, ok := profiler.hookCategories[hookName]
+	if !ok {
+		return vmhost.GasCategoryBaseOpsAPI
+	}
+
+	return category
+}
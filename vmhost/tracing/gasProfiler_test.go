@@ -0,0 +1,138 @@
+package tracing
+
+import (
+	"testing"
+
+	contextmock "github.com/kalyan3104/k-chain-vm-v1_2-go/mock/context"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGasProfiler(t *testing.T) {
+	t.Parallel()
+
+	profiler, err := NewGasProfiler(nil, make(map[string]vmhost.GasCategory), nil)
+	require.Nil(t, profiler)
+	require.Equal(t, ErrNilVMHost, err)
+
+	profiler, err = NewGasProfiler(&contextmock.VMHostMock{}, nil, nil)
+	require.Nil(t, profiler)
+	require.Equal(t, ErrNilHookCategories, err)
+
+	profiler, err = NewGasProfiler(&contextmock.VMHostMock{}, make(map[string]vmhost.GasCategory), nil)
+	require.Nil(t, err)
+	require.False(t, profiler.IsInterfaceNil())
+	require.Equal(t, uint64(0), profiler.Profile().TotalGas())
+}
+
+func TestGasProfiler_NestedExecution(t *testing.T) {
+	t.Parallel()
+
+	runtime := &contextmock.RuntimeContextMock{
+		SCAddress:    []byte("parent"),
+		CallFunction: "callChild",
+	}
+	host := &contextmock.VMHostMock{
+		RuntimeContext:  runtime,
+		MeteringContext: &contextmock.MeteringContextMock{},
+	}
+	hookCategories := map[string]vmhost.GasCategory{
+		"storageStore":         vmhost.GasCategoryBaseOpsAPI,
+		"executeOnDestContext": vmhost.GasCategoryBaseOpsAPI,
+		"bigIntAdd":            vmhost.GasCategoryBigIntAPI,
+	}
+	memoryTracer := NewMemoryTracer()
+
+	profiler, err := NewGasProfiler(host, hookCategories, memoryTracer)
+	require.Nil(t, err)
+
+	profiler.StartFrame()
+	profiler.StartInstance()
+
+	storeEvent := &vmhost.HookCallEvent{HookName: "storageStore", GasBefore: 1000}
+	profiler.TraceHookCallStart(storeEvent)
+	profiler.AddCategorizedGas(30, vmhost.GasCategoryStoragePerByte)
+	storeEvent.GasAfter = 950
+	profiler.TraceHookCall(storeEvent)
+
+	callEvent := &vmhost.HookCallEvent{HookName: "executeOnDestContext", GasBefore: 950}
+	profiler.TraceHookCallStart(callEvent)
+
+	runtime.SCAddress = []byte("child")
+	runtime.CallFunction = "add"
+	profiler.StartFrame()
+	profiler.StartInstance()
+	addEvent := &vmhost.HookCallEvent{HookName: "bigIntAdd", GasBefore: 200, GasAfter: 190}
+	profiler.TraceHookCallStart(addEvent)
+	profiler.TraceHookCall(addEvent)
+	runtime.PointsUsed = 100
+	profiler.EndFrame()
+
+	runtime.SCAddress = []byte("parent")
+	runtime.CallFunction = "callChild"
+	callEvent.GasAfter = 800
+	profiler.TraceHookCall(callEvent)
+
+	runtime.PointsUsed = 500
+	profiler.Finish()
+
+	profile := profiler.Profile()
+	require.Equal(t, uint64(500), profile.TotalGas())
+	require.Equal(t, map[vmhost.GasCategory]uint64{
+		vmhost.GasCategoryBaseOpsAPI:     70,
+		vmhost.GasCategoryStoragePerByte: 30,
+		vmhost.GasCategoryBigIntAPI:      10,
+		vmhost.GasCategoryWasmOpcodes:    390,
+	}, profile.GasByCategory())
+	require.Equal(t, map[string]uint64{
+		"parent": 400,
+		"child":  100,
+	}, profile.GasByContract())
+
+	require.Equal(t, []string{
+		"706172656e74::callChild;6368696c64::add;BigIntAPICost;bigIntAdd 10",
+		"706172656e74::callChild;6368696c64::add;WASMOpcodes 90",
+		"706172656e74::callChild;BaseOpsAPICost;executeOnDestContext 50",
+		"706172656e74::callChild;BaseOpsAPICost;storageStore 20",
+		"706172656e74::callChild;StoragePerByte;storageStore 30",
+		"706172656e74::callChild;WASMOpcodes 300",
+	}, profile.FoldedStacks(nil))
+
+	require.Equal(t, []string{"storageStore", "bigIntAdd", "executeOnDestContext"}, memoryTracer.HookNames())
+
+	profiler.Reset()
+	require.Equal(t, uint64(0), profiler.Profile().TotalGas())
+}
+
+func TestGasProfiler_UpdateFrameWithoutInstance(t *testing.T) {
+	t.Parallel()
+
+	runtime := &contextmock.RuntimeContextMock{
+		SCAddress:    []byte("sc"),
+		CallFunction: "DCDTTransfer",
+		PointsUsed:   1234,
+	}
+	host := &contextmock.VMHostMock{
+		RuntimeContext:  runtime,
+		MeteringContext: &contextmock.MeteringContextMock{},
+	}
+
+	profiler, _ := NewGasProfiler(host, make(map[string]vmhost.GasCategory), nil)
+	profiler.StartFrame()
+	runtime.CallFunction = "deposit"
+	profiler.UpdateFrame()
+	profiler.EndFrame()
+
+	// the frame never started its own instance, so the points used belong to the caller
+	require.Equal(t, uint64(0), profiler.Profile().TotalGas())
+
+	profiler.StartFrame()
+	profiler.StartInstance()
+	profiler.Finish()
+
+	entries := profiler.Profile().Entries()
+	require.Len(t, entries, 1)
+	require.Equal(t, "deposit", entries[0].Stack[0].Function)
+	require.Equal(t, vmhost.GasCategoryWasmOpcodes, entries[0].Category)
+	require.Equal(t, uint64(1234), entries[0].Gas)
+}
@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/big"
+	"sync"
+
+	logger "github.com/kalyan3104/k-chain-logger-go"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+)
+
+var log = logger.GetOrCreate("vm/tracing")
+
+var _ vmhost.HookTracer = (*JSONLinesTracer)(nil)
+
+type jsonHookArgument struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+type jsonHookCallEvent struct {
+	Hook      string             `json:"hook"`
+	Arguments []jsonHookArgument `json:"arguments"`
+	GasBefore uint64             `json:"gasBefore"`
+	GasAfter  uint64             `json:"gasAfter"`
+	GasUsed   uint64             `json:"gasUsed"`
+	SCAddress string             `json:"scAddress"`
+	CallDepth uint64             `json:"callDepth"`
+}
+
+// JSONLinesTracer is a HookTracer which writes each received event as a
+// single line of JSON to the provided io.Writer
+type JSONLinesTracer struct {
+	mutWriter sync.Mutex
+	encoder   *json.Encoder
+}
+
+// NewJSONLinesTracer creates a new JSONLinesTracer writing to the provided io.Writer
+func NewJSONLinesTracer(writer io.Writer) (*JSONLinesTracer, error) {
+	if writer == nil {
+		return nil, ErrNilWriter
+	}
+
+	return &JSONLinesTracer{
+		encoder: json.NewEncoder(writer),
+	}, nil
+}
+
+// TraceHookCall writes the provided event as a line of JSON
+func (tracer *JSONLinesTracer) TraceHookCall(event *vmhost.HookCallEvent) {
+	jsonEvent := &jsonHookCallEvent{
+		Hook:      event.HookName,
+		Arguments: make([]jsonHookArgument, len(event.Arguments)),
+		GasBefore: event.GasBefore,
+		GasAfter:  event.GasAfter,
+		GasUsed:   event.GasUsed(),
+		SCAddress: hex.EncodeToString(event.SCAddress),
+		CallDepth: event.CallDepth,
+	}
+	for i, argument := range event.Arguments {
+		jsonEvent.Arguments[i] = jsonHookArgument{
+			Name:  argument.Name,
+			Value: jsonHookArgumentValue(argument.Value),
+		}
+	}
+
+	tracer.mutWriter.Lock()
+	err := tracer.encoder.Encode(jsonEvent)
+	tracer.mutWriter.Unlock()
+	if err != nil {
+		log.Error("JSONLinesTracer.TraceHookCall", "hook", event.HookName, "error", err)
+	}
+}
+
+// jsonHookArgumentValue writes decoded bytes as hex and big ints as decimal
+// strings, so that they remain readable and keep their precision
+func jsonHookArgumentValue(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case []byte:
+		return hex.EncodeToString(typedValue)
+	case [][]byte:
+		encoded := make([]string, len(typedValue))
+		for i, item := range typedValue {
+			encoded[i] = hex.EncodeToString(item)
+		}
+		return encoded
+	case *big.Int:
+		return typedValue.String()
+	default:
+		return value
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (tracer *JSONLinesTracer) IsInterfaceNil() bool {
+	return tracer == nil
+}
@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJSONLinesTracer_NilWriter(t *testing.T) {
+	t.Parallel()
+
+	tracer, err := NewJSONLinesTracer(nil)
+	require.Equal(t, ErrNilWriter, err)
+	require.True(t, tracer.IsInterfaceNil())
+}
+
+func TestJSONLinesTracer_TraceHookCall(t *testing.T) {
+	t.Parallel()
+
+	buffer := &bytes.Buffer{}
+	tracer, err := NewJSONLinesTracer(buffer)
+	require.Nil(t, err)
+
+	tracer.TraceHookCall(&vmhost.HookCallEvent{
+		HookName: "bigIntAdd",
+		Arguments: []vmhost.HookArgument{
+			{Name: "destination", Value: int32(1)},
+			{Name: "op1", Value: int32(2)},
+		},
+		GasBefore: 1000,
+		GasAfter:  990,
+		SCAddress: []byte{0xab, 0xcd},
+		CallDepth: 1,
+	})
+	tracer.TraceHookCall(&vmhost.HookCallEvent{HookName: "finish"})
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	require.Len(t, lines, 2)
+
+	event := &jsonHookCallEvent{}
+	err = json.Unmarshal([]byte(lines[0]), event)
+	require.Nil(t, err)
+	require.Equal(t, "bigIntAdd", event.Hook)
+	require.Len(t, event.Arguments, 2)
+	require.Equal(t, "op1", event.Arguments[1].Name)
+	require.Equal(t, float64(2), event.Arguments[1].Value)
+	require.Equal(t, uint64(10), event.GasUsed)
+	require.Equal(t, "abcd", event.SCAddress)
+	require.Equal(t, uint64(1), event.CallDepth)
+}
+
+func TestJSONLinesTracer_DecodedArguments(t *testing.T) {
+	t.Parallel()
+
+	buffer := &bytes.Buffer{}
+	tracer, _ := NewJSONLinesTracer(buffer)
+
+	tracer.TraceHookCall(&vmhost.HookCallEvent{
+		HookName: "bigIntStorageStoreUnsigned",
+		Arguments: []vmhost.HookArgument{
+			{Name: "key", Value: []byte("abc")},
+			{Name: "arguments", Value: [][]byte{{0x01}, {0x02, 0x03}}},
+			{Name: "source", Value: big.NewInt(0).Lsh(big.NewInt(1), 100)},
+		},
+	})
+
+	event := &jsonHookCallEvent{}
+	err := json.Unmarshal(buffer.Bytes(), event)
+	require.Nil(t, err)
+	require.Equal(t, "616263", event.Arguments[0].Value)
+	require.Equal(t, []interface{}{"01", "0203"}, event.Arguments[1].Value)
+	require.Equal(t, "1267650600228229401496703205376", event.Arguments[2].Value)
+}
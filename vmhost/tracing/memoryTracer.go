@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"sync"
+
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+)
+
+var _ vmhost.HookTracer = (*MemoryTracer)(nil)
+
+// MemoryTracer is a HookTracer which keeps all the received events in memory,
+// in the order in which the hook calls have finished
+type MemoryTracer struct {
+	mutEvents sync.RWMutex
+	events    []*vmhost.HookCallEvent
+}
+
+// NewMemoryTracer creates a new, empty MemoryTracer
+func NewMemoryTracer() *MemoryTracer {
+	return &MemoryTracer{
+		events: make([]*vmhost.HookCallEvent, 0),
+	}
+}
+
+// TraceHookCall stores the provided event
+func (tracer *MemoryTracer) TraceHookCall(event *vmhost.HookCallEvent) {
+	tracer.mutEvents.Lock()
+	tracer.events = append(tracer.events, event)
+	tracer.mutEvents.Unlock()
+}
+
+// Events returns the stored events
+func (tracer *MemoryTracer) Events() []*vmhost.HookCallEvent {
+	tracer.mutEvents.RLock()
+	defer tracer.mutEvents.RUnlock()
+
+	events := make([]*vmhost.HookCallEvent, len(tracer.events))
+	copy(events, tracer.events)
+	return events
+}
+
+// HookNames returns the names of the called hooks, in the order of the stored events
+func (tracer *MemoryTracer) HookNames() []string {
+	tracer.mutEvents.RLock()
+	defer tracer.mutEvents.RUnlock()
+
+	names := make([]string, len(tracer.events))
+	for i, event := range tracer.events {
+		names[i] = event.HookName
+	}
+	return names
+}
+
+// TotalGasUsed returns the sum of the gas used by all the stored events
+func (tracer *MemoryTracer) TotalGasUsed() uint64 {
+	tracer.mutEvents.RLock()
+	defer tracer.mutEvents.RUnlock()
+
+	total := uint64(0)
+	for _, event := range tracer.events {
+		total += event.GasUsed()
+	}
+	return total
+}
+
+// Reset discards all the stored events
+func (tracer *MemoryTracer) Reset() {
+	tracer.mutEvents.Lock()
+	tracer.events = make([]*vmhost.HookCallEvent, 0)
+	tracer.mutEvents.Unlock()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (tracer *MemoryTracer) IsInterfaceNil() bool {
+	return tracer == nil
+}
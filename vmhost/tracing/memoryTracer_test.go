@@ -0,0 +1,27 @@
+package tracing
+
+import (
+	"testing"
+
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryTracer_TraceHookCall(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewMemoryTracer()
+	require.False(t, tracer.IsInterfaceNil())
+	require.Empty(t, tracer.Events())
+
+	tracer.TraceHookCall(&vmhost.HookCallEvent{HookName: "getSCAddress", GasBefore: 100, GasAfter: 90})
+	tracer.TraceHookCall(&vmhost.HookCallEvent{HookName: "storageStore", GasBefore: 90, GasAfter: 40})
+
+	require.Len(t, tracer.Events(), 2)
+	require.Equal(t, []string{"getSCAddress", "storageStore"}, tracer.HookNames())
+	require.Equal(t, uint64(60), tracer.TotalGasUsed())
+
+	tracer.Reset()
+	require.Empty(t, tracer.Events())
+	require.Equal(t, uint64(0), tracer.TotalGasUsed())
+}
@@ -13,6 +13,7 @@ package vmhooks
 // extern void			v1_2_getExternalBalance(void *context, int32_t addressOffset, int32_t resultOffset);
 // extern int32_t		v1_2_blockHash(void *context, long long nonce, int32_t resultOffset);
 // extern int32_t 	v1_2_transferValue(void *context, int32_t dstOffset, int32_t valueOffset, int32_t dataOffset, int32_t length);
+// extern int32_t 	v1_2_selfDestruct(void *context, int32_t beneficiaryOffset);
 // extern int32_t 	v1_2_transferDCDT(void *context, int32_t dstOffset, int32_t tokenIDOffset, int32_t tokenIdLen, int32_t valueOffset, long long gasLimit, int32_t dataOffset, int32_t length);
 // extern int32_t 	v1_2_transferDCDTExecute(void *context, int32_t dstOffset, int32_t tokenIDOffset, int32_t tokenIdLen, int32_t valueOffset, long long gasLimit, int32_t functionOffset, int32_t functionLength, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
 // extern int32_t 	v1_2_transferDCDTNFTExecute(void *context, int32_t dstOffset, int32_t tokenIDOffset, int32_t tokenIdLen, int32_t valueOffset, long long nonce, long long gasLimit, int32_t functionOffset, int32_t functionLength, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
@@ -32,6 +33,11 @@ package vmhooks
 // extern int32_t		v1_2_getDCDTTokenName(void *context, int32_t resultOffset);
 // extern long long v1_2_getDCDTTokenNonce(void *context);
 // extern int32_t		v1_2_getDCDTTokenType(void *context);
+// extern int32_t		v1_2_getNumDCDTTransfers(void *context);
+// extern int32_t		v1_2_getDCDTValueByIndex(void *context, int32_t resultOffset, int32_t index);
+// extern int32_t		v1_2_getDCDTTokenNameByIndex(void *context, int32_t resultOffset, int32_t index);
+// extern long long v1_2_getDCDTTokenNonceByIndex(void *context, int32_t index);
+// extern int32_t		v1_2_getDCDTTokenTypeByIndex(void *context, int32_t index);
 // extern long long v1_2_getCurrentDCDTNFTNonce(void *context, int32_t addressOffset, int32_t tokenIDOffset, int32_t tokenIDLen);
 // extern int32_t		v1_2_getCallValueTokenName(void *context, int32_t callValueOffset, int32_t tokenNameOffset);
 // extern void			v1_2_writeLog(void *context, int32_t pointer, int32_t length, int32_t topicPtr, int32_t numTopics);
@@ -112,6 +118,13 @@ func getFirstDCDTTransferIfExist(vmInput *vmcommon.VMInput) *vmcommon.DCDTTransf
 	}
 }
 
+func getDCDTTransferByIndex(vmInput *vmcommon.VMInput, index int32) *vmcommon.DCDTTransfer {
+	if index < 0 || int(index) >= len(vmInput.DCDTTransfers) {
+		return nil
+	}
+	return vmInput.DCDTTransfers[index]
+}
+
 // BaseOpsAPIImports creates a new wasmer.Imports populated with the BaseOpsAPI API methods
 func BaseOpsAPIImports() (*wasmer.Imports, error) {
 	imports := wasmer.NewImports()
@@ -282,6 +295,31 @@ func BaseOpsAPIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("getNumDCDTTransfers", v1_2_getNumDCDTTransfers, C.v1_2_getNumDCDTTransfers)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getDCDTValueByIndex", v1_2_getDCDTValueByIndex, C.v1_2_getDCDTValueByIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getDCDTTokenNameByIndex", v1_2_getDCDTTokenNameByIndex, C.v1_2_getDCDTTokenNameByIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getDCDTTokenNonceByIndex", v1_2_getDCDTTokenNonceByIndex, C.v1_2_getDCDTTokenNonceByIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getDCDTTokenTypeByIndex", v1_2_getDCDTTokenTypeByIndex, C.v1_2_getDCDTTokenTypeByIndex)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("getCurrentDCDTNFTNonce", v1_2_getCurrentDCDTNFTNonce, C.v1_2_getCurrentDCDTNFTNonce)
 	if err != nil {
 		return nil, err
@@ -452,11 +490,20 @@ func BaseOpsAPIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("selfDestruct", v1_2_selfDestruct, C.v1_2_selfDestruct)
+	if err != nil {
+		return nil, err
+	}
+
 	return imports, nil
 }
 
 //export v1_2_getGasLeft
 func v1_2_getGasLeft(context unsafe.Pointer) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getGasLeft")()
+	}
+
 	metering := vmhost.GetMeteringContext(context)
 
 	gasToUse := metering.GasSchedule().BaseOpsAPICost.GetGasLeft
@@ -467,6 +514,11 @@ func v1_2_getGasLeft(context unsafe.Pointer) int64 {
 
 //export v1_2_getSCAddress
 func v1_2_getSCAddress(context unsafe.Pointer, resultOffset int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getSCAddress",
+			"result", vmhost.TraceMemoryResult(resultOffset, vmhost.AddressLen))()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -482,6 +534,11 @@ func v1_2_getSCAddress(context unsafe.Pointer, resultOffset int32) {
 
 //export v1_2_getOwnerAddress
 func v1_2_getOwnerAddress(context unsafe.Pointer, resultOffset int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getOwnerAddress",
+			"result", vmhost.TraceMemoryResult(resultOffset, vmhost.AddressLen))()
+	}
+
 	blockchain := vmhost.GetBlockchainContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -502,6 +559,11 @@ func v1_2_getOwnerAddress(context unsafe.Pointer, resultOffset int32) {
 
 //export v1_2_getShardOfAddress
 func v1_2_getShardOfAddress(context unsafe.Pointer, addressOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getShardOfAddress",
+			"address", vmhost.TraceMemory(addressOffset, vmhost.AddressLen))()
+	}
+
 	blockchain := vmhost.GetBlockchainContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -519,6 +581,10 @@ func v1_2_getShardOfAddress(context unsafe.Pointer, addressOffset int32) int32 {
 
 //export v1_2_isSmartContract
 func v1_2_isSmartContract(context unsafe.Pointer, addressOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "isSmartContract", "address", vmhost.TraceMemory(addressOffset, vmhost.AddressLen))()
+	}
+
 	blockchain := vmhost.GetBlockchainContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -537,6 +603,10 @@ func v1_2_isSmartContract(context unsafe.Pointer, addressOffset int32) int32 {
 
 //export v1_2_signalError
 func v1_2_signalError(context unsafe.Pointer, messageOffset int32, messageLength int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "signalError", "message", vmhost.TraceMemory(messageOffset, messageLength))()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -552,6 +622,12 @@ func v1_2_signalError(context unsafe.Pointer, messageOffset int32, messageLength
 
 //export v1_2_getExternalBalance
 func v1_2_getExternalBalance(context unsafe.Pointer, addressOffset int32, resultOffset int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getExternalBalance",
+			"address", vmhost.TraceMemory(addressOffset, vmhost.AddressLen),
+			"resultOffset", resultOffset)()
+	}
+
 	blockchain := vmhost.GetBlockchainContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -574,6 +650,10 @@ func v1_2_getExternalBalance(context unsafe.Pointer, addressOffset int32, result
 
 //export v1_2_blockHash
 func v1_2_blockHash(context unsafe.Pointer, nonce int64, resultOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getBlockHash", "nonce", nonce, "resultOffset", resultOffset)()
+	}
+
 	blockchain := vmhost.GetBlockchainContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -637,6 +717,14 @@ func v1_2_getDCDTBalance(
 	nonce int64,
 	resultOffset int32,
 ) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getDCDTBalance",
+			"address", vmhost.TraceMemory(addressOffset, vmhost.AddressLen),
+			"tokenID", vmhost.TraceMemory(tokenIDOffset, tokenIDLen),
+			"nonce", nonce,
+			"resultOffset", resultOffset)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	dcdtData, err := getDCDTDataFromBlockchainHook(context, addressOffset, tokenIDOffset, tokenIDLen, nonce)
 	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
@@ -658,6 +746,13 @@ func v1_2_getDCDTNFTNameLength(
 	tokenIDLen int32,
 	nonce int64,
 ) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getDCDTNFTNameLength",
+			"address", vmhost.TraceMemory(addressOffset, vmhost.AddressLen),
+			"tokenID", vmhost.TraceMemory(tokenIDOffset, tokenIDLen),
+			"nonce", nonce)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	dcdtData, err := getDCDTDataFromBlockchainHook(context, addressOffset, tokenIDOffset, tokenIDLen, nonce)
 	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
@@ -678,6 +773,13 @@ func v1_2_getDCDTNFTAttributeLength(
 	tokenIDLen int32,
 	nonce int64,
 ) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getDCDTNFTAttributeLength",
+			"address", vmhost.TraceMemory(addressOffset, vmhost.AddressLen),
+			"tokenID", vmhost.TraceMemory(tokenIDOffset, tokenIDLen),
+			"nonce", nonce)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	dcdtData, err := getDCDTDataFromBlockchainHook(context, addressOffset, tokenIDOffset, tokenIDLen, nonce)
 	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
@@ -698,6 +800,13 @@ func v1_2_getDCDTNFTURILength(
 	tokenIDLen int32,
 	nonce int64,
 ) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getDCDTNFTURILength",
+			"address", vmhost.TraceMemory(addressOffset, vmhost.AddressLen),
+			"tokenID", vmhost.TraceMemory(tokenIDOffset, tokenIDLen),
+			"nonce", nonce)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	dcdtData, err := getDCDTDataFromBlockchainHook(context, addressOffset, tokenIDOffset, tokenIDLen, nonce)
 	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
@@ -729,6 +838,21 @@ func v1_2_getDCDTTokenData(
 	royaltiesOffset int32,
 	urisOffset int32,
 ) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getDCDTTokenData",
+			"address", vmhost.TraceMemory(addressOffset, vmhost.AddressLen),
+			"tokenID", vmhost.TraceMemory(tokenIDOffset, tokenIDLen),
+			"nonce", nonce,
+			"valueOffset", valueOffset,
+			"propertiesOffset", propertiesOffset,
+			"hashOffset", hashOffset,
+			"nameOffset", nameOffset,
+			"attributesOffset", attributesOffset,
+			"creatorOffset", creatorOffset,
+			"royaltiesOffset", royaltiesOffset,
+			"urisOffset", urisOffset)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	dcdtData, err := getDCDTDataFromBlockchainHook(context, addressOffset, tokenIDOffset, tokenIDLen, nonce)
 	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
@@ -777,6 +901,13 @@ func v1_2_getDCDTTokenData(
 
 //export v1_2_transferValue
 func v1_2_transferValue(context unsafe.Pointer, destOffset int32, valueOffset int32, dataOffset int32, length int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "transferValue",
+			"dest", vmhost.TraceMemory(destOffset, vmhost.AddressLen),
+			"value", vmhost.TraceMemory(valueOffset, vmhost.BalanceLen),
+			"data", vmhost.TraceMemory(dataOffset, length))()
+	}
+
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -816,6 +947,34 @@ func v1_2_transferValue(context unsafe.Pointer, destOffset int32, valueOffset in
 	return 0
 }
 
+//export v1_2_selfDestruct
+func v1_2_selfDestruct(context unsafe.Pointer, beneficiaryOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "selfDestruct",
+			"beneficiary", vmhost.TraceMemory(beneficiaryOffset, vmhost.AddressLen))()
+	}
+
+	host := vmhost.GetVMHost(context)
+	runtime := host.Runtime()
+	metering := host.Metering()
+	output := host.Output()
+
+	gasToUse := metering.GasSchedule().EthAPICost.SelfDestruct
+	metering.UseGas(gasToUse)
+
+	beneficiary, err := runtime.MemLoad(beneficiaryOffset, vmhost.AddressLen)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return 1
+	}
+
+	err = output.SelfDestruct(runtime.GetSCAddress(), beneficiary)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return 0
+}
+
 //export v1_2_transferValueExecute
 func v1_2_transferValueExecute(
 	context unsafe.Pointer,
@@ -828,6 +987,15 @@ func v1_2_transferValueExecute(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "transferValueExecute",
+			"dest", vmhost.TraceMemory(destOffset, vmhost.AddressLen),
+			"value", vmhost.TraceMemory(valueOffset, vmhost.BalanceLen),
+			"gasLimit", gasLimit,
+			"function", vmhost.TraceMemory(functionOffset, functionLength),
+			"arguments", vmhost.TraceMemoryArguments(numArguments, argumentsLengthOffset, dataOffset))()
+	}
+
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -917,6 +1085,15 @@ func v1_2_transferDCDT(
 	dataOffset int32,
 	length int32,
 ) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "transferDCDT",
+			"dest", vmhost.TraceMemory(destOffset, vmhost.AddressLen),
+			"tokenID", vmhost.TraceMemory(tokenIDOffset, tokenIDLen),
+			"value", vmhost.TraceMemory(valueOffset, vmhost.BalanceLen),
+			"gasLimit", gasLimit,
+			"data", vmhost.TraceMemory(dataOffset, length))()
+	}
+
 	host := vmhost.GetVMHost(context)
 	metering := host.Metering()
 
@@ -944,7 +1121,17 @@ func v1_2_transferDCDTExecute(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
-	return v1_2_transferDCDTNFTExecute(context, destOffset, tokenIDOffset, tokenIDLen, valueOffset, 0,
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "transferDCDTExecute",
+			"dest", vmhost.TraceMemory(destOffset, vmhost.AddressLen),
+			"tokenID", vmhost.TraceMemory(tokenIDOffset, tokenIDLen),
+			"value", vmhost.TraceMemory(valueOffset, vmhost.BalanceLen),
+			"gasLimit", gasLimit,
+			"function", vmhost.TraceMemory(functionOffset, functionLength),
+			"arguments", vmhost.TraceMemoryArguments(numArguments, argumentsLengthOffset, dataOffset))()
+	}
+
+	return transferDCDTNFTExecute(context, destOffset, tokenIDOffset, tokenIDLen, valueOffset, 0,
 		gasLimit, functionOffset, functionLength, numArguments, argumentsLengthOffset, dataOffset)
 }
 
@@ -962,6 +1149,35 @@ func v1_2_transferDCDTNFTExecute(
 	numArguments int32,
 	argumentsLengthOffset int32,
 	dataOffset int32,
+) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "transferDCDTNFTExecute",
+			"dest", vmhost.TraceMemory(destOffset, vmhost.AddressLen),
+			"tokenID", vmhost.TraceMemory(tokenIDOffset, tokenIDLen),
+			"value", vmhost.TraceMemory(valueOffset, vmhost.BalanceLen),
+			"nonce", nonce,
+			"gasLimit", gasLimit,
+			"function", vmhost.TraceMemory(functionOffset, functionLength),
+			"arguments", vmhost.TraceMemoryArguments(numArguments, argumentsLengthOffset, dataOffset))()
+	}
+
+	return transferDCDTNFTExecute(context, destOffset, tokenIDOffset, tokenIDLen, valueOffset, nonce,
+		gasLimit, functionOffset, functionLength, numArguments, argumentsLengthOffset, dataOffset)
+}
+
+func transferDCDTNFTExecute(
+	context unsafe.Pointer,
+	destOffset int32,
+	tokenIDOffset int32,
+	tokenIDLen int32,
+	valueOffset int32,
+	nonce int64,
+	gasLimit int64,
+	functionOffset int32,
+	functionLength int32,
+	numArguments int32,
+	argumentsLengthOffset int32,
+	dataOffset int32,
 ) int32 {
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
@@ -1058,6 +1274,17 @@ func v1_2_createAsyncCall(context unsafe.Pointer,
 	errorLength int32,
 	gas int64,
 ) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "createAsyncCall",
+			"identifier", vmhost.TraceMemory(asyncContextIdentifier, identifierLength),
+			"dest", vmhost.TraceMemory(destOffset, vmhost.AddressLen),
+			"value", vmhost.TraceMemory(valueOffset, vmhost.BalanceLen),
+			"data", vmhost.TraceMemory(dataOffset, length),
+			"successCallback", vmhost.TraceMemory(successOffset, successLength),
+			"errorCallback", vmhost.TraceMemory(errorOffset, errorLength),
+			"gas", gas)()
+	}
+
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 
@@ -1113,6 +1340,12 @@ func v1_2_setAsyncContextCallback(context unsafe.Pointer,
 	callback int32,
 	callbackLength int32,
 ) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "setAsyncContextCallback",
+			"identifier", vmhost.TraceMemory(asyncContextIdentifier, identifierLength),
+			"callback", vmhost.TraceMemory(callback, callbackLength))()
+	}
+
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 
@@ -1151,6 +1384,16 @@ func v1_2_upgradeContract(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "upgradeContract",
+			"dest", vmhost.TraceMemory(destOffset, vmhost.AddressLen),
+			"gasLimit", gasLimit,
+			"value", vmhost.TraceMemory(valueOffset, vmhost.BalanceLen),
+			"code", vmhost.TraceMemory(codeOffset, length),
+			"codeMetadata", vmhost.TraceMemory(codeMetadataOffset, vmhost.CodeMetadataLen),
+			"arguments", vmhost.TraceMemoryArguments(numArguments, argumentsLengthOffset, dataOffset))()
+	}
+
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -1230,6 +1473,13 @@ func v1_2_upgradeContract(
 
 //export v1_2_asyncCall
 func v1_2_asyncCall(context unsafe.Pointer, destOffset int32, valueOffset int32, dataOffset int32, length int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "asyncCall",
+			"dest", vmhost.TraceMemory(destOffset, vmhost.AddressLen),
+			"value", vmhost.TraceMemory(valueOffset, vmhost.BalanceLen),
+			"data", vmhost.TraceMemory(dataOffset, length))()
+	}
+
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -1268,6 +1518,10 @@ func v1_2_asyncCall(context unsafe.Pointer, destOffset int32, valueOffset int32,
 
 //export v1_2_getArgumentLength
 func v1_2_getArgumentLength(context unsafe.Pointer, id int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getArgumentLength", "id", id)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1284,6 +1538,10 @@ func v1_2_getArgumentLength(context unsafe.Pointer, id int32) int32 {
 
 //export v1_2_getArgument
 func v1_2_getArgument(context unsafe.Pointer, id int32, argOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getArgument", "id", id, "argOffset", argOffset)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1305,6 +1563,10 @@ func v1_2_getArgument(context unsafe.Pointer, id int32, argOffset int32) int32 {
 
 //export v1_2_getFunction
 func v1_2_getFunction(context unsafe.Pointer, functionOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getFunction", "functionOffset", functionOffset)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1322,6 +1584,10 @@ func v1_2_getFunction(context unsafe.Pointer, functionOffset int32) int32 {
 
 //export v1_2_getNumArguments
 func v1_2_getNumArguments(context unsafe.Pointer) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getNumArguments")()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1334,6 +1600,12 @@ func v1_2_getNumArguments(context unsafe.Pointer) int32 {
 
 //export v1_2_storageStore
 func v1_2_storageStore(context unsafe.Pointer, keyOffset int32, keyLength int32, dataOffset int32, dataLength int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "storageStore",
+			"key", vmhost.TraceMemory(keyOffset, keyLength),
+			"data", vmhost.TraceMemory(dataOffset, dataLength))()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -1361,6 +1633,10 @@ func v1_2_storageStore(context unsafe.Pointer, keyOffset int32, keyLength int32,
 
 //export v1_2_storageLoadLength
 func v1_2_storageLoadLength(context unsafe.Pointer, keyOffset int32, keyLength int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "storageLoadLength", "key", vmhost.TraceMemory(keyOffset, keyLength))()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -1380,6 +1656,13 @@ func v1_2_storageLoadLength(context unsafe.Pointer, keyOffset int32, keyLength i
 
 //export v1_2_storageLoadFromAddress
 func v1_2_storageLoadFromAddress(context unsafe.Pointer, addressOffset int32, keyOffset int32, keyLength int32, dataOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "storageLoadFromAddress",
+			"address", vmhost.TraceMemory(addressOffset, vmhost.AddressLen),
+			"key", vmhost.TraceMemory(keyOffset, keyLength),
+			"dataOffset", dataOffset)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -1409,6 +1692,12 @@ func v1_2_storageLoadFromAddress(context unsafe.Pointer, addressOffset int32, ke
 
 //export v1_2_storageLoad
 func v1_2_storageLoad(context unsafe.Pointer, keyOffset int32, keyLength int32, dataOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "storageLoad",
+			"key", vmhost.TraceMemory(keyOffset, keyLength),
+			"dataOffset", dataOffset)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -1433,6 +1722,16 @@ func v1_2_storageLoad(context unsafe.Pointer, keyOffset int32, keyLength int32,
 
 //export v1_2_setStorageLock
 func v1_2_setStorageLock(context unsafe.Pointer, keyOffset int32, keyLength int32, lockTimestamp int64) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "setStorageLock",
+			"key", vmhost.TraceMemory(keyOffset, keyLength),
+			"lockTimestamp", lockTimestamp)()
+	}
+
+	return setStorageLock(context, keyOffset, keyLength, lockTimestamp)
+}
+
+func setStorageLock(context unsafe.Pointer, keyOffset int32, keyLength int32, lockTimestamp int64) int32 {
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -1456,6 +1755,14 @@ func v1_2_setStorageLock(context unsafe.Pointer, keyOffset int32, keyLength int3
 
 //export v1_2_getStorageLock
 func v1_2_getStorageLock(context unsafe.Pointer, keyOffset int32, keyLength int32) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getStorageLock", "key", vmhost.TraceMemory(keyOffset, keyLength))()
+	}
+
+	return getStorageLock(context, keyOffset, keyLength)
+}
+
+func getStorageLock(context unsafe.Pointer, keyOffset int32, keyLength int32) int64 {
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	storage := vmhost.GetStorageContext(context)
@@ -1477,12 +1784,16 @@ func v1_2_getStorageLock(context unsafe.Pointer, keyOffset int32, keyLength int3
 
 //export v1_2_isStorageLocked
 func v1_2_isStorageLocked(context unsafe.Pointer, keyOffset int32, keyLength int32) int32 {
-	timeLock := v1_2_getStorageLock(context, keyOffset, keyLength)
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "isStorageLocked", "key", vmhost.TraceMemory(keyOffset, keyLength))()
+	}
+
+	timeLock := getStorageLock(context, keyOffset, keyLength)
 	if timeLock < 0 {
 		return -1
 	}
 
-	currentTimestamp := v1_2_getBlockTimestamp(context)
+	currentTimestamp := getBlockTimestamp(context)
 	if timeLock <= currentTimestamp {
 		return 0
 	}
@@ -1492,11 +1803,19 @@ func v1_2_isStorageLocked(context unsafe.Pointer, keyOffset int32, keyLength int
 
 //export v1_2_clearStorageLock
 func v1_2_clearStorageLock(context unsafe.Pointer, keyOffset int32, keyLength int32) int32 {
-	return v1_2_setStorageLock(context, keyOffset, keyLength, 0)
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "clearStorageLock", "key", vmhost.TraceMemory(keyOffset, keyLength))()
+	}
+
+	return setStorageLock(context, keyOffset, keyLength, 0)
 }
 
 //export v1_2_getCaller
 func v1_2_getCaller(context unsafe.Pointer, resultOffset int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getCaller", "result", vmhost.TraceMemoryResult(resultOffset, vmhost.AddressLen))()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1513,6 +1832,10 @@ func v1_2_getCaller(context unsafe.Pointer, resultOffset int32) {
 
 //export v1_2_checkNoPayment
 func v1_2_checkNoPayment(context unsafe.Pointer) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "checkNoPayment")()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1534,6 +1857,10 @@ func v1_2_checkNoPayment(context unsafe.Pointer) {
 
 //export v1_2_callValue
 func v1_2_callValue(context unsafe.Pointer, resultOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getCallValue", "resultOffset", resultOffset)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1553,6 +1880,10 @@ func v1_2_callValue(context unsafe.Pointer, resultOffset int32) int32 {
 
 //export v1_2_getDCDTValue
 func v1_2_getDCDTValue(context unsafe.Pointer, resultOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getDCDTValue", "resultOffset", resultOffset)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1577,6 +1908,10 @@ func v1_2_getDCDTValue(context unsafe.Pointer, resultOffset int32) int32 {
 
 //export v1_2_getDCDTTokenName
 func v1_2_getDCDTTokenName(context unsafe.Pointer, resultOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getDCDTTokenName", "resultOffset", resultOffset)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1596,6 +1931,10 @@ func v1_2_getDCDTTokenName(context unsafe.Pointer, resultOffset int32) int32 {
 
 //export v1_2_getDCDTTokenNonce
 func v1_2_getDCDTTokenNonce(context unsafe.Pointer) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getDCDTTokenNonce")()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1608,6 +1947,12 @@ func v1_2_getDCDTTokenNonce(context unsafe.Pointer) int64 {
 
 //export v1_2_getCurrentDCDTNFTNonce
 func v1_2_getCurrentDCDTNFTNonce(context unsafe.Pointer, addressOffset int32, tokenIDOffset int32, tokenIDLen int32) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getCurrentDCDTNFTNonce",
+			"address", vmhost.TraceMemory(addressOffset, vmhost.AddressLen),
+			"tokenID", vmhost.TraceMemory(tokenIDOffset, tokenIDLen))()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 	storage := vmhost.GetStorageContext(context)
@@ -1634,6 +1979,10 @@ func v1_2_getCurrentDCDTNFTNonce(context unsafe.Pointer, addressOffset int32, to
 
 //export v1_2_getDCDTTokenType
 func v1_2_getDCDTTokenType(context unsafe.Pointer) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getDCDTTokenType")()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1644,8 +1993,158 @@ func v1_2_getDCDTTokenType(context unsafe.Pointer) int32 {
 	return int32(dcdtTransfer.DCDTTokenType)
 }
 
+//export v1_2_getNumDCDTTransfers
+func v1_2_getNumDCDTTransfers(context unsafe.Pointer) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getNumDCDTTransfers")()
+	}
+
+	host := vmhost.GetVMHost(context)
+	return GetNumDCDTTransfersWithHost(host)
+}
+
+// GetNumDCDTTransfersWithHost - getNumDCDTTransfers with host instead of pointer context
+func GetNumDCDTTransfersWithHost(host vmhost.VMHost) int32 {
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	gasToUse := metering.GasSchedule().BaseOpsAPICost.GetCallValue
+	metering.UseGas(gasToUse)
+
+	return int32(len(runtime.GetVMInput().DCDTTransfers))
+}
+
+//export v1_2_getDCDTValueByIndex
+func v1_2_getDCDTValueByIndex(context unsafe.Pointer, resultOffset int32, index int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getDCDTValueByIndex", "resultOffset", resultOffset, "index", index)()
+	}
+
+	host := vmhost.GetVMHost(context)
+	return GetDCDTValueByIndexWithHost(host, resultOffset, index)
+}
+
+// GetDCDTValueByIndexWithHost - getDCDTValueByIndex with host instead of pointer context
+func GetDCDTValueByIndexWithHost(host vmhost.VMHost, resultOffset int32, index int32) int32 {
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	gasToUse := metering.GasSchedule().BaseOpsAPICost.GetCallValue
+	metering.UseGas(gasToUse)
+
+	dcdtTransfer := getDCDTTransferByIndex(runtime.GetVMInput(), index)
+	if dcdtTransfer == nil {
+		vmhost.WithFaultAndHost(host, vmhost.ErrInvalidTokenIndex, runtime.BaseOpsErrorShouldFailExecution())
+		return -1
+	}
+
+	var value []byte
+	if dcdtTransfer.DCDTValue.Cmp(vmhost.Zero) > 0 {
+		value = dcdtTransfer.DCDTValue.Bytes()
+		value = vmhost.PadBytesLeft(value, vmhost.BalanceLen)
+	}
+
+	err := runtime.MemStore(resultOffset, value)
+	if vmhost.WithFaultAndHost(host, err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(value))
+}
+
+//export v1_2_getDCDTTokenNameByIndex
+func v1_2_getDCDTTokenNameByIndex(context unsafe.Pointer, resultOffset int32, index int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getDCDTTokenNameByIndex", "resultOffset", resultOffset, "index", index)()
+	}
+
+	host := vmhost.GetVMHost(context)
+	return GetDCDTTokenNameByIndexWithHost(host, resultOffset, index)
+}
+
+// GetDCDTTokenNameByIndexWithHost - getDCDTTokenNameByIndex with host instead of pointer context
+func GetDCDTTokenNameByIndexWithHost(host vmhost.VMHost, resultOffset int32, index int32) int32 {
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	gasToUse := metering.GasSchedule().BaseOpsAPICost.GetCallValue
+	metering.UseGas(gasToUse)
+
+	dcdtTransfer := getDCDTTransferByIndex(runtime.GetVMInput(), index)
+	if dcdtTransfer == nil {
+		vmhost.WithFaultAndHost(host, vmhost.ErrInvalidTokenIndex, runtime.BaseOpsErrorShouldFailExecution())
+		return -1
+	}
+
+	tokenName := dcdtTransfer.DCDTTokenName
+	err := runtime.MemStore(resultOffset, tokenName)
+	if vmhost.WithFaultAndHost(host, err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(tokenName))
+}
+
+//export v1_2_getDCDTTokenNonceByIndex
+func v1_2_getDCDTTokenNonceByIndex(context unsafe.Pointer, index int32) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getDCDTTokenNonceByIndex", "index", index)()
+	}
+
+	host := vmhost.GetVMHost(context)
+	return GetDCDTTokenNonceByIndexWithHost(host, index)
+}
+
+// GetDCDTTokenNonceByIndexWithHost - getDCDTTokenNonceByIndex with host instead of pointer context
+func GetDCDTTokenNonceByIndexWithHost(host vmhost.VMHost, index int32) int64 {
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	gasToUse := metering.GasSchedule().BaseOpsAPICost.GetCallValue
+	metering.UseGas(gasToUse)
+
+	dcdtTransfer := getDCDTTransferByIndex(runtime.GetVMInput(), index)
+	if dcdtTransfer == nil {
+		vmhost.WithFaultAndHost(host, vmhost.ErrInvalidTokenIndex, runtime.BaseOpsErrorShouldFailExecution())
+		return -1
+	}
+
+	return int64(dcdtTransfer.DCDTTokenNonce)
+}
+
+//export v1_2_getDCDTTokenTypeByIndex
+func v1_2_getDCDTTokenTypeByIndex(context unsafe.Pointer, index int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getDCDTTokenTypeByIndex", "index", index)()
+	}
+
+	host := vmhost.GetVMHost(context)
+	return GetDCDTTokenTypeByIndexWithHost(host, index)
+}
+
+// GetDCDTTokenTypeByIndexWithHost - getDCDTTokenTypeByIndex with host instead of pointer context
+func GetDCDTTokenTypeByIndexWithHost(host vmhost.VMHost, index int32) int32 {
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	gasToUse := metering.GasSchedule().BaseOpsAPICost.GetCallValue
+	metering.UseGas(gasToUse)
+
+	dcdtTransfer := getDCDTTransferByIndex(runtime.GetVMInput(), index)
+	if dcdtTransfer == nil {
+		vmhost.WithFaultAndHost(host, vmhost.ErrInvalidTokenIndex, runtime.BaseOpsErrorShouldFailExecution())
+		return -1
+	}
+
+	return int32(dcdtTransfer.DCDTTokenType)
+}
+
 //export v1_2_getCallValueTokenName
 func v1_2_getCallValueTokenName(context unsafe.Pointer, callValueOffset int32, tokenNameOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getCallValueTokenName", "callValueOffset", callValueOffset, "tokenNameOffset", tokenNameOffset)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1678,6 +2177,12 @@ func v1_2_getCallValueTokenName(context unsafe.Pointer, callValueOffset int32, t
 
 //export v1_2_writeLog
 func v1_2_writeLog(context unsafe.Pointer, dataPointer int32, dataLength int32, topicPtr int32, numTopics int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "writeLog",
+			"data", vmhost.TraceMemory(dataPointer, dataLength),
+			"topics", vmhost.TraceMemory(topicPtr, numTopics*vmhost.HashLen))()
+	}
+
 	// note: deprecated
 	runtime := vmhost.GetRuntimeContext(context)
 	output := vmhost.GetOutputContext(context)
@@ -1716,6 +2221,11 @@ func v1_2_writeEventLog(
 	topicOffset int32,
 	dataOffset int32,
 	dataLength int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "writeEventLog",
+			"topics", vmhost.TraceMemoryArguments(numTopics, topicLengthsOffset, topicOffset),
+			"data", vmhost.TraceMemory(dataOffset, dataLength))()
+	}
 
 	host := vmhost.GetVMHost(context)
 	runtime := vmhost.GetRuntimeContext(context)
@@ -1749,6 +2259,14 @@ func v1_2_writeEventLog(
 
 //export v1_2_getBlockTimestamp
 func v1_2_getBlockTimestamp(context unsafe.Pointer) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getBlockTimestamp")()
+	}
+
+	return getBlockTimestamp(context)
+}
+
+func getBlockTimestamp(context unsafe.Pointer) int64 {
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1760,6 +2278,10 @@ func v1_2_getBlockTimestamp(context unsafe.Pointer) int64 {
 
 //export v1_2_getBlockNonce
 func v1_2_getBlockNonce(context unsafe.Pointer) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getBlockNonce")()
+	}
+
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1771,6 +2293,10 @@ func v1_2_getBlockNonce(context unsafe.Pointer) int64 {
 
 //export v1_2_getBlockRound
 func v1_2_getBlockRound(context unsafe.Pointer) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getBlockRound")()
+	}
+
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1782,6 +2308,10 @@ func v1_2_getBlockRound(context unsafe.Pointer) int64 {
 
 //export v1_2_getBlockEpoch
 func v1_2_getBlockEpoch(context unsafe.Pointer) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getBlockEpoch")()
+	}
+
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1793,6 +2323,10 @@ func v1_2_getBlockEpoch(context unsafe.Pointer) int64 {
 
 //export v1_2_getBlockRandomSeed
 func v1_2_getBlockRandomSeed(context unsafe.Pointer, pointer int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getBlockRandomSeed", "pointer", pointer)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -1807,6 +2341,10 @@ func v1_2_getBlockRandomSeed(context unsafe.Pointer, pointer int32) {
 
 //export v1_2_getStateRootHash
 func v1_2_getStateRootHash(context unsafe.Pointer, pointer int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getStateRootHash", "pointer", pointer)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -1821,6 +2359,10 @@ func v1_2_getStateRootHash(context unsafe.Pointer, pointer int32) {
 
 //export v1_2_getPrevBlockTimestamp
 func v1_2_getPrevBlockTimestamp(context unsafe.Pointer) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getPrevBlockTimestamp")()
+	}
+
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1832,6 +2374,10 @@ func v1_2_getPrevBlockTimestamp(context unsafe.Pointer) int64 {
 
 //export v1_2_getPrevBlockNonce
 func v1_2_getPrevBlockNonce(context unsafe.Pointer) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getPrevBlockNonce")()
+	}
+
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1843,6 +2389,10 @@ func v1_2_getPrevBlockNonce(context unsafe.Pointer) int64 {
 
 //export v1_2_getPrevBlockRound
 func v1_2_getPrevBlockRound(context unsafe.Pointer) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getPrevBlockRound")()
+	}
+
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1854,6 +2404,10 @@ func v1_2_getPrevBlockRound(context unsafe.Pointer) int64 {
 
 //export v1_2_getPrevBlockEpoch
 func v1_2_getPrevBlockEpoch(context unsafe.Pointer) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getPrevBlockEpoch")()
+	}
+
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -1865,6 +2419,10 @@ func v1_2_getPrevBlockEpoch(context unsafe.Pointer) int64 {
 
 //export v1_2_getPrevBlockRandomSeed
 func v1_2_getPrevBlockRandomSeed(context unsafe.Pointer, pointer int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getPrevBlockRandomSeed", "pointer", pointer)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	blockchain := vmhost.GetBlockchainContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -1879,6 +2437,10 @@ func v1_2_getPrevBlockRandomSeed(context unsafe.Pointer, pointer int32) {
 
 //export v1_2_returnData
 func v1_2_returnData(context unsafe.Pointer, pointer int32, length int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "finish", "data", vmhost.TraceMemory(pointer, length))()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	output := vmhost.GetOutputContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -1908,6 +2470,15 @@ func v1_2_executeOnSameContext(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "executeOnSameContext",
+			"gasLimit", gasLimit,
+			"address", vmhost.TraceMemory(addressOffset, vmhost.AddressLen),
+			"value", vmhost.TraceMemory(valueOffset, vmhost.BalanceLen),
+			"function", vmhost.TraceMemory(functionOffset, functionLength),
+			"arguments", vmhost.TraceMemoryArguments(numArguments, argumentsLengthOffset, dataOffset))()
+	}
+
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -2148,6 +2719,15 @@ func v1_2_executeOnDestContext(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "executeOnDestContext",
+			"gasLimit", gasLimit,
+			"address", vmhost.TraceMemory(addressOffset, vmhost.AddressLen),
+			"value", vmhost.TraceMemory(valueOffset, vmhost.BalanceLen),
+			"function", vmhost.TraceMemory(functionOffset, functionLength),
+			"arguments", vmhost.TraceMemoryArguments(numArguments, argumentsLengthOffset, dataOffset))()
+	}
+
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -2214,6 +2794,15 @@ func v1_2_executeOnDestContextByCaller(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "executeOnDestContextByCaller",
+			"gasLimit", gasLimit,
+			"address", vmhost.TraceMemory(addressOffset, vmhost.AddressLen),
+			"value", vmhost.TraceMemory(valueOffset, vmhost.BalanceLen),
+			"function", vmhost.TraceMemory(functionOffset, functionLength),
+			"arguments", vmhost.TraceMemoryArguments(numArguments, argumentsLengthOffset, dataOffset))()
+	}
+
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -2268,6 +2857,14 @@ func v1_2_delegateExecution(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "delegateExecution",
+			"gasLimit", gasLimit,
+			"address", vmhost.TraceMemory(addressOffset, vmhost.AddressLen),
+			"function", vmhost.TraceMemory(functionOffset, functionLength),
+			"arguments", vmhost.TraceMemoryArguments(numArguments, argumentsLengthOffset, dataOffset))()
+	}
+
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -2318,6 +2915,14 @@ func v1_2_executeReadOnly(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "executeReadOnly",
+			"gasLimit", gasLimit,
+			"address", vmhost.TraceMemory(addressOffset, vmhost.AddressLen),
+			"function", vmhost.TraceMemory(functionOffset, functionLength),
+			"arguments", vmhost.TraceMemoryArguments(numArguments, argumentsLengthOffset, dataOffset))()
+	}
+
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -2373,6 +2978,16 @@ func v1_2_createContract(
 	argumentsLengthOffset int32,
 	dataOffset int32,
 ) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "createContract",
+			"gasLimit", gasLimit,
+			"value", vmhost.TraceMemory(valueOffset, vmhost.BalanceLen),
+			"code", vmhost.TraceMemory(codeOffset, length),
+			"codeMetadata", vmhost.TraceMemory(codeMetadataOffset, vmhost.CodeMetadataLen),
+			"newAddress", vmhost.TraceMemoryResult(resultOffset, vmhost.AddressLen),
+			"arguments", vmhost.TraceMemoryArguments(numArguments, argumentsLengthOffset, dataOffset))()
+	}
+
 	host := vmhost.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -2437,6 +3052,10 @@ func v1_2_createContract(
 
 //export v1_2_getNumReturnData
 func v1_2_getNumReturnData(context unsafe.Pointer) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getNumReturnData")()
+	}
+
 	output := vmhost.GetOutputContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -2449,6 +3068,10 @@ func v1_2_getNumReturnData(context unsafe.Pointer) int32 {
 
 //export v1_2_getReturnDataSize
 func v1_2_getReturnDataSize(context unsafe.Pointer, resultID int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getReturnDataSize", "resultID", resultID)()
+	}
+
 	output := vmhost.GetOutputContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -2465,6 +3088,10 @@ func v1_2_getReturnDataSize(context unsafe.Pointer, resultID int32) int32 {
 
 //export v1_2_getReturnData
 func v1_2_getReturnData(context unsafe.Pointer, resultID int32, dataOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getReturnData", "resultID", resultID, "dataOffset", dataOffset)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	output := vmhost.GetOutputContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -2487,6 +3114,10 @@ func v1_2_getReturnData(context unsafe.Pointer, resultID int32, dataOffset int32
 
 //export v1_2_getOriginalTxHash
 func v1_2_getOriginalTxHash(context unsafe.Pointer, dataOffset int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "getOriginalTxHash", "dataOffset", dataOffset)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -39,6 +39,12 @@ package vmhooks
 // extern void 			v1_2_bigIntShr(void* context, int32_t destination, int32_t op, int32_t bits);
 // extern void 			v1_2_bigIntShl(void* context, int32_t destination, int32_t op, int32_t bits);
 //
+// extern void			v1_2_bigIntPow(void* context, int32_t destination, int32_t op1, int32_t op2);
+// extern void			v1_2_bigIntSqrt(void* context, int32_t destination, int32_t op);
+// extern int32_t		v1_2_bigIntLog2(void* context, int32_t op);
+// extern void			v1_2_bigIntMin(void* context, int32_t destination, int32_t op1, int32_t op2);
+// extern void			v1_2_bigIntMax(void* context, int32_t destination, int32_t op1, int32_t op2);
+//
 // extern void			v1_2_bigIntFinishUnsigned(void* context, int32_t reference);
 // extern void			v1_2_bigIntFinishSigned(void* context, int32_t reference);
 // extern int32_t		v1_2_bigIntStorageStoreUnsigned(void *context, int32_t keyOffset, int32_t keyLength, int32_t source);
@@ -200,6 +206,31 @@ func BigIntImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("bigIntPow", v1_2_bigIntPow, C.v1_2_bigIntPow)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigIntSqrt", v1_2_bigIntSqrt, C.v1_2_bigIntSqrt)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigIntLog2", v1_2_bigIntLog2, C.v1_2_bigIntLog2)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigIntMin", v1_2_bigIntMin, C.v1_2_bigIntMin)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigIntMax", v1_2_bigIntMax, C.v1_2_bigIntMax)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("bigIntFinishUnsigned", v1_2_bigIntFinishUnsigned, C.v1_2_bigIntFinishUnsigned)
 	if err != nil {
 		return nil, err
@@ -264,8 +295,32 @@ func useExtraGasForOperations(metering vmhost.MeteringContext, values []*big.Int
 	}
 }
 
+// maxBigIntByteLenForResult caps the length of the results produced by the
+// operations which can grow their operands (multiplication, pow, left shift);
+// the multiplication and the left shift are only capped once
+// BigIntResultSizeLimitFlag is enabled
+const maxBigIntByteLenForResult = 4096
+
+// isBigIntResultTooLarge signals an error and returns true if a result of
+// the given upper bound of bits would exceed maxBigIntByteLenForResult
+func isBigIntResultTooLarge(host vmhost.VMHost, resultBitLen uint64) bool {
+	if resultBitLen <= maxBigIntByteLenForResult*8 {
+		return false
+	}
+
+	runtime := host.Runtime()
+	vmhost.WithFaultAndHost(host, vmhost.ErrBigIntResultTooLarge, runtime.BigIntAPIErrorShouldFailExecution())
+	return true
+}
+
 //export v1_2_bigIntGetUnsignedArgument
 func v1_2_bigIntGetUnsignedArgument(context unsafe.Pointer, id int32, destination int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntGetUnsignedArgument",
+			"id", id,
+			"destination", vmhost.TraceBigIntResult(destination))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -285,6 +340,12 @@ func v1_2_bigIntGetUnsignedArgument(context unsafe.Pointer, id int32, destinatio
 
 //export v1_2_bigIntGetSignedArgument
 func v1_2_bigIntGetSignedArgument(context unsafe.Pointer, id int32, destination int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntGetSignedArgument",
+			"id", id,
+			"destination", vmhost.TraceBigIntResult(destination))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -304,6 +365,12 @@ func v1_2_bigIntGetSignedArgument(context unsafe.Pointer, id int32, destination
 
 //export v1_2_bigIntStorageStoreUnsigned
 func v1_2_bigIntStorageStoreUnsigned(context unsafe.Pointer, keyOffset int32, keyLength int32, source int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntStorageStoreUnsigned",
+			"key", vmhost.TraceMemory(keyOffset, keyLength),
+			"source", vmhost.TraceBigInt(source))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
@@ -330,6 +397,12 @@ func v1_2_bigIntStorageStoreUnsigned(context unsafe.Pointer, keyOffset int32, ke
 
 //export v1_2_bigIntStorageLoadUnsigned
 func v1_2_bigIntStorageLoadUnsigned(context unsafe.Pointer, keyOffset int32, keyLength int32, destination int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntStorageLoadUnsigned",
+			"key", vmhost.TraceMemory(keyOffset, keyLength),
+			"destination", vmhost.TraceBigIntResult(destination))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
@@ -353,6 +426,10 @@ func v1_2_bigIntStorageLoadUnsigned(context unsafe.Pointer, keyOffset int32, key
 
 //export v1_2_bigIntGetCallValue
 func v1_2_bigIntGetCallValue(context unsafe.Pointer, destination int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntGetCallValue", "destination", vmhost.TraceBigIntResult(destination))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -366,6 +443,10 @@ func v1_2_bigIntGetCallValue(context unsafe.Pointer, destination int32) {
 
 //export v1_2_bigIntGetDCDTCallValue
 func v1_2_bigIntGetDCDTCallValue(context unsafe.Pointer, destination int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntGetDCDTCallValue", "destination", vmhost.TraceBigIntResult(destination))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -381,6 +462,12 @@ func v1_2_bigIntGetDCDTCallValue(context unsafe.Pointer, destination int32) {
 
 //export v1_2_bigIntGetExternalBalance
 func v1_2_bigIntGetExternalBalance(context unsafe.Pointer, addressOffset int32, result int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntGetExternalBalance",
+			"address", vmhost.TraceMemory(addressOffset, vmhost.AddressLen),
+			"result", vmhost.TraceBigIntResult(result))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	blockchain := vmhost.GetBlockchainContext(context)
@@ -402,6 +489,14 @@ func v1_2_bigIntGetExternalBalance(context unsafe.Pointer, addressOffset int32,
 
 //export v1_2_bigIntGetDCDTExternalBalance
 func v1_2_bigIntGetDCDTExternalBalance(context unsafe.Pointer, addressOffset int32, tokenIDOffset int32, tokenIDLen int32, nonce int64, result int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntGetDCDTExternalBalance",
+			"address", vmhost.TraceMemory(addressOffset, vmhost.AddressLen),
+			"tokenID", vmhost.TraceMemory(tokenIDOffset, tokenIDLen),
+			"nonce", nonce,
+			"result", vmhost.TraceBigIntResult(result))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -423,6 +518,10 @@ func v1_2_bigIntGetDCDTExternalBalance(context unsafe.Pointer, addressOffset int
 
 //export v1_2_bigIntNew
 func v1_2_bigIntNew(context unsafe.Pointer, smallValue int64) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntNew", "smallValue", smallValue)()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -434,6 +533,10 @@ func v1_2_bigIntNew(context unsafe.Pointer, smallValue int64) int32 {
 
 //export v1_2_bigIntUnsignedByteLength
 func v1_2_bigIntUnsignedByteLength(context unsafe.Pointer, reference int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntUnsignedByteLength", "reference", vmhost.TraceBigInt(reference))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -448,6 +551,10 @@ func v1_2_bigIntUnsignedByteLength(context unsafe.Pointer, reference int32) int3
 
 //export v1_2_bigIntSignedByteLength
 func v1_2_bigIntSignedByteLength(context unsafe.Pointer, reference int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntSignedByteLength", "reference", vmhost.TraceBigInt(reference))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -462,6 +569,12 @@ func v1_2_bigIntSignedByteLength(context unsafe.Pointer, reference int32) int32
 
 //export v1_2_bigIntGetUnsignedBytes
 func v1_2_bigIntGetUnsignedBytes(context unsafe.Pointer, reference int32, byteOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntGetUnsignedBytes",
+			"reference", vmhost.TraceBigInt(reference),
+			"byteOffset", byteOffset)()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -485,6 +598,12 @@ func v1_2_bigIntGetUnsignedBytes(context unsafe.Pointer, reference int32, byteOf
 
 //export v1_2_bigIntGetSignedBytes
 func v1_2_bigIntGetSignedBytes(context unsafe.Pointer, reference int32, byteOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntGetSignedBytes",
+			"reference", vmhost.TraceBigInt(reference),
+			"byteOffset", byteOffset)()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -508,6 +627,12 @@ func v1_2_bigIntGetSignedBytes(context unsafe.Pointer, reference int32, byteOffs
 
 //export v1_2_bigIntSetUnsignedBytes
 func v1_2_bigIntSetUnsignedBytes(context unsafe.Pointer, destination int32, byteOffset int32, byteLength int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntSetUnsignedBytes",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"bytes", vmhost.TraceMemory(byteOffset, byteLength))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -529,6 +654,12 @@ func v1_2_bigIntSetUnsignedBytes(context unsafe.Pointer, destination int32, byte
 
 //export v1_2_bigIntSetSignedBytes
 func v1_2_bigIntSetSignedBytes(context unsafe.Pointer, destination int32, byteOffset int32, byteLength int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntSetSignedBytes",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"bytes", vmhost.TraceMemory(byteOffset, byteLength))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -550,6 +681,10 @@ func v1_2_bigIntSetSignedBytes(context unsafe.Pointer, destination int32, byteOf
 
 //export v1_2_bigIntIsInt64
 func v1_2_bigIntIsInt64(context unsafe.Pointer, destination int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntIsInt64", "destination", vmhost.TraceBigInt(destination))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -565,6 +700,10 @@ func v1_2_bigIntIsInt64(context unsafe.Pointer, destination int32) int32 {
 
 //export v1_2_bigIntGetInt64
 func v1_2_bigIntGetInt64(context unsafe.Pointer, destination int32) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntGetInt64", "destination", vmhost.TraceBigInt(destination))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -577,6 +716,12 @@ func v1_2_bigIntGetInt64(context unsafe.Pointer, destination int32) int64 {
 
 //export v1_2_bigIntSetInt64
 func v1_2_bigIntSetInt64(context unsafe.Pointer, destination int32, value int64) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntSetInt64",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"value", value)()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -589,6 +734,13 @@ func v1_2_bigIntSetInt64(context unsafe.Pointer, destination int32, value int64)
 
 //export v1_2_bigIntAdd
 func v1_2_bigIntAdd(context unsafe.Pointer, destination, op1, op2 int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntAdd",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op1", vmhost.TraceBigInt(op1),
+			"op2", vmhost.TraceBigInt(op2))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -602,6 +754,13 @@ func v1_2_bigIntAdd(context unsafe.Pointer, destination, op1, op2 int32) {
 
 //export v1_2_bigIntSub
 func v1_2_bigIntSub(context unsafe.Pointer, destination, op1, op2 int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntSub",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op1", vmhost.TraceBigInt(op1),
+			"op2", vmhost.TraceBigInt(op2))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -615,19 +774,46 @@ func v1_2_bigIntSub(context unsafe.Pointer, destination, op1, op2 int32) {
 
 //export v1_2_bigIntMul
 func v1_2_bigIntMul(context unsafe.Pointer, destination, op1, op2 int32) {
-	bigInt := vmhost.GetBigIntContext(context)
-	metering := vmhost.GetMeteringContext(context)
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntMul",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op1", vmhost.TraceBigInt(op1),
+			"op2", vmhost.TraceBigInt(op2))()
+	}
+
+	host := vmhost.GetVMHost(context)
+	BigIntMulWithHost(host, destination, op1, op2)
+}
+
+// BigIntMulWithHost - bigIntMul with host instead of pointer context
+func BigIntMulWithHost(host vmhost.VMHost, destination, op1, op2 int32) {
+	bigInt := host.BigInt()
+	metering := host.Metering()
 
 	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntMul
 	metering.UseGas(gasToUse)
 
 	dest, a, b := bigInt.GetThree(destination, op1, op2)
 	useExtraGasForOperations(metering, []*big.Int{dest, a, b})
+	resultBitLen := uint64(a.BitLen()) + uint64(b.BitLen())
+	if host.IsBigIntResultSizeLimitEnabled() && isBigIntResultTooLarge(host, resultBitLen) {
+		return
+	}
 	dest.Mul(a, b)
+	if host.IsBigIntResultSizeLimitEnabled() {
+		useExtraGasForOperations(metering, []*big.Int{dest})
+	}
 }
 
 //export v1_2_bigIntTDiv
 func v1_2_bigIntTDiv(context unsafe.Pointer, destination, op1, op2 int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntTDiv",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op1", vmhost.TraceBigInt(op1),
+			"op2", vmhost.TraceBigInt(op2))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -642,10 +828,20 @@ func v1_2_bigIntTDiv(context unsafe.Pointer, destination, op1, op2 int32) {
 		return
 	}
 	dest.Quo(a, b) // Quo implements truncated division (like Go)
+	if vmhost.GetVMHost(context).IsBigIntResultSizeLimitEnabled() {
+		useExtraGasForOperations(metering, []*big.Int{dest})
+	}
 }
 
 //export v1_2_bigIntTMod
 func v1_2_bigIntTMod(context unsafe.Pointer, destination, op1, op2 int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntTMod",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op1", vmhost.TraceBigInt(op1),
+			"op2", vmhost.TraceBigInt(op2))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -664,6 +860,13 @@ func v1_2_bigIntTMod(context unsafe.Pointer, destination, op1, op2 int32) {
 
 //export v1_2_bigIntEDiv
 func v1_2_bigIntEDiv(context unsafe.Pointer, destination, op1, op2 int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntEDiv",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op1", vmhost.TraceBigInt(op1),
+			"op2", vmhost.TraceBigInt(op2))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -678,10 +881,20 @@ func v1_2_bigIntEDiv(context unsafe.Pointer, destination, op1, op2 int32) {
 		return
 	}
 	dest.Div(a, b) // Div implements Euclidean division (unlike Go)
+	if vmhost.GetVMHost(context).IsBigIntResultSizeLimitEnabled() {
+		useExtraGasForOperations(metering, []*big.Int{dest})
+	}
 }
 
 //export v1_2_bigIntEMod
 func v1_2_bigIntEMod(context unsafe.Pointer, destination, op1, op2 int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntEMod",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op1", vmhost.TraceBigInt(op1),
+			"op2", vmhost.TraceBigInt(op2))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -700,6 +913,12 @@ func v1_2_bigIntEMod(context unsafe.Pointer, destination, op1, op2 int32) {
 
 //export v1_2_bigIntAbs
 func v1_2_bigIntAbs(context unsafe.Pointer, destination, op int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntAbs",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op", vmhost.TraceBigInt(op))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -713,6 +932,12 @@ func v1_2_bigIntAbs(context unsafe.Pointer, destination, op int32) {
 
 //export v1_2_bigIntNeg
 func v1_2_bigIntNeg(context unsafe.Pointer, destination, op int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntNeg",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op", vmhost.TraceBigInt(op))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -726,6 +951,10 @@ func v1_2_bigIntNeg(context unsafe.Pointer, destination, op int32) {
 
 //export v1_2_bigIntSign
 func v1_2_bigIntSign(context unsafe.Pointer, op int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntSign", "op", vmhost.TraceBigInt(op))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -739,6 +968,10 @@ func v1_2_bigIntSign(context unsafe.Pointer, op int32) int32 {
 
 //export v1_2_bigIntCmp
 func v1_2_bigIntCmp(context unsafe.Pointer, op1, op2 int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntCmp", "op1", vmhost.TraceBigInt(op1), "op2", vmhost.TraceBigInt(op2))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -752,6 +985,12 @@ func v1_2_bigIntCmp(context unsafe.Pointer, op1, op2 int32) int32 {
 
 //export v1_2_bigIntNot
 func v1_2_bigIntNot(context unsafe.Pointer, destination, op int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntNot",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op", vmhost.TraceBigInt(op))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -770,6 +1009,13 @@ func v1_2_bigIntNot(context unsafe.Pointer, destination, op int32) {
 
 //export v1_2_bigIntAnd
 func v1_2_bigIntAnd(context unsafe.Pointer, destination, op1, op2 int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntAnd",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op1", vmhost.TraceBigInt(op1),
+			"op2", vmhost.TraceBigInt(op2))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -788,6 +1034,13 @@ func v1_2_bigIntAnd(context unsafe.Pointer, destination, op1, op2 int32) {
 
 //export v1_2_bigIntOr
 func v1_2_bigIntOr(context unsafe.Pointer, destination, op1, op2 int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntOr",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op1", vmhost.TraceBigInt(op1),
+			"op2", vmhost.TraceBigInt(op2))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -806,6 +1059,13 @@ func v1_2_bigIntOr(context unsafe.Pointer, destination, op1, op2 int32) {
 
 //export v1_2_bigIntXor
 func v1_2_bigIntXor(context unsafe.Pointer, destination, op1, op2 int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntXor",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op1", vmhost.TraceBigInt(op1),
+			"op2", vmhost.TraceBigInt(op2))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -824,6 +1084,13 @@ func v1_2_bigIntXor(context unsafe.Pointer, destination, op1, op2 int32) {
 
 //export v1_2_bigIntShr
 func v1_2_bigIntShr(context unsafe.Pointer, destination, op, bits int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntShr",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op", vmhost.TraceBigInt(op),
+			"bits", bits)()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -843,8 +1110,21 @@ func v1_2_bigIntShr(context unsafe.Pointer, destination, op, bits int32) {
 
 //export v1_2_bigIntShl
 func v1_2_bigIntShl(context unsafe.Pointer, destination, op, bits int32) {
-	bigInt := vmhost.GetBigIntContext(context)
-	metering := vmhost.GetMeteringContext(context)
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntShl",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op", vmhost.TraceBigInt(op),
+			"bits", bits)()
+	}
+
+	host := vmhost.GetVMHost(context)
+	BigIntShlWithHost(host, destination, op, bits)
+}
+
+// BigIntShlWithHost - bigIntShl with host instead of pointer context
+func BigIntShlWithHost(host vmhost.VMHost, destination, op, bits int32) {
+	bigInt := host.BigInt()
+	metering := host.Metering()
 
 	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntSub
 	metering.UseGas(gasToUse)
@@ -852,16 +1132,177 @@ func v1_2_bigIntShl(context unsafe.Pointer, destination, op, bits int32) {
 	dest, a := bigInt.GetTwo(destination, op)
 	useExtraGasForOperations(metering, []*big.Int{a})
 	if a.Sign() < 0 || bits < 0 {
-		runtime := vmhost.GetRuntimeContext(context)
-		vmhost.WithFault(vmhost.ErrShiftNegative, context, runtime.BigIntAPIErrorShouldFailExecution())
+		runtime := host.Runtime()
+		vmhost.WithFaultAndHost(host, vmhost.ErrShiftNegative, runtime.BigIntAPIErrorShouldFailExecution())
+		return
+	}
+	resultBitLen := uint64(a.BitLen()) + uint64(bits)
+	if host.IsBigIntResultSizeLimitEnabled() && isBigIntResultTooLarge(host, resultBitLen) {
 		return
 	}
 	dest.Lsh(a, uint(bits))
 	useExtraGasForOperations(metering, []*big.Int{dest})
 }
 
+//export v1_2_bigIntPow
+func v1_2_bigIntPow(context unsafe.Pointer, destination, op1, op2 int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntPow",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op1", vmhost.TraceBigInt(op1),
+			"op2", vmhost.TraceBigInt(op2))()
+	}
+
+	host := vmhost.GetVMHost(context)
+	BigIntPowWithHost(host, destination, op1, op2)
+}
+
+// BigIntPowWithHost - bigIntPow with host instead of pointer context
+func BigIntPowWithHost(host vmhost.VMHost, destination, op1, op2 int32) {
+	bigInt := host.BigInt()
+	metering := host.Metering()
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntPow
+	metering.UseGas(gasToUse)
+
+	dest, a, b := bigInt.GetThree(destination, op1, op2)
+	useExtraGasForOperations(metering, []*big.Int{a, b})
+	if b.Sign() < 0 {
+		runtime := host.Runtime()
+		vmhost.WithFaultAndHost(host, vmhost.ErrNegativeExponent, runtime.BigIntAPIErrorShouldFailExecution())
+		return
+	}
+
+	// 0, 1 and -1 never grow, whatever the exponent
+	if a.CmpAbs(big.NewInt(1)) > 0 {
+		resultBitLen := uint64(maxBigIntByteLenForResult*8 + 1)
+		if b.IsUint64() {
+			maxResultBitLen, err := math.MulUint64WithErr(uint64(a.BitLen()), b.Uint64())
+			if err == nil {
+				resultBitLen = maxResultBitLen
+			}
+		}
+		if isBigIntResultTooLarge(host, resultBitLen) {
+			return
+		}
+	}
+
+	dest.Exp(a, b, nil)
+	useExtraGasForOperations(metering, []*big.Int{dest})
+}
+
+//export v1_2_bigIntSqrt
+func v1_2_bigIntSqrt(context unsafe.Pointer, destination, op int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntSqrt",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op", vmhost.TraceBigInt(op))()
+	}
+
+	host := vmhost.GetVMHost(context)
+	BigIntSqrtWithHost(host, destination, op)
+}
+
+// BigIntSqrtWithHost - bigIntSqrt with host instead of pointer context
+func BigIntSqrtWithHost(host vmhost.VMHost, destination, op int32) {
+	bigInt := host.BigInt()
+	metering := host.Metering()
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntSqrt
+	metering.UseGas(gasToUse)
+
+	dest, a := bigInt.GetTwo(destination, op)
+	useExtraGasForOperations(metering, []*big.Int{a})
+	if a.Sign() < 0 {
+		runtime := host.Runtime()
+		vmhost.WithFaultAndHost(host, vmhost.ErrSqrtNegative, runtime.BigIntAPIErrorShouldFailExecution())
+		return
+	}
+	dest.Sqrt(a)
+}
+
+//export v1_2_bigIntLog2
+func v1_2_bigIntLog2(context unsafe.Pointer, op int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntLog2", "op", vmhost.TraceBigInt(op))()
+	}
+
+	host := vmhost.GetVMHost(context)
+	return BigIntLog2WithHost(host, op)
+}
+
+// BigIntLog2WithHost - bigIntLog2 with host instead of pointer context
+func BigIntLog2WithHost(host vmhost.VMHost, op int32) int32 {
+	bigInt := host.BigInt()
+	metering := host.Metering()
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntLog2
+	metering.UseGas(gasToUse)
+
+	a := bigInt.GetOne(op)
+	useExtraGasForOperations(metering, []*big.Int{a})
+	if a.Sign() <= 0 {
+		runtime := host.Runtime()
+		vmhost.WithFaultAndHost(host, vmhost.ErrLog2NotPositive, runtime.BigIntAPIErrorShouldFailExecution())
+		return -1
+	}
+	return int32(a.BitLen() - 1)
+}
+
+//export v1_2_bigIntMin
+func v1_2_bigIntMin(context unsafe.Pointer, destination, op1, op2 int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntMin",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op1", vmhost.TraceBigInt(op1),
+			"op2", vmhost.TraceBigInt(op2))()
+	}
+
+	bigInt := vmhost.GetBigIntContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntMin
+	metering.UseGas(gasToUse)
+
+	dest, a, b := bigInt.GetThree(destination, op1, op2)
+	useExtraGasForOperations(metering, []*big.Int{a, b})
+	if a.Cmp(b) <= 0 {
+		dest.Set(a)
+	} else {
+		dest.Set(b)
+	}
+}
+
+//export v1_2_bigIntMax
+func v1_2_bigIntMax(context unsafe.Pointer, destination, op1, op2 int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntMax",
+			"destination", vmhost.TraceBigIntResult(destination),
+			"op1", vmhost.TraceBigInt(op1),
+			"op2", vmhost.TraceBigInt(op2))()
+	}
+
+	bigInt := vmhost.GetBigIntContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntMax
+	metering.UseGas(gasToUse)
+
+	dest, a, b := bigInt.GetThree(destination, op1, op2)
+	useExtraGasForOperations(metering, []*big.Int{a, b})
+	if a.Cmp(b) >= 0 {
+		dest.Set(a)
+	} else {
+		dest.Set(b)
+	}
+}
+
 //export v1_2_bigIntFinishUnsigned
 func v1_2_bigIntFinishUnsigned(context unsafe.Pointer, reference int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntFinishUnsigned", "reference", vmhost.TraceBigInt(reference))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	output := vmhost.GetOutputContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -879,6 +1320,10 @@ func v1_2_bigIntFinishUnsigned(context unsafe.Pointer, reference int32) {
 
 //export v1_2_bigIntFinishSigned
 func v1_2_bigIntFinishSigned(context unsafe.Pointer, reference int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "bigIntFinishSigned", "reference", vmhost.TraceBigInt(reference))()
+	}
+
 	bigInt := vmhost.GetBigIntContext(context)
 	output := vmhost.GetOutputContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -0,0 +1,1200 @@
+package vmhooks
+
+// // Declare the function signatures (see [cgo](https://golang.org/cmd/cgo/)).
+//
+// #include <stdlib.h>
+// typedef unsigned char uint8_t;
+// typedef int int32_t;
+//
+// extern void			v1_2_ethereum_useGas(void *context, long long gas);
+// extern void			v1_2_ethereum_getAddress(void *context, int32_t resultOffset);
+// extern void			v1_2_ethereum_getExternalBalance(void *context, int32_t addressOffset, int32_t resultOffset);
+// extern int32_t		v1_2_ethereum_getBlockHash(void *context, long long number, int32_t resultOffset);
+// extern int32_t		v1_2_ethereum_call(void *context, long long gasLimit, int32_t addressOffset, int32_t valueOffset, int32_t dataOffset, int32_t dataLength);
+// extern void			v1_2_ethereum_callDataCopy(void *context, int32_t resultOffset, int32_t dataOffset, int32_t length);
+// extern int32_t		v1_2_ethereum_getCallDataSize(void *context);
+// extern int32_t		v1_2_ethereum_callCode(void *context, long long gasLimit, int32_t addressOffset, int32_t valueOffset, int32_t dataOffset, int32_t dataLength);
+// extern int32_t		v1_2_ethereum_callDelegate(void *context, long long gasLimit, int32_t addressOffset, int32_t dataOffset, int32_t dataLength);
+// extern int32_t		v1_2_ethereum_callStatic(void *context, long long gasLimit, int32_t addressOffset, int32_t dataOffset, int32_t dataLength);
+// extern void			v1_2_ethereum_storageStore(void *context, int32_t pathOffset, int32_t valueOffset);
+// extern void			v1_2_ethereum_storageLoad(void *context, int32_t pathOffset, int32_t resultOffset);
+// extern void			v1_2_ethereum_getCaller(void *context, int32_t resultOffset);
+// extern void			v1_2_ethereum_getCallValue(void *context, int32_t resultOffset);
+// extern void			v1_2_ethereum_codeCopy(void *context, int32_t resultOffset, int32_t codeOffset, int32_t length);
+// extern int32_t		v1_2_ethereum_getCodeSize(void *context);
+// extern void			v1_2_ethereum_getBlockCoinbase(void *context, int32_t resultOffset);
+// extern int32_t		v1_2_ethereum_create(void *context, int32_t valueOffset, int32_t dataOffset, int32_t length, int32_t resultOffset);
+// extern void			v1_2_ethereum_getBlockDifficulty(void *context, int32_t resultOffset);
+// extern void			v1_2_ethereum_externalCodeCopy(void *context, int32_t addressOffset, int32_t resultOffset, int32_t codeOffset, int32_t length);
+// extern int32_t		v1_2_ethereum_getExternalCodeSize(void *context, int32_t addressOffset);
+// extern long long	v1_2_ethereum_getGasLeft(void *context);
+// extern long long	v1_2_ethereum_getBlockGasLimit(void *context);
+// extern void			v1_2_ethereum_getTxGasPrice(void *context, int32_t valueOffset);
+// extern void			v1_2_ethereum_log(void *context, int32_t dataOffset, int32_t length, int32_t numberOfTopics, int32_t topic1, int32_t topic2, int32_t topic3, int32_t topic4);
+// extern long long	v1_2_ethereum_getBlockNumber(void *context);
+// extern void			v1_2_ethereum_getTxOrigin(void *context, int32_t resultOffset);
+// extern void			v1_2_ethereum_finish(void *context, int32_t dataOffset, int32_t length);
+// extern void			v1_2_ethereum_revert(void *context, int32_t dataOffset, int32_t length);
+// extern int32_t		v1_2_ethereum_getReturnDataSize(void *context);
+// extern void			v1_2_ethereum_returnDataCopy(void *context, int32_t resultOffset, int32_t dataOffset, int32_t length);
+// extern void			v1_2_ethereum_selfDestruct(void *context, int32_t addressOffset);
+// extern long long	v1_2_ethereum_getBlockTimestamp(void *context);
+import "C"
+
+import (
+	"errors"
+	"math/big"
+	"unsafe"
+
+	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/math"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/wasmer"
+)
+
+// EthereumNamespace is the import namespace of the EWASM environment interface
+const EthereumNamespace = "ethereum"
+
+const (
+	// ethereumWordLen is the length of the storage keys and values, of the
+	// topics, of the block hashes and of the padded call data arguments
+	ethereumWordLen = 32
+
+	// ethereumValueLen is the length of the little endian 128 bit values
+	ethereumValueLen = 16
+
+	ethereumMaxTopics = 4
+)
+
+const (
+	ethereumCallSuccess = 0
+	ethereumCallFailure = 1
+	ethereumCallRevert  = 2
+)
+
+// EthereumImports populates imports with the EWASM environment interface, in
+// the ethereum namespace. The hooks are priced in EthAPICost. Addresses are
+// vmhost.AddressLen bytes long, instead of the 20 bytes of Ethereum.
+func EthereumImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
+	imports = imports.Namespace(EthereumNamespace)
+
+	imports, err := imports.Append("useGas", v1_2_ethereum_useGas, C.v1_2_ethereum_useGas)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getAddress", v1_2_ethereum_getAddress, C.v1_2_ethereum_getAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getExternalBalance", v1_2_ethereum_getExternalBalance, C.v1_2_ethereum_getExternalBalance)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getBlockHash", v1_2_ethereum_getBlockHash, C.v1_2_ethereum_getBlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("call", v1_2_ethereum_call, C.v1_2_ethereum_call)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("callDataCopy", v1_2_ethereum_callDataCopy, C.v1_2_ethereum_callDataCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getCallDataSize", v1_2_ethereum_getCallDataSize, C.v1_2_ethereum_getCallDataSize)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("callCode", v1_2_ethereum_callCode, C.v1_2_ethereum_callCode)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("callDelegate", v1_2_ethereum_callDelegate, C.v1_2_ethereum_callDelegate)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("callStatic", v1_2_ethereum_callStatic, C.v1_2_ethereum_callStatic)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("storageStore", v1_2_ethereum_storageStore, C.v1_2_ethereum_storageStore)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("storageLoad", v1_2_ethereum_storageLoad, C.v1_2_ethereum_storageLoad)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getCaller", v1_2_ethereum_getCaller, C.v1_2_ethereum_getCaller)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getCallValue", v1_2_ethereum_getCallValue, C.v1_2_ethereum_getCallValue)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("codeCopy", v1_2_ethereum_codeCopy, C.v1_2_ethereum_codeCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getCodeSize", v1_2_ethereum_getCodeSize, C.v1_2_ethereum_getCodeSize)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getBlockCoinbase", v1_2_ethereum_getBlockCoinbase, C.v1_2_ethereum_getBlockCoinbase)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("create", v1_2_ethereum_create, C.v1_2_ethereum_create)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getBlockDifficulty", v1_2_ethereum_getBlockDifficulty, C.v1_2_ethereum_getBlockDifficulty)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("externalCodeCopy", v1_2_ethereum_externalCodeCopy, C.v1_2_ethereum_externalCodeCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getExternalCodeSize", v1_2_ethereum_getExternalCodeSize, C.v1_2_ethereum_getExternalCodeSize)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getGasLeft", v1_2_ethereum_getGasLeft, C.v1_2_ethereum_getGasLeft)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getBlockGasLimit", v1_2_ethereum_getBlockGasLimit, C.v1_2_ethereum_getBlockGasLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getTxGasPrice", v1_2_ethereum_getTxGasPrice, C.v1_2_ethereum_getTxGasPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("log", v1_2_ethereum_log, C.v1_2_ethereum_log)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getBlockNumber", v1_2_ethereum_getBlockNumber, C.v1_2_ethereum_getBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getTxOrigin", v1_2_ethereum_getTxOrigin, C.v1_2_ethereum_getTxOrigin)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("finish", v1_2_ethereum_finish, C.v1_2_ethereum_finish)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("revert", v1_2_ethereum_revert, C.v1_2_ethereum_revert)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getReturnDataSize", v1_2_ethereum_getReturnDataSize, C.v1_2_ethereum_getReturnDataSize)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("returnDataCopy", v1_2_ethereum_returnDataCopy, C.v1_2_ethereum_returnDataCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("selfDestruct", v1_2_ethereum_selfDestruct, C.v1_2_ethereum_selfDestruct)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getBlockTimestamp", v1_2_ethereum_getBlockTimestamp, C.v1_2_ethereum_getBlockTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+//export v1_2_ethereum_useGas
+func v1_2_ethereum_useGas(context unsafe.Pointer, gas int64) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.useGas", "gas", gas)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.UseGas
+	metering.UseGas(gasToUse)
+
+	if gas < 0 {
+		_ = vmhost.WithFault(vmhost.ErrNegativeLength, context, runtime.BaseOpsErrorShouldFailExecution())
+		return
+	}
+	metering.UseGas(uint64(gas))
+}
+
+//export v1_2_ethereum_getAddress
+func v1_2_ethereum_getAddress(context unsafe.Pointer, resultOffset int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.getAddress", "resultOffset", resultOffset)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.GetAddress
+	metering.UseGas(gasToUse)
+
+	err := runtime.MemStore(resultOffset, runtime.GetSCAddress())
+	_ = vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution())
+}
+
+//export v1_2_ethereum_getExternalBalance
+func v1_2_ethereum_getExternalBalance(context unsafe.Pointer, addressOffset int32, resultOffset int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.getExternalBalance",
+			"addressOffset", addressOffset,
+			"resultOffset", resultOffset)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	blockchain := vmhost.GetBlockchainContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.GetExternalBalance
+	metering.UseGas(gasToUse)
+
+	address, err := runtime.MemLoad(addressOffset, vmhost.AddressLen)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	storeEthereumValue(context, resultOffset, blockchain.GetBalanceBigInt(address))
+}
+
+//export v1_2_ethereum_getBlockHash
+func v1_2_ethereum_getBlockHash(context unsafe.Pointer, number int64, resultOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.getBlockHash", "number", number, "resultOffset", resultOffset)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	blockchain := vmhost.GetBlockchainContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.GetBlockHash
+	metering.UseGas(gasToUse)
+
+	hash := blockchain.BlockHash(number)
+	if len(hash) == 0 {
+		return 1
+	}
+
+	err := runtime.MemStore(resultOffset, hash)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return 0
+}
+
+//export v1_2_ethereum_call
+func v1_2_ethereum_call(context unsafe.Pointer, gasLimit int64, addressOffset int32, valueOffset int32, dataOffset int32, dataLength int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.call",
+			"gasLimit", gasLimit,
+			"addressOffset", addressOffset,
+			"valueOffset", valueOffset,
+			"dataOffset", dataOffset,
+			"dataLength", dataLength)()
+	}
+
+	host := vmhost.GetVMHost(context)
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	gasToUse := metering.GasSchedule().EthAPICost.Call
+	metering.UseGas(gasToUse)
+
+	value, err := loadEthereumValue(context, valueOffset)
+	if vmhost.WithFault(err, context, runtime.SyncExecAPIErrorShouldFailExecution()) {
+		return ethereumCallFailure
+	}
+
+	contractCallInput, err := prepareEthereumCallInput(host, runtime.GetSCAddress(), value, gasLimit, addressOffset, dataOffset, dataLength)
+	if vmhost.WithFault(err, context, runtime.SyncExecAPIErrorShouldFailExecution()) {
+		return ethereumCallFailure
+	}
+
+	_, _, gasUsedBeforeReset, err := host.ExecuteOnDestContext(contractCallInput)
+	if err != nil {
+		return ethereumCallResult(err)
+	}
+	metering.UseGas(gasUsedBeforeReset)
+
+	return ethereumCallSuccess
+}
+
+//export v1_2_ethereum_callDataCopy
+func v1_2_ethereum_callDataCopy(context unsafe.Pointer, resultOffset int32, dataOffset int32, length int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.callDataCopy",
+			"resultOffset", resultOffset,
+			"dataOffset", dataOffset,
+			"length", length)()
+	}
+
+	host := vmhost.GetVMHost(context)
+	EthereumCallDataCopyWithHost(host, resultOffset, dataOffset, length)
+}
+
+// EthereumCallDataCopyWithHost - callDataCopy with host instead of pointer context
+func EthereumCallDataCopyWithHost(host vmhost.VMHost, resultOffset int32, dataOffset int32, length int32) {
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	gasToUse := metering.GasSchedule().EthAPICost.CallDataCopy
+	metering.UseGas(gasToUse)
+
+	callData, err := ethereumCallData(host)
+	if vmhost.WithFaultAndHost(host, err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	copyToMemory(host, resultOffset, callData, dataOffset, length)
+}
+
+//export v1_2_ethereum_getCallDataSize
+func v1_2_ethereum_getCallDataSize(context unsafe.Pointer) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.getCallDataSize")()
+	}
+
+	host := vmhost.GetVMHost(context)
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	gasToUse := metering.GasSchedule().EthAPICost.GetCallDataSize
+	metering.UseGas(gasToUse)
+
+	callData, err := ethereumCallData(host)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return 0
+	}
+
+	return int32(len(callData))
+}
+
+//export v1_2_ethereum_callCode
+func v1_2_ethereum_callCode(context unsafe.Pointer, gasLimit int64, addressOffset int32, valueOffset int32, dataOffset int32, dataLength int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.callCode",
+			"gasLimit", gasLimit,
+			"addressOffset", addressOffset,
+			"valueOffset", valueOffset,
+			"dataOffset", dataOffset,
+			"dataLength", dataLength)()
+	}
+
+	host := vmhost.GetVMHost(context)
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	gasToUse := metering.GasSchedule().EthAPICost.CallCode
+	metering.UseGas(gasToUse)
+
+	value, err := loadEthereumValue(context, valueOffset)
+	if vmhost.WithFault(err, context, runtime.SyncExecAPIErrorShouldFailExecution()) {
+		return ethereumCallFailure
+	}
+
+	contractCallInput, err := prepareEthereumCallInput(host, runtime.GetSCAddress(), value, gasLimit, addressOffset, dataOffset, dataLength)
+	if vmhost.WithFault(err, context, runtime.SyncExecAPIErrorShouldFailExecution()) {
+		return ethereumCallFailure
+	}
+
+	_, err = host.ExecuteOnSameContext(contractCallInput)
+	return ethereumCallResult(err)
+}
+
+//export v1_2_ethereum_callDelegate
+func v1_2_ethereum_callDelegate(context unsafe.Pointer, gasLimit int64, addressOffset int32, dataOffset int32, dataLength int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.callDelegate",
+			"gasLimit", gasLimit,
+			"addressOffset", addressOffset,
+			"dataOffset", dataOffset,
+			"dataLength", dataLength)()
+	}
+
+	host := vmhost.GetVMHost(context)
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	gasToUse := metering.GasSchedule().EthAPICost.CallDelegate
+	metering.UseGas(gasToUse)
+
+	// the callee runs with the caller and the value of the current call
+	vmInput := runtime.GetVMInput()
+	contractCallInput, err := prepareEthereumCallInput(host, vmInput.CallerAddr, big.NewInt(0).Set(vmInput.CallValue), gasLimit, addressOffset, dataOffset, dataLength)
+	if vmhost.WithFault(err, context, runtime.SyncExecAPIErrorShouldFailExecution()) {
+		return ethereumCallFailure
+	}
+
+	_, err = host.ExecuteOnSameContext(contractCallInput)
+	return ethereumCallResult(err)
+}
+
+//export v1_2_ethereum_callStatic
+func v1_2_ethereum_callStatic(context unsafe.Pointer, gasLimit int64, addressOffset int32, dataOffset int32, dataLength int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.callStatic",
+			"gasLimit", gasLimit,
+			"addressOffset", addressOffset,
+			"dataOffset", dataOffset,
+			"dataLength", dataLength)()
+	}
+
+	host := vmhost.GetVMHost(context)
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	gasToUse := metering.GasSchedule().EthAPICost.CallStatic
+	metering.UseGas(gasToUse)
+
+	contractCallInput, err := prepareEthereumCallInput(host, runtime.GetSCAddress(), big.NewInt(0), gasLimit, addressOffset, dataOffset, dataLength)
+	if vmhost.WithFault(err, context, runtime.SyncExecAPIErrorShouldFailExecution()) {
+		return ethereumCallFailure
+	}
+
+	wasReadOnly := runtime.ReadOnly()
+	runtime.SetReadOnly(true)
+	_, err = host.ExecuteOnSameContext(contractCallInput)
+	runtime.SetReadOnly(wasReadOnly)
+
+	return ethereumCallResult(err)
+}
+
+//export v1_2_ethereum_storageStore
+func v1_2_ethereum_storageStore(context unsafe.Pointer, pathOffset int32, valueOffset int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.storageStore", "pathOffset", pathOffset, "valueOffset", valueOffset)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	storage := vmhost.GetStorageContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.StorageStore
+	metering.UseGas(gasToUse)
+
+	key, err := runtime.MemLoad(pathOffset, ethereumWordLen)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	value, err := runtime.MemLoad(valueOffset, ethereumWordLen)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	// the leading zeros are not stored, so that storing a zero word clears the key
+	_, err = storage.SetStorage(key, big.NewInt(0).SetBytes(value).Bytes())
+	_ = vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution())
+}
+
+//export v1_2_ethereum_storageLoad
+func v1_2_ethereum_storageLoad(context unsafe.Pointer, pathOffset int32, resultOffset int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.storageLoad", "pathOffset", pathOffset, "resultOffset", resultOffset)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	storage := vmhost.GetStorageContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.StorageLoad
+	metering.UseGas(gasToUse)
+
+	key, err := runtime.MemLoad(pathOffset, ethereumWordLen)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	value := storage.GetStorage(key)
+	if len(value) > ethereumWordLen {
+		_ = vmhost.WithFault(vmhost.ErrEthereumWordTooLong, context, runtime.BaseOpsErrorShouldFailExecution())
+		return
+	}
+
+	err = runtime.MemStore(resultOffset, padLeft(value, ethereumWordLen))
+	_ = vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution())
+}
+
+//export v1_2_ethereum_getCaller
+func v1_2_ethereum_getCaller(context unsafe.Pointer, resultOffset int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.getCaller", "resultOffset", resultOffset)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.GetCaller
+	metering.UseGas(gasToUse)
+
+	err := runtime.MemStore(resultOffset, runtime.GetVMInput().CallerAddr)
+	_ = vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution())
+}
+
+//export v1_2_ethereum_getCallValue
+func v1_2_ethereum_getCallValue(context unsafe.Pointer, resultOffset int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.getCallValue", "resultOffset", resultOffset)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.GetCallValue
+	metering.UseGas(gasToUse)
+
+	storeEthereumValue(context, resultOffset, runtime.GetVMInput().CallValue)
+}
+
+//export v1_2_ethereum_codeCopy
+func v1_2_ethereum_codeCopy(context unsafe.Pointer, resultOffset int32, codeOffset int32, length int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.codeCopy",
+			"resultOffset", resultOffset,
+			"codeOffset", codeOffset,
+			"length", length)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.CodeCopy
+	metering.UseGas(gasToUse)
+
+	code, err := runtime.GetSCCode()
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	copyToMemory(vmhost.GetVMHost(context), resultOffset, code, codeOffset, length)
+}
+
+//export v1_2_ethereum_getCodeSize
+func v1_2_ethereum_getCodeSize(context unsafe.Pointer) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.getCodeSize")()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.GetCodeSize
+	metering.UseGas(gasToUse)
+
+	code, err := runtime.GetSCCode()
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return 0
+	}
+
+	return int32(len(code))
+}
+
+//export v1_2_ethereum_getBlockCoinbase
+func v1_2_ethereum_getBlockCoinbase(context unsafe.Pointer, resultOffset int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.getBlockCoinbase", "resultOffset", resultOffset)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.GetBlockCoinbase
+	metering.UseGas(gasToUse)
+
+	// the blocks do not have a coinbase, the fees are not paid to a single address
+	err := runtime.MemStore(resultOffset, make([]byte, vmhost.AddressLen))
+	_ = vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution())
+}
+
+//export v1_2_ethereum_create
+func v1_2_ethereum_create(context unsafe.Pointer, valueOffset int32, dataOffset int32, length int32, resultOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.create",
+			"valueOffset", valueOffset,
+			"dataOffset", dataOffset,
+			"length", length,
+			"resultOffset", resultOffset)()
+	}
+
+	host := vmhost.GetVMHost(context)
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	gasToUse := metering.GasSchedule().EthAPICost.Create
+	metering.UseGas(gasToUse)
+
+	value, err := loadEthereumValue(context, valueOffset)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return ethereumCallFailure
+	}
+
+	code, err := runtime.MemLoad(dataOffset, length)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return ethereumCallFailure
+	}
+
+	contractCreate := &vmcommon.ContractCreateInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:         runtime.GetSCAddress(),
+			Arguments:          make([][]byte, 0),
+			CallValue:          value,
+			GasPrice:           0,
+			GasProvided:        metering.GasLeft(),
+			OriginalCallerAddr: ethereumTxOrigin(runtime.GetVMInput()),
+		},
+		ContractCode:         code,
+		ContractCodeMetadata: []byte{vmcommon.MetadataUpgradeable, 0},
+	}
+
+	newAddress, err := host.CreateNewContract(contractCreate)
+	if err != nil {
+		return ethereumCallResult(err)
+	}
+
+	err = runtime.MemStore(resultOffset, newAddress)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return ethereumCallFailure
+	}
+
+	return ethereumCallSuccess
+}
+
+//export v1_2_ethereum_getBlockDifficulty
+func v1_2_ethereum_getBlockDifficulty(context unsafe.Pointer, resultOffset int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.getBlockDifficulty", "resultOffset", resultOffset)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	blockchain := vmhost.GetBlockchainContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.GetBlockDifficulty
+	metering.UseGas(gasToUse)
+
+	// there is no proof of work, the random seed of the block takes the place
+	// of the difficulty, like the previous randao value does on Ethereum
+	difficulty := make([]byte, ethereumWordLen)
+	copy(difficulty, blockchain.CurrentRandomSeed())
+
+	err := runtime.MemStore(resultOffset, difficulty)
+	_ = vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution())
+}
+
+//export v1_2_ethereum_externalCodeCopy
+func v1_2_ethereum_externalCodeCopy(context unsafe.Pointer, addressOffset int32, resultOffset int32, codeOffset int32, length int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.externalCodeCopy",
+			"addressOffset", addressOffset,
+			"resultOffset", resultOffset,
+			"codeOffset", codeOffset,
+			"length", length)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	blockchain := vmhost.GetBlockchainContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.ExternalCodeCopy
+	metering.UseGas(gasToUse)
+
+	address, err := runtime.MemLoad(addressOffset, vmhost.AddressLen)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	code, err := blockchain.GetCode(address)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	copyToMemory(vmhost.GetVMHost(context), resultOffset, code, codeOffset, length)
+}
+
+//export v1_2_ethereum_getExternalCodeSize
+func v1_2_ethereum_getExternalCodeSize(context unsafe.Pointer, addressOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.getExternalCodeSize", "addressOffset", addressOffset)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	blockchain := vmhost.GetBlockchainContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.GetExternalCodeSize
+	metering.UseGas(gasToUse)
+
+	address, err := runtime.MemLoad(addressOffset, vmhost.AddressLen)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return 0
+	}
+
+	codeSize, err := blockchain.GetCodeSize(address)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return 0
+	}
+
+	return codeSize
+}
+
+//export v1_2_ethereum_getGasLeft
+func v1_2_ethereum_getGasLeft(context unsafe.Pointer) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.getGasLeft")()
+	}
+
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.GetGasLeft
+	metering.UseGas(gasToUse)
+
+	return int64(metering.GasLeft())
+}
+
+//export v1_2_ethereum_getBlockGasLimit
+func v1_2_ethereum_getBlockGasLimit(context unsafe.Pointer) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.getBlockGasLimit")()
+	}
+
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.GetBlockGasLimit
+	metering.UseGas(gasToUse)
+
+	return int64(metering.BlockGasLimit())
+}
+
+//export v1_2_ethereum_getTxGasPrice
+func v1_2_ethereum_getTxGasPrice(context unsafe.Pointer, valueOffset int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.getTxGasPrice", "valueOffset", valueOffset)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.GetTxGasPrice
+	metering.UseGas(gasToUse)
+
+	gasPrice := big.NewInt(0).SetUint64(runtime.GetVMInput().GasPrice)
+	storeEthereumValue(context, valueOffset, gasPrice)
+}
+
+//export v1_2_ethereum_log
+func v1_2_ethereum_log(context unsafe.Pointer, dataOffset int32, length int32, numberOfTopics int32, topic1 int32, topic2 int32, topic3 int32, topic4 int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.log",
+			"dataOffset", dataOffset,
+			"length", length,
+			"numberOfTopics", numberOfTopics,
+			"topic1", topic1,
+			"topic2", topic2,
+			"topic3", topic3,
+			"topic4", topic4)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	output := vmhost.GetOutputContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.Log
+	metering.UseGas(gasToUse)
+
+	if numberOfTopics < 0 || numberOfTopics > ethereumMaxTopics {
+		_ = vmhost.WithFault(vmhost.ErrInvalidNumberOfTopics, context, runtime.BaseOpsErrorShouldFailExecution())
+		return
+	}
+
+	topicOffsets := []int32{topic1, topic2, topic3, topic4}[:numberOfTopics]
+	topics := make([][]byte, 0, numberOfTopics)
+	for _, topicOffset := range topicOffsets {
+		topic, err := runtime.MemLoad(topicOffset, ethereumWordLen)
+		if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+			return
+		}
+		topics = append(topics, topic)
+	}
+
+	data, err := runtime.MemLoad(dataOffset, length)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	output.WriteLog(runtime.GetSCAddress(), topics, data)
+}
+
+//export v1_2_ethereum_getBlockNumber
+func v1_2_ethereum_getBlockNumber(context unsafe.Pointer) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.getBlockNumber")()
+	}
+
+	blockchain := vmhost.GetBlockchainContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.GetBlockNumber
+	metering.UseGas(gasToUse)
+
+	return int64(blockchain.CurrentNonce())
+}
+
+//export v1_2_ethereum_getTxOrigin
+func v1_2_ethereum_getTxOrigin(context unsafe.Pointer, resultOffset int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.getTxOrigin", "resultOffset", resultOffset)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.GetTxOrigin
+	metering.UseGas(gasToUse)
+
+	err := runtime.MemStore(resultOffset, ethereumTxOrigin(runtime.GetVMInput()))
+	_ = vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution())
+}
+
+//export v1_2_ethereum_finish
+func v1_2_ethereum_finish(context unsafe.Pointer, dataOffset int32, length int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.finish", "dataOffset", dataOffset, "length", length)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	output := vmhost.GetOutputContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.Finish
+	metering.UseGas(gasToUse)
+
+	data, err := runtime.MemLoad(dataOffset, length)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	output.Finish(data)
+	runtime.SetRuntimeBreakpointValue(vmhost.BreakpointExit)
+}
+
+//export v1_2_ethereum_revert
+func v1_2_ethereum_revert(context unsafe.Pointer, dataOffset int32, length int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.revert", "dataOffset", dataOffset, "length", length)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.Revert
+	metering.UseGas(gasToUse)
+
+	data, err := runtime.MemLoad(dataOffset, length)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	runtime.SignalUserError(string(data))
+}
+
+//export v1_2_ethereum_getReturnDataSize
+func v1_2_ethereum_getReturnDataSize(context unsafe.Pointer) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.getReturnDataSize")()
+	}
+
+	output := vmhost.GetOutputContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.GetReturnDataSize
+	metering.UseGas(gasToUse)
+
+	return int32(len(lastReturnData(output)))
+}
+
+//export v1_2_ethereum_returnDataCopy
+func v1_2_ethereum_returnDataCopy(context unsafe.Pointer, resultOffset int32, dataOffset int32, length int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.returnDataCopy",
+			"resultOffset", resultOffset,
+			"dataOffset", dataOffset,
+			"length", length)()
+	}
+
+	output := vmhost.GetOutputContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.ReturnDataCopy
+	metering.UseGas(gasToUse)
+
+	copyToMemory(vmhost.GetVMHost(context), resultOffset, lastReturnData(output), dataOffset, length)
+}
+
+//export v1_2_ethereum_selfDestruct
+func v1_2_ethereum_selfDestruct(context unsafe.Pointer, addressOffset int32) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.selfDestruct", "addressOffset", addressOffset)()
+	}
+
+	runtime := vmhost.GetRuntimeContext(context)
+	output := vmhost.GetOutputContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.SelfDestruct
+	metering.UseGas(gasToUse)
+
+	beneficiary, err := runtime.MemLoad(addressOffset, vmhost.AddressLen)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	err = output.SelfDestruct(runtime.GetSCAddress(), beneficiary)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	runtime.SetRuntimeBreakpointValue(vmhost.BreakpointExit)
+}
+
+//export v1_2_ethereum_getBlockTimestamp
+func v1_2_ethereum_getBlockTimestamp(context unsafe.Pointer) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "ethereum.getBlockTimestamp")()
+	}
+
+	blockchain := vmhost.GetBlockchainContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EthAPICost.GetBlockTimeStamp
+	metering.UseGas(gasToUse)
+
+	return int64(blockchain.CurrentTimeStamp())
+}
+
+// ethereumCallData rebuilds the Ethereum call data of the current call: the
+// selector of the called function, followed by the arguments, each one padded
+// to a word. The constructor receives only the arguments, while calls to main
+// receive their arguments concatenated, as raw call data.
+func ethereumCallData(host vmhost.VMHost) ([]byte, error) {
+	runtime := host.Runtime()
+	function := runtime.Function()
+	arguments := runtime.Arguments()
+
+	callData := make([]byte, 0)
+	if function == vmhost.MainFunctionNameEth {
+		for _, argument := range arguments {
+			callData = append(callData, argument...)
+		}
+		return callData, nil
+	}
+
+	// direct deployments do not set the function to call
+	isConstructor := len(function) == 0 || function == vmhost.InitFunctionName || function == vmhost.InitFunctionNameEth
+	if !isConstructor {
+		functionHash, err := host.Crypto().Keccak256([]byte(function))
+		if err != nil {
+			return nil, err
+		}
+		callData = append(callData, functionHash[:4]...)
+	}
+
+	for _, argument := range arguments {
+		callData = append(callData, padLeft(argument, ethereumWordLen)...)
+	}
+
+	return callData, nil
+}
+
+// prepareEthereumCallInput creates the input of a call made by an EWASM
+// contract, passing the call data unchanged to the main function of the callee
+func prepareEthereumCallInput(
+	host vmhost.VMHost,
+	sender []byte,
+	value *big.Int,
+	gasLimit int64,
+	addressOffset int32,
+	dataOffset int32,
+	dataLength int32,
+) (*vmcommon.ContractCallInput, error) {
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	destination, err := runtime.MemLoad(addressOffset, vmhost.AddressLen)
+	if err != nil {
+		return nil, err
+	}
+
+	if !host.AreInSameShard(runtime.GetSCAddress(), destination) {
+		return nil, vmhost.ErrSyncExecutionNotInSameShard
+	}
+
+	data, err := runtime.MemLoad(dataOffset, dataLength)
+	if err != nil {
+		return nil, err
+	}
+
+	contractCallInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:         sender,
+			Arguments:          [][]byte{data},
+			CallValue:          value,
+			GasPrice:           0,
+			GasProvided:        metering.BoundGasLimit(gasLimit),
+			OriginalCallerAddr: ethereumTxOrigin(runtime.GetVMInput()),
+		},
+		RecipientAddr: destination,
+		Function:      vmhost.MainFunctionNameEth,
+	}
+
+	return contractCallInput, nil
+}
+
+// ethereumCallResult converts the error of a nested call into the result
+// expected by EWASM contracts
+func ethereumCallResult(err error) int32 {
+	if err == nil {
+		return ethereumCallSuccess
+	}
+	if errors.Is(err, vmhost.ErrSignalError) {
+		return ethereumCallRevert
+	}
+	return ethereumCallFailure
+}
+
+// ethereumTxOrigin returns the address which initiated the transaction
+func ethereumTxOrigin(vmInput *vmcommon.VMInput) []byte {
+	if len(vmInput.OriginalCallerAddr) > 0 {
+		return vmInput.OriginalCallerAddr
+	}
+	return vmInput.CallerAddr
+}
+
+// lastReturnData returns the data finished by the most recent nested call,
+// which is appended to the return data of the current call
+func lastReturnData(output vmhost.OutputContext) []byte {
+	returnData := output.ReturnData()
+	if len(returnData) == 0 {
+		return nil
+	}
+	return returnData[len(returnData)-1]
+}
+
+func loadEthereumValue(context unsafe.Pointer, valueOffset int32) (*big.Int, error) {
+	runtime := vmhost.GetRuntimeContext(context)
+
+	littleEndianValue, err := runtime.MemLoad(valueOffset, ethereumValueLen)
+	if err != nil {
+		return nil, err
+	}
+
+	return big.NewInt(0).SetBytes(reverseBytes(littleEndianValue)), nil
+}
+
+func storeEthereumValue(context unsafe.Pointer, resultOffset int32, value *big.Int) {
+	runtime := vmhost.GetRuntimeContext(context)
+
+	if value.Sign() < 0 || value.BitLen() > ethereumValueLen*8 {
+		_ = vmhost.WithFault(vmhost.ErrEthereumValueTooLarge, context, runtime.BaseOpsErrorShouldFailExecution())
+		return
+	}
+
+	littleEndianValue := reverseBytes(padLeft(value.Bytes(), ethereumValueLen))
+	err := runtime.MemStore(resultOffset, littleEndianValue)
+	_ = vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution())
+}
+
+// copyToMemory stores length bytes of data, starting at dataOffset, into the
+// memory of the contract; the bytes past the end of data are zero, as on Ethereum.
+// The copied bytes are paid for, and the destination is checked before the
+// result is allocated, since length is chosen by the contract.
+func copyToMemory(host vmhost.VMHost, resultOffset int32, data []byte, dataOffset int32, length int32) {
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	if dataOffset < 0 || length < 0 {
+		_ = vmhost.WithFaultAndHost(host, vmhost.ErrNegativeLength, runtime.BaseOpsErrorShouldFailExecution())
+		return
+	}
+
+	gasToUse := math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(length))
+	metering.UseGas(gasToUse)
+
+	err := runtime.CheckMemStoreBounds(resultOffset, length)
+	if vmhost.WithFaultAndHost(host, err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	result := make([]byte, length)
+	if int(dataOffset) < len(data) {
+		copy(result, data[dataOffset:])
+	}
+
+	err = runtime.MemStore(resultOffset, result)
+	_ = vmhost.WithFaultAndHost(host, err, runtime.BaseOpsErrorShouldFailExecution())
+}
+
+func padLeft(data []byte, length int) []byte {
+	if len(data) >= length {
+		return data
+	}
+
+	padded := make([]byte, length)
+	copy(padded[length-len(data):], data)
+	return padded
+}
+
+func reverseBytes(data []byte) []byte {
+	reversed := make([]byte, len(data))
+	for i, b := range data {
+		reversed[len(data)-1-i] = b
+	}
+	return reversed
+}
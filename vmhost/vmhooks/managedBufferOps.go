@@ -0,0 +1,380 @@
+package vmhooks
+
+// // Declare the function signatures (see [cgo](https://golang.org/cmd/cgo/)).
+//
+// #include <stdlib.h>
+// typedef unsigned char uint8_t;
+// typedef int int32_t;
+//
+// extern int32_t		v1_2_mBufferNew(void* context);
+// extern int32_t		v1_2_mBufferNewFromBytes(void* context, int32_t dataOffset, int32_t dataLength);
+// extern int32_t		v1_2_mBufferGetLength(void* context, int32_t mBufferHandle);
+// extern int32_t		v1_2_mBufferGetBytes(void* context, int32_t mBufferHandle, int32_t resultOffset);
+// extern int32_t		v1_2_mBufferSetBytes(void* context, int32_t mBufferHandle, int32_t dataOffset, int32_t dataLength);
+// extern int32_t		v1_2_mBufferAppend(void* context, int32_t accumulatorHandle, int32_t dataHandle);
+// extern int32_t		v1_2_mBufferAppendBytes(void* context, int32_t accumulatorHandle, int32_t dataOffset, int32_t dataLength);
+//
+// extern int32_t		v1_2_mBufferStorageStore(void* context, int32_t keyHandle, int32_t sourceHandle);
+// extern int32_t		v1_2_mBufferStorageLoad(void* context, int32_t keyHandle, int32_t destinationHandle);
+// extern int32_t		v1_2_mBufferGetArgument(void* context, int32_t id, int32_t destinationHandle);
+// extern int32_t		v1_2_mBufferFinish(void* context, int32_t sourceHandle);
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/math"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/wasmer"
+)
+
+// ManagedBufferImports creates a new wasmer.Imports populated with the managed buffer API methods
+func ManagedBufferImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
+	imports = imports.Namespace("env")
+
+	imports, err := imports.Append("mBufferNew", v1_2_mBufferNew, C.v1_2_mBufferNew)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferNewFromBytes", v1_2_mBufferNewFromBytes, C.v1_2_mBufferNewFromBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferGetLength", v1_2_mBufferGetLength, C.v1_2_mBufferGetLength)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferGetBytes", v1_2_mBufferGetBytes, C.v1_2_mBufferGetBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferSetBytes", v1_2_mBufferSetBytes, C.v1_2_mBufferSetBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferAppend", v1_2_mBufferAppend, C.v1_2_mBufferAppend)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferAppendBytes", v1_2_mBufferAppendBytes, C.v1_2_mBufferAppendBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferStorageStore", v1_2_mBufferStorageStore, C.v1_2_mBufferStorageStore)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferStorageLoad", v1_2_mBufferStorageLoad, C.v1_2_mBufferStorageLoad)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferGetArgument", v1_2_mBufferGetArgument, C.v1_2_mBufferGetArgument)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferFinish", v1_2_mBufferFinish, C.v1_2_mBufferFinish)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+//export v1_2_mBufferNew
+func v1_2_mBufferNew(context unsafe.Pointer) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "mBufferNew")()
+	}
+
+	managedBuffer := vmhost.GetManagedBufferContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferNew
+	metering.UseGas(gasToUse)
+
+	return managedBuffer.NewBuffer()
+}
+
+//export v1_2_mBufferNewFromBytes
+func v1_2_mBufferNewFromBytes(context unsafe.Pointer, dataOffset int32, dataLength int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "mBufferNewFromBytes", "dataOffset", dataOffset, "dataLength", dataLength)()
+	}
+
+	managedBuffer := vmhost.GetManagedBufferContext(context)
+	runtime := vmhost.GetRuntimeContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferNewFromBytes
+	metering.UseGas(gasToUse)
+
+	data, err := runtime.MemLoad(dataOffset, dataLength)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(data)))
+	metering.UseGas(gasToUse)
+
+	return managedBuffer.NewBufferFromBytes(data)
+}
+
+//export v1_2_mBufferGetLength
+func v1_2_mBufferGetLength(context unsafe.Pointer, mBufferHandle int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "mBufferGetLength", "mBufferHandle", mBufferHandle)()
+	}
+
+	managedBuffer := vmhost.GetManagedBufferContext(context)
+	runtime := vmhost.GetRuntimeContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferGetLength
+	metering.UseGas(gasToUse)
+
+	length := managedBuffer.GetLength(mBufferHandle)
+	if length < 0 {
+		_ = vmhost.WithFault(vmhost.ErrNoManagedBufferUnderThisHandle, context, runtime.BaseOpsErrorShouldFailExecution())
+		return -1
+	}
+
+	return length
+}
+
+//export v1_2_mBufferGetBytes
+func v1_2_mBufferGetBytes(context unsafe.Pointer, mBufferHandle int32, resultOffset int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "mBufferGetBytes", "mBufferHandle", mBufferHandle, "resultOffset", resultOffset)()
+	}
+
+	managedBuffer := vmhost.GetManagedBufferContext(context)
+	runtime := vmhost.GetRuntimeContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferGetBytes
+	metering.UseGas(gasToUse)
+
+	data, err := managedBuffer.GetBytes(mBufferHandle)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(data)))
+	metering.UseGas(gasToUse)
+
+	err = runtime.MemStore(resultOffset, data)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return 0
+}
+
+//export v1_2_mBufferSetBytes
+func v1_2_mBufferSetBytes(context unsafe.Pointer, mBufferHandle int32, dataOffset int32, dataLength int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "mBufferSetBytes",
+			"mBufferHandle", mBufferHandle,
+			"dataOffset", dataOffset,
+			"dataLength", dataLength)()
+	}
+
+	managedBuffer := vmhost.GetManagedBufferContext(context)
+	runtime := vmhost.GetRuntimeContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferSetBytes
+	metering.UseGas(gasToUse)
+
+	data, err := runtime.MemLoad(dataOffset, dataLength)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(data)))
+	metering.UseGas(gasToUse)
+
+	managedBuffer.SetBytes(mBufferHandle, data)
+
+	return 0
+}
+
+//export v1_2_mBufferAppend
+func v1_2_mBufferAppend(context unsafe.Pointer, accumulatorHandle int32, dataHandle int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "mBufferAppend", "accumulatorHandle", accumulatorHandle, "dataHandle", dataHandle)()
+	}
+
+	managedBuffer := vmhost.GetManagedBufferContext(context)
+	runtime := vmhost.GetRuntimeContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferAppend
+	metering.UseGas(gasToUse)
+
+	data, err := managedBuffer.GetBytes(dataHandle)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(data)))
+	metering.UseGas(gasToUse)
+
+	isSuccess := managedBuffer.AppendBytes(accumulatorHandle, data)
+	if !isSuccess {
+		_ = vmhost.WithFault(vmhost.ErrNoManagedBufferUnderThisHandle, context, runtime.BaseOpsErrorShouldFailExecution())
+		return -1
+	}
+
+	return 0
+}
+
+//export v1_2_mBufferAppendBytes
+func v1_2_mBufferAppendBytes(context unsafe.Pointer, accumulatorHandle int32, dataOffset int32, dataLength int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "mBufferAppendBytes",
+			"accumulatorHandle", accumulatorHandle,
+			"dataOffset", dataOffset,
+			"dataLength", dataLength)()
+	}
+
+	managedBuffer := vmhost.GetManagedBufferContext(context)
+	runtime := vmhost.GetRuntimeContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferAppendBytes
+	metering.UseGas(gasToUse)
+
+	data, err := runtime.MemLoad(dataOffset, dataLength)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(data)))
+	metering.UseGas(gasToUse)
+
+	isSuccess := managedBuffer.AppendBytes(accumulatorHandle, data)
+	if !isSuccess {
+		_ = vmhost.WithFault(vmhost.ErrNoManagedBufferUnderThisHandle, context, runtime.BaseOpsErrorShouldFailExecution())
+		return -1
+	}
+
+	return 0
+}
+
+//export v1_2_mBufferStorageStore
+func v1_2_mBufferStorageStore(context unsafe.Pointer, keyHandle int32, sourceHandle int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "mBufferStorageStore", "keyHandle", keyHandle, "sourceHandle", sourceHandle)()
+	}
+
+	managedBuffer := vmhost.GetManagedBufferContext(context)
+	runtime := vmhost.GetRuntimeContext(context)
+	storage := vmhost.GetStorageContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferStorageStore
+	metering.UseGas(gasToUse)
+
+	key, err := managedBuffer.GetBytes(keyHandle)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	data, err := managedBuffer.GetBytes(sourceHandle)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	storageStatus, err := storage.SetStorage(key, data)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(storageStatus)
+}
+
+//export v1_2_mBufferStorageLoad
+func v1_2_mBufferStorageLoad(context unsafe.Pointer, keyHandle int32, destinationHandle int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "mBufferStorageLoad", "keyHandle", keyHandle, "destinationHandle", destinationHandle)()
+	}
+
+	managedBuffer := vmhost.GetManagedBufferContext(context)
+	runtime := vmhost.GetRuntimeContext(context)
+	storage := vmhost.GetStorageContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferStorageLoad
+	metering.UseGas(gasToUse)
+
+	key, err := managedBuffer.GetBytes(keyHandle)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	data := storage.GetStorage(key)
+	managedBuffer.SetBytes(destinationHandle, data)
+
+	return 0
+}
+
+//export v1_2_mBufferGetArgument
+func v1_2_mBufferGetArgument(context unsafe.Pointer, id int32, destinationHandle int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "mBufferGetArgument", "id", id, "destinationHandle", destinationHandle)()
+	}
+
+	managedBuffer := vmhost.GetManagedBufferContext(context)
+	runtime := vmhost.GetRuntimeContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferGetArgument
+	metering.UseGas(gasToUse)
+
+	args := runtime.Arguments()
+	if id < 0 || int32(len(args)) <= id {
+		return -1
+	}
+
+	managedBuffer.SetBytes(destinationHandle, args[id])
+
+	return 0
+}
+
+//export v1_2_mBufferFinish
+func v1_2_mBufferFinish(context unsafe.Pointer, sourceHandle int32) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "mBufferFinish", "sourceHandle", sourceHandle)()
+	}
+
+	managedBuffer := vmhost.GetManagedBufferContext(context)
+	runtime := vmhost.GetRuntimeContext(context)
+	output := vmhost.GetOutputContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferFinish
+	metering.UseGas(gasToUse)
+
+	data, err := managedBuffer.GetBytes(sourceHandle)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.PersistPerByte, uint64(len(data)))
+	metering.UseGas(gasToUse)
+
+	output.Finish(data)
+
+	return 0
+}
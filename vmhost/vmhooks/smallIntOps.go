@@ -104,6 +104,10 @@ func SmallIntImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
 
 //export v1_2_smallIntGetUnsignedArgument
 func v1_2_smallIntGetUnsignedArgument(context unsafe.Pointer, id int32) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "smallIntGetUnsignedArgument", "id", id)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -127,6 +131,14 @@ func v1_2_smallIntGetUnsignedArgument(context unsafe.Pointer, id int32) int64 {
 
 //export v1_2_smallIntGetSignedArgument
 func v1_2_smallIntGetSignedArgument(context unsafe.Pointer, id int32) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "smallIntGetSignedArgument", "id", id)()
+	}
+
+	return smallIntGetSignedArgument(context, id)
+}
+
+func smallIntGetSignedArgument(context unsafe.Pointer, id int32) int64 {
 	runtime := vmhost.GetRuntimeContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -150,6 +162,10 @@ func v1_2_smallIntGetSignedArgument(context unsafe.Pointer, id int32) int64 {
 
 //export v1_2_smallIntFinishUnsigned
 func v1_2_smallIntFinishUnsigned(context unsafe.Pointer, value int64) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "smallIntFinishUnsigned", "value", value)()
+	}
+
 	output := vmhost.GetOutputContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -162,6 +178,14 @@ func v1_2_smallIntFinishUnsigned(context unsafe.Pointer, value int64) {
 
 //export v1_2_smallIntFinishSigned
 func v1_2_smallIntFinishSigned(context unsafe.Pointer, value int64) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "smallIntFinishSigned", "value", value)()
+	}
+
+	smallIntFinishSigned(context, value)
+}
+
+func smallIntFinishSigned(context unsafe.Pointer, value int64) {
 	output := vmhost.GetOutputContext(context)
 	metering := vmhost.GetMeteringContext(context)
 
@@ -174,6 +198,16 @@ func v1_2_smallIntFinishSigned(context unsafe.Pointer, value int64) {
 
 //export v1_2_smallIntStorageStoreUnsigned
 func v1_2_smallIntStorageStoreUnsigned(context unsafe.Pointer, keyOffset int32, keyLength int32, value int64) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "smallIntStorageStoreUnsigned",
+			"key", vmhost.TraceMemory(keyOffset, keyLength),
+			"value", value)()
+	}
+
+	return smallIntStorageStoreUnsigned(context, keyOffset, keyLength, value)
+}
+
+func smallIntStorageStoreUnsigned(context unsafe.Pointer, keyOffset int32, keyLength int32, value int64) int32 {
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -197,6 +231,12 @@ func v1_2_smallIntStorageStoreUnsigned(context unsafe.Pointer, keyOffset int32,
 
 //export v1_2_smallIntStorageStoreSigned
 func v1_2_smallIntStorageStoreSigned(context unsafe.Pointer, keyOffset int32, keyLength int32, value int64) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "smallIntStorageStoreSigned",
+			"key", vmhost.TraceMemory(keyOffset, keyLength),
+			"value", value)()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -220,6 +260,14 @@ func v1_2_smallIntStorageStoreSigned(context unsafe.Pointer, keyOffset int32, ke
 
 //export v1_2_smallIntStorageLoadUnsigned
 func v1_2_smallIntStorageLoadUnsigned(context unsafe.Pointer, keyOffset int32, keyLength int32) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "smallIntStorageLoadUnsigned", "key", vmhost.TraceMemory(keyOffset, keyLength))()
+	}
+
+	return smallIntStorageLoadUnsigned(context, keyOffset, keyLength)
+}
+
+func smallIntStorageLoadUnsigned(context unsafe.Pointer, keyOffset int32, keyLength int32) int64 {
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -244,6 +292,10 @@ func v1_2_smallIntStorageLoadUnsigned(context unsafe.Pointer, keyOffset int32, k
 
 //export v1_2_smallIntStorageLoadSigned
 func v1_2_smallIntStorageLoadSigned(context unsafe.Pointer, keyOffset int32, keyLength int32) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "smallIntStorageLoadSigned", "key", vmhost.TraceMemory(keyOffset, keyLength))()
+	}
+
 	runtime := vmhost.GetRuntimeContext(context)
 	storage := vmhost.GetStorageContext(context)
 	metering := vmhost.GetMeteringContext(context)
@@ -268,24 +320,42 @@ func v1_2_smallIntStorageLoadSigned(context unsafe.Pointer, keyOffset int32, key
 
 //export v1_2_int64getArgument
 func v1_2_int64getArgument(context unsafe.Pointer, id int32) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "int64getArgument", "id", id)()
+	}
+
 	// backwards compatibility
-	return v1_2_smallIntGetSignedArgument(context, id)
+	return smallIntGetSignedArgument(context, id)
 }
 
 //export v1_2_int64finish
 func v1_2_int64finish(context unsafe.Pointer, value int64) {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "int64finish", "value", value)()
+	}
+
 	// backwards compatibility
-	v1_2_smallIntFinishSigned(context, value)
+	smallIntFinishSigned(context, value)
 }
 
 //export v1_2_int64storageStore
 func v1_2_int64storageStore(context unsafe.Pointer, keyOffset int32, keyLength int32, value int64) int32 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "int64storageStore",
+			"key", vmhost.TraceMemory(keyOffset, keyLength),
+			"value", value)()
+	}
+
 	// backwards compatibility
-	return v1_2_smallIntStorageStoreUnsigned(context, keyOffset, keyLength, value)
+	return smallIntStorageStoreUnsigned(context, keyOffset, keyLength, value)
 }
 
 //export v1_2_int64storageLoad
 func v1_2_int64storageLoad(context unsafe.Pointer, keyOffset int32, keyLength int32) int64 {
+	if vmhost.IsHookTracingEnabled(context) {
+		defer vmhost.TraceHookCall(context, "int64storageLoad", "key", vmhost.TraceMemory(keyOffset, keyLength))()
+	}
+
 	// backwards compatibility
-	return v1_2_smallIntStorageLoadUnsigned(context, keyOffset, keyLength)
+	return smallIntStorageLoadUnsigned(context, keyOffset, keyLength)
 }
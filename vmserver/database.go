@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"strings"
 )
 
 type database struct {
@@ -29,6 +30,16 @@ func (db *database) initFolders() {
 	if err != nil {
 		log.Error("database.initFolders", "err", err)
 	}
+
+	err = os.MkdirAll(path.Join(db.rootPath, "snapshots"), os.ModePerm)
+	if err != nil {
+		log.Error("database.initFolders", "err", err)
+	}
+
+	err = os.MkdirAll(path.Join(db.rootPath, "sessions"), os.ModePerm)
+	if err != nil {
+		log.Error("database.initFolders", "err", err)
+	}
 }
 
 func (db *database) loadWorld(worldID string) (*world, error) {
@@ -55,6 +66,109 @@ func (db *database) getWorldFile(worldID string) string {
 	return path.Join(db.rootPath, "worlds", fmt.Sprintf("%s.json", worldID))
 }
 
+func (db *database) worldExists(worldID string) bool {
+	return fileExists(db.getWorldFile(worldID))
+}
+
+func (db *database) listWorlds() ([]string, error) {
+	files, err := ioutil.ReadDir(path.Join(db.rootPath, "worlds"))
+	if err != nil {
+		return nil, err
+	}
+
+	worlds := make([]string, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || path.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		worlds = append(worlds, strings.TrimSuffix(file.Name(), ".json"))
+	}
+
+	return worlds, nil
+}
+
+func (db *database) storeWorldDataModel(dataModel *worldDataModel) error {
+	filePath := db.getWorldFile(dataModel.ID)
+	log.Trace("Database.storeWorldDataModel()", "file", filePath)
+
+	return db.marshalDataModel(filePath, dataModel)
+}
+
+func (db *database) storeSnapshot(snapshotID string, dataModel *worldDataModel) error {
+	err := os.MkdirAll(db.getSnapshotsFolder(dataModel.ID), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	filePath := db.getSnapshotFile(dataModel.ID, snapshotID)
+	log.Trace("Database.storeSnapshot()", "file", filePath)
+
+	return db.marshalDataModel(filePath, dataModel)
+}
+
+func (db *database) loadSnapshot(worldID string, snapshotID string) (*worldDataModel, error) {
+	filePath := db.getSnapshotFile(worldID, snapshotID)
+	if !fileExists(filePath) {
+		return nil, ErrSnapshotDoesntExist
+	}
+
+	return db.readWorldDataModel(filePath)
+}
+
+func (db *database) getSnapshotsFolder(worldID string) string {
+	return path.Join(db.rootPath, "snapshots", worldID)
+}
+
+func (db *database) getSnapshotFile(worldID string, snapshotID string) string {
+	return path.Join(db.getSnapshotsFolder(worldID), fmt.Sprintf("%s.json", snapshotID))
+}
+
+func (db *database) getSnapshotSessionFile(worldID string, snapshotID string) string {
+	return path.Join(db.getSnapshotsFolder(worldID), fmt.Sprintf("%s.session.json", snapshotID))
+}
+
+func (db *database) loadSession(worldID string) (*worldSession, error) {
+	return db.readSession(worldID, db.getSessionFile(worldID))
+}
+
+func (db *database) storeSession(session *worldSession) error {
+	filePath := db.getSessionFile(session.World)
+	log.Trace("Database.storeSession()", "file", filePath)
+
+	return db.marshalDataModel(filePath, session)
+}
+
+func (db *database) storeSnapshotSession(snapshotID string, session *worldSession) error {
+	filePath := db.getSnapshotSessionFile(session.World, snapshotID)
+	log.Trace("Database.storeSnapshotSession()", "file", filePath)
+
+	return db.marshalDataModel(filePath, session)
+}
+
+func (db *database) loadSnapshotSession(worldID string, snapshotID string) (*worldSession, error) {
+	return db.readSession(worldID, db.getSnapshotSessionFile(worldID, snapshotID))
+}
+
+func (db *database) getSessionFile(worldID string) string {
+	return path.Join(db.rootPath, "sessions", fmt.Sprintf("%s.json", worldID))
+}
+
+// readSession reads a session file, falling back to an empty session if the file does not exist
+func (db *database) readSession(worldID string, filePath string) (*worldSession, error) {
+	if !fileExists(filePath) {
+		return newWorldSession(worldID), nil
+	}
+
+	session := &worldSession{}
+	err := db.unmarshalDataModel(filePath, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
 func fileExists(filePath string) bool {
 	_, err := os.Stat(filePath)
 	return err == nil
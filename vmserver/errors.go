@@ -44,3 +44,15 @@ var ErrInvalidArgumentEncoding = errors.New("invalid contract argument encoding"
 
 // ErrAccountDoesntExist signals an error
 var ErrAccountDoesntExist = errors.New("account does not exist")
+
+// ErrWorldDoesntExist signals an error
+var ErrWorldDoesntExist = errors.New("world does not exist")
+
+// ErrWorldAlreadyExists signals an error
+var ErrWorldAlreadyExists = errors.New("world already exists")
+
+// ErrSnapshotDoesntExist signals an error
+var ErrSnapshotDoesntExist = errors.New("snapshot does not exist")
+
+// ErrGasEstimationNotSupported signals an error
+var ErrGasEstimationNotSupported = errors.New("gas estimation not supported by the VM")
@@ -3,8 +3,11 @@ package vmserver
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 
 	logger "github.com/kalyan3104/k-chain-logger-go"
+	scenjsonwrite "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/json/write"
 )
 
 var log = logger.GetOrCreate("vmserver")
@@ -40,6 +43,11 @@ func (f *DebugFacade) DeploySmartContract(request DeployRequest) (*DeployRespons
 		return nil, err
 	}
 
+	err = f.recordSessionStep(database, request.World, newDeployStep(request, response))
+	if err != nil {
+		return nil, err
+	}
+
 	err = database.storeOutcome(request.Outcome, response)
 	if err != nil {
 		return nil, err
@@ -76,6 +84,11 @@ func (f *DebugFacade) UpgradeSmartContract(request UpgradeRequest) (*UpgradeResp
 		return nil, err
 	}
 
+	err = f.recordSessionStep(database, request.World, newUpgradeStep(request, response))
+	if err != nil {
+		return nil, err
+	}
+
 	err = database.storeOutcome(request.Outcome, response)
 	if err != nil {
 		return nil, err
@@ -107,6 +120,11 @@ func (f *DebugFacade) RunSmartContract(request RunRequest) (*RunResponse, error)
 		return nil, err
 	}
 
+	err = f.recordSessionStep(database, request.World, newRunStep(sessionStepRun, request, response.Output))
+	if err != nil {
+		return nil, err
+	}
+
 	err = database.storeOutcome(request.Outcome, response)
 	if err != nil {
 		return nil, err
@@ -133,6 +151,38 @@ func (f *DebugFacade) QuerySmartContract(request QueryRequest) (*QueryResponse,
 
 	response := world.querySmartContract(request)
 
+	err = f.recordSessionStep(database, request.World, newRunStep(sessionStepQuery, request.RunRequest, response.Output))
+	if err != nil {
+		return nil, err
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, err
+}
+
+// EstimateGas measures the gas consumed by a smart contract call, without
+// changing the world, and optionally searches the smallest sufficient gas limit
+func (f *DebugFacade) EstimateGas(request EstimateRequest) (*EstimateResponse, error) {
+	log.Debug("Debugf.EstimateGas()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	world, err := database.loadWorld(request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	response := world.estimateGas(request)
+
 	err = database.storeOutcome(request.Outcome, response)
 	if err != nil {
 		return nil, err
@@ -164,6 +214,216 @@ func (f *DebugFacade) CreateAccount(request CreateAccountRequest) (*CreateAccoun
 		return nil, err
 	}
 
+	err = f.recordSessionStep(database, request.World, newCreateAccountStep(request))
+	if err != nil {
+		return nil, err
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, err
+}
+
+// SnapshotWorld saves a copy of a world, which can be restored later
+func (f *DebugFacade) SnapshotWorld(request SnapshotWorldRequest) (*SnapshotWorldResponse, error) {
+	log.Debug("Debugf.SnapshotWorld()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	if !database.worldExists(request.World) {
+		return nil, ErrWorldDoesntExist
+	}
+
+	world, err := database.loadWorld(request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := world.cloneDataModel(request.World)
+	err = database.storeSnapshot(request.SnapshotID, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := database.loadSession(request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	err = database.storeSnapshotSession(request.SnapshotID, session)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SnapshotWorldResponse{
+		World:       request.World,
+		SnapshotID:  request.SnapshotID,
+		NumAccounts: len(snapshot.Accounts),
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, err
+}
+
+// ForkWorld copies a world under a new world ID, so that both can evolve independently
+func (f *DebugFacade) ForkWorld(request ForkWorldRequest) (*ForkWorldResponse, error) {
+	log.Debug("Debugf.ForkWorld()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	if !database.worldExists(request.World) {
+		return nil, ErrWorldDoesntExist
+	}
+	if database.worldExists(request.NewWorld) {
+		return nil, ErrWorldAlreadyExists
+	}
+
+	world, err := database.loadWorld(request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	fork := world.cloneDataModel(request.NewWorld)
+	err = database.storeWorldDataModel(fork)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := database.loadSession(request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	session.World = request.NewWorld
+	err = database.storeSession(session)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ForkWorldResponse{
+		World:       request.World,
+		NewWorld:    request.NewWorld,
+		NumAccounts: len(fork.Accounts),
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, err
+}
+
+// RestoreWorld replaces the state of a world with one of its snapshots
+func (f *DebugFacade) RestoreWorld(request RestoreWorldRequest) (*RestoreWorldResponse, error) {
+	log.Debug("Debugf.RestoreWorld()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	snapshot, err := database.loadSnapshot(request.World, request.SnapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.ID = request.World
+	err = database.storeWorldDataModel(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := database.loadSnapshotSession(request.World, request.SnapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = database.storeSession(session)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RestoreWorldResponse{
+		World:       request.World,
+		SnapshotID:  request.SnapshotID,
+		NumAccounts: len(snapshot.Accounts),
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, err
+}
+
+// ListWorlds lists the worlds stored in the database
+func (f *DebugFacade) ListWorlds(request ListWorldsRequest) (*ListWorldsResponse, error) {
+	log.Debug("Debugf.ListWorlds()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	worlds, err := database.listWorlds()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListWorldsResponse{Worlds: worlds}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, err
+}
+
+// GetAccount fetches the balance, nonce, owner and code details of an account
+func (f *DebugFacade) GetAccount(request AccountRequest) (*GetAccountResponse, error) {
+	log.Debug("Debugf.GetAccount()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	world, err := f.loadExistingWorld(database, request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := world.getAccount(request)
+	if err != nil {
+		return nil, err
+	}
+
 	err = database.storeOutcome(request.Outcome, response)
 	if err != nil {
 		return nil, err
@@ -173,6 +433,138 @@ func (f *DebugFacade) CreateAccount(request CreateAccountRequest) (*CreateAccoun
 	return response, err
 }
 
+// GetAccountStorage fetches a page of the storage of an account, optionally
+// filtered by a key prefix; the keys are sorted
+func (f *DebugFacade) GetAccountStorage(request GetAccountStorageRequest) (*GetAccountStorageResponse, error) {
+	log.Debug("Debugf.GetAccountStorage()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	world, err := f.loadExistingWorld(database, request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := world.getAccountStorage(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, err
+}
+
+// GetAccountDCDT fetches the DCDT tokens and NFTs held by an account
+func (f *DebugFacade) GetAccountDCDT(request AccountRequest) (*GetAccountDCDTResponse, error) {
+	log.Debug("Debugf.GetAccountDCDT()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	world, err := f.loadExistingWorld(database, request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := world.getAccountDCDT(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, err
+}
+
+func (f *DebugFacade) loadExistingWorld(database *database, worldID string) (*world, error) {
+	if !database.worldExists(worldID) {
+		return nil, ErrWorldDoesntExist
+	}
+
+	return database.loadWorld(worldID)
+}
+
+// ExportScenario converts the requests recorded for a world into an equivalent
+// scenario, with the expected results taken from the actual outcomes
+func (f *DebugFacade) ExportScenario(request ExportScenarioRequest) (*ExportScenarioResponse, error) {
+	log.Debug("Debugf.ExportScenario()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	if !database.worldExists(request.World) {
+		return nil, ErrWorldDoesntExist
+	}
+
+	session, err := database.loadSession(request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	scenarioFolder, err := filepath.Abs(filepath.Dir(request.ScenarioPath))
+	if err != nil {
+		return nil, err
+	}
+
+	scenario := newScenarioExporter(scenarioFolder).export(session)
+	scenarioJSON := scenjsonwrite.ScenarioToJSONString(scenario)
+
+	if len(request.ScenarioPath) > 0 {
+		err = ioutil.WriteFile(request.ScenarioPath, []byte(scenarioJSON), 0644)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response := &ExportScenarioResponse{
+		World:        request.World,
+		ScenarioPath: request.ScenarioPath,
+		NumSteps:     len(scenario.Steps),
+		Scenario:     scenarioJSON,
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, err
+}
+
+func (f *DebugFacade) recordSessionStep(database *database, worldID string, step *sessionStep) error {
+	if step == nil {
+		return nil
+	}
+
+	session, err := database.loadSession(worldID)
+	if err != nil {
+		return err
+	}
+
+	session.Steps = append(session.Steps, step)
+	return database.storeSession(session)
+}
+
 func dumpOutcome(outcome interface{}) {
 	data, err := json.MarshalIndent(outcome, "", "\t")
 	if err != nil {
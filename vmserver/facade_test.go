@@ -1,10 +1,17 @@
 package vmserver
 
 import (
+	"math"
+	"math/big"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/kalyan3104/k-chain-core-go/core"
+	"github.com/kalyan3104/k-chain-core-go/data/dcdt"
 	worldmock "github.com/kalyan3104/k-chain-vm-v1_2-go/mock/world"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/scenarioexec"
+	mc "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/controller"
 	"github.com/stretchr/testify/require"
 )
 
@@ -45,6 +52,25 @@ func TestFacade_RunContract_Counter(t *testing.T) {
 	require.Equal(t, []byte{2}, state["COUNTER"])
 }
 
+func TestFacade_EstimateGas_Counter(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	context.createAccount(alice.hex, "42")
+	contractAddressHex := context.deployContract(wasmCounterPath, alice.hex).ContractAddressHex
+
+	estimate := context.estimateGas(contractAddressHex, alice.hex, "increment")
+	require.Equal(t, uint64(gasLimit), estimate.GasProvided)
+	require.Greater(t, estimate.GasConsumed, uint64(0))
+	require.GreaterOrEqual(t, estimate.GasLimit, estimate.GasConsumed)
+	require.Less(t, estimate.GasLimit, uint64(gasLimit))
+	require.Greater(t, estimate.NumExecutions, 1)
+
+	// the estimation does not change the world
+	counterValue := context.queryContract(contractAddressHex, alice.hex, "get").getFirstResultAsInt64()
+	require.Equal(t, int64(1), counterValue)
+}
+
 func TestFacade_RunContract_ERC20(t *testing.T) {
 	context := newTestContext(t)
 
@@ -104,3 +130,326 @@ func TestFacade_UpgradeContract_CounterToERC20(t *testing.T) {
 	require.Equal(t, int64(90), balanceOfAlice)
 	require.Equal(t, int64(10), balanceOfBob)
 }
+
+func TestFacade_ForkWorld(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	context.createAccount(alice.hex, "42")
+	deployResponse := context.deployContract(wasmCounterPath, alice.hex)
+	contractAddressHex := deployResponse.ContractAddressHex
+
+	forkedContext := context.forkWorld()
+	forkedContext.runContract(contractAddressHex, alice.hex, "increment")
+	forkedContext.runContract(contractAddressHex, alice.hex, "increment")
+
+	counterValue := context.queryContract(contractAddressHex, alice.hex, "get").getFirstResultAsInt64()
+	require.Equal(t, int64(1), counterValue)
+	counterValue = forkedContext.queryContract(contractAddressHex, alice.hex, "get").getFirstResultAsInt64()
+	require.Equal(t, int64(3), counterValue)
+
+	// A world cannot be forked over an existing one
+	_, err := context.facade.ForkWorld(ForkWorldRequest{
+		RequestBase: context.createRequestBase(),
+		NewWorld:    forkedContext.worldID,
+	})
+	require.Equal(t, ErrWorldAlreadyExists, err)
+}
+
+func TestFacade_SnapshotAndRestoreWorld(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	bob := newDummyAddress("bob")
+	context.createAccount(alice.hex, "42")
+	deployResponse := context.deployContract(wasmCounterPath, alice.hex)
+	contractAddressHex := deployResponse.ContractAddressHex
+
+	snapshotResponse := context.snapshotWorld("afterDeploy")
+	require.Equal(t, 2, snapshotResponse.NumAccounts)
+
+	context.runContract(contractAddressHex, alice.hex, "increment")
+	context.createAccount(bob.hex, "7")
+	counterValue := context.queryContract(contractAddressHex, alice.hex, "get").getFirstResultAsInt64()
+	require.Equal(t, int64(2), counterValue)
+	require.True(t, context.accountExists(bob.raw))
+
+	restoreResponse := context.restoreWorld("afterDeploy")
+	require.Equal(t, 2, restoreResponse.NumAccounts)
+
+	counterValue = context.queryContract(contractAddressHex, alice.hex, "get").getFirstResultAsInt64()
+	require.Equal(t, int64(1), counterValue)
+	require.False(t, context.accountExists(bob.raw))
+
+	_, err := context.facade.RestoreWorld(RestoreWorldRequest{
+		RequestBase: context.createRequestBase(),
+		SnapshotID:  "missing",
+	})
+	require.Equal(t, ErrSnapshotDoesntExist, err)
+}
+
+func TestFacade_InspectAccount(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	context.createAccount(alice.hex, "42")
+	deployResponse := context.deployContract(wasmCounterPath, alice.hex)
+	contractAddressHex := deployResponse.ContractAddressHex
+
+	worldsResponse, err := context.facade.ListWorlds(ListWorldsRequest{RequestBase: context.createRequestBase()})
+	require.Nil(t, err)
+	require.Contains(t, worldsResponse.Worlds, context.worldID)
+
+	aliceResponse := context.getAccount(alice.hex)
+	require.Equal(t, "42", aliceResponse.Balance)
+	require.False(t, aliceResponse.IsSmartContract)
+
+	contractResponse := context.getAccount(contractAddressHex)
+	require.Equal(t, alice.hex, contractResponse.OwnerAddressHex)
+	require.NotEmpty(t, contractResponse.CodeHashHex)
+	require.NotEmpty(t, contractResponse.CodeMetadataHex)
+
+	storageResponse := context.getAccountStorage(contractAddressHex, toHex([]byte("COUNTER")), 0, 0)
+	require.Equal(t, 1, storageResponse.Total)
+	require.Equal(t, DefaultStoragePageSize, storageResponse.Limit)
+	require.Equal(t, []StorageEntry{{KeyHex: toHex([]byte("COUNTER")), ValueHex: "01"}}, storageResponse.Entries)
+
+	storageResponse = context.getAccountStorage(contractAddressHex, toHex([]byte("MISSING")), 0, 0)
+	require.Equal(t, 0, storageResponse.Total)
+	require.Empty(t, storageResponse.Entries)
+
+	_, err = context.facade.GetAccount(AccountRequest{
+		RequestBase: context.createRequestBase(),
+		AddressHex:  newDummyAddress("nobody").hex,
+	})
+	require.Equal(t, ErrAccountDoesntExist, err)
+}
+
+func TestFacade_GetAccountStorage_Paging(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	context.createAccount(alice.hex, "42")
+
+	world := context.loadWorld()
+	account := world.blockchainHook.AcctMap.GetAccount(alice.raw)
+	account.Storage["a1"] = []byte{1}
+	account.Storage["a2"] = []byte{2}
+	account.Storage["a3"] = []byte{3}
+	account.Storage["b1"] = []byte{4}
+	context.storeWorld(world)
+
+	storageResponse := context.getAccountStorage(alice.hex, toHex([]byte("a")), 1, 1)
+	require.Equal(t, 3, storageResponse.Total)
+	require.Equal(t, []StorageEntry{{KeyHex: toHex([]byte("a2")), ValueHex: "02"}}, storageResponse.Entries)
+
+	storageResponse = context.getAccountStorage(alice.hex, "", 3, 10)
+	require.Equal(t, 4, storageResponse.Total)
+	require.Equal(t, []StorageEntry{{KeyHex: toHex([]byte("b1")), ValueHex: "04"}}, storageResponse.Entries)
+
+	storageResponse = context.getAccountStorage(alice.hex, "", 10, 10)
+	require.Equal(t, 4, storageResponse.Total)
+	require.Empty(t, storageResponse.Entries)
+
+	storageResponse = context.getAccountStorage(alice.hex, "", 2, math.MaxInt)
+	require.Equal(t, 4, storageResponse.Total)
+	require.Equal(t, []StorageEntry{
+		{KeyHex: toHex([]byte("a3")), ValueHex: "03"},
+		{KeyHex: toHex([]byte("b1")), ValueHex: "04"},
+	}, storageResponse.Entries)
+}
+
+func TestFacade_GetAccountDCDT(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	context.createAccount(alice.hex, "42")
+
+	world := context.loadWorld()
+	account := world.blockchainHook.AcctMap.GetAccount(alice.raw)
+	err := account.SetTokenBalance(worldmock.MakeTokenKey([]byte("FUNG-123456"), 0), big.NewInt(1000))
+	require.Nil(t, err)
+	err = account.SetTokenData(worldmock.MakeTokenKey([]byte("NFT-123456"), 2), &dcdt.DCDigitalToken{
+		Type:  uint32(core.NonFungible),
+		Value: big.NewInt(1),
+		TokenMetaData: &dcdt.MetaData{
+			Nonce:      2,
+			Name:       []byte("NFT-123456"),
+			Creator:    alice.raw,
+			Royalties:  500,
+			URIs:       [][]byte{[]byte("https://example.com/2")},
+			Attributes: []byte{0xab},
+		},
+	})
+	require.Nil(t, err)
+	err = account.SetTokenRolesAsStrings([]byte("NFT-123456"), []string{core.DCDTRoleNFTCreate})
+	require.Nil(t, err)
+	err = account.SetLastNonce([]byte("NFT-123456"), 2)
+	require.Nil(t, err)
+	context.storeWorld(world)
+
+	response, err := context.facade.GetAccountDCDT(AccountRequest{
+		RequestBase: context.createRequestBase(),
+		AddressHex:  alice.hex,
+	})
+	require.Nil(t, err)
+	require.Len(t, response.Tokens, 2)
+
+	fungible := response.Tokens[0]
+	require.Equal(t, "FUNG-123456", fungible.TokenIdentifier)
+	require.Len(t, fungible.Instances, 1)
+	require.Equal(t, "1000", fungible.Instances[0].Balance)
+	require.Equal(t, uint64(0), fungible.Instances[0].Nonce)
+
+	nft := response.Tokens[1]
+	require.Equal(t, "NFT-123456", nft.TokenIdentifier)
+	require.Equal(t, uint64(2), nft.LastNonce)
+	require.Equal(t, []string{core.DCDTRoleNFTCreate}, nft.Roles)
+	require.Equal(t, []DCDTInstance{{
+		Nonce:         2,
+		Balance:       "1",
+		Type:          uint32(core.NonFungible),
+		Name:          "NFT-123456",
+		CreatorHex:    alice.hex,
+		Royalties:     500,
+		HashHex:       "",
+		URIs:          []string{"https://example.com/2"},
+		AttributesHex: "ab",
+	}}, nft.Instances)
+}
+
+func TestFacade_ExportScenario(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	context.createAccount(alice.hex, "42")
+	deployResponse := context.deployContract(wasmCounterPath, alice.hex)
+	contractAddressHex := deployResponse.ContractAddressHex
+	context.runContract(contractAddressHex, alice.hex, "increment")
+	context.queryContract(contractAddressHex, alice.hex, "get")
+
+	scenarioPath := filepath.Join(databasePath, context.worldID+".scen.json")
+	exportResponse := context.exportScenario(scenarioPath)
+	// setState (account), setState (new address), scDeploy, scCall, scQuery
+	require.Equal(t, 5, exportResponse.NumSteps)
+	require.Contains(t, exportResponse.Scenario, "file:../../../test/contracts/counter/output/counter.wasm")
+
+	executor, err := scenarioexec.NewVMTestExecutor("../scenarioexec")
+	require.Nil(t, err)
+	runner := mc.NewScenarioRunner(executor, mc.NewDefaultFileResolver())
+	err = runner.RunSingleJSONScenario(scenarioPath)
+	require.Nil(t, err)
+}
+
+func TestFacade_ExportScenario_AfterRestore(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	context.createAccount(alice.hex, "42")
+	deployResponse := context.deployContract(wasmCounterPath, alice.hex)
+	contractAddressHex := deployResponse.ContractAddressHex
+
+	context.snapshotWorld("afterDeploy")
+	context.runContract(contractAddressHex, alice.hex, "increment")
+	require.Equal(t, 4, context.exportScenario("").NumSteps)
+
+	context.restoreWorld("afterDeploy")
+	require.Equal(t, 3, context.exportScenario("").NumSteps)
+
+	forkedContext := context.forkWorld()
+	forkedContext.runContract(contractAddressHex, alice.hex, "increment")
+	require.Equal(t, 4, forkedContext.exportScenario("").NumSteps)
+	require.Equal(t, 3, context.exportScenario("").NumSteps)
+}
+
+var testFungibleKey = worldmock.MakeTokenKey([]byte("FUNG-123456"), 0)
+var testNFTKey = worldmock.MakeTokenKey([]byte("NFT-123456"), 2)
+
+func (context *testContext) setDCDTTokens(address []byte) {
+	t := context.t
+	world := context.loadWorld()
+	account := world.blockchainHook.AcctMap.GetAccount(address)
+	err := account.SetTokenBalance(testFungibleKey, big.NewInt(1000))
+	require.Nil(t, err)
+	err = account.SetTokenData(testNFTKey, &dcdt.DCDigitalToken{
+		Type:          uint32(core.NonFungible),
+		Value:         big.NewInt(1),
+		TokenMetaData: &dcdt.MetaData{Nonce: 2, Name: []byte("NFT-123456")},
+	})
+	require.Nil(t, err)
+	context.storeWorld(world)
+}
+
+func (context *testContext) createDCDTRunRequest(contract string, impersonated string) RunRequest {
+	return RunRequest{
+		ContractRequestBase: ContractRequestBase{
+			RequestBase:     context.createRequestBase(),
+			ImpersonatedHex: impersonated,
+			GasLimit:        gasLimit,
+			DCDTPayments: []DCDTPayment{
+				{TokenIdentifier: "FUNG-123456", Value: "100"},
+				{TokenIdentifier: "NFT-123456", Nonce: 2, Value: "1"},
+			},
+		},
+		ContractAddressHex: contract,
+		Function:           "get",
+	}
+}
+
+func TestFacade_QueryAndEstimate_DCDTPaymentsRolledBack(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	bob := newDummyAddress("bob")
+	context.createAccount(alice.hex, "42")
+	context.createAccount(bob.hex, "0")
+	context.setDCDTTokens(alice.raw)
+
+	// bob has no code, but the payments are moved to him before the call
+	runRequest := context.createDCDTRunRequest(bob.hex, alice.hex)
+	queryResponse, err := context.facade.QuerySmartContract(QueryRequest{RunRequest: runRequest})
+	require.Nil(t, err)
+	require.Nil(t, queryResponse.Error)
+	require.NotNil(t, queryResponse.Output)
+	require.Equal(t, uint32(core.NonFungible), queryResponse.Input.DCDTTransfers[1].DCDTTokenType)
+
+	runRequest = context.createDCDTRunRequest(bob.hex, alice.hex)
+	estimateResponse, err := context.facade.EstimateGas(EstimateRequest{RunRequest: runRequest})
+	require.Nil(t, err)
+	require.Nil(t, estimateResponse.Error)
+	require.Equal(t, uint64(gasLimit), estimateResponse.GasProvided)
+
+	world := context.loadWorld()
+	account := world.blockchainHook.AcctMap.GetAccount(alice.raw)
+	fungibleBalance, err := account.GetTokenBalance(testFungibleKey)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(1000), fungibleBalance)
+	nftBalance, err := account.GetTokenBalance(testNFTKey)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(1), nftBalance)
+
+	account = world.blockchainHook.AcctMap.GetAccount(bob.raw)
+	fungibleBalance, err = account.GetTokenBalance(testFungibleKey)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(0), fungibleBalance)
+}
+
+func TestFacade_ExportScenario_DCDTPayments(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	bob := newDummyAddress("bob")
+	context.createAccount(alice.hex, "42")
+	context.createAccount(bob.hex, "0")
+	context.setDCDTTokens(alice.raw)
+
+	response, err := context.facade.RunSmartContract(context.createDCDTRunRequest(bob.hex, alice.hex))
+	require.Nil(t, err)
+	require.NotNil(t, response.Output)
+
+	exportResponse := context.exportScenario("")
+	require.Equal(t, 3, exportResponse.NumSteps)
+	require.Contains(t, exportResponse.Scenario, `"tokenIdentifier": "str:FUNG-123456"`)
+	require.Contains(t, exportResponse.Scenario, `"tokenIdentifier": "str:NFT-123456"`)
+	require.Contains(t, exportResponse.Scenario, `"nonce": "2"`)
+}
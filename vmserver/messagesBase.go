@@ -3,6 +3,7 @@ package vmserver
 import (
 	"math/big"
 
+	"github.com/kalyan3104/k-chain-core-go/core"
 	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
 )
 
@@ -39,6 +40,16 @@ type ContractRequestBase struct {
 	ValueAsBigInt   *big.Int
 	GasPrice        uint64
 	GasLimit        uint64
+	DCDTPayments    []DCDTPayment
+	DCDTTransfers   []*vmcommon.DCDTTransfer
+}
+
+// DCDTPayment is a token transferred along with a contract request; a nonce
+// greater than 0 designates an NFT / SFT instance
+type DCDTPayment struct {
+	TokenIdentifier string
+	Nonce           uint64
+	Value           string
 }
 
 func (request *ContractRequestBase) digest() error {
@@ -69,9 +80,44 @@ func (request *ContractRequestBase) digest() error {
 		return err
 	}
 
+	request.DCDTTransfers, err = digestDCDTPayments(request.DCDTPayments)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+func digestDCDTPayments(payments []DCDTPayment) ([]*vmcommon.DCDTTransfer, error) {
+	transfers := make([]*vmcommon.DCDTTransfer, 0, len(payments))
+
+	for _, payment := range payments {
+		if payment.TokenIdentifier == "" {
+			return nil, NewRequestError("empty DCDT token identifier")
+		}
+
+		value, err := parseValue(payment.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		// the world refines the type of the NFT / SFT payments from the token data of the caller
+		tokenType := core.Fungible
+		if payment.Nonce > 0 {
+			tokenType = core.NonFungible
+		}
+
+		transfers = append(transfers, &vmcommon.DCDTTransfer{
+			DCDTTokenName:  []byte(payment.TokenIdentifier),
+			DCDTTokenNonce: payment.Nonce,
+			DCDTValue:      value,
+			DCDTTokenType:  uint32(tokenType),
+		})
+	}
+
+	return transfers, nil
+}
+
 // ContractResponseBase is a CLI / REST response message
 type ContractResponseBase struct {
 	ResponseBase
@@ -0,0 +1,28 @@
+package vmserver
+
+import (
+	"testing"
+
+	"github.com/kalyan3104/k-chain-core-go/core"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DigestDCDTPayments(t *testing.T) {
+	transfers, err := digestDCDTPayments([]DCDTPayment{
+		{TokenIdentifier: "FUNG-123456", Value: "100"},
+		{TokenIdentifier: "NFT-123456", Nonce: 5, Value: "1"},
+	})
+	require.Nil(t, err)
+	require.Len(t, transfers, 2)
+	require.Equal(t, []byte("FUNG-123456"), transfers[0].DCDTTokenName)
+	require.Equal(t, int64(100), transfers[0].DCDTValue.Int64())
+	require.Equal(t, uint32(core.Fungible), transfers[0].DCDTTokenType)
+	require.Equal(t, uint64(5), transfers[1].DCDTTokenNonce)
+	require.Equal(t, uint32(core.NonFungible), transfers[1].DCDTTokenType)
+
+	_, err = digestDCDTPayments([]DCDTPayment{{Value: "1"}})
+	require.NotNil(t, err)
+
+	_, err = digestDCDTPayments([]DCDTPayment{{TokenIdentifier: "FUNG-123456", Value: "foo"}})
+	require.NotNil(t, err)
+}
@@ -24,6 +24,10 @@ func (request *DeployRequest) digest() error {
 		return err
 	}
 
+	if len(request.DCDTTransfers) > 0 {
+		return NewRequestError("DCDT payments are not supported on deploy")
+	}
+
 	if len(request.CodeHex) > 0 {
 		request.Code, err = fromHex(request.CodeHex)
 		if err != nil {
@@ -0,0 +1,18 @@
+package vmserver
+
+// EstimateRequest is a CLI / REST request message
+type EstimateRequest struct {
+	RunRequest
+	SearchGasLimit bool
+}
+
+// EstimateResponse is a CLI / REST response message
+type EstimateResponse struct {
+	ContractResponseBase
+	GasProvided   uint64
+	GasConsumed   uint64
+	GasForwarded  uint64
+	GasLocked     uint64
+	GasLimit      uint64
+	NumExecutions int
+}
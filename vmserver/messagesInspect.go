@@ -0,0 +1,130 @@
+package vmserver
+
+// DefaultStoragePageSize is the default number of storage entries returned by GetAccountStorage
+const DefaultStoragePageSize = 100
+
+// ListWorldsRequest is a CLI / REST request message
+type ListWorldsRequest struct {
+	RequestBase
+}
+
+// ListWorldsResponse is a CLI / REST response message
+type ListWorldsResponse struct {
+	Worlds []string
+}
+
+// AccountRequest is a CLI / REST request message
+type AccountRequest struct {
+	RequestBase
+	AddressHex string
+	Address    []byte
+}
+
+func (request *AccountRequest) digest() error {
+	err := request.RequestBase.digest()
+	if err != nil {
+		return err
+	}
+
+	if len(request.AddressHex) == 0 {
+		return NewRequestError("empty account address")
+	}
+
+	request.Address, err = fromHex(request.AddressHex)
+	if err != nil {
+		return NewRequestErrorMessageInner("invalid account address", err)
+	}
+
+	return nil
+}
+
+// GetAccountResponse is a CLI / REST response message
+type GetAccountResponse struct {
+	AddressHex      string
+	Balance         string
+	Nonce           uint64
+	OwnerAddressHex string
+	CodeHashHex     string
+	CodeMetadataHex string
+	Username        string
+	IsSmartContract bool
+	NumStorageKeys  int
+}
+
+// GetAccountStorageRequest is a CLI / REST request message
+type GetAccountStorageRequest struct {
+	AccountRequest
+	PrefixHex string
+	Prefix    []byte
+	Offset    int
+	Limit     int
+}
+
+func (request *GetAccountStorageRequest) digest() error {
+	err := request.AccountRequest.digest()
+	if err != nil {
+		return err
+	}
+
+	request.Prefix, err = fromHex(request.PrefixHex)
+	if err != nil {
+		return NewRequestErrorMessageInner("invalid storage key prefix", err)
+	}
+
+	if request.Offset < 0 {
+		return NewRequestError("negative offset")
+	}
+
+	if request.Limit < 0 {
+		return NewRequestError("negative limit")
+	}
+
+	if request.Limit == 0 {
+		request.Limit = DefaultStoragePageSize
+	}
+
+	return nil
+}
+
+// StorageEntry is a key-value pair from the storage of an account
+type StorageEntry struct {
+	KeyHex   string
+	ValueHex string
+}
+
+// GetAccountStorageResponse is a CLI / REST response message; Total is the
+// number of storage keys matching the prefix, regardless of paging
+type GetAccountStorageResponse struct {
+	AddressHex string
+	Entries    []StorageEntry
+	Offset     int
+	Limit      int
+	Total      int
+}
+
+// DCDTInstance is a single instance (nonce) of a DCDT token held by an account
+type DCDTInstance struct {
+	Nonce         uint64
+	Balance       string
+	Type          uint32
+	Name          string
+	CreatorHex    string
+	Royalties     uint32
+	HashHex       string
+	URIs          []string
+	AttributesHex string
+}
+
+// DCDTHolding groups together all the instances of a DCDT token held by an account
+type DCDTHolding struct {
+	TokenIdentifier string
+	Instances       []DCDTInstance
+	LastNonce       uint64
+	Roles           []string
+}
+
+// GetAccountDCDTResponse is a CLI / REST response message
+type GetAccountDCDTResponse struct {
+	AddressHex string
+	Tokens     []DCDTHolding
+}
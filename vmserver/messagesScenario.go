@@ -0,0 +1,20 @@
+package vmserver
+
+// ExportScenarioRequest is a CLI / REST request message; the scenario is
+// also written to ScenarioPath, if provided
+type ExportScenarioRequest struct {
+	RequestBase
+	ScenarioPath string
+}
+
+func (request *ExportScenarioRequest) digest() error {
+	return request.RequestBase.digest()
+}
+
+// ExportScenarioResponse is a CLI / REST response message
+type ExportScenarioResponse struct {
+	World        string
+	ScenarioPath string
+	NumSteps     int
+	Scenario     string
+}
@@ -0,0 +1,83 @@
+package vmserver
+
+// SnapshotWorldRequest is a CLI / REST request message
+type SnapshotWorldRequest struct {
+	RequestBase
+	SnapshotID string
+}
+
+func (request *SnapshotWorldRequest) digest() error {
+	err := request.RequestBase.digest()
+	if err != nil {
+		return err
+	}
+
+	if len(request.SnapshotID) == 0 {
+		return NewRequestError("empty snapshot ID")
+	}
+
+	return nil
+}
+
+// SnapshotWorldResponse is a CLI / REST response message
+type SnapshotWorldResponse struct {
+	World       string
+	SnapshotID  string
+	NumAccounts int
+}
+
+// ForkWorldRequest is a CLI / REST request message
+type ForkWorldRequest struct {
+	RequestBase
+	NewWorld string
+}
+
+func (request *ForkWorldRequest) digest() error {
+	err := request.RequestBase.digest()
+	if err != nil {
+		return err
+	}
+
+	if len(request.NewWorld) == 0 {
+		return NewRequestError("empty new world ID")
+	}
+
+	if request.NewWorld == request.World {
+		return NewRequestError("cannot fork a world into itself")
+	}
+
+	return nil
+}
+
+// ForkWorldResponse is a CLI / REST response message
+type ForkWorldResponse struct {
+	World       string
+	NewWorld    string
+	NumAccounts int
+}
+
+// RestoreWorldRequest is a CLI / REST request message
+type RestoreWorldRequest struct {
+	RequestBase
+	SnapshotID string
+}
+
+func (request *RestoreWorldRequest) digest() error {
+	err := request.RequestBase.digest()
+	if err != nil {
+		return err
+	}
+
+	if len(request.SnapshotID) == 0 {
+		return NewRequestError("empty snapshot ID")
+	}
+
+	return nil
+}
+
+// RestoreWorldResponse is a CLI / REST response message
+type RestoreWorldResponse struct {
+	World       string
+	SnapshotID  string
+	NumAccounts int
+}
@@ -32,6 +32,15 @@ func (server *DebugServer) Start() error {
 	router.POST("/upgrade", server.handleUpgrade)
 	router.POST("/run", server.handleRun)
 	router.POST("/query", server.handleQuery)
+	router.POST("/estimate", server.handleEstimate)
+	router.POST("/world/snapshot", server.handleSnapshotWorld)
+	router.POST("/world/fork", server.handleForkWorld)
+	router.POST("/world/restore", server.handleRestoreWorld)
+	router.POST("/world/export", server.handleExportScenario)
+	router.GET("/worlds", server.handleListWorlds)
+	router.GET("/world/:world/account/:address", server.handleGetAccount)
+	router.GET("/world/:world/account/:address/storage", server.handleGetAccountStorage)
+	router.GET("/world/:world/account/:address/dcdt", server.handleGetAccountDCDT)
 
 	return router.Run(server.address)
 }
@@ -126,6 +135,176 @@ func (server *DebugServer) handleQuery(ginContext *gin.Context) {
 	returnOkResponse(ginContext, response)
 }
 
+func (server *DebugServer) handleEstimate(ginContext *gin.Context) {
+	request := EstimateRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleEstimate.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.EstimateGas(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleEstimate.EstimateGas", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleSnapshotWorld(ginContext *gin.Context) {
+	request := SnapshotWorldRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleSnapshotWorld.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.SnapshotWorld(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleSnapshotWorld.SnapshotWorld", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleForkWorld(ginContext *gin.Context) {
+	request := ForkWorldRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleForkWorld.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.ForkWorld(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleForkWorld.ForkWorld", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleRestoreWorld(ginContext *gin.Context) {
+	request := RestoreWorldRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleRestoreWorld.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.RestoreWorld(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleRestoreWorld.RestoreWorld", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleExportScenario(ginContext *gin.Context) {
+	request := ExportScenarioRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleExportScenario.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.ExportScenario(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleExportScenario.ExportScenario", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleListWorlds(ginContext *gin.Context) {
+	request := ListWorldsRequest{}
+
+	err := ginContext.ShouldBindQuery(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleListWorlds.ShouldBindQuery", err)
+		return
+	}
+
+	response, err := server.facade.ListWorlds(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleListWorlds.ListWorlds", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleGetAccount(ginContext *gin.Context) {
+	request := AccountRequest{}
+
+	err := ginContext.ShouldBindQuery(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleGetAccount.ShouldBindQuery", err)
+		return
+	}
+	bindAccountPath(ginContext, &request)
+
+	response, err := server.facade.GetAccount(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleGetAccount.GetAccount", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleGetAccountStorage(ginContext *gin.Context) {
+	request := GetAccountStorageRequest{}
+
+	err := ginContext.ShouldBindQuery(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleGetAccountStorage.ShouldBindQuery", err)
+		return
+	}
+	bindAccountPath(ginContext, &request.AccountRequest)
+
+	response, err := server.facade.GetAccountStorage(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleGetAccountStorage.GetAccountStorage", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleGetAccountDCDT(ginContext *gin.Context) {
+	request := AccountRequest{}
+
+	err := ginContext.ShouldBindQuery(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleGetAccountDCDT.ShouldBindQuery", err)
+		return
+	}
+	bindAccountPath(ginContext, &request)
+
+	response, err := server.facade.GetAccountDCDT(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleGetAccountDCDT.GetAccountDCDT", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func bindAccountPath(ginContext *gin.Context, request *AccountRequest) {
+	request.World = ginContext.Param("world")
+	request.AddressHex = ginContext.Param("address")
+}
+
 func returnBadRequest(context *gin.Context, errScope string, err error) {
 	context.JSON(http.StatusBadRequest, gin.H{
 		"error":        fmt.Sprintf("%T", err),
@@ -0,0 +1,141 @@
+package vmserver
+
+import (
+	"math/big"
+	"path/filepath"
+
+	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
+)
+
+type sessionStepKind string
+
+const (
+	sessionStepCreateAccount sessionStepKind = "createAccount"
+	sessionStepDeploy        sessionStepKind = "deploy"
+	sessionStepUpgrade       sessionStepKind = "upgrade"
+	sessionStepRun           sessionStepKind = "run"
+	sessionStepQuery         sessionStepKind = "query"
+)
+
+// worldSession is the sequence of requests made against a world, in the order in which they were handled
+type worldSession struct {
+	World string
+	Steps []*sessionStep
+}
+
+// sessionStep is a single request recorded in a session, together with its outcome;
+// Address holds the created account, the deployed contract or the called contract
+type sessionStep struct {
+	Kind         sessionStepKind
+	Address      []byte
+	Balance      *big.Int
+	Nonce        uint64
+	Impersonated []byte
+	Value        *big.Int
+	GasLimit     uint64
+	CodePath     string
+	Code         []byte
+	CodeMetadata []byte
+	Function     string
+	Arguments    [][]byte
+	DCDTPayments []*vmcommon.DCDTTransfer
+	Output       *sessionOutput
+}
+
+// sessionOutput holds the parts of the VM output which are checked when the session is replayed
+type sessionOutput struct {
+	ReturnCode    vmcommon.ReturnCode
+	ReturnMessage string
+	ReturnData    [][]byte
+	Logs          []*vmcommon.LogEntry
+}
+
+func newWorldSession(worldID string) *worldSession {
+	return &worldSession{
+		World: worldID,
+		Steps: make([]*sessionStep, 0),
+	}
+}
+
+func newCreateAccountStep(request CreateAccountRequest) *sessionStep {
+	return &sessionStep{
+		Kind:    sessionStepCreateAccount,
+		Address: request.Address,
+		Balance: request.BalanceAsBigInt,
+		Nonce:   request.Nonce,
+	}
+}
+
+func newDeployStep(request DeployRequest, response *DeployResponse) *sessionStep {
+	if response.Output == nil {
+		return nil
+	}
+
+	step := newContractStep(sessionStepDeploy, request.ContractRequestBase, response.Output)
+	step.Address = response.ContractAddress
+	step.CodeMetadata = request.CodeMetadataBytes
+	step.Arguments = request.Arguments
+	setStepCode(step, request)
+
+	return step
+}
+
+func newUpgradeStep(request UpgradeRequest, response *UpgradeResponse) *sessionStep {
+	if response.Output == nil {
+		return nil
+	}
+
+	step := newContractStep(sessionStepUpgrade, request.ContractRequestBase, response.Output)
+	step.Address = request.ContractAddress
+	step.CodeMetadata = request.CodeMetadataBytes
+	step.Arguments = request.Arguments
+	setStepCode(step, request.DeployRequest)
+
+	return step
+}
+
+func newRunStep(kind sessionStepKind, request RunRequest, output *vmcommon.VMOutput) *sessionStep {
+	if output == nil {
+		return nil
+	}
+
+	step := newContractStep(kind, request.ContractRequestBase, output)
+	step.Address = request.ContractAddress
+	step.Function = request.Function
+	step.Arguments = request.Arguments
+
+	return step
+}
+
+func newContractStep(kind sessionStepKind, request ContractRequestBase, output *vmcommon.VMOutput) *sessionStep {
+	return &sessionStep{
+		Kind:         kind,
+		Impersonated: request.Impersonated,
+		Value:        request.ValueAsBigInt,
+		GasLimit:     request.GasLimit,
+		DCDTPayments: request.DCDTTransfers,
+		Output: &sessionOutput{
+			ReturnCode:    output.ReturnCode,
+			ReturnMessage: output.ReturnMessage,
+			ReturnData:    output.ReturnData,
+			Logs:          output.Logs,
+		},
+	}
+}
+
+// setStepCode records the path of the contract code, if known, so that the
+// exported scenario references the file instead of embedding its contents
+func setStepCode(step *sessionStep, request DeployRequest) {
+	if len(request.CodePath) == 0 {
+		step.Code = request.Code
+		return
+	}
+
+	absolutePath, err := filepath.Abs(request.CodePath)
+	if err != nil {
+		step.Code = request.Code
+		return
+	}
+
+	step.CodePath = absolutePath
+}
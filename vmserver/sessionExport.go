@@ -0,0 +1,295 @@
+package vmserver
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"path/filepath"
+
+	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
+	mj "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/json/model"
+	oj "github.com/kalyan3104/k-chain-vm-v1_2-go/scenarios/orderedjson"
+	"github.com/kalyan3104/k-chain-vm-v1_2-go/vmhost"
+)
+
+// scenarioExporter converts a recorded session into an equivalent scenario.
+//
+// The debugging worlds neither charge gas upfront nor increment the nonce of
+// the sender before a transaction, while the scenario executor does both.
+// Therefore, the exported transactions have a zero gas price, and the
+// addresses of the deployed contracts are explicitly mocked, using the nonces
+// the senders will have when the scenario is replayed.
+type scenarioExporter struct {
+	scenarioFolder string
+	nonces         map[string]uint64
+	steps          []mj.Step
+}
+
+func newScenarioExporter(scenarioFolder string) *scenarioExporter {
+	return &scenarioExporter{
+		scenarioFolder: scenarioFolder,
+		nonces:         make(map[string]uint64),
+		steps:          make([]mj.Step, 0),
+	}
+}
+
+func (exporter *scenarioExporter) export(session *worldSession) *mj.Scenario {
+	for i, step := range session.Steps {
+		txIdent := fmt.Sprintf("%d", i+1)
+
+		switch step.Kind {
+		case sessionStepCreateAccount:
+			exporter.exportCreateAccount(step)
+		case sessionStepDeploy:
+			exporter.exportDeploy(txIdent, step)
+		case sessionStepUpgrade:
+			exporter.exportUpgrade(txIdent, step)
+		case sessionStepRun:
+			exporter.exportRun(txIdent, step)
+		case sessionStepQuery:
+			exporter.exportQuery(txIdent, step)
+		default:
+			log.Warn("scenarioExporter.export(): unknown session step", "kind", step.Kind)
+		}
+	}
+
+	return &mj.Scenario{
+		Name:        session.World,
+		Comment:     "exported from a vmserver session",
+		CheckGas:    false,
+		GasSchedule: mj.GasScheduleDummy,
+		Steps:       exporter.steps,
+	}
+}
+
+func (exporter *scenarioExporter) exportCreateAccount(step *sessionStep) {
+	exporter.nonces[string(step.Address)] = step.Nonce
+
+	exporter.steps = append(exporter.steps, &mj.SetStateStep{
+		Accounts: []*mj.Account{
+			{
+				Address: bytesFromString(step.Address),
+				Nonce:   uint64Value(step.Nonce),
+				Balance: bigIntValue(step.Balance),
+				Storage: make([]*mj.StorageKeyValuePair, 0),
+			},
+		},
+	})
+}
+
+func (exporter *scenarioExporter) exportDeploy(txIdent string, step *sessionStep) {
+	if step.Output.ReturnCode == vmcommon.Ok {
+		exporter.steps = append(exporter.steps, &mj.SetStateStep{
+			NewAddressMocks: []*mj.NewAddressMock{
+				{
+					CreatorAddress: bytesFromString(step.Impersonated),
+					CreatorNonce:   uint64Value(exporter.nonces[string(step.Impersonated)]),
+					NewAddress:     bytesFromString(step.Address),
+				},
+			},
+		})
+	}
+
+	tx := exporter.newTransaction(mj.ScDeploy, step)
+	tx.Code = exporter.codeFromStep(step)
+	tx.Arguments = bytesFromTreeList(step.Arguments)
+
+	exporter.appendTxStep(txIdent, tx, step)
+
+	// the creator nonce is incremented both before the transaction and by the deployment itself
+	exporter.incrementNonce(step, 2)
+}
+
+func (exporter *scenarioExporter) exportUpgrade(txIdent string, step *sessionStep) {
+	code := exporter.codeFromStep(step)
+	arguments := []mj.JSONBytesFromTree{
+		{Value: code.Value, Original: &oj.OJsonString{Value: code.Original}},
+		bytesFromTree(step.CodeMetadata),
+	}
+	arguments = append(arguments, bytesFromTreeList(step.Arguments)...)
+
+	tx := exporter.newTransaction(mj.ScCall, step)
+	tx.To = bytesFromString(step.Address)
+	tx.Function = vmhost.UpgradeFunctionName
+	tx.Arguments = arguments
+
+	exporter.appendTxStep(txIdent, tx, step)
+	exporter.incrementNonce(step, 1)
+}
+
+func (exporter *scenarioExporter) exportRun(txIdent string, step *sessionStep) {
+	tx := exporter.newTransaction(mj.ScCall, step)
+	tx.To = bytesFromString(step.Address)
+	tx.Function = step.Function
+	tx.Arguments = bytesFromTreeList(step.Arguments)
+
+	exporter.appendTxStep(txIdent, tx, step)
+	exporter.incrementNonce(step, 1)
+}
+
+func (exporter *scenarioExporter) exportQuery(txIdent string, step *sessionStep) {
+	if len(step.DCDTPayments) > 0 {
+		// queries do not change the world, so leaving one out keeps the scenario consistent
+		log.Warn("scenarioExporter.exportQuery(): scenario queries cannot carry DCDT payments, skipping", "tx", txIdent)
+		return
+	}
+
+	tx := exporter.newTransaction(mj.ScQuery, step)
+	tx.To = bytesFromString(step.Address)
+	tx.Function = step.Function
+	tx.Arguments = bytesFromTreeList(step.Arguments)
+
+	exporter.appendTxStep(txIdent, tx, step)
+}
+
+func (exporter *scenarioExporter) newTransaction(txType mj.TransactionType, step *sessionStep) *mj.Transaction {
+	return &mj.Transaction{
+		Type:      txType,
+		From:      bytesFromString(step.Impersonated),
+		Value:     bigIntValue(step.Value),
+		DCDTValue: dcdtTxDataFromTransfers(step.DCDTPayments),
+		GasLimit:  uint64Value(step.GasLimit),
+		GasPrice:  uint64Value(0),
+	}
+}
+
+func (exporter *scenarioExporter) appendTxStep(txIdent string, tx *mj.Transaction, step *sessionStep) {
+	exporter.steps = append(exporter.steps, &mj.TxStep{
+		TxIdent:        txIdent,
+		Tx:             tx,
+		ExpectedResult: expectedResultFromOutput(step.Output),
+	})
+}
+
+// incrementNonce mirrors the nonce changes of a successful transaction; failed
+// transactions are rolled back entirely by the scenario executor
+func (exporter *scenarioExporter) incrementNonce(step *sessionStep, delta uint64) {
+	if step.Output.ReturnCode != vmcommon.Ok {
+		return
+	}
+
+	exporter.nonces[string(step.Impersonated)] += delta
+}
+
+func (exporter *scenarioExporter) codeFromStep(step *sessionStep) mj.JSONBytesFromString {
+	if len(step.CodePath) == 0 {
+		return bytesFromString(step.Code)
+	}
+
+	codePath := step.CodePath
+	relativePath, err := filepath.Rel(exporter.scenarioFolder, step.CodePath)
+	if err == nil {
+		codePath = relativePath
+	}
+
+	return mj.NewJSONBytesFromString(nil, "file:"+filepath.ToSlash(codePath))
+}
+
+func expectedResultFromOutput(output *sessionOutput) *mj.TransactionResult {
+	out := make([]mj.JSONCheckBytes, 0, len(output.ReturnData))
+	for _, data := range output.ReturnData {
+		out = append(out, checkBytes(data))
+	}
+
+	logs := make([]*mj.LogEntry, 0, len(output.Logs))
+	for _, logEntry := range output.Logs {
+		logs = append(logs, expectedLogFromOutput(logEntry))
+	}
+
+	message := mj.JSONCheckBytesReconstructed([]byte(output.ReturnMessage))
+	if len(output.ReturnMessage) > 0 {
+		message.Original = &oj.OJsonString{Value: "str:" + output.ReturnMessage}
+	}
+
+	return &mj.TransactionResult{
+		Out: out,
+		Status: mj.JSONCheckBigInt{
+			Value:    big.NewInt(int64(output.ReturnCode)),
+			Original: fmt.Sprintf("%d", int(output.ReturnCode)),
+		},
+		Message: message,
+		Gas:     mj.JSONCheckUint64Unspecified(),
+		Refund:  mj.JSONCheckBigIntUnspecified(),
+		Logs:    logs,
+	}
+}
+
+func expectedLogFromOutput(logEntry *vmcommon.LogEntry) *mj.LogEntry {
+	topics := make([]mj.JSONCheckBytes, 0, len(logEntry.Topics))
+	for _, topic := range logEntry.Topics {
+		topics = append(topics, checkBytes(topic))
+	}
+
+	return &mj.LogEntry{
+		Address:    checkBytes(logEntry.Address),
+		Identifier: checkBytes(logEntry.Identifier),
+		Topics:     topics,
+		Data:       checkBytes(logEntry.GetFirstDataItem()),
+	}
+}
+
+func dcdtTxDataFromTransfers(dcdtTransfers []*vmcommon.DCDTTransfer) []*mj.DCDTTxData {
+	dcdtData := make([]*mj.DCDTTxData, 0, len(dcdtTransfers))
+	for _, dcdtTransfer := range dcdtTransfers {
+		tokenIdentifier := dcdtTransfer.DCDTTokenName
+		dcdtData = append(dcdtData, &mj.DCDTTxData{
+			TokenIdentifier: mj.NewJSONBytesFromString(tokenIdentifier, "str:"+string(tokenIdentifier)),
+			Nonce:           uint64Value(dcdtTransfer.DCDTTokenNonce),
+			Value:           bigIntValue(dcdtTransfer.DCDTValue),
+		})
+	}
+
+	return dcdtData
+}
+
+func hexExpression(value []byte) string {
+	if len(value) == 0 {
+		return ""
+	}
+
+	return "0x" + hex.EncodeToString(value)
+}
+
+func bytesFromString(value []byte) mj.JSONBytesFromString {
+	return mj.NewJSONBytesFromString(value, hexExpression(value))
+}
+
+func bytesFromTree(value []byte) mj.JSONBytesFromTree {
+	return mj.JSONBytesFromTree{
+		Value:    value,
+		Original: &oj.OJsonString{Value: hexExpression(value)},
+	}
+}
+
+func bytesFromTreeList(values [][]byte) []mj.JSONBytesFromTree {
+	result := make([]mj.JSONBytesFromTree, 0, len(values))
+	for _, value := range values {
+		result = append(result, bytesFromTree(value))
+	}
+
+	return result
+}
+
+func checkBytes(value []byte) mj.JSONCheckBytes {
+	result := mj.JSONCheckBytesReconstructed(value)
+	result.Original = &oj.OJsonString{Value: hexExpression(value)}
+	return result
+}
+
+func uint64Value(value uint64) mj.JSONUint64 {
+	return mj.JSONUint64{
+		Value:    value,
+		Original: fmt.Sprintf("%d", value),
+	}
+}
+
+func bigIntValue(value *big.Int) mj.JSONBigInt {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	return mj.JSONBigInt{
+		Value:    value,
+		Original: value.String(),
+	}
+}
@@ -150,6 +150,81 @@ func (context *testContext) queryContract(contract string, impersonated string,
 	return response
 }
 
+func (context *testContext) estimateGas(contract string, impersonated string, function string, arguments ...string) *EstimateResponse {
+	request := EstimateRequest{
+		RunRequest: RunRequest{
+			ContractRequestBase: ContractRequestBase{
+				RequestBase:     context.createRequestBase(),
+				ImpersonatedHex: impersonated,
+				GasLimit:        gasLimit,
+			},
+			ContractAddressHex: contract,
+			Function:           function,
+			ArgumentsHex:       arguments,
+		},
+		SearchGasLimit: true,
+	}
+
+	response, err := context.facade.EstimateGas(request)
+
+	t := context.t
+	require.Nil(t, err)
+	require.NotNil(t, response)
+	require.NotNil(t, response.Output)
+	require.Nil(t, response.Error)
+	require.Equal(t, vmcommon.Ok.String(), response.ReturnCodeString, response.Output.ReturnMessage)
+
+	return response
+}
+
+func (context *testContext) snapshotWorld(snapshotID string) *SnapshotWorldResponse {
+	request := SnapshotWorldRequest{
+		RequestBase: context.createRequestBase(),
+		SnapshotID:  snapshotID,
+	}
+
+	response, err := context.facade.SnapshotWorld(request)
+
+	t := context.t
+	require.Nil(t, err)
+	require.NotNil(t, response)
+
+	return response
+}
+
+func (context *testContext) forkWorld() *testContext {
+	forkedContext := newTestContext(context.t)
+	forkedContext.worldID = context.worldID + "_fork"
+
+	request := ForkWorldRequest{
+		RequestBase: context.createRequestBase(),
+		NewWorld:    forkedContext.worldID,
+	}
+
+	response, err := context.facade.ForkWorld(request)
+
+	t := context.t
+	require.Nil(t, err)
+	require.NotNil(t, response)
+
+	return forkedContext
+}
+
+func (context *testContext) restoreWorld(snapshotID string) *RestoreWorldResponse {
+	request := RestoreWorldRequest{
+		RequestBase: context.createRequestBase(),
+		SnapshotID:  snapshotID,
+	}
+
+	response, err := context.facade.RestoreWorld(request)
+
+	t := context.t
+	require.Nil(t, err)
+	require.NotNil(t, response)
+
+	return response
+}
+
 func (response *ContractResponseBase) getFirstResultAsInt64() int64 {
 	result, err := response.Output.GetFirstReturnData(vm.AsBigInt)
 	if err != nil {
@@ -191,3 +266,59 @@ func newDummyAddress(address string) *dummyAddress {
 		raw: []byte(rawString),
 	}
 }
+
+func (context *testContext) getAccount(address string) *GetAccountResponse {
+	request := AccountRequest{
+		RequestBase: context.createRequestBase(),
+		AddressHex:  address,
+	}
+
+	response, err := context.facade.GetAccount(request)
+
+	t := context.t
+	require.Nil(t, err)
+	require.NotNil(t, response)
+
+	return response
+}
+
+func (context *testContext) getAccountStorage(address string, prefix string, offset int, limit int) *GetAccountStorageResponse {
+	request := GetAccountStorageRequest{
+		AccountRequest: AccountRequest{
+			RequestBase: context.createRequestBase(),
+			AddressHex:  address,
+		},
+		PrefixHex: prefix,
+		Offset:    offset,
+		Limit:     limit,
+	}
+
+	response, err := context.facade.GetAccountStorage(request)
+
+	t := context.t
+	require.Nil(t, err)
+	require.NotNil(t, response)
+
+	return response
+}
+
+func (context *testContext) storeWorld(world *world) {
+	database := newDatabase(databasePath)
+	err := database.storeWorld(world)
+	require.Nil(context.t, err)
+}
+
+func (context *testContext) exportScenario(scenarioPath string) *ExportScenarioResponse {
+	request := ExportScenarioRequest{
+		RequestBase:  context.createRequestBase(),
+		ScenarioPath: scenarioPath,
+	}
+
+	response, err := context.facade.ExportScenario(request)
+
+	t := context.t
+	require.Nil(t, err)
+	require.NotNil(t, response)
+
+	return response
+}
@@ -1,7 +1,12 @@
 package vmserver
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/kalyan3104/k-chain-core-go/core"
+	"github.com/kalyan3104/k-chain-core-go/data/dcdt"
+	"github.com/kalyan3104/k-chain-core-go/data/vm"
 	vmcommon "github.com/kalyan3104/k-chain-vm-common-go"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/config"
 	"github.com/kalyan3104/k-chain-vm-v1_2-go/mock"
@@ -33,10 +38,13 @@ func newWorld(dataModel *worldDataModel) (*world, error) {
 	blockchainHook := worldmock.NewMockWorld()
 	blockchainHook.AcctMap = dataModel.Accounts
 
-	vm, err := hostCore.NewVMHost(
-		blockchainHook,
-		getHostParameters(),
-	)
+	hostParameters := getHostParameters()
+	err := blockchainHook.InitBuiltinFunctions(hostParameters.GasSchedule)
+	if err != nil {
+		return nil, err
+	}
+
+	vmHost, err := hostCore.NewVMHost(blockchainHook, hostParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -44,7 +52,7 @@ func newWorld(dataModel *worldDataModel) (*world, error) {
 	return &world{
 		id:             dataModel.ID,
 		blockchainHook: blockchainHook,
-		vm:             vm,
+		vm:             vmHost,
 	}, nil
 }
 
@@ -56,7 +64,8 @@ func getHostParameters() *vmhost.VMHostParameters {
 		ProtectedKeyPrefix: []byte("E" + "L" + "R" + "O" + "N" + "D"),
 		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
 			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-				return flag == hostCore.SCDeployFlag || flag == hostCore.AheadOfTimeGasUsageFlag || flag == hostCore.RepairCallbackFlag || flag == hostCore.BuiltInFunctionsFlag
+				return flag == hostCore.SCDeployFlag || flag == hostCore.AheadOfTimeGasUsageFlag || flag == hostCore.RepairCallbackFlag || flag == hostCore.BuiltInFunctionsFlag ||
+					flag == hostCore.SelfDestructFlag || flag == hostCore.EthereumAPIFlag || flag == hostCore.ManagedBufferAPIFlag || flag == hostCore.BigIntExtendedAPIFlag || flag == hostCore.Secp256k1ExtendedAPIFlag || flag == hostCore.BLSMultiSigAPIFlag || flag == hostCore.MultiDCDTTransferFlag
 			},
 		},
 	}
@@ -68,7 +77,7 @@ func (w *world) deploySmartContract(request DeployRequest) *DeployResponse {
 
 	vmOutput, err := w.vm.RunSmartContractCreate(input)
 	if err == nil {
-		w.blockchainHook.UpdateAccounts(vmOutput.OutputAccounts, nil)
+		w.blockchainHook.UpdateAccounts(vmOutput.OutputAccounts, vmOutput.DeletedAccounts)
 	}
 
 	response := &DeployResponse{}
@@ -83,9 +92,9 @@ func (w *world) upgradeSmartContract(request UpgradeRequest) *UpgradeResponse {
 	input := w.prepareUpgradeInput(request)
 	log.Trace("w.upgradeSmartContract()", "input", prettyJson(input))
 
-	vmOutput, err := w.vm.RunSmartContractCall(input)
+	vmOutput, err := w.runSmartContractCallWithDCDT(input)
 	if err == nil {
-		w.blockchainHook.UpdateAccounts(vmOutput.OutputAccounts, nil)
+		w.blockchainHook.UpdateAccounts(vmOutput.OutputAccounts, vmOutput.DeletedAccounts)
 	}
 
 	response := &UpgradeResponse{}
@@ -99,9 +108,9 @@ func (w *world) runSmartContract(request RunRequest) *RunResponse {
 	input := w.prepareCallInput(request)
 	log.Trace("w.runSmartContract()", "input", prettyJson(input))
 
-	vmOutput, err := w.vm.RunSmartContractCall(input)
+	vmOutput, err := w.runSmartContractCallWithDCDT(input)
 	if err == nil {
-		w.blockchainHook.UpdateAccounts(vmOutput.OutputAccounts, nil)
+		w.blockchainHook.UpdateAccounts(vmOutput.OutputAccounts, vmOutput.DeletedAccounts)
 	}
 
 	response := &RunResponse{}
@@ -111,11 +120,88 @@ func (w *world) runSmartContract(request RunRequest) *RunResponse {
 	return response
 }
 
+// runSmartContractCallWithDCDT moves the DCDT payments of a call from the
+// caller to the contract before executing it, charging the builtin function
+// from the provided gas; the transfer is reverted if the call fails
+func (w *world) runSmartContractCallWithDCDT(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	if len(input.DCDTTransfers) == 0 {
+		return w.vm.RunSmartContractCall(input)
+	}
+
+	w.blockchainHook.CreateStateBackup()
+
+	var vmOutput *vmcommon.VMOutput
+	_, err := w.performDCDTPayments(input)
+	if err == nil {
+		vmOutput, err = w.vm.RunSmartContractCall(input)
+	}
+
+	if err != nil || vmOutput.ReturnCode != vmcommon.Ok {
+		errRollback := w.blockchainHook.RollbackChanges()
+		if errRollback != nil {
+			return nil, errRollback
+		}
+		return vmOutput, err
+	}
+
+	return vmOutput, w.blockchainHook.CommitChanges()
+}
+
+// runWithThrowawayDCDTPayments moves the DCDT payments of a call to the
+// contract only for the duration of the given run, since queries and
+// estimations must not change the world; it returns the gas consumed by the
+// builtin function
+func (w *world) runWithThrowawayDCDTPayments(input *vmcommon.ContractCallInput, run func() error) (uint64, error) {
+	if len(input.DCDTTransfers) == 0 {
+		return 0, run()
+	}
+
+	w.blockchainHook.CreateStateBackup()
+
+	gasUsedByPayments, err := w.performDCDTPayments(input)
+	if err == nil {
+		err = run()
+	}
+
+	errRollback := w.blockchainHook.RollbackChanges()
+	if err != nil {
+		return 0, err
+	}
+
+	return gasUsedByPayments, errRollback
+}
+
+// performDCDTPayments transfers the DCDT payments of a call from the caller to
+// the contract and leaves the call only the gas remaining after the builtin
+// function, returning the gas the builtin function consumed
+func (w *world) performDCDTPayments(input *vmcommon.ContractCallInput) (uint64, error) {
+	gasRemaining, err := w.blockchainHook.BuiltinFuncs.PerformDirectMultiDCDTTransfer(
+		input.CallerAddr,
+		input.RecipientAddr,
+		input.DCDTTransfers,
+		vm.DirectCall,
+		input.GasProvided,
+		input.GasPrice)
+	if err != nil {
+		return 0, err
+	}
+
+	gasUsedByPayments := input.GasProvided - gasRemaining
+	input.GasProvided = gasRemaining
+
+	return gasUsedByPayments, nil
+}
+
 func (w *world) querySmartContract(request QueryRequest) *QueryResponse {
 	input := w.prepareCallInput(request.RunRequest)
 	log.Trace("w.querySmartContract()", "input", prettyJson(input))
 
-	vmOutput, err := w.vm.RunSmartContractCall(input)
+	var vmOutput *vmcommon.VMOutput
+	_, err := w.runWithThrowawayDCDTPayments(input, func() error {
+		var errRun error
+		vmOutput, errRun = w.vm.RunSmartContractCall(input)
+		return errRun
+	})
 
 	response := &QueryResponse{}
 	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput)
@@ -124,16 +210,49 @@ func (w *world) querySmartContract(request QueryRequest) *QueryResponse {
 	return response
 }
 
+func (w *world) estimateGas(request EstimateRequest) *EstimateResponse {
+	input := w.prepareCallInput(request.RunRequest)
+	log.Trace("w.estimateGas()", "input", prettyJson(input))
+
+	response := &EstimateResponse{}
+	host, ok := w.vm.(vmhost.VMHost)
+	if !ok {
+		response.ContractResponseBase = createContractResponseBase(&input.VMInput, nil)
+		response.Error = ErrGasEstimationNotSupported
+		return response
+	}
+
+	var estimate *vmhost.GasEstimate
+	gasUsedByPayments, err := w.runWithThrowawayDCDTPayments(input, func() error {
+		var errEstimate error
+		estimate, errEstimate = host.EstimateGas(input, request.SearchGasLimit)
+		return errEstimate
+	})
+	if err != nil {
+		response.ContractResponseBase = createContractResponseBase(&input.VMInput, nil)
+		response.Error = err
+		return response
+	}
+
+	// the transaction also pays for the builtin function moving the payments
+	response.ContractResponseBase = createContractResponseBase(&input.VMInput, estimate.VMOutput)
+	response.GasProvided = estimate.GasProvided + gasUsedByPayments
+	response.GasConsumed = estimate.GasConsumed + gasUsedByPayments
+	response.GasForwarded = estimate.GasForwarded
+	response.GasLocked = estimate.GasLocked
+	response.GasLimit = estimate.GasLimit + gasUsedByPayments
+	response.NumExecutions = estimate.NumExecutions
+
+	return response
+}
+
 func (w *world) createAccount(request CreateAccountRequest) *CreateAccountResponse {
 	log.Trace("w.createAccount()", "request", prettyJson(request))
 
-	account := worldmock.Account{
-		Address: request.Address,
-		Nonce:   request.Nonce,
-		Balance: request.BalanceAsBigInt,
-	}
-	w.blockchainHook.AcctMap.PutAccount(&account)
-	return &CreateAccountResponse{Account: &account}
+	account := w.blockchainHook.AcctMap.CreateAccount(request.Address)
+	account.Nonce = request.Nonce
+	account.Balance = request.BalanceAsBigInt
+	return &CreateAccountResponse{Account: account}
 }
 
 func (w *world) toDataModel() *worldDataModel {
@@ -142,3 +261,148 @@ func (w *world) toDataModel() *worldDataModel {
 		Accounts: w.blockchainHook.AcctMap,
 	}
 }
+
+// cloneDataModel creates a deep copy of the accounts of the world, under the given world ID
+func (w *world) cloneDataModel(worldID string) *worldDataModel {
+	return &worldDataModel{
+		ID:       worldID,
+		Accounts: w.blockchainHook.AcctMap.Clone(),
+	}
+}
+
+func (w *world) getAccount(request AccountRequest) (*GetAccountResponse, error) {
+	account := w.blockchainHook.AcctMap.GetAccount(request.Address)
+	if account == nil {
+		return nil, ErrAccountDoesntExist
+	}
+
+	return &GetAccountResponse{
+		AddressHex:      toHex(account.Address),
+		Balance:         account.GetBalance().String(),
+		Nonce:           account.Nonce,
+		OwnerAddressHex: toHex(account.OwnerAddress),
+		CodeHashHex:     toHex(account.CodeHash),
+		CodeMetadataHex: toHex(account.CodeMetadata),
+		Username:        string(account.Username),
+		IsSmartContract: account.IsSmartContract,
+		NumStorageKeys:  len(account.Storage),
+	}, nil
+}
+
+func (w *world) getAccountStorage(request GetAccountStorageRequest) (*GetAccountStorageResponse, error) {
+	account := w.blockchainHook.AcctMap.GetAccount(request.Address)
+	if account == nil {
+		return nil, ErrAccountDoesntExist
+	}
+
+	keys := make([]string, 0, len(account.Storage))
+	for key := range account.Storage {
+		if strings.HasPrefix(key, string(request.Prefix)) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	start := request.Offset
+	if start > len(keys) {
+		start = len(keys)
+	}
+	// compared before adding, so a huge limit cannot overflow the end of the page
+	end := len(keys)
+	if request.Limit < end-start {
+		end = start + request.Limit
+	}
+
+	entries := make([]StorageEntry, 0, end-start)
+	for _, key := range keys[start:end] {
+		entries = append(entries, StorageEntry{
+			KeyHex:   toHex([]byte(key)),
+			ValueHex: toHex(account.Storage[key]),
+		})
+	}
+
+	return &GetAccountStorageResponse{
+		AddressHex: toHex(account.Address),
+		Entries:    entries,
+		Offset:     request.Offset,
+		Limit:      request.Limit,
+		Total:      len(keys),
+	}, nil
+}
+
+func (w *world) getAccountDCDT(request AccountRequest) (*GetAccountDCDTResponse, error) {
+	account := w.blockchainHook.AcctMap.GetAccount(request.Address)
+	if account == nil {
+		return nil, ErrAccountDoesntExist
+	}
+
+	dcdtData, err := account.GetFullMockDCDTData()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenNames := make([]string, 0, len(dcdtData))
+	for tokenName := range dcdtData {
+		tokenNames = append(tokenNames, tokenName)
+	}
+	sort.Strings(tokenNames)
+
+	tokens := make([]DCDTHolding, 0, len(tokenNames))
+	for _, tokenName := range tokenNames {
+		tokens = append(tokens, newDCDTHolding(dcdtData[tokenName]))
+	}
+
+	return &GetAccountDCDTResponse{
+		AddressHex: toHex(account.Address),
+		Tokens:     tokens,
+	}, nil
+}
+
+func newDCDTHolding(data *worldmock.MockDCDTData) DCDTHolding {
+	instances := make([]DCDTInstance, 0, len(data.Instances))
+	for _, token := range data.Instances {
+		instances = append(instances, newDCDTInstance(token))
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].Nonce < instances[j].Nonce
+	})
+
+	roles := make([]string, 0, len(data.Roles))
+	for _, role := range data.Roles {
+		roles = append(roles, string(role))
+	}
+
+	return DCDTHolding{
+		TokenIdentifier: string(data.TokenIdentifier),
+		Instances:       instances,
+		LastNonce:       data.LastNonce,
+		Roles:           roles,
+	}
+}
+
+func newDCDTInstance(token *dcdt.DCDigitalToken) DCDTInstance {
+	instance := DCDTInstance{
+		Balance: token.Value.String(),
+		Type:    token.Type,
+	}
+
+	metadata := token.TokenMetaData
+	if metadata == nil {
+		return instance
+	}
+
+	uris := make([]string, 0, len(metadata.URIs))
+	for _, uri := range metadata.URIs {
+		uris = append(uris, string(uri))
+	}
+
+	instance.Nonce = metadata.Nonce
+	instance.Name = string(metadata.Name)
+	instance.CreatorHex = toHex(metadata.Creator)
+	instance.Royalties = metadata.Royalties
+	instance.HashHex = toHex(metadata.Hash)
+	instance.URIs = uris
+	instance.AttributesHex = toHex(metadata.Attributes)
+
+	return instance
+}
@@ -31,6 +31,8 @@ func (w *world) prepareUpgradeInput(request UpgradeRequest) *vmcommon.ContractCa
 	callInput.Arguments = allArguments
 	callInput.GasProvided = request.GasLimit
 	callInput.GasPrice = request.GasPrice
+	callInput.DCDTTransfers = request.DCDTTransfers
+	w.blockchainHook.BuiltinFuncs.SetTokenTypesFromData(callInput.DCDTTransfers, callInput.CallerAddr)
 
 	return callInput
 }
@@ -44,6 +46,8 @@ func (w *world) prepareCallInput(request RunRequest) *vmcommon.ContractCallInput
 	callInput.Arguments = request.Arguments
 	callInput.GasProvided = request.GasLimit
 	callInput.GasPrice = request.GasPrice
+	callInput.DCDTTransfers = request.DCDTTransfers
+	w.blockchainHook.BuiltinFuncs.SetTokenTypesFromData(callInput.DCDTTransfers, callInput.CallerAddr)
 
 	return callInput
 }